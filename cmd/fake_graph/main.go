@@ -0,0 +1,208 @@
+// File: cmd/fake_graph/main.go
+
+// fake_graph simula os endpoints do Graph usados pela Cloud API do WhatsApp para testes locais:
+// consulta do número, listagem de templates e envio de mensagens, com os webhooks de status
+// (sent, delivered e read) assinados como a Meta assina. Use WHATSAPP_CLOUD_API_URL=http://localhost:8090.
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8090", "Endereço do servidor fake")
+	webhookURL := flag.String("webhook", "http://localhost:8080/webhook/cloud_api", "URL do webhook que recebe os status")
+	appSecret := flag.String("app-secret", "", "App secret usado na assinatura X-Hub-Signature-256 (mesmo WHATSAPP_CLOUD_APP_SECRET da API, que recusa webhooks sem assinatura)")
+	token := flag.String("token", "", "Access token esperado no Authorization (vazio aceita qualquer um)")
+	flag.Parse()
+
+	mux := http.NewServeMux()
+
+	// 📱 Dados do número (ou da mídia, para IDs iniciados por "media")
+	mux.HandleFunc("GET /{phone_number_id}", authorized(*token, func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("phone_number_id")
+		if strings.HasPrefix(id, "media") {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"id":                id,
+				"url":               "http://" + r.Host + "/media-content/" + id + "/download",
+				"mime_type":         "image/png",
+				"file_size":         len(samplePNG),
+				"messaging_product": "whatsapp",
+			})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{
+			"id":                   id,
+			"display_phone_number": "+55 11 90000-0000",
+			"verified_name":        "Loja Exemplo",
+			"quality_rating":       "GREEN",
+		})
+	}))
+
+	// 📎 Conteúdo da mídia (a URL retornada acima exige o mesmo token)
+	mux.HandleFunc("GET /media-content/{media_id}/download", authorized(*token, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(samplePNG)
+	}))
+
+	// 🧾 Templates da WABA
+	mux.HandleFunc("GET /{waba_id}/message_templates", authorized(*token, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": sampleTemplates(), "paging": map[string]interface{}{}})
+	}))
+
+	// 🚀 Envio de mensagens: responde o wamid e dispara os status pelo webhook
+	mux.HandleFunc("POST /{phone_number_id}/messages", authorized(*token, func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			To string `json:"to"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.To == "" {
+			writeJSON(w, http.StatusBadRequest, graphError(100, "Invalid parameter"))
+			return
+		}
+
+		messageID := "wamid.FAKE" + randomHex(12)
+		log.Printf("📨 Mensagem %s para %s (phone_number_id %s)", messageID, payload.To, r.PathValue("phone_number_id"))
+
+		go sendStatuses(*webhookURL, *appSecret, r.PathValue("phone_number_id"), payload.To, messageID)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"messaging_product": "whatsapp",
+			"contacts":          []map[string]string{{"input": payload.To, "wa_id": payload.To}},
+			"messages":          []map[string]string{{"id": messageID}},
+		})
+	}))
+
+	log.Printf("🚀 Graph fake ouvindo em %s (webhook: %s)", *addr, *webhookURL)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+// authorized confere o Bearer token quando configurado
+func authorized(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			writeJSON(w, http.StatusUnauthorized, graphError(190, "Invalid OAuth access token"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// sendStatuses envia sent, delivered e read com um intervalo entre eles
+func sendStatuses(webhookURL, appSecret, phoneNumberID, recipient, messageID string) {
+	for _, status := range []string{"sent", "delivered", "read"} {
+		time.Sleep(time.Second)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"object": "whatsapp_business_account",
+			"entry": []map[string]interface{}{{
+				"id": "0",
+				"changes": []map[string]interface{}{{
+					"field": "messages",
+					"value": map[string]interface{}{
+						"messaging_product": "whatsapp",
+						"metadata":          map[string]string{"phone_number_id": phoneNumberID},
+						"statuses": []map[string]string{{
+							"id":           messageID,
+							"status":       status,
+							"timestamp":    fmt.Sprint(time.Now().Unix()),
+							"recipient_id": strings.TrimPrefix(recipient, "+"),
+						}},
+					},
+				}},
+			}},
+		})
+
+		req, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewReader(body))
+		if err != nil {
+			log.Printf("❌ Erro ao criar webhook: %v", err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if appSecret != "" {
+			mac := hmac.New(sha256.New, []byte(appSecret))
+			mac.Write(body)
+			req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			log.Printf("❌ Erro ao enviar status %s de %s: %v", status, messageID, err)
+			return
+		}
+		resp.Body.Close()
+		log.Printf("✅ Status %s de %s enviado (HTTP %d)", status, messageID, resp.StatusCode)
+	}
+}
+
+// sampleTemplates cobre os formatos de variáveis: posicional, nomeado, cabeçalho de mídia e botões
+func sampleTemplates() []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"id": "1000000000000001", "name": "promocao_mensal", "language": "pt_BR", "status": "APPROVED",
+			"category": "MARKETING", "parameter_format": "POSITIONAL",
+			"components": []map[string]interface{}{
+				{"type": "HEADER", "format": "IMAGE", "example": map[string]interface{}{"header_handle": []string{"https://example.com/promo.jpg"}}},
+				{"type": "BODY", "text": "{{1}}\n\n{{2}}\n\n{{3}}", "example": map[string]interface{}{"body_text": [][]string{{"Olá, Maria!", "Temos novidades.", "Até logo!"}}}},
+				{"type": "FOOTER", "text": "Responda SAIR para não receber mais mensagens"},
+				{"type": "BUTTONS", "buttons": []map[string]interface{}{
+					{"type": "URL", "text": "Ver ofertas", "url": "https://example.com/{{1}}", "example": []string{"https://example.com/ofertas"}},
+				}},
+			},
+		},
+		{
+			"id": "1000000000000002", "name": "boas_vindas", "language": "pt_BR", "status": "APPROVED",
+			"category": "UTILITY", "parameter_format": "NAMED",
+			"components": []map[string]interface{}{
+				{"type": "BODY", "text": "Olá {{nome}}, seja bem-vindo à {{marca}}!", "example": map[string]interface{}{
+					"body_text_named_params": []map[string]string{{"param_name": "nome", "example": "Maria"}, {"param_name": "marca", "example": "Loja Exemplo"}},
+				}},
+			},
+		},
+		{
+			"id": "1000000000000003", "name": "cupom_desconto", "language": "pt_BR", "status": "PENDING",
+			"category": "MARKETING", "parameter_format": "POSITIONAL",
+			"components": []map[string]interface{}{
+				{"type": "BODY", "text": "Use o cupom abaixo, {{1}}!", "example": map[string]interface{}{"body_text": [][]string{{"Maria"}}}},
+				{"type": "BUTTONS", "buttons": []map[string]interface{}{
+					{"type": "COPY_CODE", "example": "PROMO10"},
+				}},
+			},
+		},
+	}
+}
+
+// samplePNG é uma imagem PNG 1x1 usada como mídia recebida
+var samplePNG = []byte{
+	0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00, 0x00, 0x0d, 0x49, 0x48, 0x44, 0x52,
+	0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x08, 0x06, 0x00, 0x00, 0x00, 0x1f, 0x15, 0xc4,
+	0x89, 0x00, 0x00, 0x00, 0x0d, 0x49, 0x44, 0x41, 0x54, 0x78, 0x9c, 0x63, 0xf8, 0xcf, 0xc0, 0xf0,
+	0x1f, 0x00, 0x05, 0x00, 0x01, 0xff, 0x89, 0x99, 0x3d, 0x1d, 0x00, 0x00, 0x00, 0x00, 0x49, 0x45,
+	0x4e, 0x44, 0xae, 0x42, 0x60, 0x82,
+}
+
+func graphError(code int, message string) map[string]interface{} {
+	return map[string]interface{}{"error": map[string]interface{}{"message": message, "type": "OAuthException", "code": code}}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return strings.ToUpper(hex.EncodeToString(b))
+}
@@ -30,6 +30,73 @@ func startWorker(ctx context.Context, worker workers.Worker, name string) {
 	}()
 }
 
+// newAssetStorage escolhe o armazenamento dos arquivos das contas: disco local (padrão) ou bucket S3
+func newAssetStorage() (service.AssetStorage, error) {
+	publicBaseURL := os.Getenv("ASSET_PUBLIC_BASE_URL")
+
+	if os.Getenv("ASSET_STORAGE") == "s3" {
+		return service.NewS3AssetStorage(context.Background(), service.S3AssetStorageConfig{
+			Bucket:        os.Getenv("ASSET_S3_BUCKET"),
+			Region:        os.Getenv("ASSET_S3_REGION"),
+			Endpoint:      os.Getenv("ASSET_S3_ENDPOINT"),
+			PathStyle:     os.Getenv("ASSET_S3_PATH_STYLE") == "true",
+			AccessKey:     os.Getenv("ASSET_S3_ACCESS_KEY"),
+			SecretKey:     os.Getenv("ASSET_S3_SECRET_KEY"),
+			PublicBaseURL: publicBaseURL,
+		})
+	}
+
+	basePath := os.Getenv("ASSET_STORAGE_PATH")
+	if basePath == "" {
+		basePath = "./uploads/assets"
+	}
+	if publicBaseURL == "" {
+		logger.Warn("⚠️ ASSET_PUBLIC_BASE_URL não configurada: as URLs dos arquivos serão relativas (/files/...)")
+		publicBaseURL = "/files"
+	}
+	return service.NewLocalAssetStorage(basePath, publicBaseURL), nil
+}
+
+// newChatMediaStorage escolhe o armazenamento das mídias do atendimento (CHAT_MEDIA_STORAGE=local|s3).
+// As mídias são privadas: servidas por GET /chat-media/{key} com URL assinada, nunca pela URL do bucket.
+func newChatMediaStorage() (service.AssetStorage, error) {
+	if os.Getenv("CHAT_MEDIA_STORAGE") == "s3" {
+		bucket := os.Getenv("CHAT_MEDIA_S3_BUCKET")
+		if bucket == "" {
+			bucket = os.Getenv("ASSET_S3_BUCKET")
+		}
+		return service.NewS3AssetStorage(context.Background(), service.S3AssetStorageConfig{
+			Bucket:    bucket,
+			Region:    os.Getenv("ASSET_S3_REGION"),
+			Endpoint:  os.Getenv("ASSET_S3_ENDPOINT"),
+			PathStyle: os.Getenv("ASSET_S3_PATH_STYLE") == "true",
+			AccessKey: os.Getenv("ASSET_S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("ASSET_S3_SECRET_KEY"),
+		})
+	}
+
+	basePath := os.Getenv("CHAT_MEDIA_STORAGE_PATH")
+	if basePath == "" {
+		basePath = "./uploads/chat-media"
+	}
+	return service.NewLocalAssetStorage(basePath, ""), nil
+}
+
+// newSpeechToText escolhe a transcrição dos áudios do atendimento (CHAT_TRANSCRIPTION_PROVIDER=openai|whisper_cpp|none)
+func newSpeechToText() service.SpeechToTextService {
+	language := os.Getenv("CHAT_TRANSCRIPTION_LANGUAGE")
+	switch os.Getenv("CHAT_TRANSCRIPTION_PROVIDER") {
+	case "", service.SpeechToTextOpenAI:
+		return service.NewOpenAISpeechToText(os.Getenv("OPENAI_API_KEY"), os.Getenv("CHAT_TRANSCRIPTION_MODEL"), language)
+	case service.SpeechToTextWhisperCpp:
+		return service.NewWhisperCppSpeechToText(os.Getenv("WHISPER_CPP_URL"), language)
+	case "none":
+	default:
+		logger.Warn("⚠️ CHAT_TRANSCRIPTION_PROVIDER desconhecido: áudios do atendimento não serão transcritos", "provider", os.Getenv("CHAT_TRANSCRIPTION_PROVIDER"))
+	}
+	return nil
+}
+
 func main() {
 	// Carregar configurações do .env
 	config.LoadConfig()
@@ -60,17 +127,82 @@ func main() {
 	chatRepo := postgres.NewChatRepository(dbConn)
 	chatContactRepo := postgres.NewChatContactRepository(dbConn)
 	chatMessageRepo := postgres.NewChatMessageRepository(dbConn)
+	queueJobRepo := postgres.NewQueueJobRepository(dbConn)
+	sendPolicyRepo := postgres.NewSendPolicyRepository(dbConn)
+	campaignStatusHistoryRepo := postgres.NewCampaignStatusHistoryRepository(dbConn)
+	engagementRepo := postgres.NewEngagementRepository(dbConn)
+	suppressionRepo := postgres.NewSuppressionRepository(dbConn)
+	senderIdentityRepo := postgres.NewSenderIdentityRepository(dbConn)
+	inboundEmailRepo := postgres.NewInboundEmailRepository(dbConn)
+	assetRepo := postgres.NewAssetRepository(dbConn)
+	deliveredMessageRepo := postgres.NewDeliveredMessageRepository(dbConn)
+	whatsappPhoneNumberRepo := postgres.NewWhatsAppPhoneNumberRepository(dbConn)
+	whatsappTemplateRepo := postgres.NewWhatsAppTemplateRepository(dbConn)
 
 	// Inicializar serviços
-	sqsService, _ := service.NewSQSService(os.Getenv("SQS_EMAIL_URL"), os.Getenv("SQS_WHATSAPP_URL"))
+	sqsService, err := service.NewQueueService(queueJobRepo)
+	if err != nil {
+		logger.Fatal("Erro ao inicializar serviço de filas", err)
+	}
 	openAIService := service.NewOpenAIService()
 	campaignProcessor := service.NewCampaignProcessorService(sqsService, openAIService, audienceRepo)
-	emailService := service.NewEmailService(openAIService)
-	whatsappService := service.NewWhatsAppService(
-		os.Getenv("EVOLUTION_API_URL"),
-		os.Getenv("EVOLUTION_API_KEY"),
-		os.Getenv("EVOLUTION_INSTANCE"),
+	emailTracking := service.NewEmailTrackingService(os.Getenv("TRACKING_BASE_URL"), os.Getenv("TRACKING_SECRET"))
+	unsubscribeService := service.NewUnsubscribeService(os.Getenv("UNSUBSCRIBE_BASE_URL"), os.Getenv("UNSUBSCRIBE_SECRET"), audienceRepo, contactRepo)
+	if !unsubscribeService.Enabled() {
+		logger.Warn("⚠️ Descadastro não configurado (UNSUBSCRIBE_BASE_URL/UNSUBSCRIBE_SECRET): e-mails serão enviados sem List-Unsubscribe")
+	}
+	sesConfigurationSet := os.Getenv("SES_CONFIGURATION_SET")
+	if sesConfigurationSet == "" {
+		sesConfigurationSet = "SES-Bounce-Config"
+	}
+	assetStorage, err := newAssetStorage()
+	if err != nil {
+		logger.Fatal("Erro ao inicializar armazenamento de arquivos", err)
+	}
+	assetService := service.NewAssetService(
+		assetRepo, assetStorage,
+		int64(config.GetEnvInt("ASSET_MAX_SIZE_MB", 10))<<20,
+		int64(config.GetEnvInt("EMAIL_ATTACHMENTS_MAX_MB", 7))<<20,
+	)
+	chatMediaStorage, err := newChatMediaStorage()
+	if err != nil {
+		logger.Fatal("Erro ao inicializar armazenamento de mídias do atendimento", err)
+	}
+	chatMedia := service.NewChatMediaService(
+		chatMediaStorage, os.Getenv("CHAT_MEDIA_BASE_URL"), os.Getenv("CHAT_MEDIA_SECRET"),
+		int64(config.GetEnvInt("CHAT_MEDIA_MAX_SIZE_MB", 16))<<20,
+		time.Duration(config.GetEnvInt("CHAT_MEDIA_URL_TTL_MINUTES", 60))*time.Minute,
+		time.Duration(config.GetEnvInt("CHAT_MEDIA_PROVIDER_URL_TTL_HOURS", 24))*time.Hour,
+	)
+	if !chatMedia.Enabled() {
+		logger.Warn("⚠️ Mídias do atendimento não configuradas (CHAT_MEDIA_BASE_URL/CHAT_MEDIA_SECRET): mensagens recebidas serão gravadas sem arquivo")
+	}
+	emailService := service.NewEmailService(openAIService, emailTracking, unsubscribeService, service.NewEmailSender(sesConfigurationSet), assetService)
+	senderIdentities := service.NewSenderIdentityService(senderIdentityRepo, accountSettingsRepo, campaignSettingsRepo)
+	sendPacer := service.NewSendPacerService(sendPolicyRepo)
+	suppressionService := service.NewSuppressionService(
+		suppressionRepo, audienceRepo, contactRepo,
+		config.GetEnvInt("SUPPRESSION_SOFT_BOUNCE_LIMIT", 3),
+		time.Duration(config.GetEnvInt("SUPPRESSION_SOFT_BOUNCE_DAYS", 7))*24*time.Hour,
+	)
+	snsVerifier := service.NewSNSVerifier(nil, config.GetEnvList("SNS_TOPIC_ARNS"))
+	if !snsVerifier.Enabled() {
+		logger.Warn("⚠️ Tópicos SNS não configurados (SNS_TOPIC_ARNS): /ses-feedback e /inbound-email/ses recusarão todas as mensagens")
+	}
+	sesEventService := service.NewSESEventService(audienceRepo, engagementRepo, suppressionService)
+	inboundEmails := service.NewInboundEmailService(inboundEmailRepo, audienceRepo, contactRepo, chatRepo, chatContactRepo, chatMessageRepo, nil)
+	emailValidator := service.NewEmailValidationService(nil, time.Duration(config.GetEnvInt("EMAIL_VALIDATION_DNS_TIMEOUT", 3))*time.Second)
+	campaignState := service.NewCampaignStateService(campaignRepo, audienceRepo, campaignStatusHistoryRepo)
+	if os.Getenv("WHATSAPP_CLOUD_APP_SECRET") == "" {
+		logger.Warn("⚠️ WHATSAPP_CLOUD_APP_SECRET não configurado: webhooks da Cloud API (mensagens e status) serão recusados")
+	}
+	whatsappProviders := service.NewWhatsAppProviders(
+		service.NewBaileysProvider(os.Getenv("WHATSAPP_API_URL"), os.Getenv("WHATSAPP_API_KEY")),
+		service.NewEvolutionProvider(os.Getenv("EVOLUTION_API_URL"), os.Getenv("EVOLUTION_API_KEY")),
+		service.NewCloudAPIProvider(os.Getenv("WHATSAPP_CLOUD_API_URL"), os.Getenv("WHATSAPP_CLOUD_ACCESS_TOKEN"), os.Getenv("WHATSAPP_CLOUD_APP_SECRET")),
 	)
+	whatsappTemplates := service.NewWhatsAppTemplateService(whatsappProviders, whatsappPhoneNumberRepo, whatsappTemplateRepo)
+	whatsappStatus := service.NewWhatsAppStatusService(audienceRepo, engagementRepo)
 
 	// Criar contexto de controle para os workers
 	ctx, cancel := context.WithCancel(context.Background())
@@ -79,16 +211,61 @@ func main() {
 	// Iniciar Workers de forma otimizada
 	emailWorker := workers.NewEmailWorker(
 		sqsService, emailService, audienceRepo, contactRepo, campaignRepo,
-		accountRepo, accountSettingsRepo, campaignSettingsRepo, openAIService,
+		accountRepo, accountSettingsRepo, campaignSettingsRepo, openAIService, sendPacer, campaignState, suppressionService,
+		senderIdentities, deliveredMessageRepo, config.GetEnvInt("EMAIL_WORKER_CONCURRENCY", 5),
 	)
 	startWorker(ctx, emailWorker, "EmailWorker")
 
 	whatsappWorker := workers.NewWhatsAppWorker(
-		sqsService, whatsappService, audienceRepo, contactRepo, campaignRepo,
-		accountRepo, accountSettingsRepo, campaignSettingsRepo, openAIService,
+		sqsService, whatsappProviders, chatRepo, campaignProcessor, audienceRepo, contactRepo, campaignRepo,
+		accountRepo, accountSettingsRepo, campaignSettingsRepo, sendPacer, campaignState, suppressionService,
+		deliveredMessageRepo, whatsappTemplates, config.GetEnvInt("WHATSAPP_WORKER_CONCURRENCY", 2),
 	)
 	startWorker(ctx, whatsappWorker, "WhatsAppWorker")
 
+	// 🧹 Limpeza do conteúdo entregue conforme a retenção de cada conta
+	messageRetentionWorker := workers.NewMessageRetentionWorker(
+		deliveredMessageRepo,
+		time.Duration(config.GetEnvInt("MESSAGE_RETENTION_INTERVAL_MINUTES", 60))*time.Minute,
+	)
+	startWorker(ctx, messageRetentionWorker, "MessageRetentionWorker")
+
+	// 🗓️ Agendador de campanhas (seguro com várias réplicas: cada campanha é reservada por uma só, com lease)
+	campaignScheduler := workers.NewCampaignScheduler(
+		campaignRepo, audienceRepo, campaignProcessor, campaignState, senderIdentities,
+		time.Duration(config.GetEnvInt("CAMPAIGN_SCHEDULER_INTERVAL", 30))*time.Second,
+		time.Duration(config.GetEnvInt("CAMPAIGN_SCHEDULER_LEASE", 300))*time.Second,
+	)
+	startWorker(ctx, campaignScheduler, "CampaignScheduler")
+
+	// 🎤 Transcrição e descrição das mídias recebidas no atendimento
+	if chatMedia.Enabled() {
+		visionModel := os.Getenv("CHAT_VISION_MODEL")
+		if visionModel == "" {
+			visionModel = "gpt-4o-mini"
+		} else if visionModel == "none" {
+			visionModel = ""
+		}
+		chatMediaWorker := workers.NewChatMediaWorker(
+			chatMessageRepo, service.NewChatMediaProcessor(chatMedia, newSpeechToText(), openAIService, visionModel),
+			time.Duration(config.GetEnvInt("CHAT_MEDIA_PROCESSING_INTERVAL", 10))*time.Second,
+			config.GetEnvInt("CHAT_MEDIA_PROCESSING_CONCURRENCY", 2),
+			config.GetEnvInt("CHAT_MEDIA_PROCESSING_MAX_ATTEMPTS", 3),
+		)
+		startWorker(ctx, chatMediaWorker, "ChatMediaWorker")
+	}
+
+	// 📬 Eventos do SES via SQS (alternativa ao webhook /ses-feedback quando o SNS não alcança a API)
+	if sesEventsQueueURL := os.Getenv("SQS_SES_EVENTS_URL"); sesEventsQueueURL != "" {
+		sesEventsConsumer, err := service.NewSQSConsumer("ses-events", sesEventsQueueURL,
+			time.Duration(config.GetEnvInt("QUEUE_VISIBILITY_TIMEOUT", 60))*time.Second)
+		if err != nil {
+			logger.Fatal("Erro ao inicializar fila de eventos do SES", err)
+		}
+		sesFeedbackWorker := workers.NewSESFeedbackWorker(sesEventsConsumer, sesEventService, config.GetEnvInt("SES_FEEDBACK_WORKER_CONCURRENCY", 5))
+		startWorker(ctx, sesFeedbackWorker, "SESFeedbackWorker")
+	}
+
 	// Criar servidor HTTP com middleware CORS
 	port := os.Getenv("APP_PORT")
 	mux := http.NewServeMux()
@@ -99,6 +276,11 @@ func main() {
 		templateRepo, campaignRepo, audienceRepo, campaignSettingsRepo,
 		openAIService, campaignProcessor, contactImportRepo,
 		campaignMessageRepo, chatRepo, chatContactRepo, chatMessageRepo,
+		sendPolicyRepo, sendPacer, campaignState, engagementRepo, emailTracking,
+		unsubscribeService, suppressionRepo, sesEventService, snsVerifier,
+		senderIdentityRepo, senderIdentities, inboundEmailRepo, inboundEmails, assetService, emailValidator,
+		deliveredMessageRepo, whatsappProviders, whatsappPhoneNumberRepo, whatsappTemplateRepo,
+		whatsappTemplates, whatsappStatus, chatMedia,
 	))
 
 	mux.Handle("/", router)
@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -64,3 +65,23 @@ func (c *Config) loadFromFile() {
 func GetEnvVar(key string) string {
 	return os.Getenv(key)
 }
+
+// GetEnvInt lê uma variável inteira do ambiente, usando o valor padrão se ausente ou inválida
+func GetEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// GetEnvList lê uma lista separada por vírgulas do ambiente, ignorando itens vazios
+func GetEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,21 @@
+// File: /internal/db/asset_repo.go
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// AssetRepository define as operações sobre os arquivos das contas
+type AssetRepository interface {
+	Create(ctx context.Context, asset *models.Asset) (*models.Asset, error)
+	GetByID(ctx context.Context, assetID uuid.UUID) (*models.Asset, error)
+	GetByStorageKey(ctx context.Context, storageKey string) (*models.Asset, error)
+	GetByIDs(ctx context.Context, accountID uuid.UUID, assetIDs []uuid.UUID) ([]models.Asset, error)
+	GetPaginated(ctx context.Context, accountID uuid.UUID, filters map[string]string, currentPage int, perPage int) (*models.Paginator, error)
+	CountCampaignReferences(ctx context.Context, assetID uuid.UUID) (int, error)
+	DeleteByID(ctx context.Context, assetID uuid.UUID) error
+}
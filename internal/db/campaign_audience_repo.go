@@ -4,20 +4,53 @@ package db
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/dto"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
 )
 
+// ErrContactNotInCampaignAccount indica contato inexistente ou de outra conta ao montar a audiência
+var ErrContactNotInCampaignAccount = errors.New("contato não encontrado na conta da campanha")
+
 type CampaignAudienceRepository interface {
-	AddContactsToCampaign(ctx context.Context, campaignID uuid.UUID, contacts []models.CampaignAudience) ([]models.CampaignAudience, error)
-	AddAllFilteredContacts(ctx context.Context, accountID uuid.UUID, campaignID uuid.UUID, filters *map[string]string, channelType models.ChannelType) error
+	AddContactsToCampaign(ctx context.Context, campaignID uuid.UUID, contacts []models.CampaignAudience) ([]models.CampaignAudience, int, error)
+	AddAllFilteredContacts(ctx context.Context, accountID uuid.UUID, campaignID uuid.UUID, filters *map[string]string, channelType models.ChannelType) (int, error)
 	GetCampaignAudience(ctx context.Context, campaignID uuid.UUID, contactType *string) ([]dto.CampaignAudienceDTO, error)
 	GetCampaignAudienceToSQS(ctx context.Context, accountID uuid.UUID, campaignID uuid.UUID, contactType *string) ([]dto.CampaignMessageDTO, error)
 	RemoveContactFromCampaign(ctx context.Context, campaignID, audienceID uuid.UUID) error
-	UpdateStatus(ctx context.Context, contactID uuid.UUID, status, messageID string, feedback map[string]interface{}) error
-	UpdateStatusByMessageID(ctx context.Context, messageID string, status string, feedbackAPI *string) error
+	GetByID(ctx context.Context, audienceID uuid.UUID) (*models.CampaignAudience, error)
+	UpdateStatus(ctx context.Context, audienceID uuid.UUID, status, messageID string, feedback map[string]interface{}) error
+	// MarkQueued marca a audiência não enviada como "fila" com o token do novo enfileiramento.
+	// Retorna false se a audiência já foi reservada, enviada ou cancelada (não deve ser enfileirada).
+	MarkQueued(ctx context.Context, audienceID uuid.UUID, token uuid.UUID) (bool, error)
+	// ClaimForSending reserva a audiência ("pendente"/"fila" -> "enviando") para um único worker, somente
+	// se `token` for o do último enfileiramento. Reservas mais antigas que `lease` (worker que caiu) podem
+	// ser retomadas. Retorna false se outro worker já reservou ou se a mensagem é de um enfileiramento anterior.
+	ClaimForSending(ctx context.Context, audienceID uuid.UUID, token uuid.UUID, lease time.Duration) (bool, error)
+	// ReleaseClaim devolve para "fila" a audiência ainda reservada (envio reagendado ou mensagem reentregue pela fila)
+	ReleaseClaim(ctx context.Context, audienceID uuid.UUID) error
+	GetUnsentToSQS(ctx context.Context, accountID uuid.UUID, campaignID uuid.UUID) ([]dto.CampaignMessageDTO, error)
+	ReturnQueuedToPending(ctx context.Context, campaignID uuid.UUID) (int64, error)
+	CancelUnsent(ctx context.Context, campaignID uuid.UUID) (int64, error)
+	CancelUnsentByContact(ctx context.Context, contactID uuid.UUID, channel models.ChannelType) (int64, error)
+	HasPending(ctx context.Context, campaignID uuid.UUID) (bool, error)
+	// CountUnsentFailures conta os contatos que não receberam a mensagem (falha de envio, de renderização ou dead-letter)
+	CountUnsentFailures(ctx context.Context, campaignID uuid.UUID) (int, error)
+	CountPendingByChannel(ctx context.Context, campaignID uuid.UUID) (map[string]int, error)
+	GetStatusCounts(ctx context.Context, campaignID uuid.UUID) ([]dto.CampaignStatusCountDTO, error)
+	GetStatsSeries(ctx context.Context, campaignID uuid.UUID, interval, timezone string) ([]dto.CampaignStatsPointDTO, error)
+	GetAccountCampaignStats(ctx context.Context, accountID uuid.UUID, filter dto.CampaignAnalyticsFilterDTO) ([]dto.CampaignComparisonDTO, error)
+	RegisterFailure(ctx context.Context, audienceID uuid.UUID, lastError string) (int, error)
+	GetDeadLetters(ctx context.Context, campaignID uuid.UUID) ([]dto.CampaignDeadLetterDTO, error)
+	RequeueDeadLetters(ctx context.Context, campaignID uuid.UUID, audienceIDs []uuid.UUID) ([]dto.CampaignMessageDTO, error)
+	// UpdateStatusByMessageID aplica o status de um evento do SES sem regredir (ex.: "enviado" depois de "entregue")
+	UpdateStatusByMessageID(ctx context.Context, messageID string, status string, feedback map[string]interface{}) (bool, error)
+	// AdvanceStatusByMessageID aplica o status informado pelo provedor sem voltar de "entregue"/"rejeitado" para "enviado"
+	AdvanceStatusByMessageID(ctx context.Context, messageID string, status models.AudienceStatus, feedback map[string]interface{}) (bool, error)
+	GetMessageByMessageID(ctx context.Context, messageID string) (*dto.CampaignMessageDTO, error)
 	GetPaginatedCampaignAudience(ctx context.Context, campaignID uuid.UUID, contactType *string, currentPage int, perPage int) (*models.Paginator, error)
 	RemoveAllContactsFromCampaign(ctx context.Context, campaignID uuid.UUID) error
 	GetRandomContact(ctx context.Context, campaignID uuid.UUID, channel string) (*models.Contact, error)
@@ -4,6 +4,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
@@ -15,7 +16,13 @@ type CampaignRepository interface {
 	GetByID(ctx context.Context, campaignID uuid.UUID) (*models.Campaign, error)
 	GetAllByAccountID(ctx context.Context, accountID uuid.UUID, filters *map[string]string) ([]models.Campaign, error)
 	UpdateByID(ctx context.Context, campaignID uuid.UUID, campaign *models.Campaign) (*models.Campaign, error)
-	UpdateStatus(ctx context.Context, campaignID uuid.UUID, status string) error
+	TransitionStatus(ctx context.Context, campaignID uuid.UUID, from, to models.CampaignStatus) (bool, error)
+	Schedule(ctx context.Context, campaignID uuid.UUID, scheduledAt time.Time, timezone string) error
+	Unschedule(ctx context.Context, campaignID uuid.UUID) error
+	ClaimDueScheduled(ctx context.Context, owner string, limit int) ([]models.Campaign, error)
+	// ReclaimExpired retoma campanhas "processando" cuja reserva do agendador não é renovada há mais de `lease`
+	ReclaimExpired(ctx context.Context, owner string, lease time.Duration, limit int) ([]models.Campaign, error)
+	// RenewClaim renova a reserva do agendador enquanto a audiência é enfileirada
+	RenewClaim(ctx context.Context, campaignID uuid.UUID, owner string) error
 	DeleteByID(ctx context.Context, campaignID uuid.UUID) error
-	UpdateStatusConcluido(ctx context.Context, campaignID uuid.UUID)
 }
@@ -0,0 +1,16 @@
+// File: /internal/db/campaign_status_history_repo.go
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// CampaignStatusHistoryRepository define as operações da linha do tempo de status das campanhas
+type CampaignStatusHistoryRepository interface {
+	Create(ctx context.Context, entry *models.CampaignStatusHistory) error
+	GetByCampaignID(ctx context.Context, campaignID uuid.UUID) ([]models.CampaignStatusHistory, error)
+}
@@ -12,6 +12,7 @@ import (
 
 type ChatContactRepository interface {
 	FindOrCreate(ctx context.Context, accountID, chatID, whatsappContactID uuid.UUID) (*models.ChatContact, error)
+	FindOrCreateByContact(ctx context.Context, accountID, chatID, contactID uuid.UUID) (*models.ChatContact, error)
 	FindByID(ctx context.Context, accountID, chatID, chatContactID uuid.UUID) (*models.ChatContact, error)
 	ListByChatID(ctx context.Context, accountID, chatID uuid.UUID) ([]dto.ChatContactFull, error)
 }
@@ -4,6 +4,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
@@ -12,4 +13,10 @@ import (
 type ChatMessageRepository interface {
 	Create(ctx context.Context, msg models.ChatMessage) (*models.ChatMessage, error)
 	ListByChatContact(ctx context.Context, chatContactID uuid.UUID) ([]models.ChatMessage, error)
+	// ClaimUnprocessed reserva (por `lease`) mensagens de clientes com mídia dos tipos informados ainda não processada
+	ClaimUnprocessed(ctx context.Context, types []string, limit, maxAttempts int, lease time.Duration) ([]models.ChatMessage, error)
+	// SaveProcessedContent grava o conteúdo extraído da mídia e marca a mensagem como processada
+	SaveProcessedContent(ctx context.Context, id uuid.UUID, content string) error
+	// SaveProcessingError registra a falha; `permanent` encerra o processamento
+	SaveProcessingError(ctx context.Context, id uuid.UUID, message string, permanent bool) error
 }
@@ -20,4 +20,6 @@ type ContactRepository interface {
 	DeleteByID(ctx context.Context, contactID uuid.UUID) error
 	GetAvailableContactsForCampaign(ctx context.Context, accountID uuid.UUID, campaignID uuid.UUID, filters map[string]string, sort string, currentPage int, perPage int) (*models.Paginator, error)
 	FindOrCreateByWhatsApp(ctx context.Context, accountID uuid.UUID, whatsappContact *models.Contact) (*models.Contact, error)
+	OptOut(ctx context.Context, contactID uuid.UUID, channel models.ChannelType) error
+	AppendHistory(ctx context.Context, contactID uuid.UUID, entry string) error
 }
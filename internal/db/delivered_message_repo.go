@@ -0,0 +1,19 @@
+// File: /internal/db/delivered_message_repo.go
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// DeliveredMessageRepository define as operações sobre o conteúdo entregue aos destinatários
+type DeliveredMessageRepository interface {
+	// Save grava a mensagem da audiência (substitui o registro anterior da mesma audiência)
+	Save(ctx context.Context, message *models.DeliveredMessage) (*models.DeliveredMessage, error)
+	GetByAudienceID(ctx context.Context, accountID, campaignID, audienceID uuid.UUID) (*models.DeliveredMessage, error)
+	// DeleteExpired remove até `limit` mensagens além da retenção de cada conta
+	DeleteExpired(ctx context.Context, limit int) (int64, error)
+}
@@ -0,0 +1,16 @@
+// File: /internal/db/engagement_repo.go
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// EngagementRepository define as operações de registro de aberturas e cliques
+type EngagementRepository interface {
+	Record(ctx context.Context, audienceID uuid.UUID, event *models.EngagementEvent) (bool, error)
+	RecordByMessageID(ctx context.Context, messageID string, event *models.EngagementEvent) (bool, error)
+}
@@ -0,0 +1,19 @@
+// File: /internal/db/inbound_email_repo.go
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// InboundEmailRepository define as operações sobre os e-mails recebidos dos contatos
+type InboundEmailRepository interface {
+	// Create grava o e-mail; retorna nil, nil se o Message-ID já foi registrado na conta
+	Create(ctx context.Context, email *models.InboundEmail) (*models.InboundEmail, error)
+	GetByMessageID(ctx context.Context, accountID uuid.UUID, messageID string) (*models.InboundEmail, error)
+	GetPaginated(ctx context.Context, accountID uuid.UUID, filters map[string]string, currentPage int, perPage int) (*models.Paginator, error)
+	SetChatMessage(ctx context.Context, inboundEmailID, chatMessageID uuid.UUID) error
+}
@@ -28,8 +28,10 @@ func (r *accountSettingsRepo) Create(ctx context.Context, settings *models.Accou
 	query := `
 		INSERT INTO account_settings (
 			account_id, openai_api_key, evolution_instance, aws_access_key_id,
-			aws_secret_access_key, aws_region, mail_from, mail_admin_to
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			aws_secret_access_key, aws_region, mail_from, mail_admin_to,
+			email_provider, smtp_host, smtp_port, smtp_username, smtp_password, smtp_tls,
+			message_retention_days
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id
 	`
 
@@ -37,6 +39,8 @@ func (r *accountSettingsRepo) Create(ctx context.Context, settings *models.Accou
 		query, settings.AccountID, settings.OpenAIAPIKey, settings.EvolutionInstance,
 		settings.AWSAccessKeyID, settings.AWSSecretAccessKey, settings.AWSRegion,
 		settings.MailFrom, settings.MailAdminTo,
+		settings.EmailProvider, settings.SMTPHost, settings.SMTPPort, settings.SMTPUsername,
+		settings.SMTPPassword, settings.SMTPTLS, settings.MessageRetentionDays,
 	).Scan(&settings.ID)
 
 	if err != nil {
@@ -50,7 +54,9 @@ func (r *accountSettingsRepo) Create(ctx context.Context, settings *models.Accou
 func (r *accountSettingsRepo) GetByAccountID(ctx context.Context, accountID uuid.UUID) (*models.AccountSettings, error) {
 	query := `
 		SELECT id, account_id, openai_api_key, evolution_instance, aws_access_key_id,
-		       aws_secret_access_key, aws_region, mail_from, mail_admin_to
+		       aws_secret_access_key, aws_region, mail_from, mail_admin_to,
+		       email_provider, COALESCE(smtp_host, ''), smtp_port, COALESCE(smtp_username, ''),
+		       COALESCE(smtp_password, ''), smtp_tls, message_retention_days
 		FROM account_settings WHERE account_id = $1
 	`
 	settings := &models.AccountSettings{}
@@ -59,6 +65,8 @@ func (r *accountSettingsRepo) GetByAccountID(ctx context.Context, accountID uuid
 		&settings.ID, &settings.AccountID, &settings.OpenAIAPIKey, &settings.EvolutionInstance,
 		&settings.AWSAccessKeyID, &settings.AWSSecretAccessKey, &settings.AWSRegion,
 		&settings.MailFrom, &settings.MailAdminTo,
+		&settings.EmailProvider, &settings.SMTPHost, &settings.SMTPPort, &settings.SMTPUsername,
+		&settings.SMTPPassword, &settings.SMTPTLS, &settings.MessageRetentionDays,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -75,14 +83,18 @@ func (r *accountSettingsRepo) UpdateByAccountID(ctx context.Context, accountID u
 	query := `
 		UPDATE account_settings
 		SET openai_api_key = $1, evolution_instance = $2, aws_access_key_id = $3,
-		    aws_secret_access_key = $4, aws_region = $5, mail_from = $6, mail_admin_to = $7
-		WHERE account_id = $8
+		    aws_secret_access_key = $4, aws_region = $5, mail_from = $6, mail_admin_to = $7,
+		    email_provider = $8, smtp_host = $9, smtp_port = $10, smtp_username = $11,
+		    smtp_password = $12, smtp_tls = $13, message_retention_days = $14
+		WHERE account_id = $15
 		RETURNING id
 	`
 
 	err := r.db.QueryRow(
 		query, settings.OpenAIAPIKey, settings.EvolutionInstance, settings.AWSAccessKeyID,
 		settings.AWSSecretAccessKey, settings.AWSRegion, settings.MailFrom, settings.MailAdminTo,
+		settings.EmailProvider, settings.SMTPHost, settings.SMTPPort, settings.SMTPUsername,
+		settings.SMTPPassword, settings.SMTPTLS, settings.MessageRetentionDays,
 		accountID,
 	).Scan(&settings.ID)
 
@@ -0,0 +1,185 @@
+// File: /internal/db/postgres/asset_repo.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/lib/pq"
+)
+
+// assetRepository implementa AssetRepository para PostgreSQL
+type assetRepository struct {
+	log *slog.Logger
+	db  *sql.DB
+}
+
+// NewAssetRepository cria um novo repositório de arquivos
+func NewAssetRepository(db *sql.DB) db.AssetRepository {
+	log := logger.GetLogger()
+	return &assetRepository{log: log, db: db}
+}
+
+const assetColumns = `id, account_id, name, storage_key, content_type, size_bytes, created_at`
+
+// scanAsset converte uma linha em Asset
+func scanAsset(scanner interface{ Scan(dest ...any) error }) (*models.Asset, error) {
+	var asset models.Asset
+	if err := scanner.Scan(
+		&asset.ID, &asset.AccountID, &asset.Name, &asset.StorageKey, &asset.ContentType, &asset.SizeBytes, &asset.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// Create grava o arquivo (o ID é gerado pelo serviço para compor a chave de armazenamento)
+func (r *assetRepository) Create(ctx context.Context, asset *models.Asset) (*models.Asset, error) {
+	query := `
+		INSERT INTO assets (id, account_id, name, storage_key, content_type, size_bytes)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + assetColumns
+
+	saved, err := scanAsset(r.db.QueryRowContext(ctx, query,
+		asset.ID, asset.AccountID, asset.Name, asset.StorageKey, asset.ContentType, asset.SizeBytes,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao salvar arquivo: %w", err)
+	}
+
+	return saved, nil
+}
+
+// GetByID busca um arquivo pelo ID
+func (r *assetRepository) GetByID(ctx context.Context, assetID uuid.UUID) (*models.Asset, error) {
+	query := `SELECT ` + assetColumns + ` FROM assets WHERE id = $1`
+
+	asset, err := scanAsset(r.db.QueryRowContext(ctx, query, assetID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar arquivo: %w", err)
+	}
+
+	return asset, nil
+}
+
+// GetByStorageKey busca um arquivo pela chave de armazenamento (URL pública)
+func (r *assetRepository) GetByStorageKey(ctx context.Context, storageKey string) (*models.Asset, error) {
+	query := `SELECT ` + assetColumns + ` FROM assets WHERE storage_key = $1`
+
+	asset, err := scanAsset(r.db.QueryRowContext(ctx, query, storageKey))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar arquivo: %w", err)
+	}
+
+	return asset, nil
+}
+
+// GetByIDs busca os arquivos da conta pelos IDs (IDs de outras contas ou inexistentes são ignorados)
+func (r *assetRepository) GetByIDs(ctx context.Context, accountID uuid.UUID, assetIDs []uuid.UUID) ([]models.Asset, error) {
+	query := `SELECT ` + assetColumns + ` FROM assets WHERE account_id = $1 AND id = ANY($2) ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID, pq.Array(assetIDs))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar arquivos: %w", err)
+	}
+	defer rows.Close()
+
+	assets := []models.Asset{}
+	for rows.Next() {
+		asset, err := scanAsset(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao escanear arquivo: %w", err)
+		}
+		assets = append(assets, *asset)
+	}
+
+	return assets, rows.Err()
+}
+
+// GetPaginated lista os arquivos da conta (filtros: name e content_type)
+func (r *assetRepository) GetPaginated(ctx context.Context, accountID uuid.UUID, filters map[string]string, currentPage int, perPage int) (*models.Paginator, error) {
+	if currentPage < 1 {
+		currentPage = 1
+	}
+	if perPage < 1 {
+		perPage = 10
+	}
+
+	baseQuery := `SELECT ` + assetColumns + ` FROM assets WHERE account_id = $1`
+	args := []interface{}{accountID}
+
+	for key, value := range filters {
+		switch key {
+		case "name":
+			args = append(args, "%"+value+"%")
+			baseQuery += fmt.Sprintf(" AND name ILIKE $%d", len(args))
+		case "content_type":
+			args = append(args, value+"%")
+			baseQuery += fmt.Sprintf(" AND content_type LIKE $%d", len(args))
+		}
+	}
+
+	var totalRecords int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+baseQuery+") AS total", args...).Scan(&totalRecords); err != nil {
+		return nil, fmt.Errorf("erro ao contar arquivos: %w", err)
+	}
+
+	offset := (currentPage - 1) * perPage
+	baseQuery += fmt.Sprintf(" ORDER BY created_at DESC LIMIT %d OFFSET %d", perPage, offset)
+
+	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar arquivos: %w", err)
+	}
+	defer rows.Close()
+
+	assets := []models.Asset{}
+	for rows.Next() {
+		asset, err := scanAsset(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao escanear arquivo: %w", err)
+		}
+		assets = append(assets, *asset)
+	}
+
+	return &models.Paginator{
+		TotalRecords: totalRecords,
+		TotalPages:   int(math.Ceil(float64(totalRecords) / float64(perPage))),
+		CurrentPage:  currentPage,
+		PerPage:      perPage,
+		Data:         assets,
+	}, nil
+}
+
+// CountCampaignReferences conta as configurações de campanha que anexam o arquivo
+func (r *assetRepository) CountCampaignReferences(ctx context.Context, assetID uuid.UUID) (int, error) {
+	var total int
+	query := `SELECT COUNT(*) FROM campaign_settings WHERE $1 = ANY(email_attachments)`
+	if err := r.db.QueryRowContext(ctx, query, assetID).Scan(&total); err != nil {
+		return 0, fmt.Errorf("erro ao contar campanhas que usam o arquivo: %w", err)
+	}
+	return total, nil
+}
+
+// DeleteByID remove o registro do arquivo
+func (r *assetRepository) DeleteByID(ctx context.Context, assetID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM assets WHERE id = $1`, assetID); err != nil {
+		return fmt.Errorf("erro ao excluir arquivo: %w", err)
+	}
+	return nil
+}
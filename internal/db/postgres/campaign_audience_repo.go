@@ -11,6 +11,7 @@ import (
 	"log/slog"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/db"
@@ -18,6 +19,7 @@ import (
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
 	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+	"github.com/lib/pq"
 )
 
 type campaignAudienceRepo struct {
@@ -31,16 +33,32 @@ func NewCampaignAudienceRepository(db *sql.DB) db.CampaignAudienceRepository {
 	return &campaignAudienceRepo{log: log, db: db}
 }
 
-// Adiciona contatos à audiência da campanha
-func (r *campaignAudienceRepo) AddContactsToCampaign(ctx context.Context, campaignID uuid.UUID, contacts []models.CampaignAudience) ([]models.CampaignAudience, error) {
+// Adiciona contatos à audiência da campanha, ignorando destinatários suprimidos no canal.
+// Retorna os registros salvos e quantos foram ignorados por supressão; contato inexistente ou de outra conta
+// cancela a operação com db.ErrContactNotInCampaignAccount.
+func (r *campaignAudienceRepo) AddContactsToCampaign(ctx context.Context, campaignID uuid.UUID, contacts []models.CampaignAudience) ([]models.CampaignAudience, int, error) {
 	audiences := []models.CampaignAudience{}
+	suppressed := 0
 
-	tx, err := r.db.Begin()
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	stmt, err := tx.Prepare(`
+	// 🔍 O contato precisa existir na conta da campanha; a supressão é verificada separadamente
+	checkStmt, err := tx.PrepareContext(ctx, `
+		SELECT `+suppressedContactCondition("c", "$3")+`
+		FROM contacts c
+		INNER JOIN campaigns cp ON cp.account_id = c.account_id
+		WHERE cp.id = $1 AND c.id = $2
+	`)
+	if err != nil {
+		tx.Rollback()
+		return nil, 0, err
+	}
+	defer checkStmt.Close()
+
+	insertStmt, err := tx.PrepareContext(ctx, `
 		INSERT INTO campaigns_audience (campaign_id, contact_id, type, status, updated_at)
 		VALUES ($1, $2, $3, 'pendente', NOW())
 		ON CONFLICT (campaign_id, contact_id) DO UPDATE 
@@ -49,34 +67,50 @@ func (r *campaignAudienceRepo) AddContactsToCampaign(ctx context.Context, campai
 	`)
 	if err != nil {
 		tx.Rollback()
-		return nil, err
+		return nil, 0, err
 	}
-	defer stmt.Close()
+	defer insertStmt.Close()
 
 	for _, contact := range contacts {
+		var isSuppressed bool
+		err := checkStmt.QueryRowContext(ctx, campaignID, contact.ContactID, contact.Type).Scan(&isSuppressed)
+		if errors.Is(err, sql.ErrNoRows) {
+			tx.Rollback()
+			return nil, 0, fmt.Errorf("%w (contact_id: %s)", db.ErrContactNotInCampaignAccount, contact.ContactID)
+		}
+		if err != nil {
+			tx.Rollback()
+			return nil, 0, err
+		}
+		if isSuppressed {
+			suppressed++ // 🛑 Destinatário na lista de supressão
+			continue
+		}
+
 		var audience models.CampaignAudience
-		err := stmt.QueryRow(campaignID, contact.ContactID, contact.Type).Scan(
+		err = insertStmt.QueryRowContext(ctx, campaignID, contact.ContactID, contact.Type).Scan(
 			&audience.ID, &audience.CampaignID, &audience.ContactID, &audience.Type, &audience.Status, &audience.MessageID, &audience.Feedback, &audience.CreatedAt, &audience.UpdatedAt,
 		)
 		if err != nil {
 			tx.Rollback()
-			return nil, err
+			return nil, 0, err
 		}
 		audiences = append(audiences, audience)
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return audiences, nil
+	return audiences, suppressed, nil
 }
 
-// Adiciona todos os contatos filtrados à audiência da campanha
-func (r *campaignAudienceRepo) AddAllFilteredContacts(ctx context.Context, accountID uuid.UUID, campaignID uuid.UUID, filters *map[string]string, channelType models.ChannelType) error {
-	// 🔍 Query base para buscar contatos filtrados
+// Adiciona todos os contatos filtrados à audiência da campanha.
+// Destinatários suprimidos no canal não são adicionados; retorna quantos foram ignorados.
+func (r *campaignAudienceRepo) AddAllFilteredContacts(ctx context.Context, accountID uuid.UUID, campaignID uuid.UUID, filters *map[string]string, channelType models.ChannelType) (int, error) {
+	// 🔍 Query base para buscar contatos filtrados (com indicação de supressão no canal)
 	selectQuery := `
-		SELECT id
+		SELECT id, ` + suppressedContactCondition("contacts", pq.QuoteLiteral(string(channelType))) + `
 		FROM contacts
 		WHERE account_id = $1 
 		AND opt_out_at IS NULL 
@@ -84,9 +118,9 @@ func (r *campaignAudienceRepo) AddAllFilteredContacts(ctx context.Context, accou
 	`
 
 	if channelType == models.EmailChannel {
-		selectQuery += " AND email IS NOT NULL"
+		selectQuery += " AND email IS NOT NULL AND email_opt_out_at IS NULL"
 	} else if channelType == models.WhatsappChannel {
-		selectQuery += " AND whatsapp IS NOT NULL"
+		selectQuery += " AND whatsapp IS NOT NULL AND whatsapp_opt_out_at IS NULL"
 	}
 
 	args := []interface{}{accountID, campaignID}
@@ -99,30 +133,40 @@ func (r *campaignAudienceRepo) AddAllFilteredContacts(ctx context.Context, accou
 			case "name", "email", "whatsapp", "cidade", "estado", "bairro":
 				selectQuery += fmt.Sprintf(" AND %s ILIKE $%d", key, filterIndex)
 				args = append(args, "%"+value+"%")
+				filterIndex++
 			case "gender":
 				selectQuery += fmt.Sprintf(" AND gender = $%d", filterIndex)
 				args = append(args, value)
+				filterIndex++
+			case "email_status":
+				// Um ou mais status separados por vírgula (ex.: valid,risky)
+				selectQuery += fmt.Sprintf(" AND email_status = ANY($%d)", filterIndex)
+				args = append(args, pq.Array(emailStatusFilter(value)))
+				filterIndex++
 			case "birth_date_start":
 				selectQuery += fmt.Sprintf(" AND birth_date >= $%d", filterIndex)
 				dateValue, err := utils.ParseDate(value)
 				if err != nil {
-					return fmt.Errorf("erro ao converter data: %w", err)
+					return 0, fmt.Errorf("erro ao converter data: %w", err)
 				}
 				args = append(args, dateValue)
+				filterIndex++
 			case "birth_date_end":
 				selectQuery += fmt.Sprintf(" AND birth_date <= $%d", filterIndex)
 				dateValue, err := utils.ParseDate(value)
 				if err != nil {
-					return fmt.Errorf("erro ao converter data: %w", err)
+					return 0, fmt.Errorf("erro ao converter data: %w", err)
 				}
 				args = append(args, dateValue)
+				filterIndex++
 			case "last_contact_at":
 				selectQuery += fmt.Sprintf(" AND last_contact_at >= $%d", filterIndex)
 				dateValue, err := utils.ParseDate(value)
 				if err != nil {
-					return fmt.Errorf("erro ao converter data: %w", err)
+					return 0, fmt.Errorf("erro ao converter data: %w", err)
 				}
 				args = append(args, dateValue)
+				filterIndex++
 			case "tags":
 				// Filtra globalmente dentro do JSONB como um texto simples
 				tags := strings.Split(value, ",")
@@ -162,16 +206,14 @@ func (r *campaignAudienceRepo) AddAllFilteredContacts(ctx context.Context, accou
 				if len(conditions) > 0 {
 					selectQuery += " AND (" + strings.Join(conditions, " OR ") + ")"
 				}
-
 			}
-			filterIndex++
 		}
 	}
 
 	// 🔹 Buscar IDs dos contatos disponíveis
 	rows, err := r.db.QueryContext(ctx, selectQuery, args...)
 	if err != nil {
-		return fmt.Errorf("erro ao buscar contatos disponíveis: %w", err)
+		return 0, fmt.Errorf("erro ao buscar contatos disponíveis: %w", err)
 	}
 	defer rows.Close()
 
@@ -180,10 +222,16 @@ func (r *campaignAudienceRepo) AddAllFilteredContacts(ctx context.Context, accou
 	insertArgs := []interface{}{campaignID} // Primeiro argumento é o ID da campanha
 	insertIndex := 2
 
+	suppressed := 0
 	for rows.Next() {
 		var contactID uuid.UUID
-		if err := rows.Scan(&contactID); err != nil {
-			return fmt.Errorf("erro ao escanear contatos disponíveis: %w", err)
+		var isSuppressed bool
+		if err := rows.Scan(&contactID, &isSuppressed); err != nil {
+			return 0, fmt.Errorf("erro ao escanear contatos disponíveis: %w", err)
+		}
+		if isSuppressed {
+			suppressed++ // 🛑 Destinatário na lista de supressão
+			continue
 		}
 
 		placeholders = append(placeholders, fmt.Sprintf("($1, $%d, $%d)", insertIndex, insertIndex+1))
@@ -200,11 +248,11 @@ func (r *campaignAudienceRepo) AddAllFilteredContacts(ctx context.Context, accou
 		)
 
 		if _, err := r.db.ExecContext(ctx, insertQuery, insertArgs...); err != nil {
-			return fmt.Errorf("erro ao inserir contatos filtrados: %w", err)
+			return 0, fmt.Errorf("erro ao inserir contatos filtrados: %w", err)
 		}
 	}
 
-	return nil
+	return suppressed, nil
 }
 
 // GetCampaignAudience retorna a audiência de uma campanha junto com os detalhes dos contatos
@@ -261,38 +309,590 @@ func (r *campaignAudienceRepo) RemoveContactFromCampaign(ctx context.Context, ca
 	return err
 }
 
-// Atualiza o status de um contato enviado
-func (r *campaignAudienceRepo) UpdateStatus(ctx context.Context, contactID uuid.UUID, status, messageID string, feedback map[string]interface{}) error {
+// GetByID busca um registro da audiência pelo ID
+func (r *campaignAudienceRepo) GetByID(ctx context.Context, audienceID uuid.UUID) (*models.CampaignAudience, error) {
+	query := `
+		SELECT id, campaign_id, contact_id, type, status, message_id, feedback_api, attempts, last_error, dispatch_token, created_at, updated_at
+		FROM campaigns_audience
+		WHERE id = $1
+	`
+
+	var audience models.CampaignAudience
+	var feedbackJSON []byte
+	err := r.db.QueryRowContext(ctx, query, audienceID).Scan(
+		&audience.ID, &audience.CampaignID, &audience.ContactID, &audience.Type, &audience.Status,
+		&audience.MessageID, &feedbackJSON, &audience.Attempts, &audience.LastError, &audience.DispatchToken, &audience.CreatedAt, &audience.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar audiência: %w", err)
+	}
+
+	if len(feedbackJSON) > 0 {
+		var feedback map[string]interface{}
+		if err := json.Unmarshal(feedbackJSON, &feedback); err == nil {
+			audience.Feedback = &feedback
+		}
+	}
+
+	return &audience, nil
+}
+
+// UpdateStatus atualiza o status de um registro da audiência (um contato em uma campanha)
+func (r *campaignAudienceRepo) UpdateStatus(ctx context.Context, audienceID uuid.UUID, status, messageID string, feedback map[string]interface{}) error {
 	feedbackJSON, err := json.Marshal(feedback)
 	if err != nil {
 		return err
 	}
 
-	_, err = r.db.Exec(`
-		UPDATE campaigns_audience SET status = $1, message_id = $2, feedback_api = $3 WHERE contact_id = $4
-	`, status, messageID, feedbackJSON, contactID)
+	_, err = r.db.ExecContext(ctx, `
+		UPDATE campaigns_audience
+		SET status = $1, message_id = NULLIF($2, ''), feedback_api = $3, updated_at = NOW(),
+			sent_at = CASE WHEN $1 = $5 THEN COALESCE(sent_at, NOW()) ELSE sent_at END
+		WHERE id = $4
+	`, status, messageID, feedbackJSON, audienceID, models.AudienceEnviado)
 	return err
 }
 
-// UpdateStatusByMessageID atualiza o status de uma mensagem usando o message_id
-func (r *campaignAudienceRepo) UpdateStatusByMessageID(ctx context.Context, messageID string, status string, feedbackAPI *string) error {
+// MarkQueued grava "fila" e o token do enfileiramento com um UPDATE condicional: a audiência reservada ou
+// enviada por uma mensagem anterior entre a busca e o enfileiramento não é enfileirada de novo
+func (r *campaignAudienceRepo) MarkQueued(ctx context.Context, audienceID uuid.UUID, token uuid.UUID) (bool, error) {
 	query := `
-		UPDATE campaigns_audience 
-		SET status = $1, feedback_api = $2, updated_at = NOW()
-		WHERE message_id = $3;
+		UPDATE campaigns_audience
+		SET status = $2, dispatch_token = $3, updated_at = NOW()
+		WHERE id = $1 AND status IN ($4, $2, $5)
 	`
-	_, err := r.db.Exec(query, status, feedbackAPI, messageID)
+
+	result, err := r.db.ExecContext(ctx, query, audienceID, models.AudienceFila, token,
+		models.AudiencePendente, models.AudienceFalhaEnvio)
 	if err != nil {
-		r.log.Error("❌ Erro ao atualizar status por message_id: %s, erro: %v", messageID, err)
-		return err
+		return false, fmt.Errorf("erro ao marcar audiência na fila: %w", err)
+	}
+
+	queued, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao marcar audiência na fila: %w", err)
+	}
+	return queued > 0, nil
+}
+
+// ClaimForSending reserva a audiência para envio com um UPDATE condicional: entre cópias da mesma mensagem
+// (reentrega da fila, visibility timeout expirado), somente uma consegue a reserva, e somente com o token
+// do último enfileiramento. Mensagens sem token (anteriores ao token) valem para audiências sem token.
+func (r *campaignAudienceRepo) ClaimForSending(ctx context.Context, audienceID uuid.UUID, token uuid.UUID, lease time.Duration) (bool, error) {
+	query := `
+		UPDATE campaigns_audience
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1 AND dispatch_token IS NOT DISTINCT FROM $6
+		AND (status IN ($3, $4) OR (status = $2 AND updated_at < NOW() - ($5 * INTERVAL '1 second')))
+		RETURNING id
+	`
+
+	var dispatchToken *uuid.UUID
+	if token != uuid.Nil {
+		dispatchToken = &token
+	}
+
+	var claimedID uuid.UUID
+	err := r.db.QueryRowContext(ctx, query, audienceID, models.AudienceEnviando,
+		models.AudiencePendente, models.AudienceFila, lease.Seconds(), dispatchToken).Scan(&claimedID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("erro ao reservar audiência para envio: %w", err)
+	}
+
+	return true, nil
+}
+
+// ReleaseClaim devolve para "fila" a audiência que continua reservada (não altera envios já concluídos)
+func (r *campaignAudienceRepo) ReleaseClaim(ctx context.Context, audienceID uuid.UUID) error {
+	query := `UPDATE campaigns_audience SET status = $2, updated_at = NOW() WHERE id = $1 AND status = $3`
+
+	if _, err := r.db.ExecContext(ctx, query, audienceID, models.AudienceFila, models.AudienceEnviando); err != nil {
+		return fmt.Errorf("erro ao liberar reserva da audiência: %w", err)
+	}
+	return nil
+}
+
+// GetUnsentToSQS busca os contatos da campanha que ainda não foram enviados (usado ao retomar campanha pausada).
+// Quem está na "fila" também retorna: o novo enfileiramento troca o token e invalida as mensagens anteriores.
+// Contatos descadastrados do canal são marcados como "cancelada" e não retornam. Quem estava em "falha_envio"
+// volta para "pendente" com as tentativas zeradas, como em RequeueDeadLetters.
+func (r *campaignAudienceRepo) GetUnsentToSQS(ctx context.Context, accountID uuid.UUID, campaignID uuid.UUID) ([]dto.CampaignMessageDTO, error) {
+	if err := r.cancelOptedOut(ctx, campaignID); err != nil {
+		return nil, err
+	}
+
+	// 🔄 Falhas anteriores recomeçam a contagem de tentativas
+	resetQuery := `
+		UPDATE campaigns_audience
+		SET status = $2, attempts = 0, last_error = NULL, feedback_api = NULL, updated_at = NOW()
+		WHERE campaign_id = $1 AND status = $3
+	`
+	if _, err := r.db.ExecContext(ctx, resetQuery, campaignID, models.AudiencePendente, models.AudienceFalhaEnvio); err != nil {
+		return nil, fmt.Errorf("erro ao reiniciar tentativas da audiência: %w", err)
+	}
+
+	query := `
+		SELECT id, campaign_id, contact_id, type
+		FROM campaigns_audience
+		WHERE campaign_id = $1 AND status IN ($2, $3)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, campaignID, models.AudiencePendente, models.AudienceFila)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar audiência não enviada: %w", err)
 	}
+	defer rows.Close()
 
-	r.log.Info("✅ Status atualizado com sucesso para message_id: %s -> %s", messageID, status)
+	var messages []dto.CampaignMessageDTO
+	for rows.Next() {
+		msg := dto.CampaignMessageDTO{AccountID: accountID}
+		if err := rows.Scan(&msg.ID, &msg.CampaignID, &msg.ContactID, &msg.Type); err != nil {
+			return nil, fmt.Errorf("erro ao escanear audiência não enviada: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// cancelOptedOut marca como "cancelada" a audiência ainda não enviada de contatos descadastrados do canal
+func (r *campaignAudienceRepo) cancelOptedOut(ctx context.Context, campaignID uuid.UUID) error {
+	query := `
+		UPDATE campaigns_audience ca
+		SET status = $2, updated_at = NOW()
+		FROM contacts c
+		WHERE ca.contact_id = c.id AND ca.campaign_id = $1 AND ca.status IN ($3, $4, $5)
+		AND (
+			c.opt_out_at IS NOT NULL
+			OR (ca.type = 'email' AND c.email_opt_out_at IS NOT NULL)
+			OR (ca.type = 'whatsapp' AND c.whatsapp_opt_out_at IS NOT NULL)
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, campaignID, models.AudienceCancelada,
+		models.AudiencePendente, models.AudienceFila, models.AudienceFalhaEnvio)
+	if err != nil {
+		return fmt.Errorf("erro ao cancelar audiência descadastrada: %w", err)
+	}
+
+	if cancelled, _ := result.RowsAffected(); cancelled > 0 {
+		r.log.Info("🚫 Contatos descadastrados removidos do envio", "campaign_id", campaignID, "total", cancelled)
+	}
 	return nil
 }
 
+// CancelUnsentByContact cancela os envios ainda não realizados de um contato em um canal (descadastro)
+func (r *campaignAudienceRepo) CancelUnsentByContact(ctx context.Context, contactID uuid.UUID, channel models.ChannelType) (int64, error) {
+	query := `
+		UPDATE campaigns_audience SET status = $1, updated_at = NOW()
+		WHERE contact_id = $2 AND type = $3 AND status IN ($4, $5, $6)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, models.AudienceCancelada, contactID, channel,
+		models.AudiencePendente, models.AudienceFila, models.AudienceFalhaEnvio)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao cancelar envios do contato: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// ReturnQueuedToPending devolve para "pendente" os contatos que estavam na fila (pausa da campanha)
+func (r *campaignAudienceRepo) ReturnQueuedToPending(ctx context.Context, campaignID uuid.UUID) (int64, error) {
+	query := `UPDATE campaigns_audience SET status = $1, updated_at = NOW() WHERE campaign_id = $2 AND status = $3`
+
+	result, err := r.db.ExecContext(ctx, query, models.AudiencePendente, campaignID, models.AudienceFila)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao devolver audiência para pendente: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// CancelUnsent marca como "cancelada" os contatos da campanha que ainda não receberam a mensagem
+func (r *campaignAudienceRepo) CancelUnsent(ctx context.Context, campaignID uuid.UUID) (int64, error) {
+	query := `UPDATE campaigns_audience SET status = $1, updated_at = NOW() WHERE campaign_id = $2 AND status IN ($3, $4, $5)`
+
+	result, err := r.db.ExecContext(ctx, query, models.AudienceCancelada, campaignID,
+		models.AudiencePendente, models.AudienceFila, models.AudienceFalhaEnvio)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao cancelar audiência da campanha: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// HasPending indica se ainda há contatos da campanha aguardando envio
+func (r *campaignAudienceRepo) HasPending(ctx context.Context, campaignID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM campaigns_audience WHERE campaign_id = $1 AND status IN ($2, $3, $4))`
+
+	var pending bool
+	if err := r.db.QueryRowContext(ctx, query, campaignID, models.AudiencePendente, models.AudienceFila, models.AudienceEnviando).Scan(&pending); err != nil {
+		return false, fmt.Errorf("erro ao verificar envios pendentes: %w", err)
+	}
+
+	return pending, nil
+}
+
+// CountUnsentFailures conta os contatos da campanha cujo envio terminou sem a mensagem chegar ao provedor
+func (r *campaignAudienceRepo) CountUnsentFailures(ctx context.Context, campaignID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM campaigns_audience WHERE campaign_id = $1 AND status IN ($2, $3, $4)`
+
+	var failed int
+	err := r.db.QueryRowContext(ctx, query, campaignID,
+		models.AudienceFalhaEnvio, models.AudienceFalhaRenderizacao, models.AudienceDeadLetter).Scan(&failed)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao contar envios com falha: %w", err)
+	}
+
+	return failed, nil
+}
+
+// CountPendingByChannel conta, por canal, os contatos da campanha que ainda aguardam envio
+func (r *campaignAudienceRepo) CountPendingByChannel(ctx context.Context, campaignID uuid.UUID) (map[string]int, error) {
+	query := `
+		SELECT type, COUNT(*)
+		FROM campaigns_audience
+		WHERE campaign_id = $1 AND status IN ($2, $3, $4)
+		GROUP BY type
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, campaignID, models.AudiencePendente, models.AudienceFila, models.AudienceEnviando)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao contar envios pendentes: %w", err)
+	}
+	defer rows.Close()
+
+	pending := map[string]int{}
+	for rows.Next() {
+		var channel string
+		var total int
+		if err := rows.Scan(&channel, &total); err != nil {
+			return nil, fmt.Errorf("erro ao escanear envios pendentes: %w", err)
+		}
+		pending[channel] = total
+	}
+
+	return pending, nil
+}
+
+// GetStatusCounts agrega a audiência da campanha por canal e status
+func (r *campaignAudienceRepo) GetStatusCounts(ctx context.Context, campaignID uuid.UUID) ([]dto.CampaignStatusCountDTO, error) {
+	query := `
+		SELECT type, status, COUNT(*), COUNT(delivered_at), COUNT(opened_at), COUNT(clicked_at)
+		FROM campaigns_audience
+		WHERE campaign_id = $1
+		GROUP BY type, status
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao agregar audiência por status: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []dto.CampaignStatusCountDTO
+	for rows.Next() {
+		var count dto.CampaignStatusCountDTO
+		if err := rows.Scan(&count.Channel, &count.Status, &count.Total, &count.Delivered, &count.Opened, &count.Clicked); err != nil {
+			return nil, fmt.Errorf("erro ao escanear agregação por status: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, nil
+}
+
+// GetStatsSeries retorna envios, entregas, aberturas e cliques da campanha agrupados por hora ou dia no fuso informado
+func (r *campaignAudienceRepo) GetStatsSeries(ctx context.Context, campaignID uuid.UUID, interval, timezone string) ([]dto.CampaignStatsPointDTO, error) {
+	query := `
+		SELECT bucket AT TIME ZONE $3, SUM(sent), SUM(delivered), SUM(opens), SUM(clicks)
+		FROM (
+			SELECT date_trunc($2, sent_at AT TIME ZONE $3) AS bucket, 1 AS sent, 0 AS delivered, 0 AS opens, 0 AS clicks
+			FROM campaigns_audience
+			WHERE campaign_id = $1 AND sent_at IS NOT NULL
+			UNION ALL
+			SELECT date_trunc($2, delivered_at AT TIME ZONE $3), 0, 1, 0, 0
+			FROM campaigns_audience
+			WHERE campaign_id = $1 AND delivered_at IS NOT NULL
+			UNION ALL
+			SELECT date_trunc($2, opened_at AT TIME ZONE $3), 0, 0, 1, 0
+			FROM campaigns_audience
+			WHERE campaign_id = $1 AND opened_at IS NOT NULL
+			UNION ALL
+			SELECT date_trunc($2, clicked_at AT TIME ZONE $3), 0, 0, 0, 1
+			FROM campaigns_audience
+			WHERE campaign_id = $1 AND clicked_at IS NOT NULL
+		) points
+		GROUP BY bucket
+		ORDER BY bucket
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, campaignID, interval, timezone)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar série de envios: %w", err)
+	}
+	defer rows.Close()
+
+	var series []dto.CampaignStatsPointDTO
+	for rows.Next() {
+		var point dto.CampaignStatsPointDTO
+		if err := rows.Scan(&point.Bucket, &point.Sent, &point.Delivered, &point.Opens, &point.Clicks); err != nil {
+			return nil, fmt.Errorf("erro ao escanear série de envios: %w", err)
+		}
+		series = append(series, point)
+	}
+
+	return series, nil
+}
+
+// GetAccountCampaignStats compara o funil das campanhas da conta criadas no período
+func (r *campaignAudienceRepo) GetAccountCampaignStats(ctx context.Context, accountID uuid.UUID, filter dto.CampaignAnalyticsFilterDTO) ([]dto.CampaignComparisonDTO, error) {
+	join := "LEFT JOIN campaigns_audience ca ON ca.campaign_id = c.id"
+	args := []interface{}{accountID, filter.From, filter.To}
+	if filter.Channel != nil {
+		join += " AND ca.type = $4"
+		args = append(args, *filter.Channel)
+	}
+
+	query := `
+		SELECT c.id, c.name, c.status, c.created_at, ca.status, COUNT(ca.id), COUNT(ca.delivered_at), COUNT(ca.opened_at), COUNT(ca.clicked_at)
+		FROM campaigns c
+		` + join + `
+		WHERE c.account_id = $1 AND c.created_at >= $2 AND c.created_at < $3
+		GROUP BY c.id, c.name, c.status, c.created_at, ca.status
+		ORDER BY c.created_at DESC, c.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao comparar campanhas: %w", err)
+	}
+	defer rows.Close()
+
+	comparison := []dto.CampaignComparisonDTO{}
+	for rows.Next() {
+		var row dto.CampaignComparisonDTO
+		var status sql.NullString
+		var count dto.CampaignStatusCountDTO
+		if err := rows.Scan(&row.CampaignID, &row.Name, &row.Status, &row.CreatedAt, &status, &count.Total, &count.Delivered, &count.Opened, &count.Clicked); err != nil {
+			return nil, fmt.Errorf("erro ao escanear comparativo de campanhas: %w", err)
+		}
+
+		// 🔗 As linhas vêm agrupadas por campanha (ORDER BY): acumula no funil da campanha atual
+		last := len(comparison) - 1
+		if last < 0 || comparison[last].CampaignID != row.CampaignID {
+			row.Funnel = dto.NewCampaignFunnelDTO()
+			comparison = append(comparison, row)
+			last++
+		}
+		if status.Valid {
+			count.Status = models.AudienceStatus(status.String)
+			comparison[last].Funnel.Add(count)
+		}
+	}
+
+	for _, row := range comparison {
+		row.Funnel.ComputeRates()
+	}
+
+	return comparison, nil
+}
+
+// RegisterFailure incrementa as tentativas com falha e guarda o último erro, retornando o total de tentativas
+func (r *campaignAudienceRepo) RegisterFailure(ctx context.Context, audienceID uuid.UUID, lastError string) (int, error) {
+	query := `
+		UPDATE campaigns_audience
+		SET attempts = attempts + 1, last_error = $2, updated_at = NOW()
+		WHERE id = $1
+		RETURNING attempts
+	`
+
+	var attempts int
+	if err := r.db.QueryRowContext(ctx, query, audienceID, lastError).Scan(&attempts); err != nil {
+		return 0, fmt.Errorf("erro ao registrar falha de envio: %w", err)
+	}
+	return attempts, nil
+}
+
+// GetDeadLetters lista os contatos da campanha cujo envio foi abandonado (dead-letter ou falha de envio)
+func (r *campaignAudienceRepo) GetDeadLetters(ctx context.Context, campaignID uuid.UUID) ([]dto.CampaignDeadLetterDTO, error) {
+	query := `
+		SELECT ca.id, ca.contact_id, ca.type, c.name, c.email, c.whatsapp, ca.attempts, ca.last_error, ca.updated_at
+		FROM campaigns_audience ca
+		INNER JOIN contacts c ON ca.contact_id = c.id
+		WHERE ca.campaign_id = $1 AND ca.status IN ($2, $3)
+		ORDER BY ca.updated_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, campaignID, models.AudienceDeadLetter, models.AudienceFalhaEnvio)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar dead-letters: %w", err)
+	}
+	defer rows.Close()
+
+	deadLetters := []dto.CampaignDeadLetterDTO{}
+	for rows.Next() {
+		var deadLetter dto.CampaignDeadLetterDTO
+		if err := rows.Scan(
+			&deadLetter.ID, &deadLetter.ContactID, &deadLetter.Type, &deadLetter.Name, &deadLetter.Email,
+			&deadLetter.WhatsApp, &deadLetter.Attempts, &deadLetter.LastError, &deadLetter.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao escanear dead-letter: %w", err)
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	return deadLetters, nil
+}
+
+// RequeueDeadLetters devolve os dead-letters (e as falhas de envio) para "pendente" zerando as tentativas e retorna
+// as mensagens a serem reenfileiradas. Sem `audienceIDs`, todos os dead-letters da campanha são reprocessados.
+func (r *campaignAudienceRepo) RequeueDeadLetters(ctx context.Context, campaignID uuid.UUID, audienceIDs []uuid.UUID) ([]dto.CampaignMessageDTO, error) {
+	query := `
+		UPDATE campaigns_audience ca
+		SET status = $3, attempts = 0, last_error = NULL, feedback_api = NULL, updated_at = NOW()
+		FROM campaigns c
+		WHERE ca.campaign_id = c.id AND ca.campaign_id = $1 AND ca.status IN ($2, $4)
+	`
+	args := []interface{}{campaignID, models.AudienceDeadLetter, models.AudiencePendente, models.AudienceFalhaEnvio}
+
+	if len(audienceIDs) > 0 {
+		query += " AND ca.id = ANY($5)"
+		args = append(args, pq.Array(audienceIDs))
+	}
+	query += " RETURNING ca.id, c.account_id, ca.campaign_id, ca.contact_id, ca.type"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao reenfileirar dead-letters: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []dto.CampaignMessageDTO
+	for rows.Next() {
+		var msg dto.CampaignMessageDTO
+		if err := rows.Scan(&msg.ID, &msg.AccountID, &msg.CampaignID, &msg.ContactID, &msg.Type); err != nil {
+			return nil, fmt.Errorf("erro ao escanear dead-letter: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// sesStatusRank ordena os status aplicados pelos eventos do SES (envio → atraso → entrega → assinatura → falhas finais).
+// Status anteriores ao envio ficam com 0.
+func sesStatusRank(column string) string {
+	return `CASE ` + column + `
+			WHEN 'enviado' THEN 1
+			WHEN 'atrasado' THEN 2
+			WHEN 'entregue' THEN 3
+			WHEN 'atualizou_assinatura' THEN 4
+			WHEN 'devolvido' THEN 5
+			WHEN 'reclamado' THEN 5
+			WHEN 'rejeitado' THEN 5
+			WHEN 'falha_renderizacao' THEN 5
+			ELSE 0 END`
+}
+
+// UpdateStatusByMessageID aplica o status de um evento do SES pelo message_id. Os eventos podem chegar fora de ordem:
+// o status só avança (um "Send" atrasado não sobrescreve entrega, bounce ou reclamação) e bounce, reclamação,
+// rejeição e falha de renderização são finais. Feedback nil mantém o anterior (feedback_api é JSONB).
+// Retorna false se nenhuma audiência foi alterada.
+func (r *campaignAudienceRepo) UpdateStatusByMessageID(ctx context.Context, messageID string, status string, feedback map[string]interface{}) (bool, error) {
+	var feedbackJSON interface{} // nil mantém o feedback anterior
+	if feedback != nil {
+		data, err := json.Marshal(feedback)
+		if err != nil {
+			return false, err
+		}
+		feedbackJSON = string(data)
+	}
+
+	query := `
+		UPDATE campaigns_audience
+		SET status = $1, feedback_api = COALESCE($2, feedback_api), updated_at = NOW(),
+			delivered_at = CASE WHEN $1 = $4 THEN COALESCE(delivered_at, NOW()) ELSE delivered_at END
+		WHERE message_id = $3
+			AND status NOT IN ('devolvido', 'reclamado', 'rejeitado', 'falha_renderizacao')
+			AND ` + sesStatusRank("status") + ` <= ` + sesStatusRank("$1") + `
+	`
+	result, err := r.db.ExecContext(ctx, query, status, feedbackJSON, messageID, models.AudienceEntregue)
+	if err != nil {
+		return false, fmt.Errorf("erro ao atualizar status por message_id: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// AdvanceStatusByMessageID aplica o status de um webhook de entrega. Os eventos podem chegar fora de ordem:
+// "enviado" não sobrescreve uma entrega ou rejeição já registrada. Retorna false se nenhuma audiência foi alterada.
+func (r *campaignAudienceRepo) AdvanceStatusByMessageID(ctx context.Context, messageID string, status models.AudienceStatus, feedback map[string]interface{}) (bool, error) {
+	var feedbackJSON interface{} // nil mantém o feedback anterior
+	if feedback != nil {
+		data, err := json.Marshal(feedback)
+		if err != nil {
+			return false, err
+		}
+		feedbackJSON = string(data)
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE campaigns_audience
+		SET status = $1, feedback_api = COALESCE($2, feedback_api), updated_at = NOW(),
+			sent_at = COALESCE(sent_at, NOW()),
+			delivered_at = CASE WHEN $1 = $4 THEN COALESCE(delivered_at, NOW()) ELSE delivered_at END
+		WHERE message_id = $3 AND NOT ($1 = $5 AND status IN ($4, $6))
+	`, status, feedbackJSON, messageID, models.AudienceEntregue, models.AudienceEnviado, models.AudienceRejeitado)
+	if err != nil {
+		return false, fmt.Errorf("erro ao atualizar status por message_id: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetMessageByMessageID identifica conta, campanha e contato de uma mensagem enviada pelo message_id do provedor
+func (r *campaignAudienceRepo) GetMessageByMessageID(ctx context.Context, messageID string) (*dto.CampaignMessageDTO, error) {
+	query := `
+		SELECT ca.id, c.account_id, ca.campaign_id, ca.contact_id, ca.type
+		FROM campaigns_audience ca
+		INNER JOIN campaigns c ON ca.campaign_id = c.id
+		WHERE ca.message_id = $1
+	`
+
+	var msg dto.CampaignMessageDTO
+	err := r.db.QueryRowContext(ctx, query, messageID).Scan(&msg.ID, &msg.AccountID, &msg.CampaignID, &msg.ContactID, &msg.Type)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar mensagem por message_id: %w", err)
+	}
+
+	return &msg, nil
+}
+
 // GetCampaignAudienceToSQS busca a audiência da campanha para envio à fila SQS.
+// Contatos descadastrados do canal são marcados como "cancelada" e não retornam.
 func (r *campaignAudienceRepo) GetCampaignAudienceToSQS(ctx context.Context, accountID uuid.UUID, campaignID uuid.UUID, contactType *string) ([]dto.CampaignMessageDTO, error) {
+	if err := r.cancelOptedOut(ctx, campaignID); err != nil {
+		return nil, err
+	}
+
 	// Query base para buscar os contatos da campanha
 	query := `
 		SELECT
@@ -304,6 +904,7 @@ func (r *campaignAudienceRepo) GetCampaignAudienceToSQS(ctx context.Context, acc
 			campaigns_audience ca
 		WHERE
 			ca.campaign_id = $1
+			AND ca.status <> 'cancelada'
 	`
 
 	args := []interface{}{campaignID} // ✅ Correção: Passa UUID diretamente
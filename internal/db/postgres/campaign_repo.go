@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/db"
@@ -67,14 +68,14 @@ func (r *campaignRepository) GetByID(ctx context.Context, campaignID uuid.UUID)
 	r.log.Debug("Buscando campanha por ID", "id", campaignID)
 
 	query := `
-		SELECT id, account_id, name, description, channels, status, created_at, updated_at
+		SELECT id, account_id, name, description, channels, status, scheduled_at, timezone, created_at, updated_at
 		FROM campaigns WHERE id = $1
 	`
 	var campaign models.Campaign
 	var channelsJSON []byte
 	err := r.db.QueryRow(query, campaignID).Scan(
 		&campaign.ID, &campaign.AccountID, &campaign.Name, &campaign.Description,
-		&channelsJSON, &campaign.Status, &campaign.CreatedAt, &campaign.UpdatedAt,
+		&channelsJSON, &campaign.Status, &campaign.ScheduledAt, &campaign.Timezone, &campaign.CreatedAt, &campaign.UpdatedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -103,7 +104,7 @@ func (r *campaignRepository) GetAllByAccountID(ctx context.Context, accountID uu
 	r.log.Debug("Buscando campanhas da conta", "account_id", accountID)
 
 	baseQuery := `
-		SELECT id, account_id, name, description, channels, status, created_at, updated_at
+		SELECT id, account_id, name, description, channels, status, scheduled_at, timezone, created_at, updated_at
 		FROM campaigns
 		WHERE account_id = $1
 	`
@@ -144,7 +145,7 @@ func (r *campaignRepository) GetAllByAccountID(ctx context.Context, accountID uu
 
 		if err := rows.Scan(
 			&campaign.ID, &campaign.AccountID, &campaign.Name, &campaign.Description,
-			&channelsJSON, &campaign.Status, &campaign.CreatedAt, &campaign.UpdatedAt,
+			&channelsJSON, &campaign.Status, &campaign.ScheduledAt, &campaign.Timezone, &campaign.CreatedAt, &campaign.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("erro ao escanear campanhas: %w", err)
 		}
@@ -176,12 +177,12 @@ func (r *campaignRepository) UpdateByID(ctx context.Context, campaignID uuid.UUI
 
 	query := `
 		UPDATE campaigns
-		SET name = $1, description = $2, channels = $3, status = $4, updated_at = NOW()
-		WHERE id = $5
+		SET name = $1, description = $2, channels = $3, updated_at = NOW()
+		WHERE id = $4
 		RETURNING updated_at
 	`
 	err = r.db.QueryRow(
-		query, campaign.Name, campaign.Description, channelsJSON, campaign.Status, campaignID,
+		query, campaign.Name, campaign.Description, channelsJSON, campaignID,
 	).Scan(&campaign.UpdatedAt)
 
 	if err != nil {
@@ -192,59 +193,142 @@ func (r *campaignRepository) UpdateByID(ctx context.Context, campaignID uuid.UUI
 	return campaign, nil
 }
 
-// UpdateStatus atualiza apenas o status da campanha
-func (r *campaignRepository) UpdateStatus(ctx context.Context, campaignID uuid.UUID, status string) error {
-	r.log.Debug("Atualizando status da campanha", "id", campaignID, "status", status)
+// TransitionStatus troca o status da campanha somente se ele ainda for `from` (compare-and-set).
+// Retorna false quando outra operação mudou o status antes.
+func (r *campaignRepository) TransitionStatus(ctx context.Context, campaignID uuid.UUID, from, to models.CampaignStatus) (bool, error) {
+	r.log.Debug("Atualizando status da campanha", "id", campaignID, "from", from, "to", to)
 
-	query := `UPDATE campaigns SET status = $1, updated_at = NOW()  WHERE id = $2`
-	_, err := r.db.Exec(query, status, campaignID)
+	// 🗓️ Qualquer mudança de status encerra a reserva do agendador
+	query := `UPDATE campaigns SET status = $1, updated_at = NOW(), claimed_at = NULL, claimed_by = NULL WHERE id = $2 AND status = $3`
+	result, err := r.db.ExecContext(ctx, query, to, campaignID, from)
 	if err != nil {
-		return fmt.Errorf("erro ao atualizar status da campanha: %w", err)
+		return false, fmt.Errorf("erro ao atualizar status da campanha: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao atualizar status da campanha: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// Schedule grava a data/hora e o fuso do disparo (o status "agendada" é aplicado pela máquina de estados)
+func (r *campaignRepository) Schedule(ctx context.Context, campaignID uuid.UUID, scheduledAt time.Time, timezone string) error {
+	r.log.Debug("Agendando campanha", "id", campaignID, "scheduled_at", scheduledAt, "timezone", timezone)
+
+	query := `UPDATE campaigns SET scheduled_at = $1, timezone = $2, updated_at = NOW() WHERE id = $3`
+	_, err := r.db.ExecContext(ctx, query, scheduledAt, timezone, campaignID)
+	if err != nil {
+		return fmt.Errorf("erro ao agendar campanha: %w", err)
 	}
 
-	r.log.Debug("Status da campanha atualizado com sucesso", "id", campaignID)
 	return nil
 }
 
-// DeleteByID remove uma campanha pelo ID
-func (r *campaignRepository) DeleteByID(ctx context.Context, campaignID uuid.UUID) error {
-	r.log.Debug("Deletando campanha", "id", campaignID)
+// Unschedule remove a data/hora e o fuso do disparo
+func (r *campaignRepository) Unschedule(ctx context.Context, campaignID uuid.UUID) error {
+	r.log.Debug("Removendo agendamento da campanha", "id", campaignID)
 
-	query := `DELETE FROM campaigns WHERE id = $1`
-	_, err := r.db.Exec(query, campaignID)
+	query := `UPDATE campaigns SET scheduled_at = NULL, timezone = NULL, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, campaignID)
 	if err != nil {
-		return fmt.Errorf("erro ao deletar campanha: %w", err)
+		return fmt.Errorf("erro ao remover agendamento da campanha: %w", err)
 	}
 
-	r.log.Debug("Campanha deletada com sucesso", "id", campaignID)
 	return nil
 }
 
-// UpdateStatusConcluido atualiza o status da campanha para "concluida"
-// somente se houver registros em campaigns_audience e nenhum estiver com status 'pendente'
-func (r *campaignRepository) UpdateStatusConcluido(ctx context.Context, campaignID uuid.UUID) {
-	r.log.Debug("Atualizando status da campanha para 'concluida'", "id", campaignID)
+// ClaimDueScheduled reserva até `limit` campanhas agendadas cujo horário já chegou, mudando o status
+// para "processando". A troca de status é atômica (FOR UPDATE SKIP LOCKED), então cada campanha é
+// reservada por uma única réplica do servidor (`owner`), que precisa renovar a reserva durante o disparo.
+func (r *campaignRepository) ClaimDueScheduled(ctx context.Context, owner string, limit int) ([]models.Campaign, error) {
+	query := `
+		UPDATE campaigns
+		SET status = $1, updated_at = NOW(), claimed_at = NOW(), claimed_by = $4
+		WHERE id IN (
+			SELECT id FROM campaigns
+			WHERE status = $2 AND scheduled_at <= NOW()
+			ORDER BY scheduled_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, account_id, name, description, channels, status, scheduled_at, timezone, created_at, updated_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, models.StatusProcessando, models.StatusAgendada, limit, owner)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao reservar campanhas agendadas: %w", err)
+	}
+	defer rows.Close()
 
+	return scanClaimedCampaigns(rows)
+}
+
+// ReclaimExpired transfere para `owner` as campanhas cuja réplica parou de renovar a reserva (queda durante o disparo)
+func (r *campaignRepository) ReclaimExpired(ctx context.Context, owner string, lease time.Duration, limit int) ([]models.Campaign, error) {
 	query := `
 		UPDATE campaigns
-		SET status = 'concluida', updated_at = NOW()
-		WHERE id = $1
-		AND EXISTS (
-			SELECT 1
-			FROM campaigns_audience
-			WHERE campaign_id = $1
+		SET updated_at = NOW(), claimed_at = NOW(), claimed_by = $4
+		WHERE id IN (
+			SELECT id FROM campaigns
+			WHERE status = $1 AND claimed_at < NOW() - ($2 * INTERVAL '1 second')
+			ORDER BY claimed_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
 		)
-		AND NOT EXISTS (
-			SELECT 1
-			FROM campaigns_audience
-			WHERE campaign_id = $1 AND status = 'pendente'
-		);
+		RETURNING id, account_id, name, description, channels, status, scheduled_at, timezone, created_at, updated_at
 	`
 
+	rows, err := r.db.QueryContext(ctx, query, models.StatusProcessando, lease.Seconds(), limit, owner)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao retomar campanhas com reserva vencida: %w", err)
+	}
+	defer rows.Close()
+
+	return scanClaimedCampaigns(rows)
+}
+
+// RenewClaim renova a reserva enquanto a campanha continua "processando" com a mesma réplica
+func (r *campaignRepository) RenewClaim(ctx context.Context, campaignID uuid.UUID, owner string) error {
+	query := `UPDATE campaigns SET claimed_at = NOW() WHERE id = $1 AND status = $2 AND claimed_by = $3`
+	if _, err := r.db.ExecContext(ctx, query, campaignID, models.StatusProcessando, owner); err != nil {
+		return fmt.Errorf("erro ao renovar reserva da campanha: %w", err)
+	}
+	return nil
+}
+
+// scanClaimedCampaigns lê as campanhas retornadas pelas reservas do agendador
+func scanClaimedCampaigns(rows *sql.Rows) ([]models.Campaign, error) {
+	var campaigns []models.Campaign
+	for rows.Next() {
+		var campaign models.Campaign
+		var channelsJSON []byte
+
+		if err := rows.Scan(
+			&campaign.ID, &campaign.AccountID, &campaign.Name, &campaign.Description,
+			&channelsJSON, &campaign.Status, &campaign.ScheduledAt, &campaign.Timezone, &campaign.CreatedAt, &campaign.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao escanear campanhas agendadas: %w", err)
+		}
+
+		_ = json.Unmarshal(channelsJSON, &campaign.Channels)
+		campaigns = append(campaigns, campaign)
+	}
+
+	return campaigns, nil
+}
+
+// DeleteByID remove uma campanha pelo ID
+func (r *campaignRepository) DeleteByID(ctx context.Context, campaignID uuid.UUID) error {
+	r.log.Debug("Deletando campanha", "id", campaignID)
+
+	query := `DELETE FROM campaigns WHERE id = $1`
 	_, err := r.db.Exec(query, campaignID)
 	if err != nil {
-		r.log.Error("Erro ao atualizar status da campanha", "error", err)
+		return fmt.Errorf("erro ao deletar campanha: %w", err)
 	}
 
-	r.log.Debug("Status da campanha atualizado para 'concluida'", "id", campaignID)
+	r.log.Debug("Campanha deletada com sucesso", "id", campaignID)
+	return nil
 }
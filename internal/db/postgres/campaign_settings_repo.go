@@ -5,12 +5,15 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"log/slog"
 
 	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/db"
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/lib/pq"
 )
 
 // campaignSettingsRepository gerencia as configurações das campanhas no banco
@@ -33,14 +36,26 @@ func (r *campaignSettingsRepository) CreateSettings(ctx context.Context, setting
 		INSERT INTO campaign_settings (
 			campaign_id, brand, subject, tone, email_from, email_reply, 
 			email_footer, email_instructions, whatsapp_from, whatsapp_reply, 
-			whatsapp_footer, whatsapp_instructions
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			whatsapp_footer, whatsapp_instructions, email_preheader, email_headers, sender_identity_id, email_attachments,
+			whatsapp_chat_id, whatsapp_template_id, whatsapp_template_params
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		RETURNING id, created_at, updated_at
 	`
-	err := r.db.QueryRowContext(ctx, query,
+	headersJSON, err := marshalEmailHeaders(settings.EmailHeaders)
+	if err != nil {
+		return nil, err
+	}
+	templateParamsJSON, err := marshalWhatsAppTemplateParams(settings.WhatsAppTemplateParams)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.QueryRowContext(ctx, query,
 		settings.CampaignID, settings.Brand, settings.Subject, settings.Tone,
 		settings.EmailFrom, settings.EmailReply, settings.EmailFooter, settings.EmailInstructions,
 		settings.WhatsAppFrom, settings.WhatsAppReply, settings.WhatsAppFooter, settings.WhatsAppInstructions,
+		settings.EmailPreheader, headersJSON, settings.SenderIdentityID, emailAttachmentsArray(settings.EmailAttachments),
+		settings.WhatsAppChatID, settings.WhatsAppTemplateID, templateParamsJSON,
 	).Scan(&settings.ID, &settings.CreatedAt, &settings.UpdatedAt)
 
 	if err != nil {
@@ -59,18 +74,14 @@ func (r *campaignSettingsRepository) GetSettingsByCampaignID(ctx context.Context
 	query := `
 		SELECT id, campaign_id, brand, subject, tone, email_from, email_reply, 
 			   email_footer, email_instructions, whatsapp_from, whatsapp_reply, 
-			   whatsapp_footer, whatsapp_instructions, created_at, updated_at
+			   whatsapp_footer, whatsapp_instructions, email_preheader, email_headers,
+			   sender_identity_id, email_attachments, whatsapp_chat_id, whatsapp_template_id, whatsapp_template_params,
+			   created_at, updated_at
 		FROM campaign_settings
 		WHERE campaign_id = $1
 	`
-	settings := models.CampaignSettings{}
 
-	err := r.db.QueryRowContext(ctx, query, campaignID).Scan(
-		&settings.ID, &settings.CampaignID, &settings.Brand, &settings.Subject, &settings.Tone,
-		&settings.EmailFrom, &settings.EmailReply, &settings.EmailFooter, &settings.EmailInstructions,
-		&settings.WhatsAppFrom, &settings.WhatsAppReply, &settings.WhatsAppFooter, &settings.WhatsAppInstructions,
-		&settings.CreatedAt, &settings.UpdatedAt,
-	)
+	settings, err := scanCampaignSettings(r.db.QueryRowContext(ctx, query, campaignID))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -82,7 +93,7 @@ func (r *campaignSettingsRepository) GetSettingsByCampaignID(ctx context.Context
 	}
 
 	r.log.Info("✅ Configurações da campanha encontradas", "campaign_id", campaignID)
-	return &settings, nil
+	return settings, nil
 }
 
 // ✅ Atualizar configurações da campanha
@@ -94,15 +105,26 @@ func (r *campaignSettingsRepository) UpdateSettings(ctx context.Context, setting
 		SET brand = $2, subject = $3, tone = $4, email_from = $5, email_reply = $6,
 			email_footer = $7, email_instructions = $8, whatsapp_from = $9, 
 			whatsapp_reply = $10, whatsapp_footer = $11, whatsapp_instructions = $12,
-			updated_at = now()
+			email_preheader = $13, email_headers = $14, sender_identity_id = $15, email_attachments = $16,
+			whatsapp_chat_id = $17, whatsapp_template_id = $18, whatsapp_template_params = $19, updated_at = now()
 		WHERE campaign_id = $1
 		RETURNING id, updated_at
 	`
+	headersJSON, err := marshalEmailHeaders(settings.EmailHeaders)
+	if err != nil {
+		return nil, err
+	}
+	templateParamsJSON, err := marshalWhatsAppTemplateParams(settings.WhatsAppTemplateParams)
+	if err != nil {
+		return nil, err
+	}
 
-	err := r.db.QueryRowContext(ctx, query,
+	err = r.db.QueryRowContext(ctx, query,
 		settings.CampaignID, settings.Brand, settings.Subject, settings.Tone,
 		settings.EmailFrom, settings.EmailReply, settings.EmailFooter, settings.EmailInstructions,
 		settings.WhatsAppFrom, settings.WhatsAppReply, settings.WhatsAppFooter, settings.WhatsAppInstructions,
+		settings.EmailPreheader, headersJSON, settings.SenderIdentityID, emailAttachmentsArray(settings.EmailAttachments),
+		settings.WhatsAppChatID, settings.WhatsAppTemplateID, templateParamsJSON,
 	).Scan(&settings.ID, &settings.UpdatedAt)
 
 	if err != nil {
@@ -144,7 +166,8 @@ func (r *campaignSettingsRepository) GetLastSettings(ctx context.Context, accoun
 		SELECT cs.id, cs.campaign_id, cs.brand, cs.subject, cs.tone, 
 			   cs.email_from, cs.email_reply, cs.email_footer, cs.email_instructions, 
 			   cs.whatsapp_from, cs.whatsapp_reply, cs.whatsapp_footer, cs.whatsapp_instructions, 
-			   cs.created_at, cs.updated_at
+			   cs.email_preheader, cs.email_headers, cs.sender_identity_id, cs.email_attachments,
+			   cs.whatsapp_chat_id, cs.whatsapp_template_id, cs.whatsapp_template_params, cs.created_at, cs.updated_at
 		FROM campaign_settings cs
 		JOIN campaigns c ON cs.campaign_id = c.id
 		WHERE c.account_id = $1
@@ -152,14 +175,7 @@ func (r *campaignSettingsRepository) GetLastSettings(ctx context.Context, accoun
 		LIMIT 1
 	`
 
-	settings := models.CampaignSettings{}
-
-	err := r.db.QueryRowContext(ctx, query, accountID).Scan(
-		&settings.ID, &settings.CampaignID, &settings.Brand, &settings.Subject, &settings.Tone,
-		&settings.EmailFrom, &settings.EmailReply, &settings.EmailFooter, &settings.EmailInstructions,
-		&settings.WhatsAppFrom, &settings.WhatsAppReply, &settings.WhatsAppFooter, &settings.WhatsAppInstructions,
-		&settings.CreatedAt, &settings.UpdatedAt,
-	)
+	settings, err := scanCampaignSettings(r.db.QueryRowContext(ctx, query, accountID))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -171,5 +187,73 @@ func (r *campaignSettingsRepository) GetLastSettings(ctx context.Context, accoun
 	}
 
 	r.log.Info("✅ Última configuração da conta encontrada", "account_id", accountID)
+	return settings, nil
+}
+
+// scanCampaignSettings lê uma linha de configurações (mesma ordem de colunas nas consultas acima)
+func scanCampaignSettings(scanner interface{ Scan(dest ...any) error }) (*models.CampaignSettings, error) {
+	var settings models.CampaignSettings
+	var headersJSON, templateParamsJSON []byte
+	var attachments pq.StringArray
+
+	err := scanner.Scan(
+		&settings.ID, &settings.CampaignID, &settings.Brand, &settings.Subject, &settings.Tone,
+		&settings.EmailFrom, &settings.EmailReply, &settings.EmailFooter, &settings.EmailInstructions,
+		&settings.WhatsAppFrom, &settings.WhatsAppReply, &settings.WhatsAppFooter, &settings.WhatsAppInstructions,
+		&settings.EmailPreheader, &headersJSON, &settings.SenderIdentityID, &attachments,
+		&settings.WhatsAppChatID, &settings.WhatsAppTemplateID, &templateParamsJSON, &settings.CreatedAt, &settings.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.EmailAttachments = make([]uuid.UUID, 0, len(attachments))
+	for _, value := range attachments {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao converter anexos do e-mail: %w", err)
+		}
+		settings.EmailAttachments = append(settings.EmailAttachments, id)
+	}
+
+	if err := json.Unmarshal(headersJSON, &settings.EmailHeaders); err != nil {
+		return nil, fmt.Errorf("erro ao converter cabeçalhos do e-mail: %w", err)
+	}
+	if err := json.Unmarshal(templateParamsJSON, &settings.WhatsAppTemplateParams); err != nil {
+		return nil, fmt.Errorf("erro ao converter variáveis do template do WhatsApp: %w", err)
+	}
+
 	return &settings, nil
 }
+
+// marshalEmailHeaders serializa os cabeçalhos adicionais para JSONB (nunca nulo)
+func marshalEmailHeaders(headers map[string]string) ([]byte, error) {
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	headersJSON, err := json.Marshal(headers)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar cabeçalhos do e-mail: %w", err)
+	}
+	return headersJSON, nil
+}
+
+// marshalWhatsAppTemplateParams serializa as origens das variáveis do template para JSONB (nunca nulo)
+func marshalWhatsAppTemplateParams(params map[string]string) ([]byte, error) {
+	if params == nil {
+		params = map[string]string{}
+	}
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar variáveis do template do WhatsApp: %w", err)
+	}
+	return paramsJSON, nil
+}
+
+// emailAttachmentsArray converte os anexos para UUID[] (nunca nulo)
+func emailAttachmentsArray(attachments []uuid.UUID) interface{} {
+	if attachments == nil {
+		attachments = []uuid.UUID{}
+	}
+	return pq.Array(attachments)
+}
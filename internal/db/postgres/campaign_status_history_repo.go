@@ -0,0 +1,75 @@
+// File: /internal/db/postgres/campaign_status_history_repo.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// campaignStatusHistoryRepository implementa CampaignStatusHistoryRepository para PostgreSQL
+type campaignStatusHistoryRepository struct {
+	log *slog.Logger
+	db  *sql.DB
+}
+
+// NewCampaignStatusHistoryRepository cria o repositório da linha do tempo de status
+func NewCampaignStatusHistoryRepository(db *sql.DB) db.CampaignStatusHistoryRepository {
+	log := logger.GetLogger()
+	return &campaignStatusHistoryRepository{log: log, db: db}
+}
+
+// Create registra uma transição de status
+func (r *campaignStatusHistoryRepository) Create(ctx context.Context, entry *models.CampaignStatusHistory) error {
+	query := `
+		INSERT INTO campaign_status_history (campaign_id, from_status, to_status, changed_by, source, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		entry.CampaignID, entry.FromStatus, entry.ToStatus, entry.ChangedBy, entry.Source, entry.Reason,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar histórico de status: %w", err)
+	}
+
+	return nil
+}
+
+// GetByCampaignID retorna a linha do tempo de status da campanha, da mais antiga para a mais recente
+func (r *campaignStatusHistoryRepository) GetByCampaignID(ctx context.Context, campaignID uuid.UUID) ([]models.CampaignStatusHistory, error) {
+	query := `
+		SELECT id, campaign_id, from_status, to_status, changed_by, source, reason, created_at
+		FROM campaign_status_history
+		WHERE campaign_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar histórico de status: %w", err)
+	}
+	defer rows.Close()
+
+	history := []models.CampaignStatusHistory{}
+	for rows.Next() {
+		var entry models.CampaignStatusHistory
+		if err := rows.Scan(
+			&entry.ID, &entry.CampaignID, &entry.FromStatus, &entry.ToStatus,
+			&entry.ChangedBy, &entry.Source, &entry.Reason, &entry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao escanear histórico de status: %w", err)
+		}
+		history = append(history, entry)
+	}
+
+	return history, nil
+}
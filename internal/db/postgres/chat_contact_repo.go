@@ -25,28 +25,39 @@ func NewChatContactRepository(db *sql.DB) db.ChatContactRepository {
 	return &chatContactRepository{log: *logger.GetLogger(), db: db}
 }
 
-// ✅ Busca atendimento existente ou cria um novo para o contato no chat
-func (r *chatContactRepository) FindOrCreate(ctx context.Context, accountID, chatID, whatsappContactID uuid.UUID) (*models.ChatContact, error) {
-	// Primeiro tenta encontrar
-	query := `
-		SELECT id, account_id, chat_id, whatsapp_contact_id, status, created_at, updated_at
-		FROM chat_contacts
-		WHERE account_id = $1 AND chat_id = $2 AND whatsapp_contact_id = $3
-		LIMIT 1
-	`
+const chatContactColumns = `id, account_id, chat_id, contact_id, whatsapp_contact_id, status, created_at, updated_at`
 
+// scanChatContact lê um atendimento (contact_id e whatsapp_contact_id dependem do canal)
+func scanChatContact(row interface{ Scan(dest ...any) error }) (*models.ChatContact, error) {
 	var chatContact models.ChatContact
-	err := r.db.QueryRowContext(ctx, query, accountID, chatID, whatsappContactID).Scan(
+	if err := row.Scan(
 		&chatContact.ID,
 		&chatContact.AccountID,
 		&chatContact.ChatID,
+		&chatContact.ContactID,
 		&chatContact.WhatsappContactID,
 		&chatContact.Status,
 		&chatContact.CreatedAt,
 		&chatContact.UpdatedAt,
-	)
+	); err != nil {
+		return nil, err
+	}
+	return &chatContact, nil
+}
+
+// ✅ Busca atendimento existente ou cria um novo para o contato no chat
+func (r *chatContactRepository) FindOrCreate(ctx context.Context, accountID, chatID, whatsappContactID uuid.UUID) (*models.ChatContact, error) {
+	// Primeiro tenta encontrar
+	query := `
+		SELECT ` + chatContactColumns + `
+		FROM chat_contacts
+		WHERE account_id = $1 AND chat_id = $2 AND whatsapp_contact_id = $3
+		LIMIT 1
+	`
+
+	chatContact, err := scanChatContact(r.db.QueryRowContext(ctx, query, accountID, chatID, whatsappContactID))
 	if err == nil {
-		return &chatContact, nil
+		return chatContact, nil
 	}
 
 	// Se não encontrou, cria novo
@@ -54,60 +65,44 @@ func (r *chatContactRepository) FindOrCreate(ctx context.Context, accountID, cha
 		return nil, err
 	}
 
-	newContact := &models.ChatContact{
-		AccountID:         accountID,
-		ChatID:            chatID,
-		WhatsappContactID: whatsappContactID,
-		Status:            "aberto",
-	}
-
 	insertQuery := `
 		INSERT INTO chat_contacts (
 			account_id, chat_id, whatsapp_contact_id, status
 		) VALUES ($1, $2, $3, $4)
-		RETURNING id, account_id, chat_id, whatsapp_contact_id, status, created_at, updated_at
+		RETURNING ` + chatContactColumns + `
+	`
+
+	return scanChatContact(r.db.QueryRowContext(ctx, insertQuery, accountID, chatID, whatsappContactID, "aberto"))
+}
+
+// ✅ Busca ou cria o atendimento de um contato do CRM (conversas por e-mail); reabre atendimentos fechados
+func (r *chatContactRepository) FindOrCreateByContact(ctx context.Context, accountID, chatID, contactID uuid.UUID) (*models.ChatContact, error) {
+	query := `
+		INSERT INTO chat_contacts (account_id, chat_id, contact_id, status)
+		VALUES ($1, $2, $3, 'aberto')
+		ON CONFLICT (chat_id, contact_id) WHERE contact_id IS NOT NULL
+		DO UPDATE SET status = 'aberto', updated_at = NOW()
+		RETURNING ` + chatContactColumns + `
 	`
 
-	err = r.db.QueryRowContext(ctx, insertQuery,
-		newContact.AccountID,
-		newContact.ChatID,
-		newContact.WhatsappContactID,
-		newContact.Status,
-	).Scan(
-		&newContact.ID,
-		&newContact.AccountID,
-		&newContact.ChatID,
-		&newContact.WhatsappContactID,
-		&newContact.Status,
-		&newContact.CreatedAt,
-		&newContact.UpdatedAt,
-	)
+	chatContact, err := scanChatContact(r.db.QueryRowContext(ctx, query, accountID, chatID, contactID))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("erro ao buscar ou criar atendimento do contato: %w", err)
 	}
 
-	return newContact, nil
+	return chatContact, nil
 }
 
 // FindByID busca um contato de chat pelo ID
 func (r *chatContactRepository) FindByID(ctx context.Context, accountID, chatID, chatContactID uuid.UUID) (*models.ChatContact, error) {
 	query := `
-		SELECT id, account_id, chat_id, whatsapp_contact_id, status, created_at, updated_at
+		SELECT ` + chatContactColumns + `
 		FROM chat_contacts
 		WHERE account_id = $1 AND chat_id = $2 AND id = $3
 		LIMIT 1
 	`
 
-	var chatContact models.ChatContact
-	err := r.db.QueryRowContext(ctx, query, accountID, chatID, chatContactID).Scan(
-		&chatContact.ID,
-		&chatContact.AccountID,
-		&chatContact.ChatID,
-		&chatContact.WhatsappContactID,
-		&chatContact.Status,
-		&chatContact.CreatedAt,
-		&chatContact.UpdatedAt,
-	)
+	chatContact, err := scanChatContact(r.db.QueryRowContext(ctx, query, accountID, chatID, chatContactID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("contato de chat não encontrado: %w", err)
@@ -115,7 +110,7 @@ func (r *chatContactRepository) FindByID(ctx context.Context, accountID, chatID,
 		return nil, err
 	}
 
-	return &chatContact, nil
+	return chatContact, nil
 }
 
 // ListByChatID retorna todos os contatos de um chat
@@ -124,17 +119,20 @@ func (r *chatContactRepository) ListByChatID(ctx context.Context, accountID, cha
 		SELECT
 			cc.id AS id,
 			cc.chat_id AS chat_id,
-			wc.contact_id AS contact_id,
-			wc.id AS whatsapp_contact_id,
-			wc.name AS name,
-			wc.phone AS phone,
-			wc.jid AS jid,
-			wc.is_business AS is_business,
+			COALESCE(cc.contact_id, wc.contact_id) AS contact_id,
+			COALESCE(wc.id::text, '') AS whatsapp_contact_id,
+			CASE WHEN cc.whatsapp_contact_id IS NULL THEN 'email' ELSE 'whatsapp' END AS channel,
+			COALESCE(wc.name, c.name) AS name,
+			COALESCE(wc.phone, '') AS phone,
+			COALESCE(wc.jid, '') AS jid,
+			COALESCE(c.email, '') AS email,
+			COALESCE(wc.is_business, FALSE) AS is_business,
 			cc.status AS status,
 			cc.updated_at AS updated_at
 		FROM
 			chat_contacts cc
-			INNER JOIN whatsapp_contacts wc ON wc.id = cc.whatsapp_contact_id
+			LEFT JOIN whatsapp_contacts wc ON wc.id = cc.whatsapp_contact_id
+			INNER JOIN contacts c ON c.id = COALESCE(cc.contact_id, wc.contact_id)
 		WHERE
 			cc.account_id = $1
 			AND cc.chat_id = $2
@@ -156,9 +154,11 @@ func (r *chatContactRepository) ListByChatID(ctx context.Context, accountID, cha
 			&contact.ChatID,
 			&contact.ContactID,
 			&contact.WhatsappContactID,
+			&contact.Channel,
 			&contact.Name,
 			&contact.Phone,
 			&contact.JID,
+			&contact.Email,
 			&contact.IsBusiness,
 			&contact.Status,
 			&contact.UpdatedAt,
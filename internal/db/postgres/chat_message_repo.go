@@ -5,12 +5,15 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/db"
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/lib/pq"
 )
 
 type chatMessageRepository struct {
@@ -29,7 +32,7 @@ func (r *chatMessageRepository) Create(ctx context.Context, msg models.ChatMessa
 	query := `
 		INSERT INTO chat_messages (chat_contact_id, actor, type, content, file_url, source_processed)
 		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, chat_contact_id, actor, type, content, file_url, source_processed, created_at, updated_at, deleted_at
+		RETURNING id, chat_contact_id, actor, type, content, file_url, source_processed, processing_error, created_at, updated_at, deleted_at
 	`
 	var newMsg models.ChatMessage
 	err := r.db.QueryRowContext(ctx, query,
@@ -47,6 +50,7 @@ func (r *chatMessageRepository) Create(ctx context.Context, msg models.ChatMessa
 		&newMsg.Content,
 		&newMsg.FileURL,
 		&newMsg.SourceProcessed,
+		&newMsg.ProcessingError,
 		&newMsg.CreatedAt,
 		&newMsg.UpdatedAt,
 		&newMsg.DeletedAt,
@@ -64,7 +68,7 @@ func (r *chatMessageRepository) Create(ctx context.Context, msg models.ChatMessa
 func (r *chatMessageRepository) ListByChatContact(ctx context.Context, chatContactID uuid.UUID) ([]models.ChatMessage, error) {
 	query := `
 		SELECT id, chat_contact_id, actor, type, content, file_url,
-		       source_processed, created_at, updated_at, deleted_at
+		       source_processed, processing_error, created_at, updated_at, deleted_at
 		FROM chat_messages
 		WHERE chat_contact_id = $1
 		ORDER BY created_at ASC
@@ -87,6 +91,7 @@ func (r *chatMessageRepository) ListByChatContact(ctx context.Context, chatConta
 			&message.Content,
 			&message.FileURL,
 			&message.SourceProcessed,
+			&message.ProcessingError,
 			&message.CreatedAt,
 			&message.UpdatedAt,
 			&message.DeletedAt,
@@ -99,3 +104,80 @@ func (r *chatMessageRepository) ListByChatContact(ctx context.Context, chatConta
 
 	return messages, nil
 }
+
+// ClaimUnprocessed reserva as mensagens pendentes mais antigas. O uso de FOR UPDATE SKIP LOCKED permite
+// várias réplicas do worker sem processar a mesma mídia duas vezes; a reserva expira se o worker cair.
+func (r *chatMessageRepository) ClaimUnprocessed(ctx context.Context, types []string, limit, maxAttempts int, lease time.Duration) ([]models.ChatMessage, error) {
+	query := `
+		UPDATE chat_messages
+		SET processing_locked_until = NOW() + ($4 * INTERVAL '1 second'), processing_attempts = processing_attempts + 1
+		WHERE id IN (
+			SELECT id FROM chat_messages
+			WHERE source_processed = FALSE AND actor = 'cliente' AND deleted_at IS NULL
+			  AND type = ANY($1) AND COALESCE(file_url, '') <> ''
+			  AND processing_attempts < $3
+			  AND (processing_locked_until IS NULL OR processing_locked_until <= NOW())
+			ORDER BY created_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, chat_contact_id, actor, type, content, file_url, source_processed, processing_error, created_at, updated_at, deleted_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(types), limit, maxAttempts, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao reservar mensagens para processamento: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []models.ChatMessage
+	for rows.Next() {
+		var message models.ChatMessage
+		err := rows.Scan(
+			&message.ID,
+			&message.ChatContactID,
+			&message.Actor,
+			&message.Type,
+			&message.Content,
+			&message.FileURL,
+			&message.SourceProcessed,
+			&message.ProcessingError,
+			&message.CreatedAt,
+			&message.UpdatedAt,
+			&message.DeletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao escanear mensagem reservada: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+// SaveProcessedContent grava o texto extraído e libera a reserva
+func (r *chatMessageRepository) SaveProcessedContent(ctx context.Context, id uuid.UUID, content string) error {
+	query := `
+		UPDATE chat_messages
+		SET content = $2, source_processed = TRUE, processing_error = NULL, processing_locked_until = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := r.db.ExecContext(ctx, query, id, content); err != nil {
+		return fmt.Errorf("erro ao gravar conteúdo processado da mensagem: %w", err)
+	}
+	return nil
+}
+
+// SaveProcessingError registra a falha. A reserva é mantida para espaçar a próxima tentativa;
+// falhas permanentes encerram o processamento (a mensagem segue com a legenda e o erro).
+func (r *chatMessageRepository) SaveProcessingError(ctx context.Context, id uuid.UUID, message string, permanent bool) error {
+	query := `
+		UPDATE chat_messages
+		SET processing_error = $2, source_processed = $3, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := r.db.ExecContext(ctx, query, id, message, permanent); err != nil {
+		return fmt.Errorf("erro ao registrar falha no processamento da mensagem: %w", err)
+	}
+	return nil
+}
@@ -28,16 +28,21 @@ func (r *chatRepository) Insert(ctx context.Context, chat *models.Chat) (*models
 	query := `
 		INSERT INTO chats (
 			account_id, department, title, instructions,
-			phone_number, instance_name, webhook_url
+			phone_number, instance_name, webhook_url, provider
 		) VALUES (
 			$1, $2, $3, $4, $5,
-			$6, $7
+			$6, $7, $8
 		)
 		RETURNING id, account_id, department, title, instructions,
-		          phone_number, instance_name, webhook_url,
+		          phone_number, instance_name, webhook_url, provider,
 		          status, session_status, created_at, updated_at
 	`
 
+	provider := chat.Provider
+	if provider == "" {
+		provider = models.WhatsAppProviderBaileys
+	}
+
 	var inserted models.Chat
 	err := r.db.QueryRowContext(ctx, query,
 		chat.AccountID,
@@ -47,6 +52,7 @@ func (r *chatRepository) Insert(ctx context.Context, chat *models.Chat) (*models
 		chat.PhoneNumber,
 		chat.InstanceName,
 		chat.WebhookURL,
+		provider,
 	).Scan(
 		&inserted.ID,
 		&inserted.AccountID,
@@ -56,6 +62,7 @@ func (r *chatRepository) Insert(ctx context.Context, chat *models.Chat) (*models
 		&inserted.PhoneNumber,
 		&inserted.InstanceName,
 		&inserted.WebhookURL,
+		&inserted.Provider,
 		&inserted.Status,
 		&inserted.SessionStatus,
 		&inserted.CreatedAt,
@@ -72,7 +79,7 @@ func (r *chatRepository) Insert(ctx context.Context, chat *models.Chat) (*models
 func (r *chatRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Chat, error) {
 	query := `
 		SELECT id, account_id, department, title, instructions,
-		       phone_number, instance_name, webhook_url,
+		       phone_number, instance_name, webhook_url, provider,
 		       status, session_status, created_at, updated_at
 		FROM chats
 		WHERE account_id = $1
@@ -97,6 +104,7 @@ func (r *chatRepository) ListByAccountID(ctx context.Context, accountID uuid.UUI
 			&chat.PhoneNumber,
 			&chat.InstanceName,
 			&chat.WebhookURL,
+			&chat.Provider,
 			&chat.Status,
 			&chat.SessionStatus,
 			&chat.CreatedAt,
@@ -114,7 +122,7 @@ func (r *chatRepository) ListByAccountID(ctx context.Context, accountID uuid.UUI
 func (r *chatRepository) GetByID(ctx context.Context, accountID, chatID uuid.UUID) (*models.Chat, error) {
 	query := `
 		SELECT id, account_id, department, title, instructions,
-		       phone_number, instance_name, webhook_url,
+		       phone_number, instance_name, webhook_url, provider,
 		       status, session_status, created_at, updated_at
 		FROM chats
 		WHERE account_id = $1 AND id = $2
@@ -131,6 +139,7 @@ func (r *chatRepository) GetByID(ctx context.Context, accountID, chatID uuid.UUI
 		&chat.PhoneNumber,
 		&chat.InstanceName,
 		&chat.WebhookURL,
+		&chat.Provider,
 		&chat.Status,
 		&chat.SessionStatus,
 		&chat.CreatedAt,
@@ -146,7 +155,7 @@ func (r *chatRepository) GetByID(ctx context.Context, accountID, chatID uuid.UUI
 func (r *chatRepository) GetActiveByID(ctx context.Context, accountID, chatID uuid.UUID) (*models.Chat, error) {
 	query := `
 		SELECT id, account_id, department, title, instructions,
-		       phone_number, instance_name, webhook_url,
+		       phone_number, instance_name, webhook_url, provider,
 		       status, session_status, created_at, updated_at
 		FROM chats
 		WHERE account_id = $1 AND id = $2 AND status = 'ativo'
@@ -165,6 +174,7 @@ func (r *chatRepository) GetActiveByID(ctx context.Context, accountID, chatID uu
 		&chat.PhoneNumber,
 		&chat.InstanceName,
 		&chat.WebhookURL,
+		&chat.Provider,
 		&chat.Status,
 		&chat.SessionStatus,
 		&chat.CreatedAt,
@@ -181,7 +191,7 @@ func (r *chatRepository) GetActiveByID(ctx context.Context, accountID, chatID uu
 func (r *chatRepository) GetActiveByDepartment(ctx context.Context, accountID, department string) (*models.Chat, error) {
 	query := `
 		SELECT id, account_id, department, title, instructions,
-		       phone_number, instance_name, webhook_url,
+		       phone_number, instance_name, webhook_url, provider,
 		       status, session_status, created_at, updated_at
 		FROM chats
 		WHERE account_id = $1 AND department = $2 AND status = 'ativo'
@@ -198,6 +208,7 @@ func (r *chatRepository) GetActiveByDepartment(ctx context.Context, accountID, d
 		&chat.PhoneNumber,
 		&chat.InstanceName,
 		&chat.WebhookURL,
+		&chat.Provider,
 		&chat.Status,
 		&chat.SessionStatus,
 		&chat.CreatedAt,
@@ -219,10 +230,11 @@ func (r *chatRepository) Update(ctx context.Context, chat *models.Chat) (*models
 		    phone_number = $3,
 		    instance_name = $4,
 		    webhook_url = $5,
-		    updated_at = $6
-		WHERE id = $7 AND account_id = $8
+		    provider = $6,
+		    updated_at = $7
+		WHERE id = $8 AND account_id = $9
 		RETURNING id, account_id, department, title, instructions,
-		          phone_number, instance_name, webhook_url,
+		          phone_number, instance_name, webhook_url, provider,
 		          status, session_status, created_at, updated_at
 	`
 
@@ -233,6 +245,7 @@ func (r *chatRepository) Update(ctx context.Context, chat *models.Chat) (*models
 		chat.PhoneNumber,
 		chat.InstanceName,
 		chat.WebhookURL,
+		chat.Provider,
 		chat.UpdatedAt,
 		chat.ID,
 		chat.AccountID,
@@ -245,6 +258,7 @@ func (r *chatRepository) Update(ctx context.Context, chat *models.Chat) (*models
 		&updated.PhoneNumber,
 		&updated.InstanceName,
 		&updated.WebhookURL,
+		&updated.Provider,
 		&updated.Status,
 		&updated.SessionStatus,
 		&updated.CreatedAt,
@@ -261,7 +275,7 @@ func (r *chatRepository) Update(ctx context.Context, chat *models.Chat) (*models
 func (r *chatRepository) GetActiveByInstanceName(ctx context.Context, instance string) (*models.Chat, error) {
 	query := `
 		SELECT id, account_id, department, title, instructions, phone_number,
-		       instance_name, webhook_url, status, session_status, created_at, updated_at
+		       instance_name, webhook_url, provider, status, session_status, created_at, updated_at
 		FROM chats
 		WHERE instance_name = $1 AND status = 'ativo'
 		LIMIT 1
@@ -279,6 +293,7 @@ func (r *chatRepository) GetActiveByInstanceName(ctx context.Context, instance s
 		&chat.PhoneNumber,
 		&chat.InstanceName,
 		&chat.WebhookURL,
+		&chat.Provider,
 		&chat.Status,
 		&chat.SessionStatus,
 		&chat.CreatedAt,
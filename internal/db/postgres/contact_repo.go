@@ -17,6 +17,7 @@ import (
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
 	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+	"github.com/lib/pq"
 )
 
 // contactRepo implementa ContactRepository para PostgreSQL.
@@ -46,8 +47,9 @@ func (r *contactRepo) Create(ctx context.Context, contact *models.Contact) (*mod
 
 	query := `
 		INSERT INTO contacts (
-			account_id, name, email, whatsapp, gender, birth_date, bairro, cidade, estado, tags, history, opt_out_at, last_contact_at, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW(), NOW())
+			account_id, name, email, whatsapp, gender, birth_date, bairro, cidade, estado, tags, history, opt_out_at, last_contact_at,
+			email_status, email_status_reason, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NOW(), NOW())
 		RETURNING id, created_at, updated_at
 	`
 
@@ -56,6 +58,7 @@ func (r *contactRepo) Create(ctx context.Context, contact *models.Contact) (*mod
 		contact.AccountID, contact.Name, contact.Email, contact.WhatsApp,
 		contact.Gender, contact.BirthDate, contact.Bairro, contact.Cidade, contact.Estado,
 		tagsJSON, contact.History, contact.OptOutAt, contact.LastContactAt,
+		contact.EmailStatus, contact.EmailStatusReason,
 	).Scan(&contact.ID, &contact.CreatedAt, &contact.UpdatedAt)
 
 	if err != nil {
@@ -78,7 +81,8 @@ func (r *contactRepo) GetByID(ctx context.Context, contactID uuid.UUID) (*models
 		slog.String("contact_id", contactID.String()))
 
 	query := `
-		SELECT id, account_id, name, email, whatsapp, gender, birth_date, bairro, cidade, estado, tags, history, opt_out_at, last_contact_at, created_at, updated_at
+		SELECT id, account_id, name, email, email_status, email_status_reason, whatsapp, gender, birth_date, bairro, cidade, estado,
+			tags, history, opt_out_at, email_opt_out_at, whatsapp_opt_out_at, last_contact_at, created_at, updated_at
 		FROM contacts WHERE id = $1
 	`
 
@@ -86,9 +90,9 @@ func (r *contactRepo) GetByID(ctx context.Context, contactID uuid.UUID) (*models
 	var tagsJSON []byte
 
 	err := r.db.QueryRow(query, contactID).Scan(
-		&contact.ID, &contact.AccountID, &contact.Name, &contact.Email, &contact.WhatsApp,
+		&contact.ID, &contact.AccountID, &contact.Name, &contact.Email, &contact.EmailStatus, &contact.EmailStatusReason, &contact.WhatsApp,
 		&contact.Gender, &contact.BirthDate, &contact.Bairro, &contact.Cidade, &contact.Estado,
-		&tagsJSON, &contact.History, &contact.OptOutAt, &contact.LastContactAt,
+		&tagsJSON, &contact.History, &contact.OptOutAt, &contact.EmailOptOutAt, &contact.WhatsOptOutAt, &contact.LastContactAt,
 		&contact.CreatedAt, &contact.UpdatedAt,
 	)
 
@@ -132,7 +136,7 @@ func (r *contactRepo) GetPaginatedContacts(
 
 	// Query base
 	baseQuery := `
-		SELECT id, name, email, whatsapp, gender, birth_date, bairro, cidade, estado, last_contact_at, created_at, updated_at
+		SELECT id, name, email, email_status, whatsapp, gender, birth_date, bairro, cidade, estado, last_contact_at, created_at, updated_at
 		FROM contacts
 		WHERE account_id = $1 AND opt_out_at IS NULL
 	`
@@ -151,6 +155,11 @@ func (r *contactRepo) GetPaginatedContacts(
 			baseQuery += fmt.Sprintf(" AND gender = $%d", filterIndex)
 			args = append(args, value)
 			filterIndex++
+		case "email_status":
+			// Um ou mais status separados por vírgula (ex.: valid,risky)
+			baseQuery += fmt.Sprintf(" AND email_status = ANY($%d)", filterIndex)
+			args = append(args, pq.Array(emailStatusFilter(value)))
+			filterIndex++
 		case "birth_date_start":
 			baseQuery += fmt.Sprintf(" AND birth_date >= $%d", filterIndex)
 			start_date, err := utils.ParseDate(value)
@@ -238,7 +247,7 @@ func (r *contactRepo) GetPaginatedContacts(
 		var contact models.Contact
 
 		if err := rows.Scan(
-			&contact.ID, &contact.Name, &contact.Email, &contact.WhatsApp, &contact.Gender,
+			&contact.ID, &contact.Name, &contact.Email, &contact.EmailStatus, &contact.WhatsApp, &contact.Gender,
 			&contact.BirthDate, &contact.Bairro, &contact.Cidade, &contact.Estado,
 			&contact.LastContactAt, &contact.CreatedAt, &contact.UpdatedAt,
 		); err != nil {
@@ -264,7 +273,7 @@ func (r *contactRepo) GetByAccountID(ctx context.Context, accountID uuid.UUID, f
 		slog.String("account_id", accountID.String()))
 
 	baseQuery := `
-		SELECT id, account_id, name, email, whatsapp, gender, birth_date, bairro, cidade, estado, tags, history, opt_out_at, last_contact_at, created_at, updated_at
+		SELECT id, account_id, name, email, email_status, whatsapp, gender, birth_date, bairro, cidade, estado, tags, history, opt_out_at, last_contact_at, created_at, updated_at
 		FROM contacts
 		WHERE account_id = $1
 	`
@@ -281,6 +290,9 @@ func (r *contactRepo) GetByAccountID(ctx context.Context, accountID uuid.UUID, f
 			// Busca qualquer tag que contenha o valor passado
 			baseQuery += fmt.Sprintf(" AND tags::text ILIKE $%d", filterIndex)
 			args = append(args, "%"+value+"%")
+		case "email_status":
+			baseQuery += fmt.Sprintf(" AND email_status = ANY($%d)", filterIndex)
+			args = append(args, pq.Array(emailStatusFilter(value)))
 		case "interesses":
 			// Busca dentro da chave "interesses" do JSONB
 			baseQuery += fmt.Sprintf(" AND tags->'interesses' ? $%d", filterIndex)
@@ -311,7 +323,7 @@ func (r *contactRepo) GetByAccountID(ctx context.Context, accountID uuid.UUID, f
 		var tagsJSON []byte
 
 		if err := rows.Scan(
-			&contact.ID, &contact.AccountID, &contact.Name, &contact.Email, &contact.WhatsApp,
+			&contact.ID, &contact.AccountID, &contact.Name, &contact.Email, &contact.EmailStatus, &contact.WhatsApp,
 			&contact.Gender, &contact.BirthDate, &contact.Bairro, &contact.Cidade, &contact.Estado,
 			&tagsJSON, &contact.History, &contact.OptOutAt, &contact.LastContactAt,
 			&contact.CreatedAt, &contact.UpdatedAt,
@@ -372,7 +384,8 @@ func (r *contactRepo) UpdateByID(ctx context.Context, contactID uuid.UUID, conta
 
 	query := `
 		UPDATE contacts
-		SET name = $1, email = $2, whatsapp = $3, gender = $4, birth_date = $5, bairro = $6, cidade = $7, estado = $8, tags = $9, history = $10, opt_out_at = $11, updated_at = NOW()
+		SET name = $1, email = $2, whatsapp = $3, gender = $4, birth_date = $5, bairro = $6, cidade = $7, estado = $8, tags = $9, history = $10, opt_out_at = $11,
+			email_status = $13, email_status_reason = $14, updated_at = NOW()
 		WHERE id = $12
 		RETURNING updated_at
 	`
@@ -380,6 +393,7 @@ func (r *contactRepo) UpdateByID(ctx context.Context, contactID uuid.UUID, conta
 		query,
 		contact.Name, contact.Email, contact.WhatsApp, contact.Gender, contact.BirthDate,
 		contact.Bairro, contact.Cidade, contact.Estado, tagsJSON, contact.History, contact.OptOutAt, contactID,
+		contact.EmailStatus, contact.EmailStatusReason,
 	).Scan(&contact.UpdatedAt)
 
 	if err != nil {
@@ -438,7 +452,7 @@ func (r *contactRepo) GetAvailableContactsForCampaign(
 
 	// Query base (excluindo contatos que já estão na audiência da campanha)
 	baseQuery := `
-		SELECT id, name, email, whatsapp, gender, birth_date, bairro, cidade, estado, tags, last_contact_at, created_at, updated_at
+		SELECT id, name, email, email_status, whatsapp, gender, birth_date, bairro, cidade, estado, tags, last_contact_at, created_at, updated_at
 		FROM contacts
 		WHERE account_id = $1 
 		AND opt_out_at IS NULL 
@@ -457,6 +471,10 @@ func (r *contactRepo) GetAvailableContactsForCampaign(
 		case "gender":
 			baseQuery += fmt.Sprintf(" AND gender = $%d", filterIndex)
 			args = append(args, value)
+		case "email_status":
+			// Segmenta pela verificação do e-mail (ex.: valid,risky)
+			baseQuery += fmt.Sprintf(" AND email_status = ANY($%d)", filterIndex)
+			args = append(args, pq.Array(emailStatusFilter(value)))
 		case "birth_date_start":
 			baseQuery += fmt.Sprintf(" AND birth_date >= $%d", filterIndex)
 			startDate, err := utils.ParseDate(value)
@@ -564,7 +582,7 @@ func (r *contactRepo) GetAvailableContactsForCampaign(
 		var tagsJSON []byte
 
 		if err := rows.Scan(
-			&contact.ID, &contact.Name, &contact.Email, &contact.WhatsApp, &contact.Gender,
+			&contact.ID, &contact.Name, &contact.Email, &contact.EmailStatus, &contact.WhatsApp, &contact.Gender,
 			&contact.BirthDate, &contact.Bairro, &contact.Cidade, &contact.Estado, &tagsJSON,
 			&contact.LastContactAt, &contact.CreatedAt, &contact.UpdatedAt,
 		); err != nil {
@@ -679,3 +697,44 @@ func (r *contactRepo) FindOrCreateByWhatsApp(ctx context.Context, accountID uuid
 
 	return contact, nil
 }
+
+// OptOut registra o descadastro do contato no canal (mantém a data do primeiro pedido)
+func (r *contactRepo) OptOut(ctx context.Context, contactID uuid.UUID, channel models.ChannelType) error {
+	var column string
+	switch channel {
+	case models.EmailChannel:
+		column = "email_opt_out_at"
+	case models.WhatsappChannel:
+		column = "whatsapp_opt_out_at"
+	default:
+		return fmt.Errorf("canal inválido para descadastro: %s", channel)
+	}
+
+	query := fmt.Sprintf(`UPDATE contacts SET %[1]s = COALESCE(%[1]s, NOW()), updated_at = NOW() WHERE id = $1`, column)
+	if _, err := r.db.ExecContext(ctx, query, contactID); err != nil {
+		return fmt.Errorf("erro ao registrar descadastro do contato: %w", err)
+	}
+
+	r.log.Info("🚫 Contato descadastrado", slog.String("contact_id", contactID.String()), slog.String("channel", string(channel)))
+	return nil
+}
+
+// AppendHistory acrescenta uma linha ao histórico do contato (ex.: resposta recebida por e-mail)
+func (r *contactRepo) AppendHistory(ctx context.Context, contactID uuid.UUID, entry string) error {
+	query := `UPDATE contacts SET history = CONCAT_WS(E'\n', NULLIF(history, ''), $2::text), updated_at = NOW() WHERE id = $1`
+	if _, err := r.db.ExecContext(ctx, query, contactID, entry); err != nil {
+		return fmt.Errorf("erro ao atualizar histórico do contato: %w", err)
+	}
+	return nil
+}
+
+// emailStatusFilter separa a lista de status do filtro (ex.: "valid,risky")
+func emailStatusFilter(value string) []string {
+	var statuses []string
+	for _, status := range strings.Split(value, ",") {
+		if status = strings.ToLower(strings.TrimSpace(status)); status != "" {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
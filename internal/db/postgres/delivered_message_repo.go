@@ -0,0 +1,186 @@
+// File: /internal/db/postgres/delivered_message_repo.go
+
+package postgres
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// deliveredMessageRepository implementa DeliveredMessageRepository para PostgreSQL.
+// Corpo, parte texto e prompt são gravados comprimidos com gzip.
+type deliveredMessageRepository struct {
+	log *slog.Logger
+	db  *sql.DB
+}
+
+// NewDeliveredMessageRepository cria um novo repositório de mensagens entregues
+func NewDeliveredMessageRepository(db *sql.DB) db.DeliveredMessageRepository {
+	log := logger.GetLogger()
+	return &deliveredMessageRepository{log: log, db: db}
+}
+
+const deliveredMessageColumns = `id, account_id, campaign_id, audience_id, contact_id, channel, provider, provider_message_id,
+	subject, body_format, body, body_text, template_id, template_version, ai_model, ai_prompt, sent_at, created_at`
+
+// scanDeliveredMessage converte uma linha em DeliveredMessage, descomprimindo os conteúdos
+func scanDeliveredMessage(scanner interface{ Scan(dest ...any) error }) (*models.DeliveredMessage, error) {
+	var message models.DeliveredMessage
+	var body, bodyText, aiPrompt []byte
+	if err := scanner.Scan(
+		&message.ID, &message.AccountID, &message.CampaignID, &message.AudienceID, &message.ContactID,
+		&message.Channel, &message.Provider, &message.ProviderMessageID, &message.Subject, &message.BodyFormat,
+		&body, &bodyText, &message.TemplateID, &message.TemplateVersion, &message.AIModel, &aiPrompt,
+		&message.SentAt, &message.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if message.Body, err = gunzipText(body); err != nil {
+		return nil, err
+	}
+	if message.BodyText, err = gunzipOptional(bodyText); err != nil {
+		return nil, err
+	}
+	if message.AIPrompt, err = gunzipOptional(aiPrompt); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// Save grava a mensagem da audiência (uma reentrega da fila sobrescreve o registro anterior)
+func (r *deliveredMessageRepository) Save(ctx context.Context, message *models.DeliveredMessage) (*models.DeliveredMessage, error) {
+	body, err := gzipText(message.Body)
+	if err != nil {
+		return nil, err
+	}
+	bodyText, err := gzipOptional(message.BodyText)
+	if err != nil {
+		return nil, err
+	}
+	aiPrompt, err := gzipOptional(message.AIPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO delivered_messages (
+			account_id, campaign_id, audience_id, contact_id, channel, provider, provider_message_id,
+			subject, body_format, body, body_text, template_id, template_version, ai_model, ai_prompt, sent_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (audience_id) DO UPDATE SET
+			provider = EXCLUDED.provider, provider_message_id = EXCLUDED.provider_message_id,
+			subject = EXCLUDED.subject, body_format = EXCLUDED.body_format, body = EXCLUDED.body,
+			body_text = EXCLUDED.body_text, template_id = EXCLUDED.template_id,
+			template_version = EXCLUDED.template_version, ai_model = EXCLUDED.ai_model,
+			ai_prompt = EXCLUDED.ai_prompt, sent_at = EXCLUDED.sent_at
+		RETURNING ` + deliveredMessageColumns
+
+	saved, err := scanDeliveredMessage(r.db.QueryRowContext(ctx, query,
+		message.AccountID, message.CampaignID, message.AudienceID, message.ContactID, message.Channel,
+		message.Provider, message.ProviderMessageID, message.Subject, message.BodyFormat, body, bodyText,
+		message.TemplateID, message.TemplateVersion, message.AIModel, aiPrompt, message.SentAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao salvar mensagem entregue: %w", err)
+	}
+
+	return saved, nil
+}
+
+// GetByAudienceID busca a mensagem entregue a uma audiência da campanha da conta
+func (r *deliveredMessageRepository) GetByAudienceID(ctx context.Context, accountID, campaignID, audienceID uuid.UUID) (*models.DeliveredMessage, error) {
+	query := `SELECT ` + deliveredMessageColumns + `
+		FROM delivered_messages
+		WHERE account_id = $1 AND campaign_id = $2 AND audience_id = $3`
+
+	message, err := scanDeliveredMessage(r.db.QueryRowContext(ctx, query, accountID, campaignID, audienceID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar mensagem entregue: %w", err)
+	}
+
+	return message, nil
+}
+
+// DeleteExpired remove as mensagens mais antigas que a retenção da conta (padrão para contas sem configuração)
+func (r *deliveredMessageRepository) DeleteExpired(ctx context.Context, limit int) (int64, error) {
+	query := `
+		DELETE FROM delivered_messages
+		WHERE id IN (
+			SELECT dm.id
+			FROM delivered_messages dm
+			LEFT JOIN account_settings s ON s.account_id = dm.account_id
+			WHERE dm.sent_at < NOW() - make_interval(days => COALESCE(s.message_retention_days, $1))
+			LIMIT $2
+		)`
+
+	result, err := r.db.ExecContext(ctx, query, models.DefaultMessageRetentionDays, limit)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover mensagens entregues expiradas: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// gzipText comprime o conteúdo para gravação
+func gzipText(content string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(content)); err != nil {
+		return nil, fmt.Errorf("erro ao comprimir conteúdo: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("erro ao comprimir conteúdo: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// gzipOptional comprime o conteúdo opcional (nil continua NULL)
+func gzipOptional(content *string) ([]byte, error) {
+	if content == nil {
+		return nil, nil
+	}
+	return gzipText(*content)
+}
+
+// gunzipText descomprime o conteúdo gravado
+func gunzipText(content []byte) (string, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return "", fmt.Errorf("erro ao descomprimir conteúdo: %w", err)
+	}
+	defer reader.Close()
+
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("erro ao descomprimir conteúdo: %w", err)
+	}
+	return string(decoded), nil
+}
+
+// gunzipOptional descomprime o conteúdo opcional (NULL continua nil)
+func gunzipOptional(content []byte) (*string, error) {
+	if content == nil {
+		return nil, nil
+	}
+	decoded, err := gunzipText(content)
+	if err != nil {
+		return nil, err
+	}
+	return &decoded, nil
+}
@@ -0,0 +1,66 @@
+// File: /internal/db/postgres/engagement_repo.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// engagementRepository implementa EngagementRepository para PostgreSQL
+type engagementRepository struct {
+	log *slog.Logger
+	db  *sql.DB
+}
+
+// NewEngagementRepository cria o repositório de eventos de engajamento
+func NewEngagementRepository(db *sql.DB) db.EngagementRepository {
+	log := logger.GetLogger()
+	return &engagementRepository{log: log, db: db}
+}
+
+// Record registra o evento para o contato da campanha (audience_id). Retorna false se a audiência não existe.
+func (r *engagementRepository) Record(ctx context.Context, audienceID uuid.UUID, event *models.EngagementEvent) (bool, error) {
+	return r.record(ctx, "id", audienceID, event)
+}
+
+// RecordByMessageID registra o evento localizando a audiência pelo message_id do provedor
+func (r *engagementRepository) RecordByMessageID(ctx context.Context, messageID string, event *models.EngagementEvent) (bool, error) {
+	return r.record(ctx, "message_id", messageID, event)
+}
+
+// record grava o evento e a primeira abertura/clique da audiência em um único comando.
+// Um clique também conta como abertura (o pixel costuma ser bloqueado pelos clientes de e-mail).
+func (r *engagementRepository) record(ctx context.Context, column string, key interface{}, event *models.EngagementEvent) (bool, error) {
+	query := `
+		WITH audience AS (
+			UPDATE campaigns_audience
+			SET opened_at = COALESCE(opened_at, NOW()),
+				clicked_at = CASE WHEN $2 = 'click' THEN COALESCE(clicked_at, NOW()) ELSE clicked_at END
+			WHERE ` + column + ` = $1
+			RETURNING id, campaign_id
+		)
+		INSERT INTO campaign_engagement_events (audience_id, campaign_id, type, url, ip_address, user_agent, source)
+		SELECT id, campaign_id, $2, $3, $4, $5, $6 FROM audience
+		RETURNING id, audience_id, campaign_id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, key, event.Type, event.URL, event.IPAddress, event.UserAgent, event.Source).
+		Scan(&event.ID, &event.AudienceID, &event.CampaignID, &event.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("erro ao registrar engajamento: %w", err)
+	}
+
+	return true, nil
+}
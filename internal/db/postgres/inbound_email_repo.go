@@ -0,0 +1,150 @@
+// File: /internal/db/postgres/inbound_email_repo.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// inboundEmailRepository implementa InboundEmailRepository para PostgreSQL
+type inboundEmailRepository struct {
+	log *slog.Logger
+	db  *sql.DB
+}
+
+// NewInboundEmailRepository cria um novo repositório de e-mails recebidos
+func NewInboundEmailRepository(db *sql.DB) db.InboundEmailRepository {
+	log := logger.GetLogger()
+	return &inboundEmailRepository{log: log, db: db}
+}
+
+const inboundEmailColumns = `id, account_id, contact_id, campaign_id, audience_id, chat_message_id, message_id, in_reply_to,
+	from_address, from_name, to_address, subject, text_body, source, received_at, created_at`
+
+// scanInboundEmail converte uma linha em InboundEmail
+func scanInboundEmail(scanner interface{ Scan(dest ...any) error }) (*models.InboundEmail, error) {
+	var email models.InboundEmail
+	if err := scanner.Scan(
+		&email.ID, &email.AccountID, &email.ContactID, &email.CampaignID, &email.AudienceID, &email.ChatMessageID,
+		&email.MessageID, &email.InReplyTo, &email.FromAddress, &email.FromName, &email.ToAddress,
+		&email.Subject, &email.TextBody, &email.Source, &email.ReceivedAt, &email.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &email, nil
+}
+
+// Create grava o e-mail recebido; reentregas do mesmo Message-ID são ignoradas (retorna nil, nil)
+func (r *inboundEmailRepository) Create(ctx context.Context, email *models.InboundEmail) (*models.InboundEmail, error) {
+	query := `
+		INSERT INTO inbound_emails (account_id, contact_id, campaign_id, audience_id, message_id, in_reply_to,
+			from_address, from_name, to_address, subject, text_body, source, received_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (account_id, message_id) DO NOTHING
+		RETURNING ` + inboundEmailColumns
+
+	saved, err := scanInboundEmail(r.db.QueryRowContext(ctx, query,
+		email.AccountID, email.ContactID, email.CampaignID, email.AudienceID, email.MessageID, email.InReplyTo,
+		email.FromAddress, email.FromName, email.ToAddress, email.Subject, email.TextBody, email.Source, email.ReceivedAt,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao salvar e-mail recebido: %w", err)
+	}
+
+	return saved, nil
+}
+
+// GetByMessageID busca um e-mail recebido pelo Message-ID na conta
+func (r *inboundEmailRepository) GetByMessageID(ctx context.Context, accountID uuid.UUID, messageID string) (*models.InboundEmail, error) {
+	query := `SELECT ` + inboundEmailColumns + ` FROM inbound_emails WHERE account_id = $1 AND message_id = $2`
+
+	email, err := scanInboundEmail(r.db.QueryRowContext(ctx, query, accountID, messageID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar e-mail recebido: %w", err)
+	}
+
+	return email, nil
+}
+
+// GetPaginated lista os e-mails recebidos da conta (filtros: contact_id, campaign_id e from)
+func (r *inboundEmailRepository) GetPaginated(ctx context.Context, accountID uuid.UUID, filters map[string]string, currentPage int, perPage int) (*models.Paginator, error) {
+	if currentPage < 1 {
+		currentPage = 1
+	}
+	if perPage < 1 {
+		perPage = 10
+	}
+
+	baseQuery := `SELECT ` + inboundEmailColumns + ` FROM inbound_emails WHERE account_id = $1`
+	args := []interface{}{accountID}
+
+	for key, value := range filters {
+		switch key {
+		case "contact_id", "campaign_id":
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("filtro %s inválido: %w", key, err)
+			}
+			args = append(args, id)
+			baseQuery += fmt.Sprintf(" AND %s = $%d", key, len(args))
+		case "from":
+			args = append(args, "%"+value+"%")
+			baseQuery += fmt.Sprintf(" AND from_address ILIKE $%d", len(args))
+		}
+	}
+
+	var totalRecords int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+baseQuery+") AS total", args...).Scan(&totalRecords); err != nil {
+		return nil, fmt.Errorf("erro ao contar e-mails recebidos: %w", err)
+	}
+
+	offset := (currentPage - 1) * perPage
+	baseQuery += fmt.Sprintf(" ORDER BY received_at DESC LIMIT %d OFFSET %d", perPage, offset)
+
+	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar e-mails recebidos: %w", err)
+	}
+	defer rows.Close()
+
+	emails := []models.InboundEmail{}
+	for rows.Next() {
+		email, err := scanInboundEmail(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao escanear e-mail recebido: %w", err)
+		}
+		emails = append(emails, *email)
+	}
+
+	return &models.Paginator{
+		TotalRecords: totalRecords,
+		TotalPages:   int(math.Ceil(float64(totalRecords) / float64(perPage))),
+		CurrentPage:  currentPage,
+		PerPage:      perPage,
+		Data:         emails,
+	}, nil
+}
+
+// SetChatMessage vincula o e-mail à mensagem criada na conversa
+func (r *inboundEmailRepository) SetChatMessage(ctx context.Context, inboundEmailID, chatMessageID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE inbound_emails SET chat_message_id = $1 WHERE id = $2`, chatMessageID, inboundEmailID); err != nil {
+		return fmt.Errorf("erro ao vincular e-mail recebido à conversa: %w", err)
+	}
+	return nil
+}
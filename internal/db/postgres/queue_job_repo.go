@@ -0,0 +1,97 @@
+// File: /internal/db/postgres/queue_job_repo.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// queueJobRepository implementa QueueJobRepository para PostgreSQL
+type queueJobRepository struct {
+	log *slog.Logger
+	db  *sql.DB
+}
+
+// NewQueueJobRepository cria um novo repositório para a fila em banco
+func NewQueueJobRepository(db *sql.DB) db.QueueJobRepository {
+	log := logger.GetLogger()
+	return &queueJobRepository{log: log, db: db}
+}
+
+// Enqueue insere uma nova mensagem na fila, visível somente após `delay`
+func (r *queueJobRepository) Enqueue(ctx context.Context, queue string, payload []byte, delay time.Duration) error {
+	query := `INSERT INTO queue_jobs (queue, payload, visible_at) VALUES ($1, $2, NOW() + ($3 * INTERVAL '1 second'))`
+	if _, err := r.db.ExecContext(ctx, query, queue, payload, delay.Seconds()); err != nil {
+		return fmt.Errorf("erro ao enfileirar mensagem: %w", err)
+	}
+	return nil
+}
+
+// Dequeue reserva até `limit` mensagens visíveis da fila, escondendo-as pelo tempo de visibilidade.
+// O uso de FOR UPDATE SKIP LOCKED permite vários consumidores concorrentes sem entregas duplicadas.
+func (r *queueJobRepository) Dequeue(ctx context.Context, queue string, limit int, visibilityTimeout time.Duration) ([]models.QueueJob, error) {
+	query := `
+		UPDATE queue_jobs
+		SET visible_at = NOW() + ($3 * INTERVAL '1 second'), attempts = attempts + 1
+		WHERE id IN (
+			SELECT id FROM queue_jobs
+			WHERE queue = $1 AND visible_at <= NOW() AND dead_lettered_at IS NULL
+			ORDER BY created_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, queue, payload, attempts, visible_at, created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, queue, limit, visibilityTimeout.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consumir mensagens da fila: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []models.QueueJob
+	for rows.Next() {
+		var job models.QueueJob
+		if err := rows.Scan(&job.ID, &job.Queue, &job.Payload, &job.Attempts, &job.VisibleAt, &job.CreatedAt); err != nil {
+			return nil, fmt.Errorf("erro ao escanear mensagem da fila: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// ExtendVisibility mantém a mensagem invisível enquanto ela ainda está sendo processada
+func (r *queueJobRepository) ExtendVisibility(ctx context.Context, jobID uuid.UUID, visibilityTimeout time.Duration) error {
+	query := `UPDATE queue_jobs SET visible_at = NOW() + ($2 * INTERVAL '1 second') WHERE id = $1`
+	if _, err := r.db.ExecContext(ctx, query, jobID, visibilityTimeout.Seconds()); err != nil {
+		return fmt.Errorf("erro ao estender visibilidade da mensagem: %w", err)
+	}
+	return nil
+}
+
+// Delete remove uma mensagem já processada da fila
+func (r *queueJobRepository) Delete(ctx context.Context, jobID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM queue_jobs WHERE id = $1`, jobID); err != nil {
+		return fmt.Errorf("erro ao remover mensagem da fila: %w", err)
+	}
+	return nil
+}
+
+// MoveToDeadLetter mantém a mensagem na tabela para inspeção, mas fora do consumo
+func (r *queueJobRepository) MoveToDeadLetter(ctx context.Context, jobID uuid.UUID, reason string) error {
+	query := `UPDATE queue_jobs SET dead_lettered_at = NOW(), last_error = $2 WHERE id = $1`
+	if _, err := r.db.ExecContext(ctx, query, jobID, reason); err != nil {
+		return fmt.Errorf("erro ao mover mensagem para dead-letter: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// File: /internal/db/postgres/send_policy_repo.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/lib/pq"
+)
+
+// sendPolicyRepository implementa SendPolicyRepository para PostgreSQL
+type sendPolicyRepository struct {
+	log *slog.Logger
+	db  *sql.DB
+}
+
+// NewSendPolicyRepository cria um novo repositório de janelas de envio
+func NewSendPolicyRepository(db *sql.DB) db.SendPolicyRepository {
+	log := logger.GetLogger()
+	return &sendPolicyRepository{log: log, db: db}
+}
+
+const sendPolicyColumns = `id, account_id, channel, timezone, weekdays, start_time, end_time, rate_per_minute, daily_cap, created_at, updated_at`
+
+// scanSendPolicy converte uma linha em SendPolicy
+func scanSendPolicy(scanner interface{ Scan(dest ...any) error }) (*models.SendPolicy, error) {
+	var policy models.SendPolicy
+	var weekdays pq.Int64Array
+
+	if err := scanner.Scan(
+		&policy.ID, &policy.AccountID, &policy.Channel, &policy.Timezone, &weekdays,
+		&policy.StartTime, &policy.EndTime, &policy.RatePerMinute, &policy.DailyCap,
+		&policy.CreatedAt, &policy.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	for _, weekday := range weekdays {
+		policy.Weekdays = append(policy.Weekdays, int(weekday))
+	}
+	return &policy, nil
+}
+
+// Upsert cria ou substitui a política do canal da conta
+func (r *sendPolicyRepository) Upsert(ctx context.Context, policy *models.SendPolicy) (*models.SendPolicy, error) {
+	weekdays := make(pq.Int64Array, 0, len(policy.Weekdays))
+	for _, weekday := range policy.Weekdays {
+		weekdays = append(weekdays, int64(weekday))
+	}
+
+	query := `
+		INSERT INTO send_policies (account_id, channel, timezone, weekdays, start_time, end_time, rate_per_minute, daily_cap)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (account_id, channel) DO UPDATE SET
+			timezone = EXCLUDED.timezone, weekdays = EXCLUDED.weekdays,
+			start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time,
+			rate_per_minute = EXCLUDED.rate_per_minute, daily_cap = EXCLUDED.daily_cap,
+			updated_at = NOW()
+		RETURNING ` + sendPolicyColumns
+
+	saved, err := scanSendPolicy(r.db.QueryRowContext(ctx, query,
+		policy.AccountID, policy.Channel, policy.Timezone, weekdays,
+		policy.StartTime, policy.EndTime, policy.RatePerMinute, policy.DailyCap,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao salvar janela de envio: %w", err)
+	}
+
+	return saved, nil
+}
+
+// GetByAccountID lista as políticas de envio da conta
+func (r *sendPolicyRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.SendPolicy, error) {
+	query := `SELECT ` + sendPolicyColumns + ` FROM send_policies WHERE account_id = $1 ORDER BY channel`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar janelas de envio: %w", err)
+	}
+	defer rows.Close()
+
+	policies := []models.SendPolicy{}
+	for rows.Next() {
+		policy, err := scanSendPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao escanear janela de envio: %w", err)
+		}
+		policies = append(policies, *policy)
+	}
+
+	return policies, nil
+}
+
+// GetByAccountAndChannel busca a política de um canal (nil se a conta não definiu)
+func (r *sendPolicyRepository) GetByAccountAndChannel(ctx context.Context, accountID uuid.UUID, channel string) (*models.SendPolicy, error) {
+	query := `SELECT ` + sendPolicyColumns + ` FROM send_policies WHERE account_id = $1 AND channel = $2`
+
+	policy, err := scanSendPolicy(r.db.QueryRowContext(ctx, query, accountID, channel))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar janela de envio: %w", err)
+	}
+
+	return policy, nil
+}
+
+// Delete remove a política de um canal (volta a enviar sem restrições)
+func (r *sendPolicyRepository) Delete(ctx context.Context, accountID uuid.UUID, channel string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM send_policies WHERE account_id = $1 AND channel = $2`, accountID, channel); err != nil {
+		return fmt.Errorf("erro ao remover janela de envio: %w", err)
+	}
+	return nil
+}
+
+// IncrementCounter soma um envio ao contador do período. Com `limit` > 0 o incremento só ocorre
+// se o contador ainda estiver abaixo do limite; o retorno `ok` indica se o envio foi contabilizado.
+func (r *sendPolicyRepository) IncrementCounter(ctx context.Context, accountID uuid.UUID, channel, period string, bucket time.Time, limit int) (int, bool, error) {
+	query := `
+		INSERT INTO send_counters (account_id, channel, period, bucket, count)
+		VALUES ($1, $2, $3, $4, 1)
+		ON CONFLICT (account_id, channel, period, bucket) DO UPDATE
+		SET count = send_counters.count + 1
+		WHERE $5 <= 0 OR send_counters.count < $5
+		RETURNING count
+	`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, accountID, channel, period, bucket, limit).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return limit, false, nil // 🚫 Limite atingido
+		}
+		return 0, false, fmt.Errorf("erro ao incrementar contador de envios: %w", err)
+	}
+
+	return count, true, nil
+}
+
+// DecrementCounter devolve ao contador do período um envio reservado que não aconteceu
+func (r *sendPolicyRepository) DecrementCounter(ctx context.Context, accountID uuid.UUID, channel, period string, bucket time.Time) error {
+	query := `
+		UPDATE send_counters SET count = GREATEST(count - 1, 0)
+		WHERE account_id = $1 AND channel = $2 AND period = $3 AND bucket = $4
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, accountID, channel, period, bucket); err != nil {
+		return fmt.Errorf("erro ao devolver envio ao contador: %w", err)
+	}
+	return nil
+}
+
+// GetCounter retorna o total de envios contabilizados no período
+func (r *sendPolicyRepository) GetCounter(ctx context.Context, accountID uuid.UUID, channel, period string, bucket time.Time) (int, error) {
+	query := `SELECT count FROM send_counters WHERE account_id = $1 AND channel = $2 AND period = $3 AND bucket = $4`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, accountID, channel, period, bucket).Scan(&count); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("erro ao buscar contador de envios: %w", err)
+	}
+
+	return count, nil
+}
+
+// PurgeCounters remove contadores antigos
+func (r *sendPolicyRepository) PurgeCounters(ctx context.Context, before time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM send_counters WHERE bucket < $1`, before); err != nil {
+		return fmt.Errorf("erro ao limpar contadores de envio: %w", err)
+	}
+	return nil
+}
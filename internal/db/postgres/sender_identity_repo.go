@@ -0,0 +1,166 @@
+// File: /internal/db/postgres/sender_identity_repo.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// senderIdentityRepository implementa SenderIdentityRepository para PostgreSQL
+type senderIdentityRepository struct {
+	log *slog.Logger
+	db  *sql.DB
+}
+
+// NewSenderIdentityRepository cria um novo repositório de remetentes
+func NewSenderIdentityRepository(db *sql.DB) db.SenderIdentityRepository {
+	log := logger.GetLogger()
+	return &senderIdentityRepository{log: log, db: db}
+}
+
+const senderIdentityColumns = `id, account_id, kind, identity, display_name, reply_to, configuration_set, status, verification_token, verified_at, checked_at, created_at, updated_at`
+
+// scanSenderIdentity converte uma linha em SenderIdentity
+func scanSenderIdentity(scanner interface{ Scan(dest ...any) error }) (*models.SenderIdentity, error) {
+	var identity models.SenderIdentity
+	if err := scanner.Scan(
+		&identity.ID, &identity.AccountID, &identity.Kind, &identity.Identity, &identity.DisplayName,
+		&identity.ReplyTo, &identity.ConfigurationSet, &identity.Status, &identity.VerificationToken,
+		&identity.VerifiedAt, &identity.CheckedAt, &identity.CreatedAt, &identity.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// Create cadastra um remetente da conta
+func (r *senderIdentityRepository) Create(ctx context.Context, identity *models.SenderIdentity) (*models.SenderIdentity, error) {
+	query := `
+		INSERT INTO sender_identities (account_id, kind, identity, display_name, reply_to, configuration_set, status, verification_token)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + senderIdentityColumns
+
+	saved, err := scanSenderIdentity(r.db.QueryRowContext(ctx, query,
+		identity.AccountID, identity.Kind, identity.Identity, identity.DisplayName, identity.ReplyTo,
+		identity.ConfigurationSet, identity.Status, identity.VerificationToken,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar remetente: %w", err)
+	}
+
+	return saved, nil
+}
+
+// GetByID busca um remetente pelo ID
+func (r *senderIdentityRepository) GetByID(ctx context.Context, identityID uuid.UUID) (*models.SenderIdentity, error) {
+	query := `SELECT ` + senderIdentityColumns + ` FROM sender_identities WHERE id = $1`
+
+	identity, err := scanSenderIdentity(r.db.QueryRowContext(ctx, query, identityID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar remetente: %w", err)
+	}
+
+	return identity, nil
+}
+
+// GetByAccountID lista os remetentes da conta
+func (r *senderIdentityRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.SenderIdentity, error) {
+	query := `SELECT ` + senderIdentityColumns + ` FROM sender_identities WHERE account_id = $1 ORDER BY identity`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar remetentes: %w", err)
+	}
+	defer rows.Close()
+
+	identities := []models.SenderIdentity{}
+	for rows.Next() {
+		identity, err := scanSenderIdentity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao escanear remetente: %w", err)
+		}
+		identities = append(identities, *identity)
+	}
+
+	return identities, nil
+}
+
+// FindForAddress retorna o remetente da conta que cobre o endereço (o e-mail exato tem prioridade sobre o domínio)
+func (r *senderIdentityRepository) FindForAddress(ctx context.Context, accountID uuid.UUID, address string) (*models.SenderIdentity, error) {
+	query := `
+		SELECT ` + senderIdentityColumns + `
+		FROM sender_identities
+		WHERE account_id = $1
+		AND ((kind = 'email' AND identity = LOWER($2)) OR (kind = 'domain' AND identity = LOWER(SPLIT_PART($2, '@', 2))))
+		ORDER BY CASE kind WHEN 'email' THEN 0 ELSE 1 END
+		LIMIT 1
+	`
+
+	identity, err := scanSenderIdentity(r.db.QueryRowContext(ctx, query, accountID, address))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar remetente do endereço: %w", err)
+	}
+
+	return identity, nil
+}
+
+// Update altera os dados de exibição do remetente (a identidade e o estado de verificação não mudam)
+func (r *senderIdentityRepository) Update(ctx context.Context, identity *models.SenderIdentity) (*models.SenderIdentity, error) {
+	query := `
+		UPDATE sender_identities
+		SET display_name = $2, reply_to = $3, configuration_set = $4, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + senderIdentityColumns
+
+	saved, err := scanSenderIdentity(r.db.QueryRowContext(ctx, query,
+		identity.ID, identity.DisplayName, identity.ReplyTo, identity.ConfigurationSet,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar remetente: %w", err)
+	}
+
+	return saved, nil
+}
+
+// UpdateVerification registra o resultado da consulta ao provedor (verified_at é mantido enquanto verificado)
+func (r *senderIdentityRepository) UpdateVerification(ctx context.Context, identityID uuid.UUID, status models.SenderIdentityStatus, token *string) (*models.SenderIdentity, error) {
+	query := `
+		UPDATE sender_identities
+		SET status = $2,
+			verification_token = COALESCE($3, verification_token),
+			verified_at = CASE WHEN $2 = 'verificado' THEN COALESCE(verified_at, NOW()) ELSE NULL END,
+			checked_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + senderIdentityColumns
+
+	saved, err := scanSenderIdentity(r.db.QueryRowContext(ctx, query, identityID, status, token))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar verificação do remetente: %w", err)
+	}
+
+	return saved, nil
+}
+
+// DeleteByID remove o remetente (campanhas que o usavam ficam sem remetente e não podem ser iniciadas)
+func (r *senderIdentityRepository) DeleteByID(ctx context.Context, identityID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM sender_identities WHERE id = $1`, identityID); err != nil {
+		return fmt.Errorf("erro ao remover remetente: %w", err)
+	}
+	return nil
+}
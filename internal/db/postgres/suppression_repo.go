@@ -0,0 +1,213 @@
+// File: /internal/db/postgres/suppression_repo.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// suppressionRepository implementa SuppressionRepository para PostgreSQL
+type suppressionRepository struct {
+	log *slog.Logger
+	db  *sql.DB
+}
+
+// NewSuppressionRepository cria um novo repositório da lista de supressão
+func NewSuppressionRepository(db *sql.DB) db.SuppressionRepository {
+	log := logger.GetLogger()
+	return &suppressionRepository{log: log, db: db}
+}
+
+const suppressionColumns = `id, account_id, channel, address, reason, campaign_id, bounce_count, expires_at, notes, created_at, updated_at`
+
+// scanSuppression converte uma linha em Suppression
+func scanSuppression(scanner interface{ Scan(dest ...any) error }) (*models.Suppression, error) {
+	var suppression models.Suppression
+	if err := scanner.Scan(
+		&suppression.ID, &suppression.AccountID, &suppression.Channel, &suppression.Address, &suppression.Reason,
+		&suppression.CampaignID, &suppression.BounceCount, &suppression.ExpiresAt, &suppression.Notes,
+		&suppression.CreatedAt, &suppression.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &suppression, nil
+}
+
+// suppressedContactCondition retorna a condição SQL que identifica um contato (alias informado)
+// com supressão ativa no canal (coluna ou parâmetro informado)
+func suppressedContactCondition(contact, channel string) string {
+	return fmt.Sprintf(`EXISTS (
+		SELECT 1 FROM suppressions s
+		WHERE s.account_id = %[1]s.account_id AND s.channel = %[2]s
+		AND s.address = CASE WHEN %[2]s = 'email' THEN LOWER(%[1]s.email) ELSE %[1]s.whatsapp END
+		AND (s.expires_at IS NULL OR s.expires_at > NOW())
+	)`, contact, channel)
+}
+
+// Upsert cria ou substitui a supressão do destinatário (hard bounce, reclamação ou manual).
+// O contador de bounces é acumulado.
+func (r *suppressionRepository) Upsert(ctx context.Context, suppression *models.Suppression) (*models.Suppression, error) {
+	query := `
+		INSERT INTO suppressions (account_id, channel, address, reason, campaign_id, bounce_count, expires_at, notes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (account_id, channel, address) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			campaign_id = COALESCE(EXCLUDED.campaign_id, suppressions.campaign_id),
+			bounce_count = suppressions.bounce_count + EXCLUDED.bounce_count,
+			expires_at = EXCLUDED.expires_at,
+			notes = COALESCE(EXCLUDED.notes, suppressions.notes),
+			updated_at = NOW()
+		RETURNING ` + suppressionColumns
+
+	saved, err := scanSuppression(r.db.QueryRowContext(ctx, query,
+		suppression.AccountID, suppression.Channel, suppression.Address, suppression.Reason,
+		suppression.CampaignID, suppression.BounceCount, suppression.ExpiresAt, suppression.Notes,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao salvar supressão: %w", err)
+	}
+
+	return saved, nil
+}
+
+// RegisterSoftBounce conta um soft bounce do destinatário; ao atingir `limit` a supressão vale até `expiresAt`.
+// Supressões permanentes ou manuais existentes não são alteradas (apenas o contador).
+func (r *suppressionRepository) RegisterSoftBounce(ctx context.Context, suppression *models.Suppression, limit int, expiresAt time.Time) (*models.Suppression, error) {
+	query := `
+		INSERT INTO suppressions (account_id, channel, address, reason, campaign_id, bounce_count, expires_at)
+		VALUES ($1, $2, $3, $4, $5, 1, CASE WHEN 1 >= $6 THEN $7 ELSE NOW() END)
+		ON CONFLICT (account_id, channel, address) DO UPDATE SET
+			campaign_id = COALESCE(EXCLUDED.campaign_id, suppressions.campaign_id),
+			bounce_count = suppressions.bounce_count + 1,
+			expires_at = CASE
+				WHEN suppressions.reason = $4 AND suppressions.bounce_count + 1 >= $6 THEN $7
+				ELSE suppressions.expires_at
+			END,
+			updated_at = NOW()
+		RETURNING ` + suppressionColumns
+
+	saved, err := scanSuppression(r.db.QueryRowContext(ctx, query,
+		suppression.AccountID, suppression.Channel, suppression.Address, models.SuppressionSoftBounce,
+		suppression.CampaignID, limit, expiresAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao registrar soft bounce: %w", err)
+	}
+
+	return saved, nil
+}
+
+// GetByID busca uma supressão pelo ID
+func (r *suppressionRepository) GetByID(ctx context.Context, suppressionID uuid.UUID) (*models.Suppression, error) {
+	query := `SELECT ` + suppressionColumns + ` FROM suppressions WHERE id = $1`
+
+	suppression, err := scanSuppression(r.db.QueryRowContext(ctx, query, suppressionID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar supressão: %w", err)
+	}
+
+	return suppression, nil
+}
+
+// GetActive retorna a supressão vigente do destinatário (nil se puder receber)
+func (r *suppressionRepository) GetActive(ctx context.Context, accountID uuid.UUID, channel models.ChannelType, address string) (*models.Suppression, error) {
+	query := `
+		SELECT ` + suppressionColumns + `
+		FROM suppressions
+		WHERE account_id = $1 AND channel = $2 AND address = $3
+		AND (expires_at IS NULL OR expires_at > NOW())
+	`
+
+	suppression, err := scanSuppression(r.db.QueryRowContext(ctx, query, accountID, channel, address))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao verificar supressão: %w", err)
+	}
+
+	return suppression, nil
+}
+
+// GetPaginated lista as supressões da conta (filtros: channel, reason, address e active)
+func (r *suppressionRepository) GetPaginated(ctx context.Context, accountID uuid.UUID, filters map[string]string, currentPage int, perPage int) (*models.Paginator, error) {
+	if currentPage < 1 {
+		currentPage = 1
+	}
+	if perPage < 1 {
+		perPage = 10
+	}
+
+	baseQuery := `SELECT ` + suppressionColumns + ` FROM suppressions WHERE account_id = $1`
+	args := []interface{}{accountID}
+
+	for key, value := range filters {
+		switch key {
+		case "channel", "reason":
+			args = append(args, value)
+			baseQuery += fmt.Sprintf(" AND %s = $%d", key, len(args))
+		case "address":
+			args = append(args, "%"+value+"%")
+			baseQuery += fmt.Sprintf(" AND address ILIKE $%d", len(args))
+		case "active":
+			if value == "true" {
+				baseQuery += " AND (expires_at IS NULL OR expires_at > NOW())"
+			} else if value == "false" {
+				baseQuery += " AND expires_at <= NOW()"
+			}
+		}
+	}
+
+	var totalRecords int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+baseQuery+") AS total", args...).Scan(&totalRecords); err != nil {
+		return nil, fmt.Errorf("erro ao contar supressões: %w", err)
+	}
+
+	offset := (currentPage - 1) * perPage
+	baseQuery += fmt.Sprintf(" ORDER BY updated_at DESC LIMIT %d OFFSET %d", perPage, offset)
+
+	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar supressões: %w", err)
+	}
+	defer rows.Close()
+
+	suppressions := []models.Suppression{}
+	for rows.Next() {
+		suppression, err := scanSuppression(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao escanear supressão: %w", err)
+		}
+		suppressions = append(suppressions, *suppression)
+	}
+
+	return &models.Paginator{
+		TotalRecords: totalRecords,
+		TotalPages:   int(math.Ceil(float64(totalRecords) / float64(perPage))),
+		CurrentPage:  currentPage,
+		PerPage:      perPage,
+		Data:         suppressions,
+	}, nil
+}
+
+// DeleteByID remove a supressão (o destinatário volta a receber e a contagem de bounces recomeça)
+func (r *suppressionRepository) DeleteByID(ctx context.Context, suppressionID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM suppressions WHERE id = $1`, suppressionID); err != nil {
+		return fmt.Errorf("erro ao remover supressão: %w", err)
+	}
+	return nil
+}
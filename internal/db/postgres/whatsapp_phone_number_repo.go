@@ -0,0 +1,139 @@
+// File: /internal/db/postgres/whatsapp_phone_number_repo.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// whatsAppPhoneNumberRepository implementa WhatsAppPhoneNumberRepository para PostgreSQL
+type whatsAppPhoneNumberRepository struct {
+	log *slog.Logger
+	db  *sql.DB
+}
+
+// NewWhatsAppPhoneNumberRepository cria um novo repositório de números da Cloud API
+func NewWhatsAppPhoneNumberRepository(db *sql.DB) db.WhatsAppPhoneNumberRepository {
+	log := logger.GetLogger()
+	return &whatsAppPhoneNumberRepository{log: log, db: db}
+}
+
+const whatsAppPhoneNumberColumns = `id, account_id, phone_number_id, business_account_id, display_phone_number, verified_name,
+	quality_rating, templates_synced_at, created_at, updated_at`
+
+// scanWhatsAppPhoneNumber converte uma linha em WhatsAppPhoneNumber
+func scanWhatsAppPhoneNumber(scanner interface{ Scan(dest ...any) error }) (*models.WhatsAppPhoneNumber, error) {
+	var number models.WhatsAppPhoneNumber
+	if err := scanner.Scan(
+		&number.ID, &number.AccountID, &number.PhoneNumberID, &number.BusinessAccountID, &number.DisplayPhoneNumber,
+		&number.VerifiedName, &number.QualityRating, &number.TemplatesSyncedAt, &number.CreatedAt, &number.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &number, nil
+}
+
+// Create cadastra um número da conta
+func (r *whatsAppPhoneNumberRepository) Create(ctx context.Context, number *models.WhatsAppPhoneNumber) (*models.WhatsAppPhoneNumber, error) {
+	query := `
+		INSERT INTO whatsapp_phone_numbers (account_id, phone_number_id, business_account_id, display_phone_number, verified_name, quality_rating)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + whatsAppPhoneNumberColumns
+
+	saved, err := scanWhatsAppPhoneNumber(r.db.QueryRowContext(ctx, query,
+		number.AccountID, number.PhoneNumberID, number.BusinessAccountID, number.DisplayPhoneNumber,
+		number.VerifiedName, number.QualityRating,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao cadastrar número da Cloud API: %w", err)
+	}
+
+	return saved, nil
+}
+
+// GetByID busca um número pelo ID
+func (r *whatsAppPhoneNumberRepository) GetByID(ctx context.Context, numberID uuid.UUID) (*models.WhatsAppPhoneNumber, error) {
+	query := `SELECT ` + whatsAppPhoneNumberColumns + ` FROM whatsapp_phone_numbers WHERE id = $1`
+
+	number, err := scanWhatsAppPhoneNumber(r.db.QueryRowContext(ctx, query, numberID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar número da Cloud API: %w", err)
+	}
+
+	return number, nil
+}
+
+// GetByPhoneNumberID busca o número pelo phone_number_id da Meta (único entre as contas)
+func (r *whatsAppPhoneNumberRepository) GetByPhoneNumberID(ctx context.Context, phoneNumberID string) (*models.WhatsAppPhoneNumber, error) {
+	query := `SELECT ` + whatsAppPhoneNumberColumns + ` FROM whatsapp_phone_numbers WHERE phone_number_id = $1`
+
+	number, err := scanWhatsAppPhoneNumber(r.db.QueryRowContext(ctx, query, phoneNumberID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar número da Cloud API: %w", err)
+	}
+
+	return number, nil
+}
+
+// GetByAccountID lista os números da conta
+func (r *whatsAppPhoneNumberRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.WhatsAppPhoneNumber, error) {
+	query := `SELECT ` + whatsAppPhoneNumberColumns + ` FROM whatsapp_phone_numbers WHERE account_id = $1 ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar números da Cloud API: %w", err)
+	}
+	defer rows.Close()
+
+	numbers := []models.WhatsAppPhoneNumber{}
+	for rows.Next() {
+		number, err := scanWhatsAppPhoneNumber(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao escanear número da Cloud API: %w", err)
+		}
+		numbers = append(numbers, *number)
+	}
+
+	return numbers, rows.Err()
+}
+
+// Update grava os dados consultados no Graph e o instante da última sincronização dos templates
+func (r *whatsAppPhoneNumberRepository) Update(ctx context.Context, number *models.WhatsAppPhoneNumber) (*models.WhatsAppPhoneNumber, error) {
+	query := `
+		UPDATE whatsapp_phone_numbers
+		SET display_phone_number = $2, verified_name = $3, quality_rating = $4, templates_synced_at = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + whatsAppPhoneNumberColumns
+
+	saved, err := scanWhatsAppPhoneNumber(r.db.QueryRowContext(ctx, query,
+		number.ID, number.DisplayPhoneNumber, number.VerifiedName, number.QualityRating, number.TemplatesSyncedAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar número da Cloud API: %w", err)
+	}
+
+	return saved, nil
+}
+
+// DeleteByID remove o número (os templates da WABA continuam disponíveis para os outros números)
+func (r *whatsAppPhoneNumberRepository) DeleteByID(ctx context.Context, numberID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM whatsapp_phone_numbers WHERE id = $1`, numberID); err != nil {
+		return fmt.Errorf("erro ao remover número da Cloud API: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// File: /internal/db/postgres/whatsapp_template_repo.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/lib/pq"
+)
+
+// whatsAppTemplateRepository implementa WhatsAppTemplateRepository para PostgreSQL
+type whatsAppTemplateRepository struct {
+	log *slog.Logger
+	db  *sql.DB
+}
+
+// NewWhatsAppTemplateRepository cria um novo repositório de templates da Cloud API
+func NewWhatsAppTemplateRepository(db *sql.DB) db.WhatsAppTemplateRepository {
+	log := logger.GetLogger()
+	return &whatsAppTemplateRepository{log: log, db: db}
+}
+
+const whatsAppTemplateColumns = `id, account_id, business_account_id, external_id, name, language, category, status,
+	parameter_format, components, slots, synced_at, created_at, updated_at`
+
+// scanWhatsAppTemplate converte uma linha em WhatsAppTemplate
+func scanWhatsAppTemplate(scanner interface{ Scan(dest ...any) error }) (*models.WhatsAppTemplate, error) {
+	var template models.WhatsAppTemplate
+	var components, slots []byte
+	if err := scanner.Scan(
+		&template.ID, &template.AccountID, &template.BusinessAccountID, &template.ExternalID, &template.Name,
+		&template.Language, &template.Category, &template.Status, &template.ParameterFormat, &components, &slots,
+		&template.SyncedAt, &template.CreatedAt, &template.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	template.Components = json.RawMessage(components)
+	if err := json.Unmarshal(slots, &template.Slots); err != nil {
+		return nil, fmt.Errorf("erro ao converter variáveis do template: %w", err)
+	}
+	return &template, nil
+}
+
+// Sync grava os templates da WABA em uma transação: os recebidos são inseridos ou atualizados
+// e os que sumiram da Meta ficam como DELETED (campanhas que os usam deixam de enviar)
+func (r *whatsAppTemplateRepository) Sync(ctx context.Context, accountID uuid.UUID, businessAccountID string, templates []models.WhatsAppTemplate) ([]models.WhatsAppTemplate, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar sincronização dos templates: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO whatsapp_templates (
+			account_id, business_account_id, external_id, name, language, category, status, parameter_format, components, slots
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (account_id, business_account_id, name, language) DO UPDATE SET
+			external_id = EXCLUDED.external_id, category = EXCLUDED.category, status = EXCLUDED.status,
+			parameter_format = EXCLUDED.parameter_format, components = EXCLUDED.components, slots = EXCLUDED.slots,
+			synced_at = NOW(), updated_at = NOW()
+		RETURNING ` + whatsAppTemplateColumns
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao preparar sincronização dos templates: %w", err)
+	}
+	defer stmt.Close()
+
+	synced := make([]models.WhatsAppTemplate, 0, len(templates))
+	externalIDs := make([]string, 0, len(templates))
+	for _, template := range templates {
+		slots, err := json.Marshal(template.Slots)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao serializar variáveis do template: %w", err)
+		}
+		components := []byte(template.Components)
+		if len(components) == 0 {
+			components = []byte("[]")
+		}
+
+		saved, err := scanWhatsAppTemplate(stmt.QueryRowContext(ctx,
+			accountID, businessAccountID, template.ExternalID, template.Name, template.Language, template.Category,
+			template.Status, template.ParameterFormat, components, slots,
+		))
+		if err != nil {
+			return nil, fmt.Errorf("erro ao gravar template %s (%s): %w", template.Name, template.Language, err)
+		}
+		synced = append(synced, *saved)
+		externalIDs = append(externalIDs, template.ExternalID)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE whatsapp_templates
+		SET status = $3, synced_at = NOW(), updated_at = NOW()
+		WHERE account_id = $1 AND business_account_id = $2 AND status <> $3 AND NOT (external_id = ANY($4))
+	`, accountID, businessAccountID, models.WhatsAppTemplateDeleted, pq.Array(externalIDs))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao marcar templates removidos: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("erro ao concluir sincronização dos templates: %w", err)
+	}
+
+	if deleted, _ := result.RowsAffected(); deleted > 0 {
+		r.log.Info("🗑️ Templates removidos da WABA marcados como DELETED", "business_account_id", businessAccountID, "total", deleted)
+	}
+	return synced, nil
+}
+
+// GetByID busca um template pelo ID
+func (r *whatsAppTemplateRepository) GetByID(ctx context.Context, templateID uuid.UUID) (*models.WhatsAppTemplate, error) {
+	query := `SELECT ` + whatsAppTemplateColumns + ` FROM whatsapp_templates WHERE id = $1`
+
+	template, err := scanWhatsAppTemplate(r.db.QueryRowContext(ctx, query, templateID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar template do WhatsApp: %w", err)
+	}
+
+	return template, nil
+}
+
+// GetByAccountID lista os templates da conta, opcionalmente de uma WABA e de um status
+func (r *whatsAppTemplateRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID, businessAccountID, status string) ([]models.WhatsAppTemplate, error) {
+	query := `
+		SELECT ` + whatsAppTemplateColumns + `
+		FROM whatsapp_templates
+		WHERE account_id = $1 AND ($2 = '' OR business_account_id = $2) AND ($3 = '' OR status = $3)
+		ORDER BY name, language
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID, businessAccountID, status)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar templates do WhatsApp: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []models.WhatsAppTemplate{}
+	for rows.Next() {
+		template, err := scanWhatsAppTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao escanear template do WhatsApp: %w", err)
+		}
+		templates = append(templates, *template)
+	}
+
+	return templates, rows.Err()
+}
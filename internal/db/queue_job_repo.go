@@ -0,0 +1,21 @@
+// File: /internal/db/queue_job_repo.go
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// QueueJobRepository define as operações da fila de mensagens em banco
+type QueueJobRepository interface {
+	Enqueue(ctx context.Context, queue string, payload []byte, delay time.Duration) error
+	Dequeue(ctx context.Context, queue string, limit int, visibilityTimeout time.Duration) ([]models.QueueJob, error)
+	ExtendVisibility(ctx context.Context, jobID uuid.UUID, visibilityTimeout time.Duration) error
+	Delete(ctx context.Context, jobID uuid.UUID) error
+	// MoveToDeadLetter retira a mensagem do consumo guardando o motivo (equivalente ao redrive do SQS)
+	MoveToDeadLetter(ctx context.Context, jobID uuid.UUID, reason string) error
+}
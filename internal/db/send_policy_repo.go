@@ -0,0 +1,29 @@
+// File: /internal/db/send_policy_repo.go
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// Períodos dos contadores de envio
+const (
+	SendCounterMinute = "minute"
+	SendCounterDay    = "day"
+)
+
+// SendPolicyRepository define as operações sobre janelas de envio e contadores de ritmo
+type SendPolicyRepository interface {
+	Upsert(ctx context.Context, policy *models.SendPolicy) (*models.SendPolicy, error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.SendPolicy, error)
+	GetByAccountAndChannel(ctx context.Context, accountID uuid.UUID, channel string) (*models.SendPolicy, error)
+	Delete(ctx context.Context, accountID uuid.UUID, channel string) error
+	IncrementCounter(ctx context.Context, accountID uuid.UUID, channel, period string, bucket time.Time, limit int) (int, bool, error)
+	DecrementCounter(ctx context.Context, accountID uuid.UUID, channel, period string, bucket time.Time) error
+	GetCounter(ctx context.Context, accountID uuid.UUID, channel, period string, bucket time.Time) (int, error)
+	PurgeCounters(ctx context.Context, before time.Time) error
+}
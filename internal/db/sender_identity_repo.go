@@ -0,0 +1,21 @@
+// File: /internal/db/sender_identity_repo.go
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// SenderIdentityRepository define as operações sobre os remetentes das contas
+type SenderIdentityRepository interface {
+	Create(ctx context.Context, identity *models.SenderIdentity) (*models.SenderIdentity, error)
+	GetByID(ctx context.Context, identityID uuid.UUID) (*models.SenderIdentity, error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.SenderIdentity, error)
+	FindForAddress(ctx context.Context, accountID uuid.UUID, address string) (*models.SenderIdentity, error)
+	Update(ctx context.Context, identity *models.SenderIdentity) (*models.SenderIdentity, error)
+	UpdateVerification(ctx context.Context, identityID uuid.UUID, status models.SenderIdentityStatus, token *string) (*models.SenderIdentity, error)
+	DeleteByID(ctx context.Context, identityID uuid.UUID) error
+}
@@ -0,0 +1,21 @@
+// File: /internal/db/suppression_repo.go
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// SuppressionRepository define as operações sobre a lista de supressão das contas
+type SuppressionRepository interface {
+	Upsert(ctx context.Context, suppression *models.Suppression) (*models.Suppression, error)
+	RegisterSoftBounce(ctx context.Context, suppression *models.Suppression, limit int, expiresAt time.Time) (*models.Suppression, error)
+	GetByID(ctx context.Context, suppressionID uuid.UUID) (*models.Suppression, error)
+	GetActive(ctx context.Context, accountID uuid.UUID, channel models.ChannelType, address string) (*models.Suppression, error)
+	GetPaginated(ctx context.Context, accountID uuid.UUID, filters map[string]string, currentPage int, perPage int) (*models.Paginator, error)
+	DeleteByID(ctx context.Context, suppressionID uuid.UUID) error
+}
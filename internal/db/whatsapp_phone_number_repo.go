@@ -0,0 +1,21 @@
+// File: /internal/db/whatsapp_phone_number_repo.go
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// WhatsAppPhoneNumberRepository define as operações sobre os números da Cloud API das contas
+type WhatsAppPhoneNumberRepository interface {
+	Create(ctx context.Context, number *models.WhatsAppPhoneNumber) (*models.WhatsAppPhoneNumber, error)
+	GetByID(ctx context.Context, numberID uuid.UUID) (*models.WhatsAppPhoneNumber, error)
+	// GetByPhoneNumberID busca pelo phone_number_id da Meta (instance_name do chat), em qualquer conta
+	GetByPhoneNumberID(ctx context.Context, phoneNumberID string) (*models.WhatsAppPhoneNumber, error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.WhatsAppPhoneNumber, error)
+	Update(ctx context.Context, number *models.WhatsAppPhoneNumber) (*models.WhatsAppPhoneNumber, error)
+	DeleteByID(ctx context.Context, numberID uuid.UUID) error
+}
@@ -0,0 +1,19 @@
+// File: /internal/db/whatsapp_template_repo.go
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// WhatsAppTemplateRepository define as operações sobre os templates (HSM) sincronizados das WABAs
+type WhatsAppTemplateRepository interface {
+	// Sync grava os templates da WABA e marca como DELETED os que não vieram na sincronização
+	Sync(ctx context.Context, accountID uuid.UUID, businessAccountID string, templates []models.WhatsAppTemplate) ([]models.WhatsAppTemplate, error)
+	GetByID(ctx context.Context, templateID uuid.UUID) (*models.WhatsAppTemplate, error)
+	// GetByAccountID lista os templates da conta (status vazio = todos)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID, businessAccountID, status string) ([]models.WhatsAppTemplate, error)
+}
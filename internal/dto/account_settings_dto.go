@@ -4,6 +4,7 @@ package dto
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
@@ -12,53 +13,197 @@ import (
 
 // AccountSettingsCreateDTO define os dados necessários para criar configurações de uma conta
 type AccountSettingsCreateDTO struct {
-	AccountID          *uuid.UUID `json:"account_id,omitempty"` // Admin pode definir
-	OpenAIAPIKey       string     `json:"openai_api_key,omitempty"`
-	EvolutionInstance  string     `json:"evolution_instance,omitempty"`
-	AWSAccessKeyID     string     `json:"aws_access_key_id,omitempty"`
-	AWSSecretAccessKey string     `json:"aws_secret_access_key,omitempty"`
-	AWSRegion          string     `json:"aws_region,omitempty"`
-	MailFrom           string     `json:"mail_from,omitempty"`
-	MailAdminTo        string     `json:"mail_admin_to,omitempty"`
+	AccountID            *uuid.UUID `json:"account_id,omitempty"` // Admin pode definir
+	OpenAIAPIKey         string     `json:"openai_api_key,omitempty"`
+	EvolutionInstance    string     `json:"evolution_instance,omitempty"`
+	AWSAccessKeyID       string     `json:"aws_access_key_id,omitempty"`
+	AWSSecretAccessKey   string     `json:"aws_secret_access_key,omitempty"`
+	AWSRegion            string     `json:"aws_region,omitempty"`
+	MailFrom             string     `json:"mail_from,omitempty"`
+	MailAdminTo          string     `json:"mail_admin_to,omitempty"`
+	EmailProvider        string     `json:"email_provider,omitempty"` // ses (padrão) ou smtp
+	SMTPHost             string     `json:"smtp_host,omitempty"`
+	SMTPPort             int        `json:"smtp_port,omitempty"`
+	SMTPUsername         string     `json:"smtp_username,omitempty"`
+	SMTPPassword         string     `json:"smtp_password,omitempty"`
+	SMTPTLS              string     `json:"smtp_tls,omitempty"`               // starttls (padrão), tls ou none
+	MessageRetentionDays int        `json:"message_retention_days,omitempty"` // Retenção do conteúdo entregue (padrão 365 dias)
 }
 
 // AccountSettingsUpdateDTO define os dados permitidos para atualização
 type AccountSettingsUpdateDTO struct {
-	AccountID          *uuid.UUID `json:"account_id,omitempty"` // Obrigatório para admin
-	OpenAIAPIKey       *string    `json:"openai_api_key,omitempty"`
-	EvolutionInstance  *string    `json:"evolution_instance,omitempty"`
-	AWSAccessKeyID     *string    `json:"aws_access_key_id,omitempty"`
-	AWSSecretAccessKey *string    `json:"aws_secret_access_key,omitempty"`
-	AWSRegion          *string    `json:"aws_region,omitempty"`
-	MailFrom           *string    `json:"mail_from,omitempty"`
-	MailAdminTo        *string    `json:"mail_admin_to,omitempty"`
+	AccountID            *uuid.UUID `json:"account_id,omitempty"` // Obrigatório para admin
+	OpenAIAPIKey         *string    `json:"openai_api_key,omitempty"`
+	EvolutionInstance    *string    `json:"evolution_instance,omitempty"`
+	AWSAccessKeyID       *string    `json:"aws_access_key_id,omitempty"`
+	AWSSecretAccessKey   *string    `json:"aws_secret_access_key,omitempty"`
+	AWSRegion            *string    `json:"aws_region,omitempty"`
+	MailFrom             *string    `json:"mail_from,omitempty"`
+	MailAdminTo          *string    `json:"mail_admin_to,omitempty"`
+	EmailProvider        *string    `json:"email_provider,omitempty"`
+	SMTPHost             *string    `json:"smtp_host,omitempty"`
+	SMTPPort             *int       `json:"smtp_port,omitempty"`
+	SMTPUsername         *string    `json:"smtp_username,omitempty"`
+	SMTPPassword         *string    `json:"smtp_password,omitempty"`
+	SMTPTLS              *string    `json:"smtp_tls,omitempty"`
+	MessageRetentionDays *int       `json:"message_retention_days,omitempty"`
 }
 
 // AccountSettingsResponseDTO estrutura de resposta para configurações de conta
 type AccountSettingsResponseDTO struct {
-	ID                 string `json:"id"`
-	AccountID          string `json:"account_id"`
-	OpenAIAPIKey       string `json:"openai_api_key,omitempty"`
-	EvolutionInstance  string `json:"evolution_instance,omitempty"`
-	AWSAccessKeyID     string `json:"aws_access_key_id,omitempty"`
-	AWSSecretAccessKey string `json:"aws_secret_access_key,omitempty"`
-	AWSRegion          string `json:"aws_region,omitempty"`
-	MailFrom           string `json:"mail_from,omitempty"`
-	MailAdminTo        string `json:"mail_admin_to,omitempty"`
+	ID                   string `json:"id"`
+	AccountID            string `json:"account_id"`
+	OpenAIAPIKey         string `json:"openai_api_key,omitempty"`
+	EvolutionInstance    string `json:"evolution_instance,omitempty"`
+	AWSAccessKeyID       string `json:"aws_access_key_id,omitempty"`
+	AWSSecretAccessKey   string `json:"aws_secret_access_key,omitempty"`
+	AWSRegion            string `json:"aws_region,omitempty"`
+	MailFrom             string `json:"mail_from,omitempty"`
+	MailAdminTo          string `json:"mail_admin_to,omitempty"`
+	EmailProvider        string `json:"email_provider"`
+	SMTPHost             string `json:"smtp_host,omitempty"`
+	SMTPPort             int    `json:"smtp_port,omitempty"`
+	SMTPUsername         string `json:"smtp_username,omitempty"`
+	SMTPPassword         string `json:"smtp_password,omitempty"`
+	SMTPTLS              string `json:"smtp_tls,omitempty"`
+	MessageRetentionDays int    `json:"message_retention_days"`
 }
 
 // NewAccountSettingsResponseDTO cria um DTO de resposta formatado
 func NewAccountSettingsResponseDTO(settings *models.AccountSettings) AccountSettingsResponseDTO {
 	return AccountSettingsResponseDTO{
-		ID:                 settings.ID.String(),
-		AccountID:          settings.AccountID.String(),
-		OpenAIAPIKey:       settings.OpenAIAPIKey,
-		EvolutionInstance:  settings.EvolutionInstance,
-		AWSAccessKeyID:     settings.AWSAccessKeyID,
-		AWSSecretAccessKey: settings.AWSSecretAccessKey,
-		AWSRegion:          settings.AWSRegion,
-		MailFrom:           settings.MailFrom,
-		MailAdminTo:        settings.MailAdminTo,
+		ID:                   settings.ID.String(),
+		AccountID:            settings.AccountID.String(),
+		OpenAIAPIKey:         settings.OpenAIAPIKey,
+		EvolutionInstance:    settings.EvolutionInstance,
+		AWSAccessKeyID:       settings.AWSAccessKeyID,
+		AWSSecretAccessKey:   settings.AWSSecretAccessKey,
+		AWSRegion:            settings.AWSRegion,
+		MailFrom:             settings.MailFrom,
+		MailAdminTo:          settings.MailAdminTo,
+		EmailProvider:        string(settings.EmailProvider),
+		SMTPHost:             settings.SMTPHost,
+		SMTPPort:             settings.SMTPPort,
+		SMTPUsername:         settings.SMTPUsername,
+		SMTPPassword:         settings.SMTPPassword,
+		SMTPTLS:              string(settings.SMTPTLS),
+		MessageRetentionDays: settings.MessageRetentionDays,
+	}
+}
+
+// ToModel converte o DTO em configurações da conta, aplicando os padrões do envio de e-mail
+func (a *AccountSettingsCreateDTO) ToModel() *models.AccountSettings {
+	settings := &models.AccountSettings{
+		AccountID:            *a.AccountID,
+		OpenAIAPIKey:         a.OpenAIAPIKey,
+		EvolutionInstance:    a.EvolutionInstance,
+		AWSAccessKeyID:       a.AWSAccessKeyID,
+		AWSSecretAccessKey:   a.AWSSecretAccessKey,
+		AWSRegion:            a.AWSRegion,
+		MailFrom:             a.MailFrom,
+		MailAdminTo:          a.MailAdminTo,
+		EmailProvider:        models.EmailProvider(a.EmailProvider),
+		SMTPHost:             a.SMTPHost,
+		SMTPPort:             a.SMTPPort,
+		SMTPUsername:         a.SMTPUsername,
+		SMTPPassword:         a.SMTPPassword,
+		SMTPTLS:              models.SMTPTLSMode(a.SMTPTLS),
+		MessageRetentionDays: a.MessageRetentionDays,
+	}
+	if settings.MessageRetentionDays == 0 {
+		settings.MessageRetentionDays = models.DefaultMessageRetentionDays
+	}
+	ApplyEmailProviderDefaults(settings)
+	return settings
+}
+
+// ApplyUpdate aplica somente os campos informados na requisição sobre as configurações existentes
+func (a *AccountSettingsUpdateDTO) ApplyUpdate(settings *models.AccountSettings) {
+	if a.OpenAIAPIKey != nil {
+		settings.OpenAIAPIKey = *a.OpenAIAPIKey
+	}
+	if a.EvolutionInstance != nil {
+		settings.EvolutionInstance = *a.EvolutionInstance
+	}
+	if a.AWSAccessKeyID != nil {
+		settings.AWSAccessKeyID = *a.AWSAccessKeyID
+	}
+	if a.AWSSecretAccessKey != nil {
+		settings.AWSSecretAccessKey = *a.AWSSecretAccessKey
+	}
+	if a.AWSRegion != nil {
+		settings.AWSRegion = *a.AWSRegion
+	}
+	if a.MailFrom != nil {
+		settings.MailFrom = *a.MailFrom
+	}
+	if a.MailAdminTo != nil {
+		settings.MailAdminTo = *a.MailAdminTo
+	}
+	if a.EmailProvider != nil {
+		settings.EmailProvider = models.EmailProvider(*a.EmailProvider)
+	}
+	if a.SMTPHost != nil {
+		settings.SMTPHost = *a.SMTPHost
+	}
+	if a.SMTPPort != nil {
+		settings.SMTPPort = *a.SMTPPort
+	}
+	if a.SMTPUsername != nil {
+		settings.SMTPUsername = *a.SMTPUsername
+	}
+	if a.SMTPPassword != nil {
+		settings.SMTPPassword = *a.SMTPPassword
+	}
+	if a.SMTPTLS != nil {
+		settings.SMTPTLS = models.SMTPTLSMode(*a.SMTPTLS)
+	}
+	if a.MessageRetentionDays != nil {
+		settings.MessageRetentionDays = *a.MessageRetentionDays
+	}
+	ApplyEmailProviderDefaults(settings)
+}
+
+// ApplyEmailProviderDefaults preenche provedor, porta e modo TLS quando não informados
+func ApplyEmailProviderDefaults(settings *models.AccountSettings) {
+	if settings.EmailProvider == "" {
+		settings.EmailProvider = models.EmailProviderSES
+	}
+	if settings.SMTPTLS == "" {
+		settings.SMTPTLS = models.SMTPTLSStartTLS
+	}
+	if settings.SMTPPort == 0 {
+		settings.SMTPPort = 587
+		if settings.SMTPTLS == models.SMTPTLSImplicit {
+			settings.SMTPPort = 465
+		}
+	}
+}
+
+// ValidateEmailProvider verifica se as configurações finais permitem enviar e-mails pelo provedor escolhido
+func ValidateEmailProvider(settings *models.AccountSettings) error {
+	switch settings.SMTPTLS {
+	case models.SMTPTLSStartTLS, models.SMTPTLSImplicit, models.SMTPTLSNone:
+	default:
+		return fmt.Errorf("smtp_tls inválido: %s (use starttls, tls ou none)", settings.SMTPTLS)
+	}
+	if settings.SMTPPort < 1 || settings.SMTPPort > 65535 {
+		return errors.New("smtp_port deve estar entre 1 e 65535")
+	}
+
+	switch settings.EmailProvider {
+	case models.EmailProviderSES:
+		return nil
+	case models.EmailProviderSMTP:
+		if settings.SMTPHost == "" {
+			return errors.New("smtp_host é obrigatório quando email_provider for smtp")
+		}
+		if settings.SMTPPassword != "" && settings.SMTPUsername == "" {
+			return errors.New("se smtp_password for definido, smtp_username também deve ser")
+		}
+		return nil
+	default:
+		return fmt.Errorf("email_provider inválido: %s (use ses ou smtp)", settings.EmailProvider)
 	}
 }
 
@@ -91,6 +236,11 @@ func (a *AccountSettingsCreateDTO) Validate(isAdmin bool) error {
 	if err := utils.ValidateEmail(a.MailAdminTo); err != nil {
 		return errors.New("MailAdminTo inválido: " + err.Error())
 	}
+	if a.MessageRetentionDays != 0 {
+		if err := validateMessageRetentionDays(a.MessageRetentionDays); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -123,5 +273,18 @@ func (a *AccountSettingsUpdateDTO) Validate(isAdmin bool) error {
 			return errors.New("MailAdminTo inválido: " + err.Error())
 		}
 	}
+	if a.MessageRetentionDays != nil {
+		if err := validateMessageRetentionDays(*a.MessageRetentionDays); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateMessageRetentionDays limita a retenção do conteúdo entregue (mesma faixa da constraint do banco)
+func validateMessageRetentionDays(days int) error {
+	if days < 1 || days > 3650 {
+		return errors.New("message_retention_days deve estar entre 1 e 3650")
+	}
 	return nil
 }
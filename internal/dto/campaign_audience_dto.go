@@ -4,6 +4,7 @@ package dto
 
 import (
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
@@ -72,3 +73,21 @@ func NewCampaignAudienceResponseDTO(audience *models.CampaignAudience) CampaignA
 		UpdatedAt:  audience.UpdatedAt.Format("2006-01-02 15:04:05"),
 	}
 }
+
+// CampaignDeadLetterDTO representa um contato cujo envio foi abandonado após falhas
+type CampaignDeadLetterDTO struct {
+	ID        uuid.UUID          `json:"id"` // Audience ID
+	ContactID uuid.UUID          `json:"contact_id"`
+	Type      models.ChannelType `json:"type"`
+	Name      string             `json:"name"`
+	Email     *string            `json:"email,omitempty"`
+	WhatsApp  *string            `json:"whatsapp,omitempty"`
+	Attempts  int                `json:"attempts"`
+	LastError *string            `json:"last_error,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// CampaignDeadLetterRetryDTO define quais dead-letters reenfileirar (vazio = todos da campanha)
+type CampaignDeadLetterRetryDTO struct {
+	AudienceIDs []uuid.UUID `json:"audience_ids,omitempty"`
+}
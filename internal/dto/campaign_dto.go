@@ -4,6 +4,8 @@ package dto
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
@@ -51,9 +53,9 @@ func (c *CampaignUpdateDTO) Validate() error {
 		return errors.New("pelo menos um canal deve ser definido")
 	}
 	if c.Status != nil {
-		validStatuses := map[models.CampaignStatus]bool{models.StatusPendente: true, models.StatusProcessando: true, models.StatusEnviando: true, models.StatusCancelada: true, models.StatusConcluida: true}
+		validStatuses := map[models.CampaignStatus]bool{models.StatusPendente: true, models.StatusProcessando: true, models.StatusEnviando: true, models.StatusPausada: true, models.StatusCancelada: true, models.StatusConcluida: true, models.StatusConcluidaComFalhas: true}
 		if !validStatuses[*c.Status] {
-			return errors.New("status inválido, deve ser 'pendente', 'processando', 'enviando', 'cancelada' ou 'concluida'")
+			return errors.New("status inválido, deve ser 'pendente', 'processando', 'enviando', 'pausada', 'cancelada', 'concluida' ou 'concluida_com_falhas'")
 		}
 	}
 	return nil
@@ -61,19 +63,59 @@ func (c *CampaignUpdateDTO) Validate() error {
 
 // CampaignUpdateStatusDTO define os dados permitidos para atualização de status da campanha
 type CampaignUpdateStatusDTO struct {
-	Status models.CampaignStatus `json:"status"`
+	Status      models.CampaignStatus `json:"status"`
+	ScheduledAt *string               `json:"scheduled_at,omitempty"` // Data/hora local do disparo (ex.: "2025-05-20T09:00:00") quando status = "agendada"
+	Timezone    *string               `json:"timezone,omitempty"`     // Fuso IANA (ex.: "America/Sao_Paulo")
 }
 
 // Validate valida os dados do CampaignUpdateStatusDTO
 func (c *CampaignUpdateStatusDTO) Validate() error {
-	validStatuses := map[models.CampaignStatus]bool{models.StatusPendente: true, models.StatusProcessando: true, models.StatusEnviando: true, models.StatusCancelada: true, models.StatusConcluida: true}
+	// "enviando", "concluida" e "concluida_com_falhas" são definidos pelo pipeline de envio, não pelo usuário
+	validStatuses := map[models.CampaignStatus]bool{models.StatusPendente: true, models.StatusAgendada: true, models.StatusProcessando: true, models.StatusPausada: true, models.StatusCancelada: true}
 	if !validStatuses[c.Status] {
-		return errors.New("status inválido, deve ser 'pendente', 'processando', 'enviando', 'cancelada' ou 'concluida'")
+		return errors.New("status inválido, deve ser 'pendente', 'agendada', 'processando', 'pausada' ou 'cancelada'")
+	}
+
+	if c.Status == models.StatusAgendada {
+		scheduledAt, err := c.ScheduledTime()
+		if err != nil {
+			return err
+		}
+		if !scheduledAt.After(time.Now()) {
+			return errors.New("scheduled_at deve ser uma data/hora futura")
+		}
 	}
 
 	return nil
 }
 
+// ScheduledTime converte scheduled_at para um instante absoluto usando o fuso informado
+func (c *CampaignUpdateStatusDTO) ScheduledTime() (time.Time, error) {
+	if c.ScheduledAt == nil || *c.ScheduledAt == "" {
+		return time.Time{}, errors.New("scheduled_at é obrigatório para agendar a campanha")
+	}
+	if c.Timezone == nil || *c.Timezone == "" {
+		return time.Time{}, errors.New("timezone é obrigatório para agendar a campanha")
+	}
+
+	location, err := time.LoadLocation(*c.Timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("timezone inválido: %s", *c.Timezone)
+	}
+
+	// 🕒 Aceita data/hora local (interpretada no fuso) ou RFC3339 com offset explícito
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04"} {
+		if scheduledAt, err := time.ParseInLocation(layout, *c.ScheduledAt, location); err == nil {
+			return scheduledAt, nil
+		}
+	}
+	if scheduledAt, err := time.Parse(time.RFC3339, *c.ScheduledAt); err == nil {
+		return scheduledAt.In(location), nil
+	}
+
+	return time.Time{}, errors.New("scheduled_at inválido, use o formato 'AAAA-MM-DDTHH:MM:SS'")
+}
+
 // CampaignResponseDTO estrutura a resposta para campanhas
 type CampaignResponseDTO struct {
 	ID          string                `json:"id"`
@@ -82,13 +124,27 @@ type CampaignResponseDTO struct {
 	Description *string               `json:"description,omitempty"`
 	Channels    models.ChannelsConfig `json:"channels"`
 	// Filters     *models.AudienceFilters `json:"filters,omitempty"`
-	Status    models.CampaignStatus `json:"status"`
-	CreatedAt string                `json:"created_at"`
-	UpdatedAt string                `json:"updated_at"`
+	Status      models.CampaignStatus `json:"status"`
+	ScheduledAt *string               `json:"scheduled_at,omitempty"` // RFC3339 no fuso da campanha
+	Timezone    *string               `json:"timezone,omitempty"`
+	CreatedAt   string                `json:"created_at"`
+	UpdatedAt   string                `json:"updated_at"`
 }
 
 // NewCampaignResponseDTO converte um modelo `Campaign` para um DTO de resposta
 func NewCampaignResponseDTO(campaign *models.Campaign) CampaignResponseDTO {
+	var scheduledAt *string
+	if campaign.ScheduledAt != nil {
+		scheduled := *campaign.ScheduledAt
+		if campaign.Timezone != nil {
+			if location, err := time.LoadLocation(*campaign.Timezone); err == nil {
+				scheduled = scheduled.In(location)
+			}
+		}
+		formatted := scheduled.Format(time.RFC3339)
+		scheduledAt = &formatted
+	}
+
 	return CampaignResponseDTO{
 		ID:          campaign.ID.String(),
 		AccountID:   campaign.AccountID.String(),
@@ -96,8 +152,10 @@ func NewCampaignResponseDTO(campaign *models.Campaign) CampaignResponseDTO {
 		Description: campaign.Description,
 		Channels:    campaign.Channels,
 		// Filters:     campaign.Filters,
-		Status:    campaign.Status,
-		CreatedAt: campaign.CreatedAt.Format("2006-01-02 15:04:05"),
-		UpdatedAt: campaign.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Status:      campaign.Status,
+		ScheduledAt: scheduledAt,
+		Timezone:    campaign.Timezone,
+		CreatedAt:   campaign.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   campaign.UpdatedAt.Format("2006-01-02 15:04:05"),
 	}
 }
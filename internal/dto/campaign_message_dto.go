@@ -15,6 +15,10 @@ type EmailData struct {
 	Corpo       template.HTML
 	Finalizacao template.HTML
 	Assinatura  template.HTML
+
+	UnsubscribeURL string `json:"-"` // Link de descadastro do destinatário (preenchido no envio)
+	AIModel        string `json:"-"` // Modelo que gerou o conteúdo (registrado com a mensagem entregue)
+	AIPrompt       string `json:"-"` // Prompt enviado ao modelo
 }
 
 // CampaignMessageDTO representa uma mensagem a ser enviada
@@ -24,6 +28,9 @@ type CampaignMessageDTO struct {
 	CampaignID uuid.UUID `json:"campaign_id"` // Campaign ID
 	ContactID  uuid.UUID `json:"contact_id"`  // Contact ID
 	Type       string    `json:"type"`        // "email" ou "whatsapp"
+
+	// DispatchToken é o token do enfileiramento que gerou a mensagem (vazio em mensagens antigas)
+	DispatchToken uuid.UUID `json:"dispatch_token,omitempty"`
 }
 
 // CampaignAudienceDTO representa uma mensagem a ser enviada, unificando a campanha e o contato
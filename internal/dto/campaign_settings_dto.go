@@ -4,6 +4,8 @@ package dto
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/google/uuid"
@@ -13,18 +15,25 @@ import (
 
 // CampaignSettingsDTO representa as configurações de envio de uma campanha
 type CampaignSettingsDTO struct {
-	CampaignID           uuid.UUID `json:"campaign_id"`
-	Brand                string    `json:"brand"`
-	Subject              string    `json:"subject"`
-	Tone                 *string   `json:"tone,omitempty"`
-	EmailFrom            string    `json:"email_from"`
-	EmailReply           string    `json:"email_reply"`
-	EmailFooter          *string   `json:"email_footer,omitempty"`
-	EmailInstructions    string    `json:"email_instructions"`
-	WhatsAppFrom         string    `json:"whatsapp_from"`
-	WhatsAppReply        string    `json:"whatsapp_reply"`
-	WhatsAppFooter       *string   `json:"whatsapp_footer,omitempty"`
-	WhatsAppInstructions string    `json:"whatsapp_instructions"`
+	CampaignID             uuid.UUID         `json:"campaign_id"`
+	Brand                  string            `json:"brand"`
+	Subject                string            `json:"subject"`
+	Tone                   *string           `json:"tone,omitempty"`
+	SenderIdentityID       *uuid.UUID        `json:"sender_identity_id,omitempty"` // Opcional: resolvido pelo email_from
+	EmailFrom              string            `json:"email_from"`
+	EmailReply             string            `json:"email_reply,omitempty"` // Vazio = reply-to padrão do remetente
+	EmailFooter            *string           `json:"email_footer,omitempty"`
+	EmailInstructions      string            `json:"email_instructions"`
+	EmailPreheader         *string           `json:"email_preheader,omitempty"`
+	EmailHeaders           map[string]string `json:"email_headers,omitempty"`
+	EmailAttachments       []uuid.UUID       `json:"email_attachments,omitempty"` // IDs dos arquivos da conta
+	WhatsAppFrom           string            `json:"whatsapp_from"`
+	WhatsAppReply          string            `json:"whatsapp_reply"`
+	WhatsAppFooter         *string           `json:"whatsapp_footer,omitempty"`
+	WhatsAppInstructions   string            `json:"whatsapp_instructions"`
+	WhatsAppChatID         *uuid.UUID        `json:"whatsapp_chat_id,omitempty"`         // Chat da conta com sessão do WhatsApp
+	WhatsAppTemplateID     *uuid.UUID        `json:"whatsapp_template_id,omitempty"`     // Template aprovado (obrigatório nos chats da Cloud API)
+	WhatsAppTemplateParams map[string]string `json:"whatsapp_template_params,omitempty"` // Ex: {"body.1": "contato.nome", "body.2": "corpo"}
 }
 
 // Valida os dados da CampaignSettingsDTO antes de persistir
@@ -42,8 +51,8 @@ func (c *CampaignSettingsDTO) Validate() error {
 	if c.EmailFrom == "" || len(c.EmailFrom) > 150 {
 		return errors.New("email_from é obrigatório e deve ter no máximo 150 caracteres")
 	}
-	if c.EmailReply == "" || len(c.EmailReply) > 150 {
-		return errors.New("email_reply é obrigatório e deve ter no máximo 150 caracteres")
+	if len(c.EmailReply) > 150 {
+		return errors.New("email_reply deve ter no máximo 150 caracteres")
 	}
 	if c.EmailInstructions == "" {
 		return errors.New("email_instructions é obrigatório")
@@ -62,11 +71,30 @@ func (c *CampaignSettingsDTO) Validate() error {
 	if err := utils.ValidateEmail(c.EmailFrom); err != nil {
 		return errors.New("email_from inválido")
 	}
-	if err := utils.ValidateEmail(c.EmailReply); err != nil {
-		return errors.New("email_reply inválido")
+	if c.EmailReply != "" {
+		if err := utils.ValidateEmail(c.EmailReply); err != nil {
+			return errors.New("email_reply inválido")
+		}
 	}
 
-	// 3. Validação de WhatsApp (apenas números, com prefixo internacional opcional)
+	// 3. Validação do preheader e dos cabeçalhos adicionais
+	if c.EmailPreheader != nil && len(*c.EmailPreheader) > 200 {
+		return errors.New("email_preheader deve ter no máximo 200 caracteres")
+	}
+	if err := validateEmailHeaders(c.EmailHeaders); err != nil {
+		return err
+	}
+	if err := validateEmailAttachments(c.EmailAttachments); err != nil {
+		return err
+	}
+	if c.WhatsAppChatID != nil && *c.WhatsAppChatID == uuid.Nil {
+		return errors.New("whatsapp_chat_id inválido")
+	}
+	if err := validateWhatsAppTemplateParams(c.WhatsAppTemplateID, c.WhatsAppTemplateParams); err != nil {
+		return err
+	}
+
+	// 4. Validação de WhatsApp (apenas números, com prefixo internacional opcional)
 	if err := utils.ValidateWhatsApp(c.WhatsAppFrom); err != nil {
 		return errors.New("whatsapp_from inválido")
 	}
@@ -74,7 +102,7 @@ func (c *CampaignSettingsDTO) Validate() error {
 		return errors.New("whatsapp_reply inválido")
 	}
 
-	// 4. Validação do tom de voz (Tone)
+	// 5. Validação do tom de voz (Tone)
 	if c.Tone != nil {
 		validTones := map[string]bool{"formal": true, "casual": true, "neutro": true}
 		if !validTones[strings.ToLower(*c.Tone)] {
@@ -90,8 +118,17 @@ func (c *CampaignSettingsDTO) Normalize() {
 	c.Brand = strings.TrimSpace(c.Brand)
 	c.Subject = strings.TrimSpace(c.Subject)
 	c.EmailFrom = *utils.NormalizeEmail(&c.EmailFrom)
-	c.EmailReply = *utils.NormalizeEmail(&c.EmailReply)
+	if reply := utils.NormalizeEmail(&c.EmailReply); reply != nil {
+		c.EmailReply = *reply
+	}
 	c.EmailInstructions = strings.TrimSpace(c.EmailInstructions)
+	if c.EmailPreheader != nil {
+		preheader := strings.TrimSpace(*c.EmailPreheader)
+		c.EmailPreheader = &preheader
+		if preheader == "" {
+			c.EmailPreheader = nil
+		}
+	}
 	c.WhatsAppFrom = utils.NormalizeWhatsAppNumber(c.WhatsAppFrom)
 	c.WhatsAppReply = utils.NormalizeWhatsAppNumber(c.WhatsAppReply)
 	c.WhatsAppInstructions = strings.TrimSpace(c.WhatsAppInstructions)
@@ -101,19 +138,94 @@ func (c *CampaignSettingsDTO) ToModel() models.CampaignSettings {
 	c.Normalize()
 
 	settings := models.CampaignSettings{
-		CampaignID:           c.CampaignID,
-		Brand:                c.Brand,
-		Subject:              c.Subject,
-		Tone:                 c.Tone,
-		EmailFrom:            c.EmailFrom,
-		EmailReply:           c.EmailReply,
-		EmailFooter:          c.EmailFooter,
-		EmailInstructions:    c.EmailInstructions,
-		WhatsAppFrom:         c.WhatsAppFrom,
-		WhatsAppReply:        c.WhatsAppReply,
-		WhatsAppFooter:       c.WhatsAppFooter,
-		WhatsAppInstructions: c.WhatsAppInstructions,
+		CampaignID:             c.CampaignID,
+		Brand:                  c.Brand,
+		Subject:                c.Subject,
+		Tone:                   c.Tone,
+		SenderIdentityID:       c.SenderIdentityID,
+		EmailFrom:              c.EmailFrom,
+		EmailReply:             c.EmailReply,
+		EmailFooter:            c.EmailFooter,
+		EmailInstructions:      c.EmailInstructions,
+		EmailPreheader:         c.EmailPreheader,
+		EmailHeaders:           c.EmailHeaders,
+		EmailAttachments:       c.EmailAttachments,
+		WhatsAppFrom:           c.WhatsAppFrom,
+		WhatsAppReply:          c.WhatsAppReply,
+		WhatsAppFooter:         c.WhatsAppFooter,
+		WhatsAppInstructions:   c.WhatsAppInstructions,
+		WhatsAppChatID:         c.WhatsAppChatID,
+		WhatsAppTemplateID:     c.WhatsAppTemplateID,
+		WhatsAppTemplateParams: c.WhatsAppTemplateParams,
 	}
 
 	return settings
 }
+
+// emailHeaderName aceita apenas nomes de cabeçalho no formato RFC 5322 (letras, números e hífen)
+var emailHeaderName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`)
+
+// reservedEmailHeaders são definidos pelo envio e não podem ser sobrescritos pela campanha
+var reservedEmailHeaders = map[string]bool{
+	"from": true, "to": true, "cc": true, "bcc": true, "subject": true, "date": true, "sender": true,
+	"reply-to": true, "return-path": true, "message-id": true, "mime-version": true,
+	"content-type": true, "content-transfer-encoding": true,
+	"list-unsubscribe": true, "list-unsubscribe-post": true,
+}
+
+// validateEmailHeaders valida os cabeçalhos adicionais da campanha
+func validateEmailHeaders(headers map[string]string) error {
+	if len(headers) > 20 {
+		return errors.New("email_headers deve ter no máximo 20 cabeçalhos")
+	}
+	for name, value := range headers {
+		if !emailHeaderName.MatchString(name) || len(name) > 76 {
+			return fmt.Errorf("email_headers: nome de cabeçalho inválido: %q", name)
+		}
+		if reservedEmailHeaders[strings.ToLower(name)] {
+			return fmt.Errorf("email_headers: o cabeçalho %s é definido pelo envio e não pode ser alterado", name)
+		}
+		if strings.ContainsAny(value, "\r\n") || len(value) > 900 {
+			return fmt.Errorf("email_headers: valor inválido para o cabeçalho %s", name)
+		}
+	}
+	return nil
+}
+
+// validateEmailAttachments valida a lista de anexos (propriedade e tamanho são conferidos no serviço de arquivos)
+func validateEmailAttachments(attachments []uuid.UUID) error {
+	seen := make(map[uuid.UUID]bool, len(attachments))
+	for _, id := range attachments {
+		if id == uuid.Nil {
+			return errors.New("email_attachments contém um ID inválido")
+		}
+		if seen[id] {
+			return fmt.Errorf("email_attachments: o arquivo %s foi informado mais de uma vez", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// validateWhatsAppTemplateParams valida o template da Cloud API e as origens das variáveis
+// (as variáveis são conferidas com o template sincronizado ao salvar as configurações)
+func validateWhatsAppTemplateParams(templateID *uuid.UUID, params map[string]string) error {
+	if templateID != nil && *templateID == uuid.Nil {
+		return errors.New("whatsapp_template_id inválido")
+	}
+	if templateID == nil && len(params) > 0 {
+		return errors.New("whatsapp_template_params exige whatsapp_template_id")
+	}
+	if len(params) > 50 {
+		return errors.New("whatsapp_template_params deve ter no máximo 50 variáveis")
+	}
+	for slot, source := range params {
+		if slot == "" || len(slot) > 100 {
+			return fmt.Errorf("whatsapp_template_params: variável inválida: %q", slot)
+		}
+		if !models.ValidWhatsAppParamSource(source) || len(source) > 2000 {
+			return fmt.Errorf("whatsapp_template_params: origem inválida para %s: %q (use saudacao, corpo, finalizacao, assinatura, contato.nome, marca ou valor:<texto>)", slot, source)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// File: /internal/dto/campaign_stats_dto.go
+
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// CampaignStatusCountDTO é a contagem agregada da audiência por canal e status
+type CampaignStatusCountDTO struct {
+	Channel   string                `json:"channel"`
+	Status    models.AudienceStatus `json:"status"`
+	Total     int                   `json:"total"`
+	Delivered int                   `json:"delivered"` // Registros com delivered_at (o status pode ter mudado depois da entrega)
+	Opened    int                   `json:"opened"`    // Registros com opened_at
+	Clicked   int                   `json:"clicked"`   // Registros com clicked_at
+}
+
+// CampaignFunnelDTO resume o funil de envio (contagens por status e taxas sobre os enviados)
+type CampaignFunnelDTO struct {
+	Total         int            `json:"total"`
+	ByStatus      map[string]int `json:"by_status"`
+	Pending       int            `json:"pending"`
+	Sent          int            `json:"sent"`
+	Delivered     int            `json:"delivered"`
+	Bounced       int            `json:"bounced"`
+	Complained    int            `json:"complained"`
+	Failed        int            `json:"failed"`
+	Opened        int            `json:"opened"`
+	Clicked       int            `json:"clicked"`
+	DeliveryRate  float64        `json:"delivery_rate"`
+	BounceRate    float64        `json:"bounce_rate"`
+	ComplaintRate float64        `json:"complaint_rate"`
+	OpenRate      float64        `json:"open_rate"`
+	ClickRate     float64        `json:"click_rate"`
+}
+
+// NewCampaignFunnelDTO cria um funil vazio
+func NewCampaignFunnelDTO() *CampaignFunnelDTO {
+	return &CampaignFunnelDTO{ByStatus: map[string]int{}}
+}
+
+// Add soma ao funil uma contagem agregada por status
+func (f *CampaignFunnelDTO) Add(count CampaignStatusCountDTO) {
+	status, total := count.Status, count.Total
+	f.Total += total
+	f.ByStatus[string(status)] += total
+	f.Delivered += count.Delivered
+	f.Opened += count.Opened
+	f.Clicked += count.Clicked
+
+	switch {
+	case status.AlreadySent():
+		f.Sent += total
+	case status == models.AudiencePendente || status == models.AudienceFila || status == models.AudienceEnviando:
+		f.Pending += total
+	case status == models.AudienceFalhaEnvio || status == models.AudienceFalhaRenderizacao || status == models.AudienceDeadLetter:
+		f.Failed += total
+	}
+
+	switch status {
+	case models.AudienceDevolvido:
+		f.Bounced += total
+	case models.AudienceReclamado:
+		f.Complained += total
+	}
+}
+
+// ComputeRates calcula as taxas de entrega, devolução, reclamação, abertura e clique sobre os enviados
+func (f *CampaignFunnelDTO) ComputeRates() {
+	if f.Sent == 0 {
+		return
+	}
+	f.DeliveryRate = float64(f.Delivered) / float64(f.Sent)
+	f.BounceRate = float64(f.Bounced) / float64(f.Sent)
+	f.ComplaintRate = float64(f.Complained) / float64(f.Sent)
+	f.OpenRate = float64(f.Opened) / float64(f.Sent)
+	f.ClickRate = float64(f.Clicked) / float64(f.Sent)
+}
+
+// CampaignStatsPointDTO é um ponto da série temporal de envios, entregas, aberturas e cliques
+type CampaignStatsPointDTO struct {
+	Bucket    time.Time `json:"bucket"`
+	Sent      int       `json:"sent"`
+	Delivered int       `json:"delivered"`
+	Opens     int       `json:"opens"`
+	Clicks    int       `json:"clicks"`
+}
+
+// CampaignStatsDTO é a resposta de GET /campaigns/{campaign_id}/stats
+type CampaignStatsDTO struct {
+	CampaignID uuid.UUID                     `json:"campaign_id"`
+	Status     models.CampaignStatus         `json:"status"`
+	Totals     *CampaignFunnelDTO            `json:"totals"`
+	Channels   map[string]*CampaignFunnelDTO `json:"channels"`
+	Interval   string                        `json:"interval"` // "hour" ou "day"
+	Timezone   string                        `json:"timezone"`
+	Series     []CampaignStatsPointDTO       `json:"series"`
+}
+
+// NewCampaignStatsDTO monta as métricas da campanha a partir das contagens agregadas
+func NewCampaignStatsDTO(campaign *models.Campaign, counts []CampaignStatusCountDTO, interval, timezone string, series []CampaignStatsPointDTO) CampaignStatsDTO {
+	stats := CampaignStatsDTO{
+		CampaignID: campaign.ID,
+		Status:     campaign.Status,
+		Totals:     NewCampaignFunnelDTO(),
+		Channels:   map[string]*CampaignFunnelDTO{},
+		Interval:   interval,
+		Timezone:   timezone,
+		Series:     series,
+	}
+
+	for _, count := range counts {
+		channel, ok := stats.Channels[count.Channel]
+		if !ok {
+			channel = NewCampaignFunnelDTO()
+			stats.Channels[count.Channel] = channel
+		}
+		channel.Add(count)
+		stats.Totals.Add(count)
+	}
+
+	for _, channel := range stats.Channels {
+		channel.ComputeRates()
+	}
+	stats.Totals.ComputeRates()
+
+	if stats.Series == nil {
+		stats.Series = []CampaignStatsPointDTO{}
+	}
+
+	return stats
+}
+
+// CampaignComparisonDTO é uma linha do comparativo de campanhas da conta (GET /analytics/campaigns)
+type CampaignComparisonDTO struct {
+	CampaignID uuid.UUID             `json:"campaign_id"`
+	Name       string                `json:"name"`
+	Status     models.CampaignStatus `json:"status"`
+	CreatedAt  time.Time             `json:"created_at"`
+	Funnel     *CampaignFunnelDTO    `json:"funnel"`
+}
+
+// CampaignAnalyticsFilterDTO define o período (e o canal, opcional) do comparativo de campanhas
+type CampaignAnalyticsFilterDTO struct {
+	From    time.Time
+	To      time.Time // Exclusivo
+	Channel *string
+}
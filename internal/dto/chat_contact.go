@@ -2,15 +2,17 @@
 
 package dto
 
-// ChatContactFull representa um contato completo de chat, incluindo informações do WhatsApp
+// ChatContactFull representa um contato completo de chat, incluindo informações do WhatsApp ou do e-mail
 type ChatContactFull struct {
 	ID                string `json:"id"`
 	ChatID            string `json:"chat_id"`
 	ContactID         string `json:"contact_id"`
 	WhatsappContactID string `json:"whatsapp_contact_id"`
+	Channel           string `json:"channel"` // "whatsapp" ou "email"
 	Name              string `json:"name"`
 	Phone             string `json:"phone"`
 	JID               string `json:"jid"`
+	Email             string `json:"email,omitempty"`
 	IsBusiness        bool   `json:"is_business"`
 	Status            string `json:"status"`     // "aberto", "fechado", "pendente"
 	UpdatedAt         string `json:"updated_at"` // ISO timestamp
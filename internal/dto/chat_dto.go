@@ -16,6 +16,7 @@ type ChatCreateDTO struct {
 	PhoneNumber  string `json:"phone_number"`
 	InstanceName string `json:"instance_name"`
 	WebhookURL   string `json:"webhook_url"`
+	Provider     string `json:"provider,omitempty"` // baileys (padrão), evolution ou cloud_api
 }
 
 // Validate valida os dados do ContactCreateDTO
@@ -40,11 +41,7 @@ func (c *ChatCreateDTO) Validate() error {
 		return errors.New("o nome da instância deve ter entre 3 e 50 caracteres")
 	}
 
-	if c.WebhookURL == "" || len(c.WebhookURL) < 3 || len(c.WebhookURL) > 255 {
-		return errors.New("a URL do webhook deve ter entre 3 e 255 caracteres")
-	}
-
-	return nil
+	return validateChatProvider(c.Provider, c.WebhookURL)
 }
 
 // ConvertToChat converte o DTO para o modelo de Chat
@@ -59,6 +56,7 @@ func (c *ChatCreateDTO) ToModel() *models.Chat {
 		PhoneNumber:  phoneNumber,
 		InstanceName: c.InstanceName,
 		WebhookURL:   c.WebhookURL,
+		Provider:     chatProvider(c.Provider),
 	}
 }
 
@@ -68,6 +66,7 @@ type ChatUpdateDTO struct {
 	PhoneNumber  string `json:"phone_number"`
 	InstanceName string `json:"instance_name"`
 	WebhookURL   string `json:"webhook_url"`
+	Provider     string `json:"provider,omitempty"` // Vazio mantém o provedor atual
 }
 
 // Validate valida os dados do ChatUpdateDTO
@@ -84,7 +83,27 @@ func (c *ChatUpdateDTO) Validate() error {
 	if c.InstanceName == "" || len(c.InstanceName) < 3 || len(c.InstanceName) > 50 {
 		return errors.New("o nome da instância deve ter entre 3 e 50 caracteres")
 	}
-	if c.WebhookURL == "" || len(c.WebhookURL) < 3 || len(c.WebhookURL) > 255 {
+	return validateChatProvider(c.Provider, c.WebhookURL)
+}
+
+// chatProvider converte o provedor informado (vazio = Baileys)
+func chatProvider(provider string) models.WhatsAppProviderType {
+	if provider == "" {
+		return models.WhatsAppProviderBaileys
+	}
+	return models.WhatsAppProviderType(provider)
+}
+
+// validateChatProvider valida o provedor e o webhook. Na Cloud API o webhook é cadastrado no app da Meta
+// (apontando para /webhook/cloud_api), então webhook_url é opcional.
+func validateChatProvider(provider, webhookURL string) error {
+	if provider != "" && !models.WhatsAppProviderType(provider).Valid() {
+		return errors.New("o provedor deve ser 'baileys', 'evolution' ou 'cloud_api'")
+	}
+	if len(webhookURL) > 255 {
+		return errors.New("a URL do webhook deve ter no máximo 255 caracteres")
+	}
+	if chatProvider(provider) != models.WhatsAppProviderCloudAPI && len(webhookURL) < 3 {
 		return errors.New("a URL do webhook deve ter entre 3 e 255 caracteres")
 	}
 	return nil
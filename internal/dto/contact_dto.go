@@ -130,27 +130,31 @@ type ContactUpdateDTO struct {
 
 // ContactResponseDTO estrutura a resposta para um contato
 type ContactResponseDTO struct {
-	ID            string             `json:"id"`
-	AccountID     string             `json:"account_id"`
-	Name          string             `json:"name"`
-	Email         *string            `json:"email,omitempty"`
-	WhatsApp      *string            `json:"whatsapp,omitempty"`
-	Gender        *string            `json:"gender,omitempty"`
-	BirthDate     *string            `json:"birth_date,omitempty"`
-	Bairro        *string            `json:"bairro,omitempty"`
-	Cidade        *string            `json:"cidade,omitempty"`
-	Estado        *string            `json:"estado,omitempty"`
-	Tags          models.ContactTags `json:"tags,omitempty"`
-	History       *string            `json:"history,omitempty"`
-	OptOutAt      *string            `json:"opt_out_at,omitempty"`
-	LastContactAt *string            `json:"last_contact_at,omitempty"`
-	CreatedAt     string             `json:"created_at"`
-	UpdatedAt     string             `json:"updated_at"`
+	ID                string              `json:"id"`
+	AccountID         string              `json:"account_id"`
+	Name              string              `json:"name"`
+	Email             *string             `json:"email,omitempty"`
+	EmailStatus       *models.EmailStatus `json:"email_status,omitempty"`
+	EmailStatusReason *string             `json:"email_status_reason,omitempty"`
+	WhatsApp          *string             `json:"whatsapp,omitempty"`
+	Gender            *string             `json:"gender,omitempty"`
+	BirthDate         *string             `json:"birth_date,omitempty"`
+	Bairro            *string             `json:"bairro,omitempty"`
+	Cidade            *string             `json:"cidade,omitempty"`
+	Estado            *string             `json:"estado,omitempty"`
+	Tags              models.ContactTags  `json:"tags,omitempty"`
+	History           *string             `json:"history,omitempty"`
+	OptOutAt          *string             `json:"opt_out_at,omitempty"`
+	EmailOptOutAt     *string             `json:"email_opt_out_at,omitempty"`
+	WhatsOptOutAt     *string             `json:"whatsapp_opt_out_at,omitempty"`
+	LastContactAt     *string             `json:"last_contact_at,omitempty"`
+	CreatedAt         string              `json:"created_at"`
+	UpdatedAt         string              `json:"updated_at"`
 }
 
 // NewContactResponseDTO converte um modelo `Contact` para um DTO de resposta
 func NewContactResponseDTO(contact *models.Contact) ContactResponseDTO {
-	var birthDate, optOutAt, lastContactAt *string
+	var birthDate, optOutAt, emailOptOutAt, whatsOptOutAt, lastContactAt *string
 
 	if contact.BirthDate != nil {
 		formatted := contact.BirthDate.Format("2006-01-02")
@@ -162,27 +166,41 @@ func NewContactResponseDTO(contact *models.Contact) ContactResponseDTO {
 		optOutAt = &formatted
 	}
 
+	if contact.EmailOptOutAt != nil {
+		formatted := contact.EmailOptOutAt.Format(time.RFC3339)
+		emailOptOutAt = &formatted
+	}
+
+	if contact.WhatsOptOutAt != nil {
+		formatted := contact.WhatsOptOutAt.Format(time.RFC3339)
+		whatsOptOutAt = &formatted
+	}
+
 	if contact.LastContactAt != nil {
 		formatted := contact.LastContactAt.Format(time.RFC3339)
 		lastContactAt = &formatted
 	}
 
 	return ContactResponseDTO{
-		ID:            contact.ID.String(),
-		AccountID:     contact.AccountID.String(),
-		Name:          contact.Name,
-		Email:         contact.Email,
-		WhatsApp:      contact.WhatsApp,
-		Gender:        contact.Gender,
-		BirthDate:     birthDate,
-		Bairro:        contact.Bairro,
-		Cidade:        contact.Cidade,
-		Estado:        contact.Estado,
-		Tags:          *contact.Tags,
-		History:       contact.History,
-		OptOutAt:      optOutAt,
-		LastContactAt: lastContactAt,
-		CreatedAt:     contact.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:     contact.UpdatedAt.Format(time.RFC3339),
+		ID:                contact.ID.String(),
+		AccountID:         contact.AccountID.String(),
+		Name:              contact.Name,
+		Email:             contact.Email,
+		EmailStatus:       contact.EmailStatus,
+		EmailStatusReason: contact.EmailStatusReason,
+		WhatsApp:          contact.WhatsApp,
+		Gender:            contact.Gender,
+		BirthDate:         birthDate,
+		Bairro:            contact.Bairro,
+		Cidade:            contact.Cidade,
+		Estado:            contact.Estado,
+		Tags:              *contact.Tags,
+		History:           contact.History,
+		OptOutAt:          optOutAt,
+		EmailOptOutAt:     emailOptOutAt,
+		WhatsOptOutAt:     whatsOptOutAt,
+		LastContactAt:     lastContactAt,
+		CreatedAt:         contact.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         contact.UpdatedAt.Format(time.RFC3339),
 	}
 }
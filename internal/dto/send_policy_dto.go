@@ -0,0 +1,68 @@
+// File: /internal/dto/send_policy_dto.go
+
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// SendPolicyUpsertDTO define a janela de envio e os limites de um canal
+type SendPolicyUpsertDTO struct {
+	Timezone      string `json:"timezone"`        // Ex.: "America/Sao_Paulo"
+	Weekdays      []int  `json:"weekdays"`        // 0 = domingo ... 6 = sábado
+	StartTime     string `json:"start_time"`      // "08:00"
+	EndTime       string `json:"end_time"`        // "20:00"
+	RatePerMinute int    `json:"rate_per_minute"` // 0 = sem limite
+	DailyCap      int    `json:"daily_cap"`       // 0 = sem limite
+}
+
+// Validate valida os dados do SendPolicyUpsertDTO
+func (d *SendPolicyUpsertDTO) Validate() error {
+	if _, err := time.LoadLocation(d.Timezone); err != nil || d.Timezone == "" {
+		return fmt.Errorf("timezone inválido: %s", d.Timezone)
+	}
+
+	if len(d.Weekdays) == 0 {
+		return errors.New("informe pelo menos um dia da semana (0 = domingo ... 6 = sábado)")
+	}
+	for _, weekday := range d.Weekdays {
+		if weekday < 0 || weekday > 6 {
+			return errors.New("weekdays deve conter valores entre 0 (domingo) e 6 (sábado)")
+		}
+	}
+
+	start, err := models.ParseClock(d.StartTime)
+	if err != nil {
+		return err
+	}
+	end, err := models.ParseClock(d.EndTime)
+	if err != nil {
+		return err
+	}
+	if start >= end {
+		return errors.New("start_time deve ser anterior a end_time")
+	}
+
+	if d.RatePerMinute < 0 || d.DailyCap < 0 {
+		return errors.New("rate_per_minute e daily_cap não podem ser negativos")
+	}
+
+	return nil
+}
+
+// ToModel converte o DTO para o modelo SendPolicy
+func (d *SendPolicyUpsertDTO) ToModel(channel models.ChannelType) *models.SendPolicy {
+	return &models.SendPolicy{
+		Channel:       channel,
+		Timezone:      d.Timezone,
+		Weekdays:      d.Weekdays,
+		StartTime:     d.StartTime,
+		EndTime:       d.EndTime,
+		RatePerMinute: d.RatePerMinute,
+		DailyCap:      d.DailyCap,
+	}
+}
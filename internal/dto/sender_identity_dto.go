@@ -0,0 +1,115 @@
+// File: /internal/dto/sender_identity_dto.go
+
+package dto
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+// senderDomain aceita nomes de domínio (ex.: exemplo.com.br)
+var senderDomain = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// configurationSetName segue as regras de nome do SES (letras, números, "_" e "-")
+var configurationSetName = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// SenderIdentityCreateDTO define um remetente: e-mail (ex.: contato@exemplo.com) ou domínio inteiro (ex.: exemplo.com)
+type SenderIdentityCreateDTO struct {
+	Identity         string  `json:"identity"`
+	DisplayName      *string `json:"display_name,omitempty"`
+	ReplyTo          *string `json:"reply_to,omitempty"`
+	ConfigurationSet *string `json:"configuration_set,omitempty"` // Vazio = Configuration Set padrão
+}
+
+// Validate valida os dados do SenderIdentityCreateDTO
+func (d *SenderIdentityCreateDTO) Validate() error {
+	identity := strings.ToLower(strings.TrimSpace(d.Identity))
+	if identity == "" || len(identity) > 255 {
+		return errors.New("identity é obrigatório e deve ter no máximo 255 caracteres")
+	}
+	if strings.Contains(identity, "@") {
+		if _, err := mail.ParseAddress(identity); err != nil {
+			return errors.New("identity deve ser um e-mail ou domínio válido")
+		}
+	} else if !senderDomain.MatchString(identity) {
+		return errors.New("identity deve ser um e-mail ou domínio válido")
+	}
+
+	return validateSenderIdentityFields(d.DisplayName, d.ReplyTo, d.ConfigurationSet)
+}
+
+// ToModel converte o DTO para o modelo SenderIdentity (o tipo é deduzido da presença de "@")
+func (d *SenderIdentityCreateDTO) ToModel() *models.SenderIdentity {
+	identity := &models.SenderIdentity{
+		Kind:     models.SenderIdentityDomain,
+		Identity: strings.ToLower(strings.TrimSpace(d.Identity)),
+	}
+	if strings.Contains(identity.Identity, "@") {
+		identity.Kind = models.SenderIdentityEmail
+	}
+
+	identity.DisplayName = trimmedOrNil(d.DisplayName)
+	identity.ReplyTo = utils.NormalizeEmail(trimmedOrNil(d.ReplyTo))
+	identity.ConfigurationSet = trimmedOrNil(d.ConfigurationSet)
+	return identity
+}
+
+// SenderIdentityUpdateDTO altera os dados de exibição do remetente (campos ausentes não mudam; vazio remove)
+type SenderIdentityUpdateDTO struct {
+	DisplayName      *string `json:"display_name,omitempty"`
+	ReplyTo          *string `json:"reply_to,omitempty"`
+	ConfigurationSet *string `json:"configuration_set,omitempty"`
+}
+
+// Validate valida os dados do SenderIdentityUpdateDTO
+func (d *SenderIdentityUpdateDTO) Validate() error {
+	return validateSenderIdentityFields(d.DisplayName, d.ReplyTo, d.ConfigurationSet)
+}
+
+// ApplyUpdate aplica os campos informados ao remetente
+func (d *SenderIdentityUpdateDTO) ApplyUpdate(identity *models.SenderIdentity) {
+	if d.DisplayName != nil {
+		identity.DisplayName = trimmedOrNil(d.DisplayName)
+	}
+	if d.ReplyTo != nil {
+		identity.ReplyTo = utils.NormalizeEmail(trimmedOrNil(d.ReplyTo))
+	}
+	if d.ConfigurationSet != nil {
+		identity.ConfigurationSet = trimmedOrNil(d.ConfigurationSet)
+	}
+}
+
+// validateSenderIdentityFields valida nome de exibição, reply-to e Configuration Set (vazios são aceitos)
+func validateSenderIdentityFields(displayName, replyTo, configurationSet *string) error {
+	if value := trimmedOrNil(displayName); value != nil {
+		if len(*value) > 150 || strings.ContainsAny(*value, "\r\n") {
+			return errors.New("display_name deve ter no máximo 150 caracteres em uma linha")
+		}
+	}
+	if value := trimmedOrNil(replyTo); value != nil {
+		if _, err := mail.ParseAddress(*value); err != nil || len(*value) > 150 {
+			return errors.New("reply_to deve ser um e-mail válido")
+		}
+	}
+	if value := trimmedOrNil(configurationSet); value != nil && !configurationSetName.MatchString(*value) {
+		return errors.New("configuration_set deve ter até 64 caracteres (letras, números, '_' ou '-')")
+	}
+	return nil
+}
+
+// trimmedOrNil remove espaços e retorna nil para valores vazios
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
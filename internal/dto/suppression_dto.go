@@ -0,0 +1,63 @@
+// File: /internal/dto/suppression_dto.go
+
+package dto
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+// SuppressionCreateDTO define uma supressão manual (ex.: pedido do cliente ao suporte)
+type SuppressionCreateDTO struct {
+	Channel   models.ChannelType `json:"channel"`              // "email" ou "whatsapp"
+	Address   string             `json:"address"`              // E-mail ou número de WhatsApp
+	ExpiresAt *time.Time         `json:"expires_at,omitempty"` // Vazio = permanente
+	Notes     *string            `json:"notes,omitempty"`
+}
+
+// Validate valida os dados do SuppressionCreateDTO
+func (d *SuppressionCreateDTO) Validate() error {
+	switch d.Channel {
+	case models.EmailChannel:
+		if _, err := mail.ParseAddress(strings.TrimSpace(d.Address)); err != nil {
+			return errors.New("address deve ser um e-mail válido")
+		}
+	case models.WhatsappChannel:
+		if utils.OnlyDigits(d.Address) == "" {
+			return errors.New("address deve ser um número de WhatsApp válido")
+		}
+	default:
+		return errors.New("channel deve ser 'email' ou 'whatsapp'")
+	}
+
+	if d.ExpiresAt != nil && !d.ExpiresAt.After(time.Now()) {
+		return errors.New("expires_at deve estar no futuro")
+	}
+
+	return nil
+}
+
+// ToModel converte o DTO para o modelo Suppression (motivo manual)
+func (d *SuppressionCreateDTO) ToModel() *models.Suppression {
+	return &models.Suppression{
+		Channel:   d.Channel,
+		Address:   NormalizeSuppressionAddress(d.Channel, d.Address),
+		Reason:    models.SuppressionManual,
+		ExpiresAt: d.ExpiresAt,
+		Notes:     d.Notes,
+	}
+}
+
+// NormalizeSuppressionAddress normaliza o destinatário como é gravado nos contatos
+// (e-mail em minúsculas, WhatsApp apenas com dígitos)
+func NormalizeSuppressionAddress(channel models.ChannelType, address string) string {
+	if channel == models.WhatsappChannel {
+		return utils.NormalizeWhatsAppNumber(address)
+	}
+	return strings.ToLower(strings.TrimSpace(address))
+}
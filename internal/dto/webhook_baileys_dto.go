@@ -15,4 +15,13 @@ type WebhookBaileysPayload struct {
 	FromMe      bool   `json:"fromMe"`      // true se a mensagem foi enviada por esta sessão
 	IsGroup     bool   `json:"isGroup"`     // true se veio de um grupo
 	Participant string `json:"participant"` // se for grupo, mostra quem enviou
+
+	Media *WebhookBaileysMedia `json:"media,omitempty"` // Referência da mídia (image, video, audio e document)
+}
+
+// WebhookBaileysMedia referencia a mídia da mensagem; a legenda vem em Message
+type WebhookBaileysMedia struct {
+	URL      string `json:"url"`                // Download na API Baileys (ex: /sessions/{sessionId}/media/{messageId}) ou URL absoluta
+	MimeType string `json:"mimetype"`           // Tipo do arquivo (ex: audio/ogg; codecs=opus)
+	FileName string `json:"fileName,omitempty"` // Nome do documento
 }
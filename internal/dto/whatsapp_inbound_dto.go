@@ -0,0 +1,81 @@
+// File: /internal/dto/whatsapp_inbound_dto.go
+
+package dto
+
+// Tipos normalizados das mensagens recebidas pelo WhatsApp (independentes do provedor)
+const (
+	WhatsAppInboundText     = "text"
+	WhatsAppInboundImage    = "image"
+	WhatsAppInboundVideo    = "video"
+	WhatsAppInboundAudio    = "audio"
+	WhatsAppInboundDocument = "document"
+)
+
+// WhatsAppInboundMessage é a mensagem recebida no webhook já convertida do formato do provedor
+type WhatsAppInboundMessage struct {
+	Provider    string // baileys, evolution ou cloud_api
+	SessionID   string // instance_name do chat (sessão ou phone_number_id da Cloud API)
+	From        string // JID completo (ex: 554999661111@s.whatsapp.net)
+	Phone       string // Somente o número (ex: 554999661111)
+	Message     string // Texto da mensagem ou legenda da mídia
+	Timestamp   int64  // Timestamp Unix
+	Type        string // text, image, video, audio, document (ou o tipo original quando não suportado)
+	MessageID   string // ID da mensagem no provedor
+	PushName    string // Nome visível no WhatsApp
+	FromMe      bool   // true se a mensagem foi enviada por esta sessão
+	IsGroup     bool   // true se veio de um grupo
+	Participant string // se for grupo, mostra quem enviou
+	MediaURL    string // URL da mídia (quando o provedor envia)
+	MediaID     string // ID da mídia no provedor (Cloud API: baixada pelo Graph)
+	MimeType    string // Tipo do arquivo da mídia
+	FileName    string // Nome do documento
+}
+
+// ToInbound converte o payload do webhook Baileys para a mensagem normalizada
+func (p *WebhookBaileysPayload) ToInbound() WhatsAppInboundMessage {
+	inbound := WhatsAppInboundMessage{
+		Provider:    "baileys",
+		SessionID:   p.SessionID,
+		From:        p.From,
+		Phone:       p.Phone,
+		Message:     p.Message,
+		Timestamp:   p.Timestamp,
+		Type:        p.Type,
+		MessageID:   p.MessageID,
+		PushName:    p.PushName,
+		FromMe:      p.FromMe,
+		IsGroup:     p.IsGroup,
+		Participant: p.Participant,
+	}
+	if p.Media != nil {
+		inbound.MediaURL, inbound.MimeType, inbound.FileName = p.Media.URL, p.Media.MimeType, p.Media.FileName
+	}
+	return inbound
+}
+
+// Status das mensagens enviadas informados pelo webhook do provedor
+const (
+	WhatsAppStatusSent      = "sent"
+	WhatsAppStatusDelivered = "delivered"
+	WhatsAppStatusRead      = "read"
+	WhatsAppStatusFailed    = "failed"
+)
+
+// WhatsAppStatusEvent é a atualização de status de uma mensagem enviada, já convertida do formato do provedor
+type WhatsAppStatusEvent struct {
+	Provider  string // cloud_api
+	SessionID string // phone_number_id que enviou a mensagem
+	MessageID string // ID da mensagem no provedor (message_id da audiência)
+	Status    string // sent, delivered, read ou failed
+	Recipient string // Número do destinatário
+	Timestamp int64  // Timestamp Unix
+	Errors    []WhatsAppStatusError
+}
+
+// WhatsAppStatusError detalha a falha de entrega informada pelo provedor
+type WhatsAppStatusError struct {
+	Code    int    `json:"code"`
+	Title   string `json:"title"`
+	Message string `json:"message,omitempty"`
+	Details string `json:"details,omitempty"`
+}
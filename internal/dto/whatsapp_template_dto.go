@@ -0,0 +1,45 @@
+// File: /internal/dto/whatsapp_template_dto.go
+
+package dto
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// graphObjectID aceita os IDs numéricos do Graph (phone_number_id e WABA)
+var graphObjectID = regexp.MustCompile(`^[0-9]{5,50}$`)
+
+// WhatsAppPhoneNumberCreateDTO cadastra um número da Cloud API: IDs exibidos no painel do WhatsApp Manager
+type WhatsAppPhoneNumberCreateDTO struct {
+	PhoneNumberID     string `json:"phone_number_id"`
+	BusinessAccountID string `json:"business_account_id"` // WABA (WhatsApp Business Account) do número
+}
+
+// Validate valida os dados do WhatsAppPhoneNumberCreateDTO
+func (d *WhatsAppPhoneNumberCreateDTO) Validate() error {
+	if !graphObjectID.MatchString(strings.TrimSpace(d.PhoneNumberID)) {
+		return errors.New("phone_number_id é obrigatório e deve conter apenas números")
+	}
+	if !graphObjectID.MatchString(strings.TrimSpace(d.BusinessAccountID)) {
+		return errors.New("business_account_id é obrigatório e deve conter apenas números")
+	}
+	return nil
+}
+
+// ToModel converte o DTO para o modelo WhatsAppPhoneNumber
+func (d *WhatsAppPhoneNumberCreateDTO) ToModel() *models.WhatsAppPhoneNumber {
+	return &models.WhatsAppPhoneNumber{
+		PhoneNumberID:     strings.TrimSpace(d.PhoneNumberID),
+		BusinessAccountID: strings.TrimSpace(d.BusinessAccountID),
+	}
+}
+
+// WhatsAppTemplateSyncDTO é o resultado da sincronização dos templates de um número
+type WhatsAppTemplateSyncDTO struct {
+	PhoneNumber *models.WhatsAppPhoneNumber `json:"phone_number"`
+	Templates   []models.WhatsAppTemplate   `json:"templates"`
+}
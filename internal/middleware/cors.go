@@ -25,6 +25,9 @@ func CORSMiddleware(next http.Handler) http.Handler {
 		// 🔥 Permitir headers necessários
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
+		// 🔥 Expor headers informativos das respostas
+		w.Header().Set("Access-Control-Expose-Headers", "X-Suppressed-Count")
+
 		// 🔥 Permitir credenciais (se necessário)
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 
@@ -4,14 +4,38 @@ package models
 
 import "github.com/google/uuid"
 
+// EmailProvider identifica o serviço usado para enviar os e-mails da conta
+type EmailProvider string
+
+const (
+	EmailProviderSES  EmailProvider = "ses"
+	EmailProviderSMTP EmailProvider = "smtp"
+)
+
+// SMTPTLSMode define como a conexão com o servidor SMTP é protegida
+type SMTPTLSMode string
+
+const (
+	SMTPTLSStartTLS SMTPTLSMode = "starttls" // 🔒 Porta 587: conexão em texto claro promovida com STARTTLS
+	SMTPTLSImplicit SMTPTLSMode = "tls"      // 🔒 Porta 465: TLS desde o início da conexão
+	SMTPTLSNone     SMTPTLSMode = "none"     // ⚠️ Sem criptografia (MailHog/smtp4dev em desenvolvimento)
+)
+
 type AccountSettings struct {
-	ID                 uuid.UUID `json:"id"`
-	AccountID          uuid.UUID `json:"account_id"`
-	OpenAIAPIKey       string    `json:"openai_api_key"`
-	EvolutionInstance  string    `json:"evolution_instance"`
-	AWSAccessKeyID     string    `json:"aws_access_key_id"`
-	AWSSecretAccessKey string    `json:"aws_secret_access_key"`
-	AWSRegion          string    `json:"aws_region"`
-	MailFrom           string    `json:"mail_from"`
-	MailAdminTo        string    `json:"mail_admin_to"`
+	ID                   uuid.UUID     `json:"id"`
+	AccountID            uuid.UUID     `json:"account_id"`
+	OpenAIAPIKey         string        `json:"openai_api_key"`
+	EvolutionInstance    string        `json:"evolution_instance"`
+	AWSAccessKeyID       string        `json:"aws_access_key_id"`
+	AWSSecretAccessKey   string        `json:"aws_secret_access_key"`
+	AWSRegion            string        `json:"aws_region"`
+	MailFrom             string        `json:"mail_from"`
+	MailAdminTo          string        `json:"mail_admin_to"`
+	EmailProvider        EmailProvider `json:"email_provider"`
+	SMTPHost             string        `json:"smtp_host"`
+	SMTPPort             int           `json:"smtp_port"`
+	SMTPUsername         string        `json:"smtp_username"`
+	SMTPPassword         string        `json:"smtp_password"`
+	SMTPTLS              SMTPTLSMode   `json:"smtp_tls"`
+	MessageRetentionDays int           `json:"message_retention_days"` // ⏳ Dias em que o conteúdo entregue fica guardado
 }
@@ -0,0 +1,21 @@
+// File: /internal/models/asset.go
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Asset representa um arquivo da conta (imagem para templates, catálogo em PDF ou anexo de campanha)
+type Asset struct {
+	ID          uuid.UUID `json:"id"`
+	AccountID   uuid.UUID `json:"account_id"`
+	Name        string    `json:"name"` // Nome original do arquivo (usado como nome do anexo)
+	StorageKey  string    `json:"-"`    // Caminho no armazenamento (disco local ou bucket S3)
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	URL         string    `json:"url"` // URL pública para uso nos templates (não gravada no banco)
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -10,15 +10,18 @@ import (
 
 // CampaignAudience representa um contato incluído em uma campanha específica
 type CampaignAudience struct {
-	ID         uuid.UUID               `json:"id"`
-	CampaignID uuid.UUID               `json:"campaign_id"`
-	ContactID  uuid.UUID               `json:"contact_id"`
-	Type       ChannelType             `json:"type"` // "email" ou "whatsapp"
-	Status     AudienceStatus          `json:"status"`
-	MessageID  *string                 `json:"message_id,omitempty"`
-	Feedback   *map[string]interface{} `json:"feedback_api,omitempty"` // JSONB
-	CreatedAt  time.Time               `json:"created_at"`
-	UpdatedAt  time.Time               `json:"updated_at"`
+	ID            uuid.UUID               `json:"id"`
+	CampaignID    uuid.UUID               `json:"campaign_id"`
+	ContactID     uuid.UUID               `json:"contact_id"`
+	Type          ChannelType             `json:"type"` // "email" ou "whatsapp"
+	Status        AudienceStatus          `json:"status"`
+	MessageID     *string                 `json:"message_id,omitempty"`
+	Feedback      *map[string]interface{} `json:"feedback_api,omitempty"` // JSONB
+	Attempts      int                     `json:"attempts"`               // Tentativas de envio que falharam
+	LastError     *string                 `json:"last_error,omitempty"`
+	DispatchToken *uuid.UUID              `json:"-"` // Token do último enfileiramento (mensagens com outro token são descartadas)
+	CreatedAt     time.Time               `json:"created_at"`
+	UpdatedAt     time.Time               `json:"updated_at"`
 }
 
 // NewCampaignAudience cria uma nova instância de CampaignAudience
@@ -37,13 +40,26 @@ type AudienceStatus string
 const (
 	AudiencePendente            AudienceStatus = "pendente"             // Estado inicial, não processado
 	AudienceFila                AudienceStatus = "fila"                 // Na fila de processamento
+	AudienceEnviando            AudienceStatus = "enviando"             // Reservada por um worker durante o envio
 	AudienceFalhaEnvio          AudienceStatus = "falha_envio"          // Erro no envio
 	AudienceEnviado             AudienceStatus = "enviado"              // Mensagem enviada ou entregue
 	AudienceEntregue            AudienceStatus = "entregue"             // Mensagem entregue
 	AudienceFalhaRenderizacao   AudienceStatus = "falha_renderizacao"   // Erro na renderização
-	AudienceRejeitado           AudienceStatus = "rejeitado"            // Rejeitado pelo SES
+	AudienceRejeitado           AudienceStatus = "rejeitado"            // Rejeitado pelo SES ou falha informada pelo WhatsApp
 	AudienceDevolvido           AudienceStatus = "devolvido"            // Bounce (devolvido)
 	AudienceReclamado           AudienceStatus = "reclamado"            // Complaint (reclamado)
 	AudienceAtrasado            AudienceStatus = "atrasado"             // DeliveryDelay (atrasado)
 	AudienceAtualizouAssinatura AudienceStatus = "atualizou_assinatura" // SubscriptionUpdate (atualização de assinatura)
+	AudienceDeadLetter          AudienceStatus = "dead_letter"          // Tentativas esgotadas ou falha permanente
+	AudienceCancelada           AudienceStatus = "cancelada"            // Campanha cancelada antes do envio
 )
+
+// AlreadySent indica se a mensagem já saiu para o provedor
+func (s AudienceStatus) AlreadySent() bool {
+	switch s {
+	case AudienceEnviado, AudienceEntregue, AudienceRejeitado, AudienceDevolvido,
+		AudienceReclamado, AudienceAtrasado, AudienceAtualizouAssinatura:
+		return true
+	}
+	return false
+}
@@ -10,19 +10,26 @@ import (
 
 // CampaignSettings representa as configurações de envio de uma campanha
 type CampaignSettings struct {
-	ID                   uuid.UUID `json:"id"`
-	CampaignID           uuid.UUID `json:"campaign_id"`
-	Brand                string    `json:"brand"`
-	Subject              string    `json:"subject"`
-	Tone                 *string   `json:"tone,omitempty"`
-	EmailFrom            string    `json:"email_from"`
-	EmailReply           string    `json:"email_reply"`
-	EmailFooter          *string   `json:"email_footer,omitempty"`
-	EmailInstructions    string    `json:"email_instructions"`
-	WhatsAppFrom         string    `json:"whatsapp_from"`
-	WhatsAppReply        string    `json:"whatsapp_reply"`
-	WhatsAppFooter       *string   `json:"whatsapp_footer,omitempty"`
-	WhatsAppInstructions string    `json:"whatsapp_instructions"`
-	CreatedAt            time.Time `json:"created_at"`
-	UpdatedAt            time.Time `json:"updated_at"`
+	ID                     uuid.UUID         `json:"id"`
+	CampaignID             uuid.UUID         `json:"campaign_id"`
+	Brand                  string            `json:"brand"`
+	Subject                string            `json:"subject"`
+	Tone                   *string           `json:"tone,omitempty"`
+	SenderIdentityID       *uuid.UUID        `json:"sender_identity_id,omitempty"` // Remetente verificado que autoriza o email_from
+	EmailFrom              string            `json:"email_from"`
+	EmailReply             string            `json:"email_reply"`
+	EmailFooter            *string           `json:"email_footer,omitempty"`
+	EmailInstructions      string            `json:"email_instructions"`
+	EmailPreheader         *string           `json:"email_preheader,omitempty"` // Texto de pré-visualização ao lado do assunto
+	EmailHeaders           map[string]string `json:"email_headers,omitempty"`   // Cabeçalhos adicionais da mensagem
+	EmailAttachments       []uuid.UUID       `json:"email_attachments"`         // Arquivos (assets) anexados a todos os e-mails
+	WhatsAppFrom           string            `json:"whatsapp_from"`
+	WhatsAppReply          string            `json:"whatsapp_reply"`
+	WhatsAppFooter         *string           `json:"whatsapp_footer,omitempty"`
+	WhatsAppInstructions   string            `json:"whatsapp_instructions"`
+	WhatsAppChatID         *uuid.UUID        `json:"whatsapp_chat_id,omitempty"`         // Chat conectado (sessão do WhatsApp) que envia a campanha
+	WhatsAppTemplateID     *uuid.UUID        `json:"whatsapp_template_id,omitempty"`     // Template aprovado (HSM) dos chats da Cloud API
+	WhatsAppTemplateParams map[string]string `json:"whatsapp_template_params,omitempty"` // Variável do template -> origem do valor
+	CreatedAt              time.Time         `json:"created_at"`
+	UpdatedAt              time.Time         `json:"updated_at"`
 }
@@ -0,0 +1,30 @@
+// File: /internal/models/campaign_status_history.go
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 🔹 Origem de uma mudança de status da campanha
+type StatusChangeSource string
+
+const (
+	StatusSourceAPI       StatusChangeSource = "api"       // Usuário via API
+	StatusSourceScheduler StatusChangeSource = "scheduler" // Agendador de campanhas
+	StatusSourcePipeline  StatusChangeSource = "pipeline"  // Enfileiramento/workers de envio
+)
+
+// CampaignStatusHistory registra uma transição de status da campanha
+type CampaignStatusHistory struct {
+	ID         uuid.UUID          `json:"id"`
+	CampaignID uuid.UUID          `json:"campaign_id"`
+	FromStatus CampaignStatus     `json:"from_status"`
+	ToStatus   CampaignStatus     `json:"to_status"`
+	ChangedBy  *uuid.UUID         `json:"changed_by,omitempty"` // Conta que fez a mudança (nil quando automática)
+	Source     StatusChangeSource `json:"source"`
+	Reason     *string            `json:"reason,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
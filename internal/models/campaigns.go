@@ -16,9 +16,11 @@ type Campaign struct {
 	Description *string        `json:"description,omitempty"`
 	Channels    ChannelsConfig `json:"channels"`
 	// Filters     *AudienceFilters `json:"filters,omitempty"`
-	Status    CampaignStatus `json:"status"` // Usa o enum CampaignStatus
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
+	Status      CampaignStatus `json:"status"`                 // Usa o enum CampaignStatus
+	ScheduledAt *time.Time     `json:"scheduled_at,omitempty"` // Disparo agendado (status "agendada")
+	Timezone    *string        `json:"timezone,omitempty"`     // Fuso IANA usado no agendamento
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
 }
 
 // ChannelsConfig define a estrutura dos canais e templates usados (email, whatsapp, etc.)
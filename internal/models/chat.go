@@ -9,16 +9,38 @@ import (
 )
 
 type Chat struct {
-	ID            uuid.UUID `json:"id"`
-	AccountID     uuid.UUID `json:"account_id"`
-	Department    string    `json:"department"` // ex: financeiro, comercial
-	Title         string    `json:"title"`
-	Instructions  string    `json:"instructions"`
-	PhoneNumber   string    `json:"phone_number"`
-	InstanceName  string    `json:"instance_name"`
-	WebhookURL    string    `json:"webhook_url"`
-	Status        string    `json:"status"`         // ativo, inativo
-	SessionStatus string    `json:"session_status"` // desconhecido, aguardando_qr, qrcode_expirado, conectado, desconectado, erro
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            uuid.UUID            `json:"id"`
+	AccountID     uuid.UUID            `json:"account_id"`
+	Department    string               `json:"department"` // ex: financeiro, comercial
+	Title         string               `json:"title"`
+	Instructions  string               `json:"instructions"`
+	PhoneNumber   string               `json:"phone_number"`
+	InstanceName  string               `json:"instance_name"` // Sessão (Baileys/Evolution) ou phone_number_id (Cloud API)
+	Provider      WhatsAppProviderType `json:"provider"`
+	WebhookURL    string               `json:"webhook_url"`
+	Status        string               `json:"status"`         // ativo, inativo
+	SessionStatus string               `json:"session_status"` // desconhecido, aguardando_qr, qrcode_expirado, conectado, desconectado, erro
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+// ChatDepartmentEmail é o setor do chat que reúne as conversas por e-mail da conta (respostas às campanhas)
+const ChatDepartmentEmail = "email"
+
+// WhatsAppProviderType identifica a integração usada pelo chat para falar com o WhatsApp
+type WhatsAppProviderType string
+
+const (
+	WhatsAppProviderBaileys   WhatsAppProviderType = "baileys"   // API própria de sessões Baileys
+	WhatsAppProviderEvolution WhatsAppProviderType = "evolution" // Evolution API v2
+	WhatsAppProviderCloudAPI  WhatsAppProviderType = "cloud_api" // API oficial (WhatsApp Cloud API da Meta)
+)
+
+// Valid indica se o provedor é suportado
+func (p WhatsAppProviderType) Valid() bool {
+	switch p {
+	case WhatsAppProviderBaileys, WhatsAppProviderEvolution, WhatsAppProviderCloudAPI:
+		return true
+	}
+	return false
 }
@@ -9,12 +9,12 @@ import (
 )
 
 type ChatContact struct {
-	ID                uuid.UUID `json:"id"`
-	AccountID         uuid.UUID `json:"account_id"`
-	ChatID            uuid.UUID `json:"chat_id"`
-	ContactID         uuid.UUID `json:"contact_id"`
-	WhatsappContactID uuid.UUID `json:"whatsapp_contact_id"`
-	Status            string    `json:"status"` // aberto, pendente, fechado
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
+	ID                uuid.UUID  `json:"id"`
+	AccountID         uuid.UUID  `json:"account_id"`
+	ChatID            uuid.UUID  `json:"chat_id"`
+	ContactID         *uuid.UUID `json:"contact_id,omitempty"`          // Conversas por e-mail
+	WhatsappContactID *uuid.UUID `json:"whatsapp_contact_id,omitempty"` // Conversas pelo WhatsApp
+	Status            string     `json:"status"`                        // aberto, pendente, fechado
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
 }
@@ -12,10 +12,11 @@ type ChatMessage struct {
 	ID              uuid.UUID  `json:"id"`
 	ChatContactID   uuid.UUID  `json:"chat_contact_id"`
 	Actor           string     `json:"actor"` // cliente, atendente, ai
-	Type            string     `json:"type"`  // texto, audio, imagem, video, documento
+	Type            string     `json:"type"`  // texto, audio, imagem, video, documento, email
 	Content         string     `json:"content,omitempty"`
 	FileURL         string     `json:"file_url,omitempty"`
-	SourceProcessed bool       `json:"source_processed"`
+	SourceProcessed bool       `json:"source_processed"`           // Processamento da mídia concluído (texto extraído em content)
+	ProcessingError *string    `json:"processing_error,omitempty"` // Falha no processamento da mídia (content mantém só a legenda)
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
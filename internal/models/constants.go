@@ -17,12 +17,15 @@ var AllowedChannels = []ChannelType{EmailChannel, WhatsappChannel}
 type CampaignStatus string
 
 const (
-	StatusPendente    CampaignStatus = "pendente"    // Criada, aguardando ativação
-	StatusProcessando CampaignStatus = "processando" // Enfileirando mensagens no SQS
-	StatusEnviando    CampaignStatus = "enviando"    // Mensagens sendo enviadas
-	StatusConcluida   CampaignStatus = "concluida"   // Campanha finalizada
-	StatusCancelada   CampaignStatus = "cancelada"   // Cancelada pelo usuário
+	StatusPendente           CampaignStatus = "pendente"             // Criada, aguardando ativação
+	StatusAgendada           CampaignStatus = "agendada"             // Aguardando a data/hora de disparo (scheduled_at)
+	StatusProcessando        CampaignStatus = "processando"          // Enfileirando mensagens no SQS
+	StatusEnviando           CampaignStatus = "enviando"             // Mensagens sendo enviadas
+	StatusPausada            CampaignStatus = "pausada"              // Envio interrompido pelo usuário, pode ser retomado
+	StatusConcluida          CampaignStatus = "concluida"            // Campanha finalizada
+	StatusConcluidaComFalhas CampaignStatus = "concluida_com_falhas" // Finalizada com contatos que não receberam a mensagem
+	StatusCancelada          CampaignStatus = "cancelada"            // Cancelada pelo usuário
 )
 
 // Lista de status permitidos
-var AllowedCampaignStatus = []CampaignStatus{StatusPendente, StatusProcessando, StatusEnviando, StatusConcluida, StatusCancelada}
+var AllowedCampaignStatus = []CampaignStatus{StatusPendente, StatusAgendada, StatusProcessando, StatusEnviando, StatusPausada, StatusConcluida, StatusConcluidaComFalhas, StatusCancelada}
@@ -10,22 +10,41 @@ import (
 
 // Contact representa um contato no sistema
 type Contact struct {
-	ID            uuid.UUID    `json:"id"`
-	AccountID     uuid.UUID    `json:"account_id"`
-	Name          string       `json:"name"`
-	Email         *string      `json:"email,omitempty"`
-	WhatsApp      *string      `json:"whatsapp,omitempty"`
-	Gender        *string      `json:"gender,omitempty"`
-	BirthDate     *time.Time   `json:"birth_date,omitempty"`
-	Bairro        *string      `json:"bairro,omitempty"`
-	Cidade        *string      `json:"cidade,omitempty"`
-	Estado        *string      `json:"estado,omitempty"`
-	Tags          *ContactTags `json:"tags,omitempty"` // JSONB estruturado
-	History       *string      `json:"history,omitempty"`
-	OptOutAt      *time.Time   `json:"opt_out_at,omitempty"`
-	LastContactAt *time.Time   `json:"last_contact_at,omitempty"`
-	CreatedAt     time.Time    `json:"created_at"`
-	UpdatedAt     time.Time    `json:"updated_at"`
+	ID                uuid.UUID    `json:"id"`
+	AccountID         uuid.UUID    `json:"account_id"`
+	Name              string       `json:"name"`
+	Email             *string      `json:"email,omitempty"`
+	EmailStatus       *EmailStatus `json:"email_status,omitempty"`        // Resultado da verificação do e-mail
+	EmailStatusReason *string      `json:"email_status_reason,omitempty"` // Motivo do status (ex.: disposable, role_account, no_mail_server)
+	WhatsApp          *string      `json:"whatsapp,omitempty"`
+	Gender            *string      `json:"gender,omitempty"`
+	BirthDate         *time.Time   `json:"birth_date,omitempty"`
+	Bairro            *string      `json:"bairro,omitempty"`
+	Cidade            *string      `json:"cidade,omitempty"`
+	Estado            *string      `json:"estado,omitempty"`
+	Tags              *ContactTags `json:"tags,omitempty"` // JSONB estruturado
+	History           *string      `json:"history,omitempty"`
+	OptOutAt          *time.Time   `json:"opt_out_at,omitempty"`
+	EmailOptOutAt     *time.Time   `json:"email_opt_out_at,omitempty"`
+	WhatsOptOutAt     *time.Time   `json:"whatsapp_opt_out_at,omitempty"`
+	LastContactAt     *time.Time   `json:"last_contact_at,omitempty"`
+	CreatedAt         time.Time    `json:"created_at"`
+	UpdatedAt         time.Time    `json:"updated_at"`
+}
+
+// OptedOut indica se o contato pediu para não receber mensagens no canal (ou em nenhum canal)
+func (c *Contact) OptedOut(channel ChannelType) bool {
+	if c.OptOutAt != nil {
+		return true
+	}
+
+	switch channel {
+	case EmailChannel:
+		return c.EmailOptOutAt != nil
+	case WhatsappChannel:
+		return c.WhatsOptOutAt != nil
+	}
+	return false
 }
 
 // ContactTags estrutura as tags como JSONB
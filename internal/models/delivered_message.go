@@ -0,0 +1,43 @@
+// File: /internal/models/delivered_message.go
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveredMessageFormat indica como o corpo da mensagem entregue está representado
+type DeliveredMessageFormat string
+
+const (
+	DeliveredMessageHTML DeliveredMessageFormat = "html" // HTML do e-mail (com a parte texto em BodyText)
+	DeliveredMessageText DeliveredMessageFormat = "text" // Texto enviado pelo WhatsApp
+	DeliveredMessageJSON DeliveredMessageFormat = "json" // Variáveis do template renderizado pelo provedor
+)
+
+// DefaultMessageRetentionDays é a retenção das mensagens entregues quando a conta não define a sua
+const DefaultMessageRetentionDays = 365
+
+// DeliveredMessage guarda o conteúdo exato entregue a um destinatário da campanha
+type DeliveredMessage struct {
+	ID                uuid.UUID              `json:"id"`
+	AccountID         uuid.UUID              `json:"account_id"`
+	CampaignID        uuid.UUID              `json:"campaign_id"`
+	AudienceID        uuid.UUID              `json:"audience_id"`
+	ContactID         *uuid.UUID             `json:"contact_id,omitempty"`
+	Channel           ChannelType            `json:"channel"`
+	Provider          *string                `json:"provider,omitempty"`
+	ProviderMessageID *string                `json:"provider_message_id,omitempty"`
+	Subject           *string                `json:"subject,omitempty"`
+	BodyFormat        DeliveredMessageFormat `json:"body_format"`
+	Body              string                 `json:"body"`
+	BodyText          *string                `json:"body_text,omitempty"` // Parte texto do e-mail
+	TemplateID        *uuid.UUID             `json:"template_id,omitempty"`
+	TemplateVersion   *string                `json:"template_version,omitempty"` // SHA-256 do arquivo do template
+	AIModel           *string                `json:"ai_model,omitempty"`
+	AIPrompt          *string                `json:"ai_prompt,omitempty"`
+	SentAt            time.Time              `json:"sent_at"`
+	CreatedAt         time.Time              `json:"created_at"`
+}
@@ -0,0 +1,22 @@
+// File: /internal/models/email_status.go
+
+package models
+
+// EmailStatus é o resultado da verificação do e-mail do contato
+type EmailStatus string
+
+const (
+	EmailStatusValid   EmailStatus = "valid"   // Sintaxe correta e domínio recebe e-mails
+	EmailStatusRisky   EmailStatus = "risky"   // Entregável, mas descartável ou conta de função (contato@, vendas@)
+	EmailStatusInvalid EmailStatus = "invalid" // Sintaxe inválida ou domínio sem servidor de e-mail
+	EmailStatusUnknown EmailStatus = "unknown" // Não verificado ou DNS indisponível
+)
+
+// IsValid verifica se o status é conhecido
+func (s EmailStatus) IsValid() bool {
+	switch s {
+	case EmailStatusValid, EmailStatusRisky, EmailStatusInvalid, EmailStatusUnknown:
+		return true
+	}
+	return false
+}
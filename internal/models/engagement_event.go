@@ -0,0 +1,40 @@
+// File: /internal/models/engagement_event.go
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 🔹 Tipo de engajamento registrado
+type EngagementType string
+
+const (
+	EngagementOpen  EngagementType = "open"  // Abertura do e-mail
+	EngagementClick EngagementType = "click" // Clique em um link
+)
+
+// 🔹 Origem do evento de engajamento
+type EngagementSource string
+
+const (
+	EngagementSourcePixel    EngagementSource = "pixel"    // Pixel de rastreamento servido pela API
+	EngagementSourceRedirect EngagementSource = "redirect" // Redirecionamento assinado servido pela API
+	EngagementSourceSES      EngagementSource = "ses"      // Evento Open/Click do configuration set do SES
+	EngagementSourceWhatsApp EngagementSource = "whatsapp" // Leitura informada pelo webhook de status do WhatsApp
+)
+
+// EngagementEvent registra uma abertura ou clique de um contato da campanha
+type EngagementEvent struct {
+	ID         uuid.UUID        `json:"id"`
+	AudienceID uuid.UUID        `json:"audience_id"`
+	CampaignID uuid.UUID        `json:"campaign_id"`
+	Type       EngagementType   `json:"type"`
+	URL        *string          `json:"url,omitempty"`
+	IPAddress  *string          `json:"ip_address,omitempty"`
+	UserAgent  *string          `json:"user_agent,omitempty"`
+	Source     EngagementSource `json:"source"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
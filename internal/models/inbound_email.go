@@ -0,0 +1,37 @@
+// File: /internal/models/inbound_email.go
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InboundEmailSource indica por onde a resposta chegou
+type InboundEmailSource string
+
+const (
+	InboundEmailSourceSES    InboundEmailSource = "ses"    // Receipt rule do SES (SNS com conteúdo ou objeto no S3)
+	InboundEmailSourceUpload InboundEmailSource = "upload" // MIME enviado pela API (uso local)
+)
+
+// InboundEmail representa um e-mail recebido de um contato, normalmente a resposta a uma campanha
+type InboundEmail struct {
+	ID            uuid.UUID          `json:"id"`
+	AccountID     uuid.UUID          `json:"account_id"`
+	ContactID     *uuid.UUID         `json:"contact_id,omitempty"`
+	CampaignID    *uuid.UUID         `json:"campaign_id,omitempty"` // Campanha respondida (via In-Reply-To/References)
+	AudienceID    *uuid.UUID         `json:"audience_id,omitempty"`
+	ChatMessageID *uuid.UUID         `json:"chat_message_id,omitempty"`
+	MessageID     string             `json:"message_id"` // Message-ID da resposta (sem < >)
+	InReplyTo     *string            `json:"in_reply_to,omitempty"`
+	FromAddress   string             `json:"from_address"`
+	FromName      *string            `json:"from_name,omitempty"`
+	ToAddress     *string            `json:"to_address,omitempty"`
+	Subject       *string            `json:"subject,omitempty"`
+	TextBody      *string            `json:"text_body,omitempty"` // Texto da resposta sem o conteúdo citado
+	Source        InboundEmailSource `json:"source"`
+	ReceivedAt    time.Time          `json:"received_at"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
@@ -0,0 +1,19 @@
+// File: /internal/models/queue_job.go
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QueueJob representa uma mensagem armazenada na fila PostgreSQL
+type QueueJob struct {
+	ID        uuid.UUID `json:"id"`
+	Queue     string    `json:"queue"`   // "email" ou "whatsapp"
+	Payload   []byte    `json:"payload"` // JSONB
+	Attempts  int       `json:"attempts"`
+	VisibleAt time.Time `json:"visible_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,102 @@
+// File: /internal/models/send_policy.go
+
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SendPolicy define quando e em que ritmo uma conta pode enviar mensagens por um canal
+type SendPolicy struct {
+	ID            uuid.UUID   `json:"id"`
+	AccountID     uuid.UUID   `json:"account_id"`
+	Channel       ChannelType `json:"channel"`
+	Timezone      string      `json:"timezone"`        // Fuso IANA (ex.: America/Sao_Paulo)
+	Weekdays      []int       `json:"weekdays"`        // Dias permitidos: 0 = domingo ... 6 = sábado
+	StartTime     string      `json:"start_time"`      // Início da janela (HH:MM)
+	EndTime       string      `json:"end_time"`        // Fim da janela (HH:MM)
+	RatePerMinute int         `json:"rate_per_minute"` // 0 = sem limite
+	DailyCap      int         `json:"daily_cap"`       // 0 = sem limite
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// Location retorna o fuso da política (UTC se inválido)
+func (p *SendPolicy) Location() *time.Location {
+	location, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// allowsWeekday indica se o dia da semana está liberado para envio
+func (p *SendPolicy) allowsWeekday(day time.Weekday) bool {
+	for _, weekday := range p.Weekdays {
+		if time.Weekday(weekday) == day {
+			return true
+		}
+	}
+	return false
+}
+
+// windowOn retorna início e fim da janela de envio no dia (local) de `day`
+func (p *SendPolicy) windowOn(day time.Time) (time.Time, time.Time) {
+	start, _ := ParseClock(p.StartTime)
+	end, _ := ParseClock(p.EndTime)
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	return midnight.Add(start), midnight.Add(end)
+}
+
+// NextAllowed retorna o primeiro instante, a partir de `now`, dentro da janela de envio.
+// Retorna `now` se já estiver na janela e o zero de time.Time se nenhum dia estiver liberado.
+func (p *SendPolicy) NextAllowed(now time.Time) time.Time {
+	local := now.In(p.Location())
+
+	for i := 0; i <= 7; i++ {
+		day := local.AddDate(0, 0, i)
+		if !p.allowsWeekday(day.Weekday()) {
+			continue
+		}
+
+		start, end := p.windowOn(day)
+		if !local.Before(start) && local.Before(end) {
+			return now
+		}
+		if start.After(local) {
+			return start
+		}
+	}
+
+	return time.Time{}
+}
+
+// WindowEnd retorna o fim da janela de envio que contém `now`
+func (p *SendPolicy) WindowEnd(now time.Time) time.Time {
+	_, end := p.windowOn(now.In(p.Location()))
+	return end
+}
+
+// StartOfNextDay retorna a meia-noite (no fuso da política) do dia seguinte a `now`
+func (p *SendPolicy) StartOfNextDay(now time.Time) time.Time {
+	local := now.In(p.Location())
+	return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, local.Location())
+}
+
+// DayBucket retorna a meia-noite (no fuso da política) do dia de `now`, usada no limite diário
+func (p *SendPolicy) DayBucket(now time.Time) time.Time {
+	local := now.In(p.Location())
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ParseClock converte "HH:MM" em duração desde a meia-noite
+func ParseClock(clock string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("horário inválido (use HH:MM): %s", clock)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
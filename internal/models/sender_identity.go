@@ -0,0 +1,60 @@
+// File: /internal/models/sender_identity.go
+
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SenderIdentityKind indica se o remetente é um endereço específico ou um domínio inteiro
+type SenderIdentityKind string
+
+const (
+	SenderIdentityEmail  SenderIdentityKind = "email"
+	SenderIdentityDomain SenderIdentityKind = "domain"
+)
+
+// SenderIdentityStatus é o estado de verificação do remetente no provedor
+type SenderIdentityStatus string
+
+const (
+	SenderIdentityPending  SenderIdentityStatus = "pendente"
+	SenderIdentityVerified SenderIdentityStatus = "verificado"
+	SenderIdentityFailed   SenderIdentityStatus = "falhou"
+)
+
+// SenderIdentity representa um remetente de e-mail da conta
+type SenderIdentity struct {
+	ID                uuid.UUID            `json:"id"`
+	AccountID         uuid.UUID            `json:"account_id"`
+	Kind              SenderIdentityKind   `json:"kind"`
+	Identity          string               `json:"identity"`
+	DisplayName       *string              `json:"display_name,omitempty"`
+	ReplyTo           *string              `json:"reply_to,omitempty"`
+	ConfigurationSet  *string              `json:"configuration_set,omitempty"` // nil = Configuration Set padrão
+	Status            SenderIdentityStatus `json:"status"`
+	VerificationToken *string              `json:"verification_token,omitempty"` // TXT _amazonses.<domínio>
+	VerifiedAt        *time.Time           `json:"verified_at,omitempty"`
+	CheckedAt         *time.Time           `json:"checked_at,omitempty"`
+	CreatedAt         time.Time            `json:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+}
+
+// Verified indica se o remetente pode ser usado em envios
+func (s *SenderIdentity) Verified() bool {
+	return s.Status == SenderIdentityVerified
+}
+
+// Covers indica se o endereço pode ser usado como remetente por esta identidade
+// (mesmo e-mail, ou qualquer endereço do domínio verificado)
+func (s *SenderIdentity) Covers(address string) bool {
+	address = strings.ToLower(strings.TrimSpace(address))
+	if s.Kind == SenderIdentityDomain {
+		at := strings.LastIndex(address, "@")
+		return at >= 0 && address[at+1:] == s.Identity
+	}
+	return address == s.Identity
+}
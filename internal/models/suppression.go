@@ -0,0 +1,39 @@
+// File: /internal/models/suppression.go
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SuppressionReason é o motivo da supressão de um destinatário
+type SuppressionReason string
+
+const (
+	SuppressionHardBounce SuppressionReason = "hard_bounce"
+	SuppressionSoftBounce SuppressionReason = "soft_bounce"
+	SuppressionComplaint  SuppressionReason = "complaint"
+	SuppressionManual     SuppressionReason = "manual"
+)
+
+// Suppression representa um destinatário (e-mail ou WhatsApp) bloqueado para envios da conta
+type Suppression struct {
+	ID          uuid.UUID         `json:"id"`
+	AccountID   uuid.UUID         `json:"account_id"`
+	Channel     ChannelType       `json:"channel"`
+	Address     string            `json:"address"`
+	Reason      SuppressionReason `json:"reason"`
+	CampaignID  *uuid.UUID        `json:"campaign_id,omitempty"` // Campanha que originou a supressão
+	BounceCount int               `json:"bounce_count"`
+	ExpiresAt   *time.Time        `json:"expires_at,omitempty"` // nil = permanente
+	Notes       *string           `json:"notes,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// Active indica se a supressão bloqueia envios agora
+func (s *Suppression) Active(now time.Time) bool {
+	return s.ExpiresAt == nil || s.ExpiresAt.After(now)
+}
@@ -0,0 +1,99 @@
+// File: /internal/models/whatsapp_template.go
+
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WhatsAppPhoneNumber é um número da Cloud API (Meta) cadastrado na conta
+type WhatsAppPhoneNumber struct {
+	ID                 uuid.UUID  `json:"id"`
+	AccountID          uuid.UUID  `json:"account_id"`
+	PhoneNumberID      string     `json:"phone_number_id"`     // instance_name dos chats cloud_api
+	BusinessAccountID  string     `json:"business_account_id"` // WABA dona dos templates
+	DisplayPhoneNumber *string    `json:"display_phone_number,omitempty"`
+	VerifiedName       *string    `json:"verified_name,omitempty"`
+	QualityRating      *string    `json:"quality_rating,omitempty"` // GREEN, YELLOW ou RED
+	TemplatesSyncedAt  *time.Time `json:"templates_synced_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// Status dos templates na Meta (somente APPROVED pode ser enviado)
+const (
+	WhatsAppTemplateApproved = "APPROVED"
+	WhatsAppTemplateDeleted  = "DELETED" // Template que deixou de existir na WABA
+)
+
+// Formato das variáveis do template: {{1}}, {{2}}... ou {{nome}}
+const (
+	WhatsAppTemplatePositional = "POSITIONAL"
+	WhatsAppTemplateNamed      = "NAMED"
+)
+
+// WhatsAppTemplate é um template de mensagem (HSM) sincronizado da WABA
+type WhatsAppTemplate struct {
+	ID                uuid.UUID              `json:"id"`
+	AccountID         uuid.UUID              `json:"account_id"`
+	BusinessAccountID string                 `json:"business_account_id"`
+	ExternalID        string                 `json:"external_id"` // ID do template na Meta
+	Name              string                 `json:"name"`
+	Language          string                 `json:"language"`
+	Category          *string                `json:"category,omitempty"` // MARKETING, UTILITY ou AUTHENTICATION
+	Status            string                 `json:"status"`
+	ParameterFormat   string                 `json:"parameter_format"`
+	Components        json.RawMessage        `json:"components"` // Componentes como retornados pelo Graph
+	Slots             []WhatsAppTemplateSlot `json:"slots"`
+	SyncedAt          time.Time              `json:"synced_at"`
+	CreatedAt         time.Time              `json:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at"`
+}
+
+// Approved indica se o template pode ser usado nos envios
+func (t *WhatsAppTemplate) Approved() bool {
+	return t.Status == WhatsAppTemplateApproved
+}
+
+// Componentes do template que recebem variáveis
+const (
+	WhatsAppSlotHeader = "header"
+	WhatsAppSlotBody   = "body"
+	WhatsAppSlotButton = "button"
+)
+
+// WhatsAppTemplateSlot é uma variável do template que o envio precisa preencher
+type WhatsAppTemplateSlot struct {
+	Key        string `json:"key"`                   // body.1, header.1, header.media, button.0.1 ou body.<nome> nos templates NAMED
+	Component  string `json:"component"`             // header, body ou button
+	Type       string `json:"type"`                  // text, image, video, document ou coupon_code
+	Name       string `json:"name"`                  // Número ({{1}}) ou nome ({{nome}}) da variável; "media" no cabeçalho de mídia
+	Index      int    `json:"index"`                 // Posição do botão
+	ButtonType string `json:"button_type,omitempty"` // url ou copy_code
+	Example    string `json:"example,omitempty"`     // Exemplo cadastrado na Meta
+}
+
+// Origens do valor das variáveis (campaign_settings.whatsapp_template_params: variável -> origem)
+const (
+	WhatsAppParamSaudacao      = "saudacao"    // Conteúdo gerado pela IA
+	WhatsAppParamCorpo         = "corpo"       // Conteúdo gerado pela IA
+	WhatsAppParamFinalizacao   = "finalizacao" // Conteúdo gerado pela IA
+	WhatsAppParamAssinatura    = "assinatura"  // Conteúdo gerado pela IA
+	WhatsAppParamContactName   = "contato.nome"
+	WhatsAppParamBrand         = "marca"  // Marca das configurações da campanha
+	WhatsAppParamLiteralPrefix = "valor:" // Valor fixo (ex: "valor:https://loja.com/promo.jpg")
+)
+
+// ValidWhatsAppParamSource indica se a origem do valor de uma variável é conhecida
+func ValidWhatsAppParamSource(source string) bool {
+	switch source {
+	case WhatsAppParamSaudacao, WhatsAppParamCorpo, WhatsAppParamFinalizacao, WhatsAppParamAssinatura,
+		WhatsAppParamContactName, WhatsAppParamBrand:
+		return true
+	}
+	return strings.HasPrefix(source, WhatsAppParamLiteralPrefix) && len(source) > len(WhatsAppParamLiteralPrefix)
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/jeancarlosdanese/go-marketing/internal/dto"
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
 	"github.com/jeancarlosdanese/go-marketing/internal/middleware"
-	"github.com/jeancarlosdanese/go-marketing/internal/models"
 	"github.com/jeancarlosdanese/go-marketing/internal/utils"
 )
 
@@ -63,15 +62,11 @@ func (h *accountSettingsHandle) CreateAccountSettingsHandler() http.HandlerFunc
 		}
 
 		// Criar configurações no banco
-		settings := &models.AccountSettings{
-			AccountID:          *settingsDTO.AccountID,
-			OpenAIAPIKey:       settingsDTO.OpenAIAPIKey,
-			EvolutionInstance:  settingsDTO.EvolutionInstance,
-			AWSAccessKeyID:     settingsDTO.AWSAccessKeyID,
-			AWSSecretAccessKey: settingsDTO.AWSSecretAccessKey,
-			AWSRegion:          settingsDTO.AWSRegion,
-			MailFrom:           settingsDTO.MailFrom,
-			MailAdminTo:        settingsDTO.MailAdminTo,
+		settings := settingsDTO.ToModel()
+		if err := dto.ValidateEmailProvider(settings); err != nil {
+			h.log.Warn("Erro de validação", "error", err.Error())
+			utils.SendError(w, http.StatusBadRequest, err.Error())
+			return
 		}
 
 		createdSettings, err := h.repo.Create(r.Context(), settings)
@@ -180,26 +175,11 @@ func (h *accountSettingsHandle) UpdateAccountSettingsHandler() http.HandlerFunc
 		}
 
 		// 🔄 Atualizar somente os campos informados na requisição
-		if settingsDTO.OpenAIAPIKey != nil {
-			existingSettings.OpenAIAPIKey = *settingsDTO.OpenAIAPIKey
-		}
-		if settingsDTO.EvolutionInstance != nil {
-			existingSettings.EvolutionInstance = *settingsDTO.EvolutionInstance
-		}
-		if settingsDTO.AWSAccessKeyID != nil {
-			existingSettings.AWSAccessKeyID = *settingsDTO.AWSAccessKeyID
-		}
-		if settingsDTO.AWSSecretAccessKey != nil {
-			existingSettings.AWSSecretAccessKey = *settingsDTO.AWSSecretAccessKey
-		}
-		if settingsDTO.AWSRegion != nil {
-			existingSettings.AWSRegion = *settingsDTO.AWSRegion
-		}
-		if settingsDTO.MailFrom != nil {
-			existingSettings.MailFrom = *settingsDTO.MailFrom
-		}
-		if settingsDTO.MailAdminTo != nil {
-			existingSettings.MailAdminTo = *settingsDTO.MailAdminTo
+		settingsDTO.ApplyUpdate(existingSettings)
+		if err := dto.ValidateEmailProvider(existingSettings); err != nil {
+			h.log.Warn("Erro de validação", "error", err.Error())
+			utils.SendError(w, http.StatusBadRequest, err.Error())
+			return
 		}
 
 		// 🔄 Atualizar configurações no banco
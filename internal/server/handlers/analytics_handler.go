@@ -0,0 +1,176 @@
+// File: /internal/server/handlers/analytics_handler.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/middleware"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+type AnalyticsHandle interface {
+	GetCampaignStatsHandler() http.HandlerFunc
+	GetCampaignsAnalyticsHandler() http.HandlerFunc
+}
+
+type analyticsHandle struct {
+	log          *slog.Logger
+	campaignRepo db.CampaignRepository
+	audienceRepo db.CampaignAudienceRepository
+}
+
+func NewAnalyticsHandle(campaignRepo db.CampaignRepository, audienceRepo db.CampaignAudienceRepository) AnalyticsHandle {
+	return &analyticsHandle{
+		log:          logger.GetLogger(),
+		campaignRepo: campaignRepo,
+		audienceRepo: audienceRepo,
+	}
+}
+
+// GetCampaignStatsHandler retorna o funil por canal/status e a série temporal de envios e entregas da campanha.
+// Query params: interval ("hour" ou "day", padrão "hour") e timezone (IANA, padrão "UTC").
+func (h *analyticsHandle) GetCampaignStatsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		// 🔍 Capturar `campaign_id` da URL
+		campaignID := utils.GetUUIDFromRequestPath(r, w, "campaign_id")
+
+		interval := r.URL.Query().Get("interval")
+		if interval == "" {
+			interval = "hour"
+		}
+		if interval != "hour" && interval != "day" {
+			utils.SendError(w, http.StatusBadRequest, "interval deve ser 'hour' ou 'day'")
+			return
+		}
+
+		location, ok := h.locationFromQuery(w, r)
+		if !ok {
+			return
+		}
+
+		// 🔍 Buscar campanha
+		campaign, err := h.campaignRepo.GetByID(r.Context(), campaignID)
+		if err != nil || campaign == nil {
+			utils.SendError(w, http.StatusNotFound, "Campanha não encontrada")
+			return
+		}
+
+		// Checar se é admin ou dono
+		if !middleware.IsAdminOrOwner(authAccount, campaign.AccountID) {
+			h.log.Warn("Apenas administradores podem buscar outras contas")
+			utils.SendError(w, http.StatusForbidden, "Apenas administradores podem buscar outras contas")
+			return
+		}
+
+		counts, err := h.audienceRepo.GetStatusCounts(r.Context(), campaignID)
+		if err != nil {
+			h.log.Error("Erro ao agregar audiência da campanha", "campaign_id", campaignID, "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao calcular métricas da campanha")
+			return
+		}
+
+		series, err := h.audienceRepo.GetStatsSeries(r.Context(), campaignID, interval, location.String())
+		if err != nil {
+			h.log.Error("Erro ao buscar série de envios", "campaign_id", campaignID, "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao calcular métricas da campanha")
+			return
+		}
+		for i := range series {
+			series[i].Bucket = series[i].Bucket.In(location)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(dto.NewCampaignStatsDTO(campaign, counts, interval, location.String(), series))
+	}
+}
+
+// GetCampaignsAnalyticsHandler compara o funil das campanhas da conta criadas no período.
+// Query params: from e to ("2006-01-02", inclusivos; padrão últimos 30 dias), timezone e channel (opcional).
+func (h *analyticsHandle) GetCampaignsAnalyticsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		location, ok := h.locationFromQuery(w, r)
+		if !ok {
+			return
+		}
+
+		now := time.Now().In(location)
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+		filter := dto.CampaignAnalyticsFilterDTO{From: today.AddDate(0, 0, -29), To: today.AddDate(0, 0, 1)}
+
+		if from := r.URL.Query().Get("from"); from != "" {
+			date, err := time.ParseInLocation("2006-01-02", from, location)
+			if err != nil {
+				utils.SendError(w, http.StatusBadRequest, "from inválido, use o formato AAAA-MM-DD")
+				return
+			}
+			filter.From = date
+		}
+		if to := r.URL.Query().Get("to"); to != "" {
+			date, err := time.ParseInLocation("2006-01-02", to, location)
+			if err != nil {
+				utils.SendError(w, http.StatusBadRequest, "to inválido, use o formato AAAA-MM-DD")
+				return
+			}
+			filter.To = date.AddDate(0, 0, 1) // ✅ Dia final inclusivo
+		}
+		if !filter.From.Before(filter.To) {
+			utils.SendError(w, http.StatusBadRequest, "from deve ser anterior ou igual a to")
+			return
+		}
+		if filter.To.Sub(filter.From) > 366*24*time.Hour {
+			utils.SendError(w, http.StatusBadRequest, "o período máximo é de um ano")
+			return
+		}
+
+		if channel := r.URL.Query().Get("channel"); channel != "" {
+			if channel != "email" && channel != "whatsapp" {
+				utils.SendError(w, http.StatusBadRequest, "channel deve ser 'email' ou 'whatsapp'")
+				return
+			}
+			filter.Channel = &channel
+		}
+
+		comparison, err := h.audienceRepo.GetAccountCampaignStats(r.Context(), authAccount.ID, filter)
+		if err != nil {
+			h.log.Error("Erro ao comparar campanhas", "account_id", authAccount.ID, "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao calcular métricas das campanhas")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"from":      filter.From.Format("2006-01-02"),
+			"to":        filter.To.AddDate(0, 0, -1).Format("2006-01-02"),
+			"timezone":  location.String(),
+			"campaigns": comparison,
+		})
+	}
+}
+
+// locationFromQuery lê o fuso horário do query param `timezone` (padrão UTC)
+func (h *analyticsHandle) locationFromQuery(w http.ResponseWriter, r *http.Request) (*time.Location, bool) {
+	timezone := r.URL.Query().Get("timezone")
+	if timezone == "" {
+		return time.UTC, true
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		utils.SendError(w, http.StatusBadRequest, "timezone inválido")
+		return nil, false
+	}
+	return location, true
+}
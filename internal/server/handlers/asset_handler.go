@@ -0,0 +1,177 @@
+// File: /internal/server/handlers/asset_handler.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/middleware"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+type AssetHandle interface {
+	GetAssetsHandler() http.HandlerFunc
+	UploadAssetHandler() http.HandlerFunc
+	GetAssetHandler() http.HandlerFunc
+	DeleteAssetHandler() http.HandlerFunc
+	ServeAssetHandler() http.HandlerFunc
+}
+
+type assetHandle struct {
+	log    *slog.Logger
+	assets service.AssetService
+}
+
+func NewAssetHandle(assets service.AssetService) AssetHandle {
+	return &assetHandle{
+		log:    logger.GetLogger(),
+		assets: assets,
+	}
+}
+
+// GetAssetsHandler lista os arquivos da conta (filtros: name, content_type)
+func (h *assetHandle) GetAssetsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		filters := utils.ExtractQueryFilters(r.URL.Query(), []string{"name", "content_type"})
+		page, perPage, _ := utils.ExtractPaginationParams(r)
+
+		paginator, err := h.assets.List(r.Context(), authAccount.ID, filters, page, perPage)
+		if err != nil {
+			h.log.Error("Erro ao buscar arquivos", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar arquivos")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(paginator)
+	}
+}
+
+// UploadAssetHandler recebe o arquivo no campo "file" (multipart/form-data)
+func (h *assetHandle) UploadAssetHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		// 📏 Margem de 1 MB para os cabeçalhos do formulário
+		r.Body = http.MaxBytesReader(w, r.Body, h.assets.MaxSize()+1<<20)
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			h.log.Warn("Erro ao ler arquivo enviado", "error", err)
+			utils.SendError(w, http.StatusBadRequest, "Arquivo ausente ou maior que o permitido")
+			return
+		}
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		if err != nil {
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao processar arquivo")
+			return
+		}
+
+		asset, err := h.assets.Upload(r.Context(), authAccount.ID, header.Filename, content)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidAsset) {
+				utils.SendError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			h.log.Error("Erro ao salvar arquivo", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao salvar arquivo")
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(asset)
+	}
+}
+
+// GetAssetHandler retorna um arquivo da conta (com a URL pública para uso nos templates)
+func (h *assetHandle) GetAssetHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		assetID := utils.GetUUIDFromRequestPath(r, w, "asset_id")
+		if assetID == uuid.Nil {
+			return
+		}
+
+		asset, err := h.assets.Get(r.Context(), authAccount.ID, assetID)
+		if err != nil {
+			if errors.Is(err, service.ErrAssetNotFound) {
+				utils.SendError(w, http.StatusNotFound, "Arquivo não encontrado")
+				return
+			}
+			h.log.Error("Erro ao buscar arquivo", "asset_id", assetID, "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar arquivo")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(asset)
+	}
+}
+
+// DeleteAssetHandler exclui o arquivo (recusado enquanto alguma campanha o anexa)
+func (h *assetHandle) DeleteAssetHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		assetID := utils.GetUUIDFromRequestPath(r, w, "asset_id")
+		if assetID == uuid.Nil {
+			return
+		}
+
+		if err := h.assets.Delete(r.Context(), authAccount.ID, assetID); err != nil {
+			switch {
+			case errors.Is(err, service.ErrAssetNotFound):
+				utils.SendError(w, http.StatusNotFound, "Arquivo não encontrado")
+			case errors.Is(err, service.ErrAssetInUse):
+				utils.SendError(w, http.StatusConflict, err.Error())
+			default:
+				h.log.Error("Erro ao excluir arquivo", "asset_id", assetID, "error", err)
+				utils.SendError(w, http.StatusInternalServerError, "Erro ao excluir arquivo")
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Arquivo removido com sucesso"})
+	}
+}
+
+// ServeAssetHandler entrega o arquivo publicamente (imagens e links dos templates de e-mail)
+func (h *assetHandle) ServeAssetHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		asset, content, err := h.assets.Open(r.Context(), r.PathValue("key"))
+		if err != nil {
+			if errors.Is(err, service.ErrAssetNotFound) {
+				http.NotFound(w, r)
+				return
+			}
+			h.log.Error("Erro ao ler arquivo", "key", r.PathValue("key"), "error", err)
+			http.Error(w, "Erro interno", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", asset.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "public, max-age=86400, immutable") // 🔒 A chave muda a cada upload
+		w.WriteHeader(http.StatusOK)
+		w.Write(content)
+	}
+}
@@ -4,8 +4,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/db"
@@ -16,6 +19,9 @@ import (
 	"github.com/jeancarlosdanese/go-marketing/internal/utils"
 )
 
+// suppressedCountHeader informa quantos destinatários foram ignorados por estarem na lista de supressão
+const suppressedCountHeader = "X-Suppressed-Count"
+
 type CampaignAudienceHandle interface {
 	AddContactsToCampaignHandler() http.HandlerFunc
 	AddAllFilteredContactsHandler() http.HandlerFunc
@@ -24,25 +30,29 @@ type CampaignAudienceHandle interface {
 	GetCampaignAudienceHandler() http.HandlerFunc
 	RemoveContactFromCampaignHandler() http.HandlerFunc
 	RemoveAllContactsFromCampaignHandler() http.HandlerFunc
+	GetDeliveredMessageHandler() http.HandlerFunc
 }
 
 type campaignAudienceHandle struct {
-	log          *slog.Logger
-	campaignRepo db.CampaignRepository
-	contactRepo  db.ContactRepository
-	audienceRepo db.CampaignAudienceRepository
+	log               *slog.Logger
+	campaignRepo      db.CampaignRepository
+	contactRepo       db.ContactRepository
+	audienceRepo      db.CampaignAudienceRepository
+	deliveredMessages db.DeliveredMessageRepository
 }
 
 func NewCampaignAudienceHandle(
 	campaignRepo db.CampaignRepository,
 	contactRepo db.ContactRepository,
 	audienceRepo db.CampaignAudienceRepository,
+	deliveredMessages db.DeliveredMessageRepository,
 ) CampaignAudienceHandle {
 	return &campaignAudienceHandle{
-		log:          logger.GetLogger(),
-		campaignRepo: campaignRepo,
-		contactRepo:  contactRepo,
-		audienceRepo: audienceRepo,
+		log:               logger.GetLogger(),
+		campaignRepo:      campaignRepo,
+		contactRepo:       contactRepo,
+		audienceRepo:      audienceRepo,
+		deliveredMessages: deliveredMessages,
 	}
 }
 
@@ -59,7 +69,7 @@ func (h *campaignAudienceHandle) GetAvailableContactsHandler() http.HandlerFunc
 		h.validateOwnerCampaign(r, w, campaignID)
 
 		// 🔍 Capturar filtros da query string
-		filters := utils.ExtractQueryFilters(r.URL.Query(), []string{"name", "email", "whatsapp", "cidade", "estado", "bairro", "gender", "birth_date_start", "birth_date_end", "last_contact_at", "interesses", "perfil", "eventos", "tags"})
+		filters := utils.ExtractQueryFilters(r.URL.Query(), []string{"name", "email", "email_status", "whatsapp", "cidade", "estado", "bairro", "gender", "birth_date_start", "birth_date_end", "last_contact_at", "interesses", "perfil", "eventos", "tags"})
 		page, perPage, sort := utils.ExtractPaginationParams(r)
 
 		// 🔍 Buscar contatos disponíveis para a campanha
@@ -106,13 +116,20 @@ func (h *campaignAudienceHandle) AddContactsToCampaignHandler() http.HandlerFunc
 		var validContacts []models.Contact
 		for _, contactID := range requestDTO.ContactIDs {
 			contact, err := h.contactRepo.GetByID(r.Context(), contactID)
-			if err != nil || contact == nil {
+			if err != nil {
+				h.log.Error("Erro ao buscar contato", "contact_id", contactID, "error", err)
+				utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar contato")
+				return
+			}
+			if contact == nil {
 				h.log.Warn("Contato não encontrado", "contact_id", contactID)
-				continue
+				utils.SendError(w, http.StatusNotFound, fmt.Sprintf("Contato não encontrado: %s", contactID))
+				return
 			}
 			if contact.AccountID != authAccount.ID {
 				h.log.Warn("Usuário tentou adicionar contato de outra conta", "user_id", authAccount.ID, "contact_id", contactID)
-				continue
+				utils.SendError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Contato não pertence à conta: %s", contactID))
+				return
 			}
 			validContacts = append(validContacts, *contact)
 		}
@@ -150,14 +167,20 @@ func (h *campaignAudienceHandle) AddContactsToCampaignHandler() http.HandlerFunc
 		}
 
 		// 📦 Salvar registros
-		audiencesSaved, err := h.audienceRepo.AddContactsToCampaign(r.Context(), campaignID, audiences)
+		audiencesSaved, suppressed, err := h.audienceRepo.AddContactsToCampaign(r.Context(), campaignID, audiences)
+		if errors.Is(err, db.ErrContactNotInCampaignAccount) {
+			h.log.Warn("Contato fora da conta da campanha", "campaign_id", campaignID, "error", err)
+			utils.SendError(w, http.StatusUnprocessableEntity, "Os contatos precisam pertencer à conta da campanha")
+			return
+		}
 		if err != nil {
 			h.log.Error("Erro ao adicionar contatos à campanha", "campaign_id", campaignID, "error", err)
 			utils.SendError(w, http.StatusInternalServerError, "Erro ao adicionar contatos à campanha")
 			return
 		}
 
-		h.log.Info("Contatos adicionados à campanha com sucesso", "campaign_id", campaignID, "total", len(validContacts))
+		h.log.Info("Contatos adicionados à campanha com sucesso", "campaign_id", campaignID, "total", len(audiencesSaved), "suppressed", suppressed)
+		w.Header().Set(suppressedCountHeader, strconv.Itoa(suppressed))
 		utils.SendSuccess(w, http.StatusCreated, audiencesSaved)
 	}
 }
@@ -191,17 +214,19 @@ func (h *campaignAudienceHandle) AddAllFilteredContactsHandler() http.HandlerFun
 		defer r.Body.Close()
 
 		// 🔍 Buscar contatos e garantir que pertencem ao usuário autenticado
+		suppressed := 0
 		for _, channelType := range models.AllowedChannels {
 			// 🛑 Ignorar canais não configurados
 			if _, ok := campaign.Channels[string(channelType)]; !ok {
 				continue
 			}
 
-			err := h.audienceRepo.AddAllFilteredContacts(r.Context(), authAccount.ID, campaignID, requestDTO.Filters, channelType)
+			channelSuppressed, err := h.audienceRepo.AddAllFilteredContacts(r.Context(), authAccount.ID, campaignID, requestDTO.Filters, channelType)
 			if err != nil {
 				utils.SendError(w, http.StatusInternalServerError, "Erro ao adicionar contatos")
 				return
 			}
+			suppressed += channelSuppressed
 		}
 
 		paginator, err := h.audienceRepo.GetPaginatedCampaignAudience(r.Context(), campaignID, nil, requestDTO.CurrentPage, requestDTO.PerPage)
@@ -211,7 +236,8 @@ func (h *campaignAudienceHandle) AddAllFilteredContactsHandler() http.HandlerFun
 			return
 		}
 
-		h.log.Info("Contatos adicionados à campanha com sucesso", "campaign_id", campaignID, "total", paginator.TotalRecords)
+		h.log.Info("Contatos adicionados à campanha com sucesso", "campaign_id", campaignID, "total", paginator.TotalRecords, "suppressed", suppressed)
+		w.Header().Set(suppressedCountHeader, strconv.Itoa(suppressed))
 		utils.SendSuccess(w, http.StatusCreated, paginator)
 	}
 }
@@ -317,6 +343,38 @@ func (h *campaignAudienceHandle) RemoveAllContactsFromCampaignHandler() http.Han
 	}
 }
 
+// ✅ **Obter o conteúdo entregue a um destinatário da campanha**
+func (h *campaignAudienceHandle) GetDeliveredMessageHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		campaignID := utils.GetUUIDFromRequestPath(r, w, "campaign_id")
+		if campaignID == uuid.Nil {
+			return
+		}
+		audienceID := utils.GetUUIDFromRequestPath(r, w, "audience_id")
+		if audienceID == uuid.Nil {
+			return
+		}
+
+		// 🔒 A busca é restrita à conta autenticada
+		message, err := h.deliveredMessages.GetByAudienceID(r.Context(), authAccount.ID, campaignID, audienceID)
+		if err != nil {
+			h.log.Error("Erro ao buscar mensagem entregue", "campaign_id", campaignID, "audience_id", audienceID, "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar mensagem entregue")
+			return
+		}
+		if message == nil {
+			utils.SendError(w, http.StatusNotFound, "Mensagem não encontrada (ainda não enviada ou removida pela política de retenção)")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(message)
+	}
+}
+
 func (h *campaignAudienceHandle) validateOwnerCampaign(r *http.Request, w http.ResponseWriter, campaignID uuid.UUID) {
 	// 🔍 Buscar conta autenticada
 	authAccount := r.Context().Value(middleware.AuthAccountKey).(*models.Account)
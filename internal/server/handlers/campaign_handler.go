@@ -6,9 +6,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/db"
@@ -28,6 +31,9 @@ type CampaignHandle interface {
 	UpdateCampaignStatusHandler() http.HandlerFunc
 	GetCampaignStatusHandler() http.HandlerFunc
 	DeleteCampaignHandler() http.HandlerFunc
+	GetDeadLettersHandler() http.HandlerFunc
+	RetryDeadLettersHandler() http.HandlerFunc
+	GetCampaignStatusHistoryHandler() http.HandlerFunc
 }
 
 type campaignHandle struct {
@@ -35,18 +41,27 @@ type campaignHandle struct {
 	campaignRepo      db.CampaignRepository
 	audienceRepo      db.CampaignAudienceRepository
 	campaignProcessor service.CampaignProcessorService
+	sendPacer         service.SendPacerService
+	campaignState     service.CampaignStateService
+	senderIdentities  service.SenderIdentityService
 }
 
 func NewCampaignHandle(
 	campaignRepo db.CampaignRepository,
 	audienceRepo db.CampaignAudienceRepository,
 	campaignProcessor service.CampaignProcessorService,
+	sendPacer service.SendPacerService,
+	campaignState service.CampaignStateService,
+	senderIdentities service.SenderIdentityService,
 ) CampaignHandle {
 	return &campaignHandle{
 		log:               logger.GetLogger(),
 		campaignRepo:      campaignRepo,
 		audienceRepo:      audienceRepo,
 		campaignProcessor: campaignProcessor,
+		sendPacer:         sendPacer,
+		campaignState:     campaignState,
+		senderIdentities:  senderIdentities,
 	}
 }
 
@@ -116,8 +131,6 @@ func (h *campaignHandle) GetCampaignHandler() http.HandlerFunc {
 		// 🔍 Capturar `campaign_id` da URL
 		campaignID := utils.GetUUIDFromRequestPath(r, w, "campaign_id")
 
-		h.campaignRepo.UpdateStatusConcluido(r.Context(), campaignID)
-
 		// 🔍 Buscar campanha no banco
 		campaign, err := h.campaignRepo.GetByID(r.Context(), campaignID)
 		if err != nil {
@@ -268,7 +281,12 @@ func (h *campaignHandle) UpdateCampaignHandler() http.HandlerFunc {
 		if updateDTO.Channels != nil {
 			campaign.Channels = *updateDTO.Channels
 		}
-		campaign.Status = *updateDTO.Status
+
+		// 🔀 O status só muda pela máquina de estados (PATCH /campaigns/{campaign_id}/status)
+		if updateDTO.Status != nil && *updateDTO.Status != campaign.Status {
+			utils.SendError(w, http.StatusBadRequest, "Use PATCH /campaigns/{campaign_id}/status para alterar o status da campanha")
+			return
+		}
 
 		// Validar updateDTO
 		if err := updateDTO.Validate(); err != nil {
@@ -290,7 +308,7 @@ func (h *campaignHandle) UpdateCampaignHandler() http.HandlerFunc {
 	}
 }
 
-// UpdateCampaignStatusHandler atualiza o status de uma campanha
+// UpdateCampaignStatusHandler atualiza o status de uma campanha (as transições são validadas pela máquina de estados)
 func (h *campaignHandle) UpdateCampaignStatusHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var statusDTO dto.CampaignUpdateStatusDTO
@@ -330,58 +348,286 @@ func (h *campaignHandle) UpdateCampaignStatusHandler() http.HandlerFunc {
 			return
 		}
 
-		// 🔍 Se for ativar a campanha, verificar se há contatos na audiência
-		if statusDTO.Status == "processando" {
-			audience, err := h.audienceRepo.GetCampaignAudienceToSQS(r.Context(), authAccount.ID, campaignID, nil)
+		// 🔁 Repetir o status atual não altera nada (exceto reagendar)
+		if campaign.Status == statusDTO.Status && statusDTO.Status != models.StatusAgendada {
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]string{"status": string(campaign.Status)})
+			return
+		}
+
+		// 🔀 Validar a transição antes de qualquer efeito colateral
+		if !service.CanTransitionCampaign(campaign.Status, statusDTO.Status, models.StatusSourceAPI) {
+			h.log.Warn("Transição de status inválida", "campaign_id", campaignID, "from", campaign.Status, "to", statusDTO.Status)
+			utils.SendError(w, http.StatusConflict, fmt.Sprintf("Não é possível mudar a campanha de '%s' para '%s'", campaign.Status, statusDTO.Status))
+			return
+		}
+
+		change := service.CampaignStatusChange{ChangedBy: &authAccount.ID, Source: models.StatusSourceAPI}
+
+		switch statusDTO.Status {
+		case models.StatusProcessando:
+			// ▶️ Retomar campanha pausada: reenfileira apenas quem ainda não recebeu
+			resuming := campaign.Status == models.StatusPausada
+
+			// ✉️ Recusar antes de enfileirar: sem remetente verificado todos os envios falhariam
+			if !h.senderVerifiedOrFail(w, r, campaign) {
+				return
+			}
+
+			// 🔍 Verificar se há contatos na audiência
+			var audience []dto.CampaignMessageDTO
+			if resuming {
+				audience, err = h.audienceRepo.GetUnsentToSQS(r.Context(), campaign.AccountID, campaignID)
+			} else {
+				audience, err = h.audienceRepo.GetCampaignAudienceToSQS(r.Context(), campaign.AccountID, campaignID, nil)
+			}
 			if err != nil {
 				h.log.Error("Erro ao buscar audiência", "campaign_id", campaignID, "error", err)
 				utils.SendError(w, http.StatusInternalServerError, "Erro ao verificar audiência")
 				return
 			}
-			if len(audience) == 0 {
+			if len(audience) == 0 && !resuming {
 				h.log.Warn("Tentativa de ativar campanha sem audiência", "campaign_id", campaignID)
 				utils.SendError(w, http.StatusBadRequest, "Não é possível ativar uma campanha sem audiência")
 				return
 			}
 
 			// 🟡 Atualizar status da campanha para "processando"
-			campaign.Status = "processando" // 🟡 Define status intermediário
-			if err := h.campaignRepo.UpdateStatus(r.Context(), campaignID, "processando"); err != nil {
-				utils.SendError(w, http.StatusInternalServerError, "Erro ao atualizar status")
+			if !h.transitionOrFail(w, r, campaign, models.StatusProcessando, change) {
 				return
 			}
 
+			if len(audience) == 0 {
+				// ✅ Nada restou para enviar: a campanha retomada já terminou
+				if _, err := h.campaignState.CompleteIfFinished(r.Context(), campaignID); err != nil {
+					h.log.Error("Erro ao concluir campanha retomada", "campaign_id", campaignID, "error", err)
+				}
+				break
+			}
+
 			// 🚀 Iniciar worker para processar campanha
+			queued := *campaign
 			go func() {
 				h.log.Info("Iniciando worker de envio de mensagens", "campaign_id", campaignID)
-				err := h.campaignProcessor.ProcessCampaign(r.Context(), campaign, audience)
-				if err != nil {
+				// 🔥 O contexto da requisição é cancelado ao responder; o enfileiramento precisa sobreviver a ele
+				ctx := context.WithoutCancel(r.Context())
+				if err := h.campaignProcessor.ProcessCampaign(ctx, &queued, audience); err != nil {
 					h.log.Error("Erro no processamento da campanha", "campaign_id", campaignID, "error", err)
-				} else {
-					// ✅ Atualiza para "ativa" após processar
-					h.campaignRepo.UpdateStatus(context.Background(), campaignID, "processando")
+					return
+				}
+
+				// 📤 Tudo na fila: a campanha passa a "enviando" (a não ser que tenha sido pausada/cancelada no meio)
+				err := h.campaignState.Transition(ctx, &queued, models.StatusEnviando, service.CampaignStatusChange{
+					Source: models.StatusSourcePipeline,
+					Reason: "audiência enfileirada",
+				})
+				if err != nil {
+					h.log.Warn("Campanha não passou para 'enviando'", "campaign_id", campaignID, "error", err)
 				}
 			}()
+
+		case models.StatusPausada:
+			// ⏸️ Pausar: os workers deixam de enviar e quem estava na fila volta para "pendente"
+			if !h.transitionOrFail(w, r, campaign, models.StatusPausada, change) {
+				return
+			}
+
+			returned, err := h.audienceRepo.ReturnQueuedToPending(r.Context(), campaignID)
+			if err != nil {
+				h.log.Error("Erro ao devolver audiência para pendente", "campaign_id", campaignID, "error", err)
+				utils.SendError(w, http.StatusInternalServerError, "Erro ao pausar campanha")
+				return
+			}
+			h.log.Info("Campanha pausada", "campaign_id", campaignID, "audiencia_devolvida", returned)
+
+		case models.StatusCancelada:
+			// 🚫 Cancelar: os workers descartam o que ainda está na fila e a audiência não enviada é cancelada
+			if !h.transitionOrFail(w, r, campaign, models.StatusCancelada, change) {
+				return
+			}
+
+			cancelled, err := h.audienceRepo.CancelUnsent(r.Context(), campaignID)
+			if err != nil {
+				h.log.Error("Erro ao cancelar audiência", "campaign_id", campaignID, "error", err)
+				utils.SendError(w, http.StatusInternalServerError, "Erro ao cancelar campanha")
+				return
+			}
+			h.log.Info("Campanha cancelada", "campaign_id", campaignID, "audiencia_cancelada", cancelled)
+
+		case models.StatusAgendada:
+			// 🗓️ Agendar o disparo para uma data/hora futura
+			if !h.senderVerifiedOrFail(w, r, campaign) {
+				return
+			}
+
+			audience, err := h.audienceRepo.GetCampaignAudienceToSQS(r.Context(), campaign.AccountID, campaignID, nil)
+			if err != nil {
+				h.log.Error("Erro ao buscar audiência", "campaign_id", campaignID, "error", err)
+				utils.SendError(w, http.StatusInternalServerError, "Erro ao verificar audiência")
+				return
+			}
+			if len(audience) == 0 {
+				h.log.Warn("Tentativa de agendar campanha sem audiência", "campaign_id", campaignID)
+				utils.SendError(w, http.StatusBadRequest, "Não é possível agendar uma campanha sem audiência")
+				return
+			}
+
+			// ✅ A data é gravada antes do status: o agendador só considera campanhas "agendada"
+			scheduledAt, _ := statusDTO.ScheduledTime() // ✅ Já validado em statusDTO.Validate()
+			if err := h.campaignRepo.Schedule(r.Context(), campaignID, scheduledAt, *statusDTO.Timezone); err != nil {
+				h.log.Error("Erro ao agendar campanha", "campaign_id", campaignID, "error", err)
+				utils.SendError(w, http.StatusInternalServerError, "Erro ao agendar campanha")
+				return
+			}
+			campaign.ScheduledAt = &scheduledAt
+			campaign.Timezone = statusDTO.Timezone
+
+			change.Reason = "agendada para " + scheduledAt.Format(time.RFC3339)
+			if !h.transitionOrFail(w, r, campaign, models.StatusAgendada, change) {
+				return
+			}
+			h.log.Info("Campanha agendada com sucesso", "campaign_id", campaignID, "scheduled_at", scheduledAt)
+
+		case models.StatusPendente:
+			// 🔙 Voltar para "pendente" cancela o agendamento
+			if !h.transitionOrFail(w, r, campaign, models.StatusPendente, change) {
+				return
+			}
+
+			if err := h.campaignRepo.Unschedule(r.Context(), campaignID); err != nil {
+				h.log.Error("Erro ao remover agendamento", "campaign_id", campaignID, "error", err)
+				utils.SendError(w, http.StatusInternalServerError, "Erro ao remover agendamento")
+				return
+			}
+			campaign.ScheduledAt = nil
+			campaign.Timezone = nil
 		}
 
 		h.log.Info("Status da campanha atualizado com sucesso", "campaign_id", campaignID, "status", campaign.Status)
+		response := map[string]string{"status": string(campaign.Status)}
+		if scheduled := dto.NewCampaignResponseDTO(campaign).ScheduledAt; scheduled != nil {
+			response["scheduled_at"] = *scheduled
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// transitionOrFail aplica a mudança de status pela máquina de estados, respondendo com erro em caso de falha
+func (h *campaignHandle) transitionOrFail(w http.ResponseWriter, r *http.Request, campaign *models.Campaign, to models.CampaignStatus, change service.CampaignStatusChange) bool {
+	err := h.campaignState.Transition(r.Context(), campaign, to, change)
+	if errors.Is(err, service.ErrInvalidCampaignTransition) {
+		h.log.Warn("Transição de status recusada", "campaign_id", campaign.ID, "to", to, "error", err)
+		utils.SendError(w, http.StatusConflict, err.Error())
+		return false
+	}
+	if err != nil {
+		h.log.Error("Erro ao atualizar status da campanha", "campaign_id", campaign.ID, "to", to, "error", err)
+		utils.SendError(w, http.StatusInternalServerError, "Erro ao atualizar status")
+		return false
+	}
+	return true
+}
+
+// senderVerifiedOrFail exige remetente verificado para campanhas com e-mail, respondendo com erro caso contrário
+func (h *campaignHandle) senderVerifiedOrFail(w http.ResponseWriter, r *http.Request, campaign *models.Campaign) bool {
+	err := h.senderIdentities.CheckCampaign(r.Context(), campaign)
+	if errors.Is(err, service.ErrSenderNotVerified) {
+		h.log.Warn("Campanha com remetente não verificado", "campaign_id", campaign.ID, "error", err)
+		utils.SendError(w, http.StatusUnprocessableEntity, "Não é possível iniciar a campanha: "+err.Error())
+		return false
+	}
+	if err != nil {
+		h.log.Error("Erro ao verificar remetente da campanha", "campaign_id", campaign.ID, "error", err)
+		utils.SendError(w, http.StatusInternalServerError, "Erro ao verificar remetente")
+		return false
+	}
+	return true
+}
+
+// GetCampaignStatusHistoryHandler retorna a linha do tempo de status da campanha
+func (h *campaignHandle) GetCampaignStatusHistoryHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		// 🔍 Capturar `campaign_id` da URL
+		campaignID := utils.GetUUIDFromRequestPath(r, w, "campaign_id")
+
+		// 🔍 Buscar campanha
+		campaign, err := h.campaignRepo.GetByID(r.Context(), campaignID)
+		if err != nil || campaign == nil {
+			utils.SendError(w, http.StatusNotFound, "Campanha não encontrada")
+			return
+		}
+
+		// Checar se é admin ou dono
+		if !middleware.IsAdminOrOwner(authAccount, campaign.AccountID) {
+			h.log.Warn("Apenas administradores podem buscar outras contas")
+			utils.SendError(w, http.StatusForbidden, "Apenas administradores podem buscar outras contas")
+			return
+		}
+
+		history, err := h.campaignState.GetHistory(r.Context(), campaignID)
+		if err != nil {
+			h.log.Error("Erro ao buscar histórico de status", "campaign_id", campaignID, "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar histórico de status")
+			return
+		}
+
 		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(map[string]string{"status": string(campaign.Status)})
+		json.NewEncoder(w).Encode(history)
 	}
 }
 
 // GetCampaignStatusHandler retorna o status de uma campanha
 func (h *campaignHandle) GetCampaignStatusHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		// 🔍 Capturar `campaign_id` da URL
 		campaignID := utils.GetUUIDFromRequestPath(r, w, "campaign_id")
 
+		// 🔍 Buscar campanha
 		campaign, err := h.campaignRepo.GetByID(r.Context(), campaignID)
 		if err != nil || campaign == nil {
 			utils.SendError(w, http.StatusNotFound, "Campanha não encontrada")
 			return
 		}
 
-		json.NewEncoder(w).Encode(map[string]string{"status": string(campaign.Status)})
+		// Checar se é admin ou dono
+		if !middleware.IsAdminOrOwner(authAccount, campaign.AccountID) {
+			h.log.Warn("Apenas administradores podem buscar outras contas")
+			utils.SendError(w, http.StatusForbidden, "Apenas administradores podem buscar outras contas")
+			return
+		}
+
+		response := map[string]interface{}{"status": campaign.Status}
+
+		// 🔮 Previsão de término respeitando janela de envio, ritmo e limite diário da conta
+		if campaign.Status == models.StatusProcessando || campaign.Status == models.StatusEnviando {
+			pending, err := h.audienceRepo.CountPendingByChannel(r.Context(), campaignID)
+			if err != nil {
+				h.log.Error("Erro ao contar envios pendentes", "campaign_id", campaignID, "error", err)
+				utils.SendError(w, http.StatusInternalServerError, "Erro ao calcular previsão de término")
+				return
+			}
+
+			projectedFinish, err := h.sendPacer.ProjectFinish(r.Context(), campaign.AccountID, pending)
+			if err != nil {
+				h.log.Error("Erro ao calcular previsão de término", "campaign_id", campaignID, "error", err)
+				utils.SendError(w, http.StatusInternalServerError, "Erro ao calcular previsão de término")
+				return
+			}
+
+			response["pending"] = pending
+			if projectedFinish != nil {
+				response["projected_finish_at"] = projectedFinish.Format(time.RFC3339)
+			}
+		}
+
+		json.NewEncoder(w).Encode(response)
 	}
 }
 
@@ -425,3 +671,107 @@ func (h *campaignHandle) DeleteCampaignHandler() http.HandlerFunc {
 		json.NewEncoder(w).Encode(map[string]string{"message": "Campanha removida com sucesso"})
 	}
 }
+
+// GetDeadLettersHandler lista os contatos da campanha cujo envio foi abandonado
+func (h *campaignHandle) GetDeadLettersHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		// 🔍 Capturar `campaign_id` da URL
+		campaignID := utils.GetUUIDFromRequestPath(r, w, "campaign_id")
+
+		// 🔍 Buscar campanha
+		campaign, err := h.campaignRepo.GetByID(r.Context(), campaignID)
+		if err != nil || campaign == nil {
+			utils.SendError(w, http.StatusNotFound, "Campanha não encontrada")
+			return
+		}
+
+		// Checar se é admin ou dono
+		if !middleware.IsAdminOrOwner(authAccount, campaign.AccountID) {
+			h.log.Warn("Apenas administradores podem buscar outras contas")
+			utils.SendError(w, http.StatusForbidden, "Apenas administradores podem buscar outras contas")
+			return
+		}
+
+		deadLetters, err := h.audienceRepo.GetDeadLetters(r.Context(), campaignID)
+		if err != nil {
+			h.log.Error("Erro ao buscar dead-letters", "campaign_id", campaignID, "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar dead-letters")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(deadLetters)
+	}
+}
+
+// RetryDeadLettersHandler reenfileira os dead-letters da campanha (todos ou os `audience_ids` informados)
+func (h *campaignHandle) RetryDeadLettersHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var retryDTO dto.CampaignDeadLetterRetryDTO
+
+		// Decodifica JSON (corpo opcional)
+		if err := json.NewDecoder(r.Body).Decode(&retryDTO); err != nil && err != io.EOF {
+			h.log.Warn("Erro ao decodificar JSON", "error", err)
+			utils.SendError(w, http.StatusBadRequest, "Erro ao processar requisição")
+			return
+		}
+		defer r.Body.Close()
+
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		// 🔍 Capturar `campaign_id` da URL
+		campaignID := utils.GetUUIDFromRequestPath(r, w, "campaign_id")
+
+		// 🔍 Buscar campanha
+		campaign, err := h.campaignRepo.GetByID(r.Context(), campaignID)
+		if err != nil || campaign == nil {
+			utils.SendError(w, http.StatusNotFound, "Campanha não encontrada")
+			return
+		}
+
+		// Checar se é admin ou dono
+		if !middleware.IsAdminOrOwner(authAccount, campaign.AccountID) {
+			h.log.Warn("Apenas administradores podem buscar outras contas")
+			utils.SendError(w, http.StatusForbidden, "Apenas administradores podem buscar outras contas")
+			return
+		}
+
+		if campaign.Status == models.StatusCancelada {
+			utils.SendError(w, http.StatusConflict, "Campanhas canceladas não podem reenviar dead-letters")
+			return
+		}
+
+		audience, err := h.audienceRepo.RequeueDeadLetters(r.Context(), campaignID, retryDTO.AudienceIDs)
+		if err != nil {
+			h.log.Error("Erro ao reenfileirar dead-letters", "campaign_id", campaignID, "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao reenfileirar dead-letters")
+			return
+		}
+
+		// 🔁 Campanha concluída volta a "enviando" até os dead-letters serem processados
+		if len(audience) > 0 && (campaign.Status == models.StatusConcluida || campaign.Status == models.StatusConcluidaComFalhas) {
+			change := service.CampaignStatusChange{ChangedBy: &authAccount.ID, Source: models.StatusSourceAPI, Reason: "reprocessamento de dead-letters"}
+			if !h.transitionOrFail(w, r, campaign, models.StatusEnviando, change) {
+				return
+			}
+		}
+
+		if len(audience) > 0 {
+			// 🚀 Reenviar para a fila em segundo plano, como no disparo da campanha
+			go func() {
+				err := h.campaignProcessor.ProcessCampaign(context.WithoutCancel(r.Context()), campaign, audience)
+				if err != nil {
+					h.log.Error("Erro ao reenfileirar dead-letters", "campaign_id", campaignID, "error", err)
+				}
+			}()
+		}
+
+		h.log.Info("Dead-letters reenfileirados", "campaign_id", campaignID, "total", len(audience))
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]int{"requeued": len(audience)})
+	}
+}
@@ -3,7 +3,9 @@
 package handlers
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
 	"github.com/jeancarlosdanese/go-marketing/internal/middleware"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
 	"github.com/jeancarlosdanese/go-marketing/internal/utils"
 )
 
@@ -25,17 +28,25 @@ type CampaignSettingsHandler interface {
 }
 
 type campaignSettingsHandler struct {
-	log          *slog.Logger
-	campaignRepo db.CampaignRepository
-	settingsRepo db.CampaignSettingsRepository
+	log               *slog.Logger
+	campaignRepo      db.CampaignRepository
+	settingsRepo      db.CampaignSettingsRepository
+	senderIdentities  service.SenderIdentityService
+	assets            service.AssetService
+	chatRepo          db.ChatRepository
+	whatsappTemplates service.WhatsAppTemplateService
 }
 
 // NewCampaignSettingsHandler cria um novo handler
-func NewCampaignSettingsHandler(settingsRepo db.CampaignSettingsRepository, campaignRepo db.CampaignRepository) CampaignSettingsHandler {
+func NewCampaignSettingsHandler(settingsRepo db.CampaignSettingsRepository, campaignRepo db.CampaignRepository, senderIdentities service.SenderIdentityService, assets service.AssetService, chatRepo db.ChatRepository, whatsappTemplates service.WhatsAppTemplateService) CampaignSettingsHandler {
 	return &campaignSettingsHandler{
-		log:          logger.GetLogger(),
-		campaignRepo: campaignRepo,
-		settingsRepo: settingsRepo,
+		log:               logger.GetLogger(),
+		campaignRepo:      campaignRepo,
+		settingsRepo:      settingsRepo,
+		senderIdentities:  senderIdentities,
+		assets:            assets,
+		chatRepo:          chatRepo,
+		whatsappTemplates: whatsappTemplates,
 	}
 }
 
@@ -85,8 +96,15 @@ func (h *campaignSettingsHandler) CreateSettingsHandler() http.HandlerFunc {
 			return
 		}
 
+		// ✉️ Vincular o remetente verificado
+		settingsModel := requestDTO.ToModel()
+		if !h.resolveSenderOrFail(w, r, campaign, &settingsModel) || !h.attachmentsOrFail(w, r, campaign, settingsModel) ||
+			!h.whatsappChatOrFail(w, r, campaign, settingsModel) {
+			return
+		}
+
 		// 🚀 Criar configurações
-		settings, err := h.settingsRepo.CreateSettings(r.Context(), requestDTO.ToModel())
+		settings, err := h.settingsRepo.CreateSettings(r.Context(), settingsModel)
 		if err != nil {
 			h.log.Error("Erro ao criar configurações da campanha", "error", err)
 			utils.SendError(w, http.StatusInternalServerError, "Erro ao criar configurações")
@@ -139,6 +157,9 @@ func (h *campaignSettingsHandler) GetSettingsHandler() http.HandlerFunc {
 // ✅ Atualizar configurações de uma campanha
 func (h *campaignSettingsHandler) UpdateSettingsHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
 		campaignID := utils.GetUUIDFromRequestPath(r, w, "campaign_id")
 
 		var requestDTO dto.CampaignSettingsDTO
@@ -178,8 +199,29 @@ func (h *campaignSettingsHandler) UpdateSettingsHandler() http.HandlerFunc {
 			return
 		}
 
+		campaign, err := h.campaignRepo.GetByID(r.Context(), campaignID)
+		if err != nil {
+			h.log.Error("Erro ao buscar campanha", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar campanha")
+			return
+		}
+
+		// 🔍 Verificar se a campanha pertence à conta autenticada
+		if campaign == nil || campaign.AccountID != authAccount.ID {
+			h.log.Warn("Campanha não encontrada", "campaign_id", campaignID)
+			utils.SendError(w, http.StatusNotFound, "Campanha não encontrada")
+			return
+		}
+
+		// ✉️ Vincular o remetente verificado
+		settingsModel := requestDTO.ToModel()
+		if !h.resolveSenderOrFail(w, r, campaign, &settingsModel) || !h.attachmentsOrFail(w, r, campaign, settingsModel) ||
+			!h.whatsappChatOrFail(w, r, campaign, settingsModel) {
+			return
+		}
+
 		// 🚀 Atualizar configurações
-		settingsUpdated, err := h.settingsRepo.UpdateSettings(r.Context(), requestDTO.ToModel())
+		settingsUpdated, err := h.settingsRepo.UpdateSettings(r.Context(), settingsModel)
 		if err != nil {
 			h.log.Error("Erro ao atualizar configurações da campanha", "error", err)
 			utils.SendError(w, http.StatusInternalServerError, "Erro ao atualizar configurações")
@@ -230,23 +272,49 @@ func (h *campaignSettingsHandler) GetLastSettingsHandler() http.HandlerFunc {
 			return
 		}
 
+		campaign, err := h.campaignRepo.GetByID(r.Context(), campaignID)
+		if err != nil {
+			h.log.Error("Erro ao buscar campanha", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar campanha")
+			return
+		}
+		if campaign == nil || campaign.AccountID != authAccount.ID {
+			h.log.Warn("Campanha não encontrada", "campaign_id", campaignID)
+			utils.SendError(w, http.StatusNotFound, "Campanha não encontrada")
+			return
+		}
+
 		// Clone settings to DTO
 		settingsDTO := dto.CampaignSettingsDTO{
-			CampaignID:           campaignID,
-			Brand:                settings.Brand,
-			Subject:              settings.Subject,
-			Tone:                 settings.Tone,
-			EmailFrom:            settings.EmailFrom,
-			EmailReply:           settings.EmailReply,
-			EmailFooter:          settings.EmailFooter,
-			EmailInstructions:    settings.EmailInstructions,
-			WhatsAppFrom:         settings.WhatsAppFrom,
-			WhatsAppReply:        settings.WhatsAppReply,
-			WhatsAppFooter:       settings.WhatsAppFooter,
-			WhatsAppInstructions: settings.WhatsAppInstructions,
+			CampaignID:             campaignID,
+			Brand:                  settings.Brand,
+			Subject:                settings.Subject,
+			Tone:                   settings.Tone,
+			SenderIdentityID:       settings.SenderIdentityID,
+			EmailFrom:              settings.EmailFrom,
+			EmailReply:             settings.EmailReply,
+			EmailFooter:            settings.EmailFooter,
+			EmailInstructions:      settings.EmailInstructions,
+			EmailPreheader:         settings.EmailPreheader,
+			EmailHeaders:           settings.EmailHeaders,
+			EmailAttachments:       settings.EmailAttachments,
+			WhatsAppFrom:           settings.WhatsAppFrom,
+			WhatsAppReply:          settings.WhatsAppReply,
+			WhatsAppFooter:         settings.WhatsAppFooter,
+			WhatsAppInstructions:   settings.WhatsAppInstructions,
+			WhatsAppChatID:         settings.WhatsAppChatID,
+			WhatsAppTemplateID:     settings.WhatsAppTemplateID,
+			WhatsAppTemplateParams: settings.WhatsAppTemplateParams,
+		}
+
+		// ✉️ O remetente da configuração anterior pode ter perdido a verificação
+		settingsModel := settingsDTO.ToModel()
+		if !h.resolveSenderOrFail(w, r, campaign, &settingsModel) || !h.attachmentsOrFail(w, r, campaign, settingsModel) ||
+			!h.whatsappChatOrFail(w, r, campaign, settingsModel) {
+			return
 		}
 
-		settingsCreated, err := h.settingsRepo.CreateSettings(r.Context(), settingsDTO.ToModel())
+		settingsCreated, err := h.settingsRepo.CreateSettings(r.Context(), settingsModel)
 		if err != nil {
 			h.log.Error("Erro ao criar configurações da campanha", "error", err)
 			utils.SendError(w, http.StatusInternalServerError, "Erro ao criar configurações")
@@ -257,3 +325,92 @@ func (h *campaignSettingsHandler) GetLastSettingsHandler() http.HandlerFunc {
 		utils.SendSuccess(w, http.StatusOK, settingsCreated)
 	}
 }
+
+// resolveSenderOrFail vincula as configurações ao remetente verificado da conta que cobre o email_from
+// e completa o reply-to padrão. Campanhas sem canal de e-mail não exigem remetente verificado.
+func (h *campaignSettingsHandler) resolveSenderOrFail(w http.ResponseWriter, r *http.Request, campaign *models.Campaign, settings *models.CampaignSettings) bool {
+	_, requiresSender := campaign.Channels["email"]
+
+	sender, err := h.senderIdentities.Resolve(r.Context(), campaign.AccountID, *settings)
+	switch {
+	case errors.Is(err, service.ErrSenderNotVerified) && !requiresSender:
+		settings.SenderIdentityID = nil
+	case errors.Is(err, service.ErrSenderNotVerified):
+		h.log.Warn("Remetente não verificado", "campaign_id", campaign.ID, "email_from", settings.EmailFrom, "error", err)
+		utils.SendError(w, http.StatusUnprocessableEntity, "email_from precisa de um remetente verificado da conta: "+err.Error())
+		return false
+	case err != nil:
+		h.log.Error("Erro ao buscar remetente", "campaign_id", campaign.ID, "error", err)
+		utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar remetente")
+		return false
+	default:
+		settings.SenderIdentityID = &sender.ID
+		if settings.EmailReply == "" && sender.ReplyTo != nil {
+			settings.EmailReply = *sender.ReplyTo
+		}
+	}
+
+	if settings.EmailReply == "" {
+		settings.EmailReply = settings.EmailFrom
+	}
+	return true
+}
+
+// attachmentsOrFail confere se os anexos pertencem à conta e cabem no limite de um e-mail
+func (h *campaignSettingsHandler) attachmentsOrFail(w http.ResponseWriter, r *http.Request, campaign *models.Campaign, settings models.CampaignSettings) bool {
+	err := h.assets.ValidateAttachments(r.Context(), campaign.AccountID, settings.EmailAttachments)
+	switch {
+	case errors.Is(err, service.ErrAssetNotFound), errors.Is(err, service.ErrInvalidAsset):
+		h.log.Warn("Anexos inválidos", "campaign_id", campaign.ID, "error", err)
+		utils.SendError(w, http.StatusUnprocessableEntity, "email_attachments: "+err.Error())
+		return false
+	case err != nil:
+		h.log.Error("Erro ao validar anexos", "campaign_id", campaign.ID, "error", err)
+		utils.SendError(w, http.StatusInternalServerError, "Erro ao validar anexos")
+		return false
+	}
+	return true
+}
+
+// whatsappChatOrFail confere se o chat escolhido para o envio pelo WhatsApp é um chat ativo da conta
+// e, nos chats da Cloud API, se o template e as variáveis configuradas podem ser enviados
+func (h *campaignSettingsHandler) whatsappChatOrFail(w http.ResponseWriter, r *http.Request, campaign *models.Campaign, settings models.CampaignSettings) bool {
+	if settings.WhatsAppChatID == nil {
+		return true // ✅ Validado ao enviar: sem chat, a campanha não sai pelo WhatsApp
+	}
+
+	chat, err := h.chatRepo.GetActiveByID(r.Context(), campaign.AccountID, *settings.WhatsAppChatID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		h.log.Warn("Chat do WhatsApp não encontrado", "campaign_id", campaign.ID, "chat_id", *settings.WhatsAppChatID)
+		utils.SendError(w, http.StatusUnprocessableEntity, "whatsapp_chat_id: chat não encontrado ou inativo")
+		return false
+	case err != nil:
+		h.log.Error("Erro ao buscar chat do WhatsApp", "campaign_id", campaign.ID, "error", err)
+		utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar chat do WhatsApp")
+		return false
+	case chat.Department == models.ChatDepartmentEmail || chat.InstanceName == "":
+		h.log.Warn("Chat sem sessão do WhatsApp", "campaign_id", campaign.ID, "chat_id", chat.ID)
+		utils.SendError(w, http.StatusUnprocessableEntity, "whatsapp_chat_id: o chat não possui sessão do WhatsApp")
+		return false
+	case chat.Provider != models.WhatsAppProviderCloudAPI && settings.WhatsAppTemplateID != nil:
+		utils.SendError(w, http.StatusUnprocessableEntity, "whatsapp_template_id só se aplica a chats da Cloud API")
+		return false
+	case chat.Provider != models.WhatsAppProviderCloudAPI:
+		return true
+	}
+
+	// 🧾 Na Cloud API a campanha só sai com template aprovado da WABA do número
+	_, err = h.whatsappTemplates.Resolve(r.Context(), campaign.AccountID, chat, settings)
+	switch {
+	case errors.Is(err, service.ErrWhatsAppTemplateInvalid):
+		h.log.Warn("Template do WhatsApp inválido", "campaign_id", campaign.ID, "chat_id", chat.ID, "error", err)
+		utils.SendError(w, http.StatusUnprocessableEntity, err.Error())
+		return false
+	case err != nil:
+		h.log.Error("Erro ao validar template do WhatsApp", "campaign_id", campaign.ID, "error", err)
+		utils.SendError(w, http.StatusInternalServerError, "Erro ao validar template do WhatsApp")
+		return false
+	}
+	return true
+}
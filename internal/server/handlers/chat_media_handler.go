@@ -0,0 +1,118 @@
+// File: /internal/server/handlers/chat_media_handler.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/middleware"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+type ChatMediaHandle interface {
+	UploadChatMediaHandler() http.HandlerFunc
+	ServeChatMediaHandler() http.HandlerFunc
+}
+
+type chatMediaHandle struct {
+	log   *slog.Logger
+	media service.ChatMediaService
+}
+
+func NewChatMediaHandle(media service.ChatMediaService) ChatMediaHandle {
+	return &chatMediaHandle{
+		log:   logger.GetLogger(),
+		media: media,
+	}
+}
+
+// UploadChatMediaHandler guarda a mídia que o atendente vai enviar; a URL retornada é o file_url da mensagem
+func (h *chatMediaHandle) UploadChatMediaHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		// 📏 Margem de 1 MB para os cabeçalhos do formulário
+		r.Body = http.MaxBytesReader(w, r.Body, h.media.MaxSize()+1<<20)
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			h.log.Warn("Erro ao ler mídia enviada", "error", err)
+			utils.SendError(w, http.StatusBadRequest, "Arquivo ausente ou maior que o permitido")
+			return
+		}
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		if err != nil {
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao processar arquivo")
+			return
+		}
+
+		// 🔹 O tipo da mídia vem da extensão do arquivo (image, video, audio ou document)
+		mediaType := service.ChatMediaTypeForFile(header.Filename)
+		media, err := h.media.Store(r.Context(), authAccount.ID, mediaType, header.Filename, header.Header.Get("Content-Type"), content)
+		if err != nil {
+			sendChatMediaError(w, h.log, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(media)
+	}
+}
+
+// ServeChatMediaHandler serve a mídia pela URL assinada e dentro da validade (usada direto nas tags <img>, <audio> e <video> do chat)
+func (h *chatMediaHandle) ServeChatMediaHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		media, content, err := h.media.Open(r.Context(), r.PathValue("key"), query.Get("exp"), query.Get("sig"))
+		if err != nil {
+			if errors.Is(err, service.ErrChatMediaNotFound) {
+				http.NotFound(w, r)
+				return
+			}
+			h.log.Error("Erro ao ler mídia do atendimento", "key", r.PathValue("key"), "error", err)
+			http.Error(w, "Erro interno", http.StatusInternalServerError)
+			return
+		}
+
+		disposition := "attachment"
+		if media.Inline() {
+			disposition = "inline"
+		}
+
+		w.Header().Set("Content-Type", media.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": media.FileName}))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; sandbox")
+		// 🔒 A chave muda a cada arquivo; o cache não passa da validade da URL
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d, immutable", int(time.Until(media.ExpiresAt).Seconds())))
+		w.WriteHeader(http.StatusOK)
+		w.Write(content)
+	}
+}
+
+// sendChatMediaError responde 503 sem armazenamento configurado e 400 para mídias inválidas
+func sendChatMediaError(w http.ResponseWriter, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, service.ErrChatMediaDisabled):
+		utils.SendError(w, http.StatusServiceUnavailable, err.Error())
+	case errors.Is(err, service.ErrInvalidChatMedia):
+		utils.SendError(w, http.StatusBadRequest, err.Error())
+	default:
+		log.Error("Erro ao armazenar mídia do atendimento", "error", err)
+		utils.SendError(w, http.StatusInternalServerError, "Erro ao armazenar mídia")
+	}
+}
@@ -3,7 +3,9 @@
 package handlers
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 
@@ -185,6 +187,10 @@ func (h *chatWhatsAppHandler) RegistrarMensagem() http.HandlerFunc {
 		}
 
 		msg, err := h.chatWhatsAppService.RegistrarMensagemManual(ctx, auth.ID, chatID, chatContactID, req)
+		if errors.Is(err, service.ErrInvalidChatMedia) || errors.Is(err, service.ErrChatMediaDisabled) {
+			sendChatMediaError(w, h.log, err)
+			return
+		}
 		if err != nil {
 			utils.SendError(w, 500, "Erro ao registrar mensagem")
 			return
@@ -204,6 +210,10 @@ func (h *chatWhatsAppHandler) ListarMensagens() http.HandlerFunc {
 		chatContactID := utils.GetUUIDFromRequestPath(r, w, "chat_contact_id")
 
 		mensagens, err := h.chatWhatsAppService.ListarMensagens(ctx, auth.ID, chatID, chatContactID)
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.SendError(w, 404, "Contato de chat não encontrado")
+			return
+		}
 		if err != nil {
 			utils.SendError(w, 500, "Erro ao listar mensagens")
 			return
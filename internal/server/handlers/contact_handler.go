@@ -14,6 +14,7 @@ import (
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
 	"github.com/jeancarlosdanese/go-marketing/internal/middleware"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
 	"github.com/jeancarlosdanese/go-marketing/internal/utils"
 )
 
@@ -27,14 +28,16 @@ type ContactHandle interface {
 }
 
 type contactHandle struct {
-	log         *slog.Logger
-	contactRepo db.ContactRepository
+	log            *slog.Logger
+	contactRepo    db.ContactRepository
+	emailValidator service.EmailValidationService
 }
 
-func NewContactHandle(contactRepo db.ContactRepository) ContactHandle {
+func NewContactHandle(contactRepo db.ContactRepository, emailValidator service.EmailValidationService) ContactHandle {
 	return &contactHandle{
-		log:         logger.GetLogger(),
-		contactRepo: contactRepo,
+		log:            logger.GetLogger(),
+		contactRepo:    contactRepo,
+		emailValidator: emailValidator,
 	}
 }
 
@@ -92,6 +95,11 @@ func (h *contactHandle) CreateContactHandler() http.HandlerFunc {
 			contact.BirthDate = &birthDate
 		}
 
+		// ✉️ Verificar o e-mail (sintaxe, domínio descartável, conta de função e MX)
+		if !h.verifyEmailOrFail(w, r, contact) {
+			return
+		}
+
 		// 📌 Criar contato no banco de dados
 		createdContact, err := h.contactRepo.Create(r.Context(), contact)
 		if err != nil {
@@ -140,7 +148,7 @@ func (h *contactHandle) GetPaginatedContactsHandler() http.HandlerFunc {
 
 		// Capturar filtros dinâmicos
 		filters := map[string]string{}
-		for _, key := range []string{"name", "email", "whatsapp", "gender", "birth_date_start", "birth_date_end", "bairro", "cidade", "estado", "interesses", "perfil", "eventos"} {
+		for _, key := range []string{"name", "email", "email_status", "whatsapp", "gender", "birth_date_start", "birth_date_end", "bairro", "cidade", "estado", "interesses", "perfil", "eventos"} {
 			if value := r.URL.Query().Get(key); value != "" {
 				filters[key] = value
 			}
@@ -175,7 +183,7 @@ func (h *contactHandle) GetAllContactsHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
 
-		filters := utils.ExtractQueryFilters(r.URL.Query(), []string{"name", "email", "whatsapp", "cidade", "estado", "bairro", "tags", "interesses", "perfil", "eventos"})
+		filters := utils.ExtractQueryFilters(r.URL.Query(), []string{"name", "email", "email_status", "whatsapp", "cidade", "estado", "bairro", "tags", "interesses", "perfil", "eventos"})
 		contacts, err := h.contactRepo.GetByAccountID(r.Context(), authAccount.ID, filters)
 		if err != nil {
 			h.log.Error("Erro ao buscar contatos", "error", err)
@@ -267,7 +275,7 @@ func (h *contactHandle) UpdateContactHandler() http.HandlerFunc {
 			contact.Name = *contactDTO.Name
 		}
 		if contactDTO.Email != nil {
-			contact.Email = utils.NormalizeEmail(contact.Email)
+			contact.Email = utils.NormalizeEmail(contactDTO.Email)
 		}
 		if contactDTO.WhatsApp != nil {
 			normalizedWhatsApp := utils.NormalizeWhatsAppNumber(*contactDTO.WhatsApp)
@@ -302,6 +310,11 @@ func (h *contactHandle) UpdateContactHandler() http.HandlerFunc {
 			contact.History = contactDTO.History
 		}
 
+		// ✉️ Verificar o e-mail informado (o status anterior não vale para um endereço novo)
+		if contactDTO.Email != nil && !h.verifyEmailOrFail(w, r, contact) {
+			return
+		}
+
 		// 📌 Salvar atualização
 		updatedContact, err := h.contactRepo.UpdateByID(r.Context(), contactID, contact)
 		if err != nil {
@@ -351,3 +364,23 @@ func (h *contactHandle) DeleteContactHandler() http.HandlerFunc {
 		w.WriteHeader(http.StatusNoContent)
 	}
 }
+
+// verifyEmailOrFail normaliza e classifica o e-mail do contato, recusando endereços que não recebem mensagens
+func (h *contactHandle) verifyEmailOrFail(w http.ResponseWriter, r *http.Request, contact *models.Contact) bool {
+	if contact.Email == nil {
+		contact.EmailStatus, contact.EmailStatusReason = nil, nil
+		return true
+	}
+
+	validation := h.emailValidator.Validate(r.Context(), *contact.Email)
+	if validation.Status == models.EmailStatusInvalid {
+		h.log.Warn("E-mail recusado na verificação", slog.String("email", *contact.Email), slog.String("reason", validation.Reason))
+		utils.SendError(w, http.StatusUnprocessableEntity, "e-mail inválido: "+validation.Message())
+		return false
+	}
+
+	contact.Email = &validation.Email
+	contact.EmailStatus = &validation.Status
+	contact.EmailStatusReason = validation.ReasonPtr()
+	return true
+}
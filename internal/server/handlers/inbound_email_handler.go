@@ -0,0 +1,156 @@
+// File: /internal/server/handlers/inbound_email_handler.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/middleware"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+type InboundEmailHandle interface {
+	GetInboundEmailsHandler() http.HandlerFunc
+	UploadInboundEmailHandler() http.HandlerFunc
+	HandleSESInboundEmail() http.HandlerFunc
+}
+
+type inboundEmailHandle struct {
+	log           *slog.Logger
+	repo          db.InboundEmailRepository
+	inboundEmails service.InboundEmailService
+	snsVerifier   service.SNSVerifier
+}
+
+func NewInboundEmailHandle(repo db.InboundEmailRepository, inboundEmails service.InboundEmailService, snsVerifier service.SNSVerifier) InboundEmailHandle {
+	return &inboundEmailHandle{
+		log:           logger.GetLogger(),
+		repo:          repo,
+		inboundEmails: inboundEmails,
+		snsVerifier:   snsVerifier,
+	}
+}
+
+// GetInboundEmailsHandler lista os e-mails recebidos da conta (filtros: contact_id, campaign_id, from)
+func (h *inboundEmailHandle) GetInboundEmailsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		filters := utils.ExtractQueryFilters(r.URL.Query(), []string{"contact_id", "campaign_id", "from"})
+		page, perPage, _ := utils.ExtractPaginationParams(r)
+
+		paginator, err := h.repo.GetPaginated(r.Context(), authAccount.ID, filters, page, perPage)
+		if err != nil {
+			h.log.Error("Erro ao buscar e-mails recebidos", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar e-mails recebidos")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(paginator)
+	}
+}
+
+// UploadInboundEmailHandler registra um e-mail enviado como MIME bruto no corpo (message/rfc822), para uso local
+func (h *inboundEmailHandle) UploadInboundEmailHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, service.MaxInboundEmailSize))
+		if err != nil {
+			h.log.Warn("Erro ao ler e-mail enviado", "error", err)
+			utils.SendError(w, http.StatusBadRequest, "E-mail inválido ou maior que o permitido")
+			return
+		}
+
+		inbound, err := h.inboundEmails.Ingest(r.Context(), raw, models.InboundEmailSourceUpload, &authAccount.ID)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidInboundEmail):
+				utils.SendError(w, http.StatusBadRequest, err.Error())
+			case errors.Is(err, service.ErrInboundEmailUnmatched):
+				utils.SendError(w, http.StatusUnprocessableEntity, err.Error())
+			default:
+				h.log.Error("Erro ao registrar e-mail recebido", "error", err)
+				utils.SendError(w, http.StatusInternalServerError, "Erro ao registrar e-mail recebido")
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(inbound)
+	}
+}
+
+// HandleSESInboundEmail recebe do SNS as notificações da receipt rule do SES (conteúdo na mensagem ou no S3)
+func (h *inboundEmailHandle) HandleSESInboundEmail() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSNSBodySize))
+		if err != nil {
+			h.log.Error("❌ Erro ao ler o body", "error", err)
+			utils.SendError(w, http.StatusBadRequest, "Bad Request")
+			return
+		}
+
+		var envelope service.SNSEnvelope
+		if err := json.Unmarshal(bodyBytes, &envelope); err != nil {
+			h.log.Error("❌ Erro ao decodificar mensagem do SNS", "error", err)
+			utils.SendError(w, http.StatusBadRequest, "Bad Request")
+			return
+		}
+
+		// 🔐 Só aceita mensagens assinadas pelo SNS (endpoint público)
+		if err := h.snsVerifier.Verify(r.Context(), &envelope); err != nil {
+			if !errors.Is(err, service.ErrInvalidSNSSignature) {
+				h.log.Error("❌ Erro ao verificar assinatura SNS", "error", err)
+				utils.SendError(w, http.StatusServiceUnavailable, "Não foi possível verificar a assinatura")
+				return
+			}
+			h.log.Warn("🚫 Mensagem SNS rejeitada", "topic_arn", envelope.TopicArn, "error", err)
+			utils.SendError(w, http.StatusForbidden, "Assinatura SNS inválida")
+			return
+		}
+
+		switch envelope.Type {
+		case service.SNSTypeSubscriptionConfirmation:
+			h.log.Info("🔔 Recebido evento de inscrição SNS", "topic_arn", envelope.TopicArn)
+			if err := h.snsVerifier.ConfirmSubscription(r.Context(), &envelope); err != nil {
+				h.log.Error("❌ Erro ao confirmar inscrição SNS", "error", err)
+				utils.SendError(w, http.StatusBadGateway, "Erro ao confirmar inscrição")
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		case service.SNSTypeUnsubscribeConfirmation:
+			h.log.Warn("⚠️ Inscrição SNS cancelada", "topic_arn", envelope.TopicArn)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if _, err := h.inboundEmails.IngestSESNotification(r.Context(), []byte(envelope.Message)); err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidInboundEmail):
+				h.log.Warn("⚠️ E-mail recebido do SES descartado", "error", err)
+				utils.SendError(w, http.StatusBadRequest, "Bad Request")
+			case errors.Is(err, service.ErrInboundEmailUnmatched):
+				w.WriteHeader(http.StatusOK) // ✅ Nada a reprocessar: o e-mail não pertence a nenhuma campanha ou contato
+			default:
+				h.log.Error("❌ Erro ao registrar e-mail recebido do SES", "error", err)
+				http.Error(w, "Erro interno", http.StatusInternalServerError) // 🔄 O SNS reenvia a notificação
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,130 @@
+// File: /internal/server/handlers/send_policy_handler.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/middleware"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+type SendPolicyHandle interface {
+	GetSendPoliciesHandler() http.HandlerFunc
+	UpsertSendPolicyHandler() http.HandlerFunc
+	DeleteSendPolicyHandler() http.HandlerFunc
+}
+
+type sendPolicyHandle struct {
+	log  *slog.Logger
+	repo db.SendPolicyRepository
+}
+
+func NewSendPolicyHandle(repo db.SendPolicyRepository) SendPolicyHandle {
+	return &sendPolicyHandle{
+		log:  logger.GetLogger(),
+		repo: repo,
+	}
+}
+
+// GetSendPoliciesHandler lista as janelas de envio da conta autenticada
+func (h *sendPolicyHandle) GetSendPoliciesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		policies, err := h.repo.GetByAccountID(r.Context(), authAccount.ID)
+		if err != nil {
+			h.log.Error("Erro ao buscar janelas de envio", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar janelas de envio")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(policies)
+	}
+}
+
+// UpsertSendPolicyHandler cria ou substitui a janela de envio de um canal
+func (h *sendPolicyHandle) UpsertSendPolicyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var policyDTO dto.SendPolicyUpsertDTO
+
+		// Decodifica JSON
+		if err := json.NewDecoder(r.Body).Decode(&policyDTO); err != nil {
+			h.log.Warn("Erro ao decodificar JSON", "error", err)
+			utils.SendError(w, http.StatusBadRequest, "Erro ao processar requisição")
+			return
+		}
+		defer r.Body.Close()
+
+		channel, ok := parseChannel(r.PathValue("channel"))
+		if !ok {
+			utils.SendError(w, http.StatusBadRequest, "Canal inválido, deve ser 'email' ou 'whatsapp'")
+			return
+		}
+
+		// Validar DTO
+		if err := policyDTO.Validate(); err != nil {
+			h.log.Warn("Erro de validação", "error", err.Error())
+			utils.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		policy := policyDTO.ToModel(channel)
+		policy.AccountID = authAccount.ID
+
+		saved, err := h.repo.Upsert(r.Context(), policy)
+		if err != nil {
+			h.log.Error("Erro ao salvar janela de envio", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao salvar janela de envio")
+			return
+		}
+
+		h.log.Info("Janela de envio atualizada", "account_id", authAccount.ID, "channel", channel)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(saved)
+	}
+}
+
+// DeleteSendPolicyHandler remove a janela de envio de um canal
+func (h *sendPolicyHandle) DeleteSendPolicyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel, ok := parseChannel(r.PathValue("channel"))
+		if !ok {
+			utils.SendError(w, http.StatusBadRequest, "Canal inválido, deve ser 'email' ou 'whatsapp'")
+			return
+		}
+
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		if err := h.repo.Delete(r.Context(), authAccount.ID, string(channel)); err != nil {
+			h.log.Error("Erro ao remover janela de envio", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao remover janela de envio")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Janela de envio removida com sucesso"})
+	}
+}
+
+// parseChannel valida o canal informado na URL
+func parseChannel(channel string) (models.ChannelType, bool) {
+	for _, allowed := range models.AllowedChannels {
+		if string(allowed) == channel {
+			return allowed, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,239 @@
+// File: /internal/server/handlers/sender_identity_handler.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/middleware"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+type SenderIdentityHandle interface {
+	GetSenderIdentitiesHandler() http.HandlerFunc
+	GetSenderIdentityHandler() http.HandlerFunc
+	CreateSenderIdentityHandler() http.HandlerFunc
+	UpdateSenderIdentityHandler() http.HandlerFunc
+	RefreshSenderIdentityHandler() http.HandlerFunc
+	DeleteSenderIdentityHandler() http.HandlerFunc
+}
+
+type senderIdentityHandle struct {
+	log              *slog.Logger
+	repo             db.SenderIdentityRepository
+	senderIdentities service.SenderIdentityService
+}
+
+func NewSenderIdentityHandle(repo db.SenderIdentityRepository, senderIdentities service.SenderIdentityService) SenderIdentityHandle {
+	return &senderIdentityHandle{
+		log:              logger.GetLogger(),
+		repo:             repo,
+		senderIdentities: senderIdentities,
+	}
+}
+
+// GetSenderIdentitiesHandler lista os remetentes da conta
+func (h *senderIdentityHandle) GetSenderIdentitiesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		identities, err := h.repo.GetByAccountID(r.Context(), authAccount.ID)
+		if err != nil {
+			h.log.Error("Erro ao buscar remetentes", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar remetentes")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(identities)
+	}
+}
+
+// GetSenderIdentityHandler retorna um remetente da conta
+func (h *senderIdentityHandle) GetSenderIdentityHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := h.getOwnedSenderIdentity(w, r)
+		if !ok {
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(identity)
+	}
+}
+
+// CreateSenderIdentityHandler cadastra um remetente e solicita a verificação no provedor da conta
+func (h *senderIdentityHandle) CreateSenderIdentityHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var identityDTO dto.SenderIdentityCreateDTO
+
+		// Decodifica JSON
+		if err := json.NewDecoder(r.Body).Decode(&identityDTO); err != nil {
+			h.log.Warn("Erro ao decodificar JSON", "error", err)
+			utils.SendError(w, http.StatusBadRequest, "Erro ao processar requisição")
+			return
+		}
+		defer r.Body.Close()
+
+		// Validar DTO
+		if err := identityDTO.Validate(); err != nil {
+			h.log.Warn("Erro de validação", "error", err.Error())
+			utils.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		identity := identityDTO.ToModel()
+		identity.AccountID = authAccount.ID
+
+		// 🔁 Cada identidade é cadastrada uma única vez por conta
+		existing, err := h.repo.GetByAccountID(r.Context(), authAccount.ID)
+		if err != nil {
+			h.log.Error("Erro ao buscar remetentes", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar remetentes")
+			return
+		}
+		for _, current := range existing {
+			if current.Identity == identity.Identity {
+				utils.SendError(w, http.StatusConflict, "Remetente já cadastrado")
+				return
+			}
+		}
+
+		saved, err := h.senderIdentities.Create(r.Context(), identity)
+		if err != nil {
+			if saved == nil {
+				h.log.Error("Erro ao salvar remetente", "error", err)
+				utils.SendError(w, http.StatusInternalServerError, "Erro ao salvar remetente")
+				return
+			}
+
+			// ⚠️ Salvo como pendente: a verificação é solicitada novamente em /refresh
+			h.log.Warn("Remetente salvo, mas a verificação falhou", "sender_identity_id", saved.ID, "error", err)
+			utils.SendError(w, http.StatusBadGateway, "Remetente salvo como pendente, mas a verificação no provedor falhou: "+err.Error())
+			return
+		}
+
+		h.log.Info("✉️ Remetente cadastrado", "account_id", authAccount.ID, "identity", saved.Identity, "status", saved.Status)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(saved)
+	}
+}
+
+// UpdateSenderIdentityHandler altera nome de exibição, reply-to padrão e Configuration Set do remetente
+func (h *senderIdentityHandle) UpdateSenderIdentityHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := h.getOwnedSenderIdentity(w, r)
+		if !ok {
+			return
+		}
+
+		var identityDTO dto.SenderIdentityUpdateDTO
+
+		// Decodifica JSON
+		if err := json.NewDecoder(r.Body).Decode(&identityDTO); err != nil {
+			h.log.Warn("Erro ao decodificar JSON", "error", err)
+			utils.SendError(w, http.StatusBadRequest, "Erro ao processar requisição")
+			return
+		}
+		defer r.Body.Close()
+
+		// Validar DTO
+		if err := identityDTO.Validate(); err != nil {
+			h.log.Warn("Erro de validação", "error", err.Error())
+			utils.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		identityDTO.ApplyUpdate(identity)
+
+		updated, err := h.repo.Update(r.Context(), identity)
+		if err != nil {
+			h.log.Error("Erro ao atualizar remetente", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao atualizar remetente")
+			return
+		}
+
+		h.log.Info("✅ Remetente atualizado", "sender_identity_id", updated.ID)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(updated)
+	}
+}
+
+// RefreshSenderIdentityHandler consulta o provedor e atualiza o estado de verificação do remetente
+func (h *senderIdentityHandle) RefreshSenderIdentityHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := h.getOwnedSenderIdentity(w, r)
+		if !ok {
+			return
+		}
+
+		updated, err := h.senderIdentities.Refresh(r.Context(), identity)
+		if err != nil {
+			h.log.Error("Erro ao consultar verificação do remetente", "sender_identity_id", identity.ID, "error", err)
+			utils.SendError(w, http.StatusBadGateway, "Erro ao consultar verificação no provedor: "+err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(updated)
+	}
+}
+
+// DeleteSenderIdentityHandler remove o remetente (campanhas que o usam não poderão ser iniciadas)
+func (h *senderIdentityHandle) DeleteSenderIdentityHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := h.getOwnedSenderIdentity(w, r)
+		if !ok {
+			return
+		}
+
+		if err := h.repo.DeleteByID(r.Context(), identity.ID); err != nil {
+			h.log.Error("Erro ao remover remetente", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao remover remetente")
+			return
+		}
+
+		h.log.Info("✅ Remetente removido", "sender_identity_id", identity.ID, "identity", identity.Identity)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Remetente removido com sucesso"})
+	}
+}
+
+// getOwnedSenderIdentity busca o remetente da URL e garante que pertence à conta autenticada (ou admin)
+func (h *senderIdentityHandle) getOwnedSenderIdentity(w http.ResponseWriter, r *http.Request) (*models.SenderIdentity, bool) {
+	// 🔍 Buscar conta autenticada
+	authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+	identityID := utils.GetUUIDFromRequestPath(r, w, "sender_identity_id")
+
+	identity, err := h.repo.GetByID(r.Context(), identityID)
+	if err != nil {
+		h.log.Error("Erro ao buscar remetente", "sender_identity_id", identityID, "error", err)
+		utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar remetente")
+		return nil, false
+	}
+	if identity == nil {
+		utils.SendError(w, http.StatusNotFound, "Remetente não encontrado")
+		return nil, false
+	}
+
+	// Checar se é admin ou dono
+	if !middleware.IsAdminOrOwner(authAccount, identity.AccountID) {
+		h.log.Warn("Conta tentou acessar remetente de outra conta", "account_id", authAccount.ID, "sender_identity_id", identityID)
+		utils.SendError(w, http.StatusForbidden, "Apenas administradores podem acessar remetentes de outras contas")
+		return nil, false
+	}
+
+	return identity, true
+}
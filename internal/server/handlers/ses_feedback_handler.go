@@ -3,194 +3,99 @@
 package handlers
 
 import (
-	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
 	"net/http"
 
-	"github.com/jeancarlosdanese/go-marketing/internal/db"
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
 	"github.com/jeancarlosdanese/go-marketing/internal/utils"
 )
 
 // SESFeedbackHandler define a interface para o handler
 type SESFeedbackHandler interface {
 	HandleSESFeedback() http.HandlerFunc
-	HandleSNSEvent() http.HandlerFunc
 }
 
 // sesFeedbackHandler estrutura que lida com feedback do SES
 type sesFeedbackHandler struct {
-	log          *slog.Logger
-	audienceRepo db.CampaignAudienceRepository
-	contactRepo  db.ContactRepository
+	log         *slog.Logger
+	sesEvents   service.SESEventService
+	snsVerifier service.SNSVerifier
 }
 
 // NewSESFeedbackHandler cria um novo handler para processar feedback do SES
-func NewSESFeedbackHandler(audienceRepo db.CampaignAudienceRepository, contactRepo db.ContactRepository) SESFeedbackHandler {
+func NewSESFeedbackHandler(sesEvents service.SESEventService, snsVerifier service.SNSVerifier) SESFeedbackHandler {
 	log := logger.GetLogger()
-	return &sesFeedbackHandler{log: log, contactRepo: contactRepo, audienceRepo: audienceRepo}
+	return &sesFeedbackHandler{log: log, sesEvents: sesEvents, snsVerifier: snsVerifier}
 }
 
-// SNSMessage estrutura para decodificar eventos do SNS
-type SNSMessage struct {
-	Message string `json:"Message"`
-}
-
-// SESNotification estrutura do evento SES
-type SESNotification struct {
-	EventType string `json:"eventType"`
-	Mail      struct {
-		MessageID string `json:"messageId"`
-	} `json:"mail"`
-	Bounce struct {
-		BounceType string `json:"bounceType,omitempty"`
-	} `json:"bounce,omitempty"`
-	Complaint struct {
-		ComplaintFeedbackType string `json:"complaintFeedbackType,omitempty"`
-	} `json:"complaint,omitempty"`
-}
+// maxSNSBodySize limita o corpo aceito no endpoint público (mensagens SNS têm no máximo 256 KB)
+const maxSNSBodySize = 512 << 10
 
 // HandleSESFeedback processa eventos do SNS com notificações do SES
 func (h *sesFeedbackHandler) HandleSESFeedback() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Lê todo o body
-		bodyBytes, err := io.ReadAll(r.Body)
+		bodyBytes, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSNSBodySize))
 		if err != nil {
 			h.log.Error("❌ Erro ao ler o body", "error", err)
 			utils.SendError(w, http.StatusBadRequest, "Bad Request")
 			return
 		}
-		// Restaura o Body para uso posterior
-		r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-
-		// Verifica se é uma solicitação de inscrição e, se for, confirma e retorna
-		if h.verifySubscribeURL(bodyBytes) {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
 
-		h.log.Info("🔔 Recebendo evento SNS do SES...")
-
-		var snsMessage SNSMessage
-		if err := json.Unmarshal(bodyBytes, &snsMessage); err != nil {
+		var envelope service.SNSEnvelope
+		if err := json.Unmarshal(bodyBytes, &envelope); err != nil {
 			h.log.Error("❌ Erro ao decodificar mensagem do SNS", "error", err)
 			utils.SendError(w, http.StatusBadRequest, "Bad Request")
 			return
 		}
 
-		var sesEvent SESNotification
-		if err := json.Unmarshal([]byte(snsMessage.Message), &sesEvent); err != nil {
-			h.log.Error("❌ Erro ao decodificar evento do SES", "error", err)
-			http.Error(w, "Bad Request", http.StatusBadRequest)
-			return
-		}
-
-		status, feedback := mapSESEventToAudienceStatus(sesEvent)
-
-		h.log.Info("📩 Evento SES recebido", "event", sesEvent.EventType, "message_id", sesEvent.Mail.MessageID)
-
-		err = h.audienceRepo.UpdateStatusByMessageID(r.Context(), sesEvent.Mail.MessageID, status, feedback)
-		if err != nil {
-			h.log.Error("❌ Erro ao atualizar status no banco", "error", err)
-			http.Error(w, "Erro interno", http.StatusInternalServerError)
-			return
-		}
-
-		h.log.Info("✅ Status atualizado com sucesso!", "message_id", sesEvent.Mail.MessageID, "status", status)
-		w.WriteHeader(http.StatusOK)
-	}
-}
-
-// HandleSNSEvent processa eventos do SNS com notificações do SES
-func (h *sesFeedbackHandler) HandleSNSEvent() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// Decodifica a mensagem do SNS
-		var body map[string]interface{}
-		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-			h.log.Error("❌ Erro ao decodificar mensagem do SNS", "error", err)
-			utils.SendError(w, http.StatusBadRequest, "Bad Request")
+		// 🔐 Só aceita mensagens assinadas pelo SNS (endpoint público)
+		if err := h.snsVerifier.Verify(r.Context(), &envelope); err != nil {
+			if !errors.Is(err, service.ErrInvalidSNSSignature) {
+				h.log.Error("❌ Erro ao verificar assinatura SNS", "error", err)
+				utils.SendError(w, http.StatusServiceUnavailable, "Não foi possível verificar a assinatura")
+				return
+			}
+			h.log.Warn("🚫 Mensagem SNS rejeitada", "topic_arn", envelope.TopicArn, "error", err)
+			utils.SendError(w, http.StatusForbidden, "Assinatura SNS inválida")
 			return
 		}
 
-		// Verificar se a mensagem é uma solicitação de assinatura
-		if subscribeURL, ok := body["SubscribeURL"].(string); ok {
-			h.log.Info("🔔 Recebido evento de inscrição SNS", "subscribe_url", subscribeURL)
-
-			// Acesse a URL para confirmar a assinatura automaticamente
-			resp, err := http.Get(subscribeURL)
-			if err != nil {
+		switch envelope.Type {
+		case service.SNSTypeSubscriptionConfirmation:
+			h.log.Info("🔔 Recebido evento de inscrição SNS", "topic_arn", envelope.TopicArn)
+			if err := h.snsVerifier.ConfirmSubscription(r.Context(), &envelope); err != nil {
 				h.log.Error("❌ Erro ao confirmar inscrição SNS", "error", err)
-				w.WriteHeader(http.StatusInternalServerError)
+				utils.SendError(w, http.StatusBadGateway, "Erro ao confirmar inscrição")
 				return
 			}
-			defer resp.Body.Close()
-
-			h.log.Info("✅ Inscrição no SNS confirmada com sucesso!")
+			w.WriteHeader(http.StatusOK)
+			return
+		case service.SNSTypeUnsubscribeConfirmation:
+			h.log.Warn("⚠️ Inscrição SNS cancelada", "topic_arn", envelope.TopicArn)
 			w.WriteHeader(http.StatusOK)
 			return
 		}
 
-		h.log.Info("📩 Evento SNS recebido sem SubscribeURL", "body", body)
-
-		// Responder OK sem tentar nada mais
-		w.WriteHeader(http.StatusOK)
-	}
-}
-
-// verifySubscribeURL verifica e confirma a inscrição no SNS
-func (h *sesFeedbackHandler) verifySubscribeURL(bodyBytes []byte) bool {
-	var body map[string]interface{}
-	if err := json.Unmarshal(bodyBytes, &body); err != nil {
-		return false
-	}
-
-	if subscribeURL, ok := body["SubscribeURL"].(string); ok {
-		h.log.Info("🔔 Recebido evento de inscrição SNS", "subscribe_url", subscribeURL)
+		h.log.Info("🔔 Recebendo evento SNS do SES...")
 
-		resp, err := http.Get(subscribeURL)
-		if err != nil {
-			h.log.Error("❌ Erro ao confirmar inscrição SNS", "error", err)
-			return false
+		var sesEvent service.SESNotification
+		if err := json.Unmarshal([]byte(envelope.Message), &sesEvent); err != nil {
+			h.log.Error("❌ Erro ao decodificar evento do SES", "error", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
 		}
-		defer resp.Body.Close()
 
-		h.log.Info("✅ Inscrição no SNS confirmada com sucesso!")
-		return true
-	}
-
-	return false
-}
+		if err := h.sesEvents.Process(r.Context(), sesEvent); err != nil {
+			h.log.Error("❌ Erro ao processar evento do SES", "message_id", sesEvent.Mail.MessageID, "error", err)
+			http.Error(w, "Erro interno", http.StatusInternalServerError) // 🔄 O SNS reenvia o evento
+			return
+		}
 
-// mapSESEventToAudienceStatus mapeia eventos do SES para status de audiência
-func mapSESEventToAudienceStatus(sesEvent SESNotification) (string, *string) {
-	var status string
-	var feedback string
-	switch sesEvent.EventType {
-	case "Send":
-		status = "enviado"
-	case "Rendering Failure":
-		status = "falha_renderizacao"
-	case "Reject":
-		status = "rejeitado"
-	case "Delivery":
-		status = "entregue"
-	case "Bounce":
-		status = "devolvido"
-		feedback = sesEvent.Bounce.BounceType
-	case "Complaint":
-		status = "reclamado"
-		feedback = sesEvent.Complaint.ComplaintFeedbackType
-	case "DeliveryDelay":
-		status = "atrasado"
-	case "SubscriptionUpdate":
-		status = "atualizou_assinatura"
-	default:
-		status = ""
-	}
-	if feedback != "" {
-		return status, &feedback
+		w.WriteHeader(http.StatusOK)
 	}
-	return status, nil
 }
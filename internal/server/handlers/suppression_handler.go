@@ -0,0 +1,156 @@
+// File: /internal/server/handlers/suppression_handler.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/middleware"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+type SuppressionHandle interface {
+	GetSuppressionsHandler() http.HandlerFunc
+	GetSuppressionHandler() http.HandlerFunc
+	CreateSuppressionHandler() http.HandlerFunc
+	DeleteSuppressionHandler() http.HandlerFunc
+}
+
+type suppressionHandle struct {
+	log  *slog.Logger
+	repo db.SuppressionRepository
+}
+
+func NewSuppressionHandle(repo db.SuppressionRepository) SuppressionHandle {
+	return &suppressionHandle{
+		log:  logger.GetLogger(),
+		repo: repo,
+	}
+}
+
+// GetSuppressionsHandler lista a lista de supressão da conta (filtros: channel, reason, address, active)
+func (h *suppressionHandle) GetSuppressionsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		filters := utils.ExtractQueryFilters(r.URL.Query(), []string{"channel", "reason", "address", "active"})
+		page, perPage, _ := utils.ExtractPaginationParams(r)
+
+		paginator, err := h.repo.GetPaginated(r.Context(), authAccount.ID, filters, page, perPage)
+		if err != nil {
+			h.log.Error("Erro ao buscar supressões", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar supressões")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(paginator)
+	}
+}
+
+// GetSuppressionHandler retorna uma supressão da conta
+func (h *suppressionHandle) GetSuppressionHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		suppression, ok := h.getOwnedSuppression(w, r)
+		if !ok {
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(suppression)
+	}
+}
+
+// CreateSuppressionHandler adiciona manualmente um destinatário à lista de supressão
+func (h *suppressionHandle) CreateSuppressionHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var suppressionDTO dto.SuppressionCreateDTO
+
+		// Decodifica JSON
+		if err := json.NewDecoder(r.Body).Decode(&suppressionDTO); err != nil {
+			h.log.Warn("Erro ao decodificar JSON", "error", err)
+			utils.SendError(w, http.StatusBadRequest, "Erro ao processar requisição")
+			return
+		}
+		defer r.Body.Close()
+
+		// Validar DTO
+		if err := suppressionDTO.Validate(); err != nil {
+			h.log.Warn("Erro de validação", "error", err.Error())
+			utils.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		suppression := suppressionDTO.ToModel()
+		suppression.AccountID = authAccount.ID
+
+		saved, err := h.repo.Upsert(r.Context(), suppression)
+		if err != nil {
+			h.log.Error("Erro ao salvar supressão", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao salvar supressão")
+			return
+		}
+
+		h.log.Info("🛑 Supressão manual registrada", "account_id", authAccount.ID, "channel", saved.Channel, "suppression_id", saved.ID)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(saved)
+	}
+}
+
+// DeleteSuppressionHandler remove a supressão (o destinatário volta a receber mensagens)
+func (h *suppressionHandle) DeleteSuppressionHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		suppression, ok := h.getOwnedSuppression(w, r)
+		if !ok {
+			return
+		}
+
+		if err := h.repo.DeleteByID(r.Context(), suppression.ID); err != nil {
+			h.log.Error("Erro ao remover supressão", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao remover supressão")
+			return
+		}
+
+		h.log.Info("✅ Supressão removida", "suppression_id", suppression.ID, "channel", suppression.Channel, "reason", suppression.Reason)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Supressão removida com sucesso"})
+	}
+}
+
+// getOwnedSuppression busca a supressão da URL e garante que pertence à conta autenticada (ou admin)
+func (h *suppressionHandle) getOwnedSuppression(w http.ResponseWriter, r *http.Request) (*models.Suppression, bool) {
+	// 🔍 Buscar conta autenticada
+	authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+	suppressionID := utils.GetUUIDFromRequestPath(r, w, "suppression_id")
+
+	suppression, err := h.repo.GetByID(r.Context(), suppressionID)
+	if err != nil {
+		h.log.Error("Erro ao buscar supressão", "suppression_id", suppressionID, "error", err)
+		utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar supressão")
+		return nil, false
+	}
+	if suppression == nil {
+		utils.SendError(w, http.StatusNotFound, "Supressão não encontrada")
+		return nil, false
+	}
+
+	// Checar se é admin ou dono
+	if !middleware.IsAdminOrOwner(authAccount, suppression.AccountID) {
+		h.log.Warn("Conta tentou acessar supressão de outra conta", "account_id", authAccount.ID, "suppression_id", suppressionID)
+		utils.SendError(w, http.StatusForbidden, "Apenas administradores podem acessar supressões de outras contas")
+		return nil, false
+	}
+
+	return suppression, true
+}
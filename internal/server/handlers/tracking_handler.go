@@ -0,0 +1,113 @@
+// File: /internal/server/handlers/tracking_handler.go
+
+package handlers
+
+import (
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+// transparentGIF é o pixel 1x1 devolvido no rastreamento de abertura
+var transparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+type TrackingHandle interface {
+	TrackOpenHandler() http.HandlerFunc
+	TrackClickHandler() http.HandlerFunc
+}
+
+type trackingHandle struct {
+	log            *slog.Logger
+	engagementRepo db.EngagementRepository
+	tracking       service.EmailTrackingService
+}
+
+func NewTrackingHandle(engagementRepo db.EngagementRepository, tracking service.EmailTrackingService) TrackingHandle {
+	return &trackingHandle{
+		log:            logger.GetLogger(),
+		engagementRepo: engagementRepo,
+		tracking:       tracking,
+	}
+}
+
+// TrackOpenHandler registra a abertura e sempre devolve o pixel (mesmo com assinatura inválida)
+func (h *trackingHandle) TrackOpenHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		audienceID, err := uuid.Parse(r.PathValue("audience_id"))
+		if err == nil && h.tracking.VerifyOpen(audienceID, r.URL.Query().Get("sig")) {
+			h.record(r, audienceID, models.EngagementOpen, models.EngagementSourcePixel, nil)
+		} else {
+			h.log.Warn("⚠️ Pixel de abertura com assinatura inválida", "audience_id", r.PathValue("audience_id"))
+		}
+
+		w.Header().Set("Content-Type", "image/gif")
+		w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate, private")
+		w.WriteHeader(http.StatusOK)
+		w.Write(transparentGIF)
+	}
+}
+
+// TrackClickHandler registra o clique e redireciona para o link original
+func (h *trackingHandle) TrackClickHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		audienceID, err := uuid.Parse(r.PathValue("audience_id"))
+		if err != nil {
+			utils.SendError(w, http.StatusBadRequest, "Link inválido")
+			return
+		}
+
+		target := r.URL.Query().Get("url")
+		if !h.tracking.VerifyClick(audienceID, target, r.URL.Query().Get("sig")) {
+			h.log.Warn("⚠️ Link rastreado com assinatura inválida", "audience_id", audienceID, "url", target)
+			utils.SendError(w, http.StatusBadRequest, "Link inválido")
+			return
+		}
+
+		h.record(r, audienceID, models.EngagementClick, models.EngagementSourceRedirect, &target)
+
+		w.Header().Set("Cache-Control", "no-store")
+		http.Redirect(w, r, target, http.StatusFound)
+	}
+}
+
+// record grava o evento; falhas não impedem a resposta ao destinatário
+func (h *trackingHandle) record(r *http.Request, audienceID uuid.UUID, eventType models.EngagementType, source models.EngagementSource, target *string) {
+	ip := clientIP(r)
+	userAgent := r.UserAgent()
+
+	event := &models.EngagementEvent{Type: eventType, Source: source, URL: target, IPAddress: &ip, UserAgent: &userAgent}
+	found, err := h.engagementRepo.Record(r.Context(), audienceID, event)
+	if err != nil {
+		h.log.Error("❌ Erro ao registrar engajamento", "audience_id", audienceID, "type", eventType, "error", err)
+		return
+	}
+	if !found {
+		h.log.Warn("⚠️ Engajamento de audiência inexistente", "audience_id", audienceID, "type", eventType)
+		return
+	}
+
+	h.log.Debug("👀 Engajamento registrado", "audience_id", audienceID, "campaign_id", event.CampaignID, "type", eventType)
+}
+
+// clientIP retorna o IP de origem considerando o primeiro X-Forwarded-For (API atrás de proxy)
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,114 @@
+// File: /internal/server/handlers/unsubscribe_handler.go
+
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+type UnsubscribeHandle interface {
+	ConfirmUnsubscribeHandler() http.HandlerFunc
+	UnsubscribeHandler() http.HandlerFunc
+}
+
+type unsubscribeHandle struct {
+	log         *slog.Logger
+	unsubscribe service.UnsubscribeService
+}
+
+func NewUnsubscribeHandle(unsubscribe service.UnsubscribeService) UnsubscribeHandle {
+	return &unsubscribeHandle{
+		log:         logger.GetLogger(),
+		unsubscribe: unsubscribe,
+	}
+}
+
+// ConfirmUnsubscribeHandler exibe a página de confirmação do link (GET) sem descadastrar:
+// scanners de links e o pré-carregamento dos provedores de e-mail também fazem GET.
+// O descadastro acontece somente no POST do formulário.
+func (h *unsubscribeHandle) ConfirmUnsubscribeHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel, err := h.unsubscribe.Check(r.Context(), r.PathValue("token"))
+		if !h.unsubscribeOK(w, r, err) {
+			return
+		}
+
+		question := "Deseja deixar de receber nossas mensagens?"
+		switch channel {
+		case models.EmailChannel:
+			question = "Deseja deixar de receber nossos e-mails?"
+		case models.WhatsappChannel:
+			question = "Deseja deixar de receber nossas mensagens no WhatsApp?"
+		}
+
+		writeUnsubscribeHeaders(w, http.StatusOK)
+		fmt.Fprintf(w, unsubscribePageHTML, "<p>"+html.EscapeString(question)+"</p>"+
+			`<form method="post"><button type="submit" style="padding:8px 24px">Confirmar descadastro</button></form>`)
+	}
+}
+
+// UnsubscribeHandler processa o descadastro pelo formulário de confirmação ou
+// pelo botão do provedor de e-mail (POST "List-Unsubscribe=One-Click", RFC 8058)
+func (h *unsubscribeHandle) UnsubscribeHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel, err := h.unsubscribe.Unsubscribe(r.Context(), r.PathValue("token"))
+		if !h.unsubscribeOK(w, r, err) {
+			return
+		}
+
+		message := "Você não receberá mais nossas mensagens."
+		switch channel {
+		case models.EmailChannel:
+			message = "Você não receberá mais nossos e-mails."
+		case models.WhatsappChannel:
+			message = "Você não receberá mais nossas mensagens no WhatsApp."
+		}
+		writeUnsubscribePage(w, r, http.StatusOK, message)
+	}
+}
+
+// unsubscribeOK responde os erros de token/processamento; retorna false quando a resposta já foi enviada
+func (h *unsubscribeHandle) unsubscribeOK(w http.ResponseWriter, r *http.Request, err error) bool {
+	if errors.Is(err, service.ErrInvalidUnsubscribeToken) {
+		h.log.Warn("⚠️ Token de descadastro inválido", "method", r.Method)
+		writeUnsubscribePage(w, r, http.StatusBadRequest, "Link de descadastro inválido ou expirado.")
+		return false
+	}
+	if err != nil {
+		h.log.Error("❌ Erro ao processar descadastro", "method", r.Method, "error", err)
+		writeUnsubscribePage(w, r, http.StatusInternalServerError, "Não foi possível concluir o descadastro. Tente novamente mais tarde.")
+		return false
+	}
+	return true
+}
+
+// unsubscribePageHTML é a página simples exibida ao destinatário
+const unsubscribePageHTML = `<!DOCTYPE html><html lang="pt-BR"><head><meta charset="utf-8"><title>Descadastro</title></head>` +
+	`<body style="font-family:sans-serif;text-align:center;padding:48px">%s</body></html>`
+
+// writeUnsubscribePage responde com uma página simples ou apenas o status (POST one-click do provedor de e-mail)
+func writeUnsubscribePage(w http.ResponseWriter, r *http.Request, status int, message string) {
+	if r.Method == http.MethodPost && r.PostFormValue("List-Unsubscribe") == "One-Click" {
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		return
+	}
+
+	writeUnsubscribeHeaders(w, status)
+	fmt.Fprintf(w, unsubscribePageHTML, "<p>"+html.EscapeString(message)+"</p>")
+}
+
+// writeUnsubscribeHeaders envia os cabeçalhos da página (sem cache: o link é pessoal)
+func writeUnsubscribeHeaders(w http.ResponseWriter, status int) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+}
@@ -0,0 +1,95 @@
+// File: /internal/server/handlers/unsubscribe_handler_test.go
+
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// fakeUnsubscribeService conta os descadastros efetivados
+type fakeUnsubscribeService struct {
+	service.UnsubscribeService
+
+	unsubscribed int
+}
+
+func (s *fakeUnsubscribeService) Check(ctx context.Context, token string) (models.ChannelType, error) {
+	if token != "valido" {
+		return "", service.ErrInvalidUnsubscribeToken
+	}
+	return models.EmailChannel, nil
+}
+
+func (s *fakeUnsubscribeService) Unsubscribe(ctx context.Context, token string) (models.ChannelType, error) {
+	if _, err := s.Check(ctx, token); err != nil {
+		return "", err
+	}
+	s.unsubscribed++
+	return models.EmailChannel, nil
+}
+
+func newUnsubscribeMux(svc service.UnsubscribeService) *http.ServeMux {
+	handler := NewUnsubscribeHandle(svc)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /unsubscribe/{token}", handler.ConfirmUnsubscribeHandler())
+	mux.HandleFunc("POST /unsubscribe/{token}", handler.UnsubscribeHandler())
+	return mux
+}
+
+func TestUnsubscribeGetOnlyRendersConfirmation(t *testing.T) {
+	svc := &fakeUnsubscribeService{}
+	rec := httptest.NewRecorder()
+	newUnsubscribeMux(svc).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/unsubscribe/valido", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, esperado 200", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `<form method="post">`) {
+		t.Fatalf("página sem formulário de confirmação: %s", rec.Body.String())
+	}
+	if svc.unsubscribed != 0 {
+		t.Fatal("GET não deve descadastrar (scanners de links abrem o link)")
+	}
+}
+
+func TestUnsubscribePost(t *testing.T) {
+	tests := []struct {
+		name             string
+		token            string
+		body             string
+		wantStatus       int
+		wantPage         bool
+		wantUnsubscribed int
+	}{
+		{name: "formulário de confirmação", token: "valido", wantStatus: http.StatusOK, wantPage: true, wantUnsubscribed: 1},
+		{name: "one-click do provedor", token: "valido", body: "List-Unsubscribe=One-Click", wantStatus: http.StatusOK, wantUnsubscribed: 1},
+		{name: "token inválido", token: "adulterado", body: "List-Unsubscribe=One-Click", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeUnsubscribeService{}
+			req := httptest.NewRequest(http.MethodPost, "/unsubscribe/"+tt.token, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			newUnsubscribeMux(svc).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, esperado %d", rec.Code, tt.wantStatus)
+			}
+			if hasPage := rec.Body.Len() > 0; hasPage != tt.wantPage {
+				t.Fatalf("página na resposta = %v, esperado %v", hasPage, tt.wantPage)
+			}
+			if svc.unsubscribed != tt.wantUnsubscribed {
+				t.Fatalf("descadastros = %d, esperado %d", svc.unsubscribed, tt.wantUnsubscribed)
+			}
+		})
+	}
+}
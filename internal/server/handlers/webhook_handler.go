@@ -3,60 +3,123 @@
 package handlers
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/subtle"
 	"io"
 	"log/slog"
 	"net/http"
 
-	"github.com/jeancarlosdanese/go-marketing/internal/dto"
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
 	"github.com/jeancarlosdanese/go-marketing/internal/service"
 	"github.com/jeancarlosdanese/go-marketing/internal/utils"
 )
 
 type WebhookHandler interface {
 	Handle() http.HandlerFunc
+	VerifyCloudSubscription() http.HandlerFunc
 }
 
 type webhookHandler struct {
-	log     *slog.Logger
-	chatSvc service.ChatWhatsAppService
+	log              *slog.Logger
+	chatSvc          service.ChatWhatsAppService
+	providers        service.WhatsAppProviders
+	statusSvc        service.WhatsAppStatusService
+	cloudVerifyToken string
 }
 
-func NewWebhookHandler(chatSvc service.ChatWhatsAppService) WebhookHandler {
+// NewWebhookHandler cria o handler dos webhooks do WhatsApp. cloudVerifyToken é o token
+// cadastrado no app da Meta para a verificação da assinatura do webhook.
+func NewWebhookHandler(chatSvc service.ChatWhatsAppService, providers service.WhatsAppProviders, statusSvc service.WhatsAppStatusService, cloudVerifyToken string) WebhookHandler {
 	return &webhookHandler{
-		log:     logger.GetLogger(),
-		chatSvc: chatSvc,
+		log:              logger.GetLogger(),
+		chatSvc:          chatSvc,
+		providers:        providers,
+		statusSvc:        statusSvc,
+		cloudVerifyToken: cloudVerifyToken,
 	}
 }
 
+// Handle recebe o webhook do provedor informado na rota ({provider}; sem provedor = Baileys),
+// converte para mensagens e status normalizados e processa em segundo plano
 func (h *webhookHandler) Handle() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var rawBody bytes.Buffer
+		providerName := models.WhatsAppProviderType(r.PathValue("provider"))
+		if providerName == "" {
+			providerName = models.WhatsAppProviderBaileys
+		}
 
-		// TeeReader permite ler e salvar simultaneamente
-		tee := io.TeeReader(r.Body, &rawBody)
-		defer r.Body.Close()
+		provider, err := h.providers.Get(providerName)
+		if err != nil {
+			h.log.Warn("Webhook de provedor desconhecido", slog.String("provider", string(providerName)))
+			utils.SendError(w, http.StatusNotFound, "Provedor do WhatsApp não encontrado")
+			return
+		}
 
-		var webhookPayload *dto.WebhookBaileysPayload
-		if err := json.NewDecoder(tee).Decode(&webhookPayload); err != nil {
-			h.log.Error("❌ Payload inválido no webhook", slog.Any("erro", err))
-			utils.SendError(w, 400, "Payload inválido")
+		rawBody, err := io.ReadAll(io.LimitReader(r.Body, 5<<20))
+		defer r.Body.Close()
+		if err != nil {
+			utils.SendError(w, http.StatusBadRequest, "Erro ao ler payload")
 			return
 		}
 
 		// Log do JSON cru
-		h.log.Debug("🔍 Payload cru recebido", slog.String("body", rawBody.String()))
+		h.log.Debug("🔍 Payload cru recebido", slog.String("provider", string(providerName)), slog.String("body", string(rawBody)))
+
+		if err := provider.VerifyWebhook(r.Header, rawBody); err != nil {
+			h.log.Warn("❌ Webhook do WhatsApp rejeitado", slog.String("provider", string(providerName)), slog.Any("erro", err))
+			utils.SendError(w, http.StatusUnauthorized, "Assinatura inválida")
+			return
+		}
+
+		messages, err := provider.ParseWebhook(rawBody)
+		if err != nil {
+			h.log.Error("❌ Payload inválido no webhook", slog.String("provider", string(providerName)), slog.Any("erro", err))
+			utils.SendError(w, http.StatusBadRequest, "Payload inválido")
+			return
+		}
+		statuses, err := provider.ParseStatuses(rawBody)
+		if err != nil {
+			h.log.Error("❌ Status inválidos no webhook", slog.String("provider", string(providerName)), slog.Any("erro", err))
+			utils.SendError(w, http.StatusBadRequest, "Payload inválido")
+			return
+		}
 
 		// 🔧 Processamento principal
 		go func() {
-			if err := h.chatSvc.ProcessarMensagemRecebida(context.Background(), webhookPayload); err != nil {
-				h.log.Error("Erro ao processar mensagem recebida", slog.Any("err", err))
+			for i := range messages {
+				if err := h.chatSvc.ProcessarMensagemRecebida(context.Background(), &messages[i]); err != nil {
+					h.log.Error("Erro ao processar mensagem recebida", slog.String("message_id", messages[i].MessageID), slog.Any("err", err))
+				}
+			}
+
+			// 📬 Status das mensagens enviadas (entregue, lida, falha) atualizam a audiência das campanhas
+			for _, status := range statuses {
+				if err := h.statusSvc.Process(context.Background(), status); err != nil {
+					h.log.Error("Erro ao processar status do WhatsApp", slog.String("message_id", status.MessageID), slog.Any("err", err))
+				}
 			}
 		}()
 
 		utils.SendSuccess(w, 200, map[string]string{"status": "ok"})
 	}
 }
+
+// VerifyCloudSubscription responde ao desafio de verificação do webhook da Cloud API (hub.challenge)
+func (h *webhookHandler) VerifyCloudSubscription() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		token := query.Get("hub.verify_token")
+
+		if h.cloudVerifyToken == "" || query.Get("hub.mode") != "subscribe" ||
+			subtle.ConstantTimeCompare([]byte(token), []byte(h.cloudVerifyToken)) != 1 {
+			h.log.Warn("❌ Verificação do webhook da Cloud API recusada", slog.String("mode", query.Get("hub.mode")))
+			utils.SendError(w, http.StatusForbidden, "Token de verificação inválido")
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, query.Get("hub.challenge"))
+	}
+}
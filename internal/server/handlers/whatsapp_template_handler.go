@@ -0,0 +1,265 @@
+// File: /internal/server/handlers/whatsapp_template_handler.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/middleware"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+type WhatsAppTemplateHandle interface {
+	GetPhoneNumbersHandler() http.HandlerFunc
+	GetPhoneNumberHandler() http.HandlerFunc
+	CreatePhoneNumberHandler() http.HandlerFunc
+	DeletePhoneNumberHandler() http.HandlerFunc
+	SyncTemplatesHandler() http.HandlerFunc
+	GetTemplatesHandler() http.HandlerFunc
+	GetTemplateHandler() http.HandlerFunc
+}
+
+type whatsAppTemplateHandle struct {
+	log          *slog.Logger
+	numberRepo   db.WhatsAppPhoneNumberRepository
+	templateRepo db.WhatsAppTemplateRepository
+	templates    service.WhatsAppTemplateService
+}
+
+func NewWhatsAppTemplateHandle(numberRepo db.WhatsAppPhoneNumberRepository, templateRepo db.WhatsAppTemplateRepository, templates service.WhatsAppTemplateService) WhatsAppTemplateHandle {
+	return &whatsAppTemplateHandle{
+		log:          logger.GetLogger(),
+		numberRepo:   numberRepo,
+		templateRepo: templateRepo,
+		templates:    templates,
+	}
+}
+
+// GetPhoneNumbersHandler lista os números da Cloud API da conta
+func (h *whatsAppTemplateHandle) GetPhoneNumbersHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		numbers, err := h.numberRepo.GetByAccountID(r.Context(), authAccount.ID)
+		if err != nil {
+			h.log.Error("Erro ao buscar números do WhatsApp", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar números do WhatsApp")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(numbers)
+	}
+}
+
+// GetPhoneNumberHandler retorna um número da Cloud API da conta
+func (h *whatsAppTemplateHandle) GetPhoneNumberHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		number, ok := h.getOwnedPhoneNumber(w, r)
+		if !ok {
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(number)
+	}
+}
+
+// CreatePhoneNumberHandler cadastra um número da Cloud API depois de conferi-lo no Graph
+func (h *whatsAppTemplateHandle) CreatePhoneNumberHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var numberDTO dto.WhatsAppPhoneNumberCreateDTO
+
+		// Decodifica JSON
+		if err := json.NewDecoder(r.Body).Decode(&numberDTO); err != nil {
+			h.log.Warn("Erro ao decodificar JSON", "error", err)
+			utils.SendError(w, http.StatusBadRequest, "Erro ao processar requisição")
+			return
+		}
+		defer r.Body.Close()
+
+		// Validar DTO
+		if err := numberDTO.Validate(); err != nil {
+			h.log.Warn("Erro de validação", "error", err.Error())
+			utils.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		number := numberDTO.ToModel()
+		number.AccountID = authAccount.ID
+
+		// 🔁 O número roteia os webhooks: pertence a uma única conta
+		existing, err := h.numberRepo.GetByPhoneNumberID(r.Context(), number.PhoneNumberID)
+		if err != nil {
+			h.log.Error("Erro ao buscar número do WhatsApp", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar número do WhatsApp")
+			return
+		}
+		if existing != nil {
+			if existing.AccountID != authAccount.ID {
+				h.log.Warn("Número do WhatsApp já cadastrado em outra conta", "account_id", authAccount.ID, "phone_number_id", number.PhoneNumberID)
+			}
+			utils.SendError(w, http.StatusConflict, "Número do WhatsApp já cadastrado")
+			return
+		}
+
+		saved, err := h.templates.RegisterPhoneNumber(r.Context(), number)
+		if err != nil {
+			h.log.Error("Erro ao cadastrar número do WhatsApp", "phone_number_id", number.PhoneNumberID, "error", err)
+			h.sendProviderError(w, err, "Erro ao consultar o número na Cloud API: ")
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(saved)
+	}
+}
+
+// DeletePhoneNumberHandler remove o número (os templates sincronizados continuam na conta)
+func (h *whatsAppTemplateHandle) DeletePhoneNumberHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		number, ok := h.getOwnedPhoneNumber(w, r)
+		if !ok {
+			return
+		}
+
+		if err := h.numberRepo.DeleteByID(r.Context(), number.ID); err != nil {
+			h.log.Error("Erro ao remover número do WhatsApp", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao remover número do WhatsApp")
+			return
+		}
+
+		h.log.Info("✅ Número do WhatsApp removido", "whatsapp_phone_number_id", number.ID, "phone_number_id", number.PhoneNumberID)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Número do WhatsApp removido com sucesso"})
+	}
+}
+
+// SyncTemplatesHandler baixa os templates da WABA do número e atualiza a cópia local
+func (h *whatsAppTemplateHandle) SyncTemplatesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		number, ok := h.getOwnedPhoneNumber(w, r)
+		if !ok {
+			return
+		}
+
+		updated, templates, err := h.templates.SyncTemplates(r.Context(), number)
+		if err != nil {
+			h.log.Error("Erro ao sincronizar templates do WhatsApp", "whatsapp_phone_number_id", number.ID, "error", err)
+			h.sendProviderError(w, err, "Erro ao sincronizar templates na Cloud API: ")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(dto.WhatsAppTemplateSyncDTO{PhoneNumber: updated, Templates: templates})
+	}
+}
+
+// GetTemplatesHandler lista os templates sincronizados (filtros opcionais: status e business_account_id)
+func (h *whatsAppTemplateHandle) GetTemplatesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		status := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("status")))
+		businessAccountID := strings.TrimSpace(r.URL.Query().Get("business_account_id"))
+
+		templates, err := h.templateRepo.GetByAccountID(r.Context(), authAccount.ID, businessAccountID, status)
+		if err != nil {
+			h.log.Error("Erro ao buscar templates do WhatsApp", "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar templates do WhatsApp")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(templates)
+	}
+}
+
+// GetTemplateHandler retorna um template com as variáveis que a campanha precisa mapear
+func (h *whatsAppTemplateHandle) GetTemplateHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 🔍 Buscar conta autenticada
+		authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+		templateID := utils.GetUUIDFromRequestPath(r, w, "whatsapp_template_id")
+		if templateID == uuid.Nil {
+			return
+		}
+
+		template, err := h.templateRepo.GetByID(r.Context(), templateID)
+		if err != nil {
+			h.log.Error("Erro ao buscar template do WhatsApp", "whatsapp_template_id", templateID, "error", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar template do WhatsApp")
+			return
+		}
+		if template == nil {
+			utils.SendError(w, http.StatusNotFound, "Template do WhatsApp não encontrado")
+			return
+		}
+
+		// Checar se é admin ou dono
+		if !middleware.IsAdminOrOwner(authAccount, template.AccountID) {
+			h.log.Warn("Conta tentou acessar template do WhatsApp de outra conta", "account_id", authAccount.ID, "whatsapp_template_id", templateID)
+			utils.SendError(w, http.StatusForbidden, "Apenas administradores podem acessar templates de outras contas")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(template)
+	}
+}
+
+// sendProviderError responde 503 quando a Cloud API não está configurada no servidor e 502 para falhas do Graph
+func (h *whatsAppTemplateHandle) sendProviderError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, service.ErrWhatsAppProviderNotConfigured) || errors.Is(err, service.ErrWhatsAppNotSupported) {
+		utils.SendError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	utils.SendError(w, http.StatusBadGateway, message+err.Error())
+}
+
+// getOwnedPhoneNumber busca o número da URL e garante que pertence à conta autenticada (ou admin)
+func (h *whatsAppTemplateHandle) getOwnedPhoneNumber(w http.ResponseWriter, r *http.Request) (*models.WhatsAppPhoneNumber, bool) {
+	// 🔍 Buscar conta autenticada
+	authAccount := middleware.GetAuthAccountOrFail(r.Context(), w, h.log)
+
+	numberID := utils.GetUUIDFromRequestPath(r, w, "whatsapp_phone_number_id")
+	if numberID == uuid.Nil {
+		return nil, false
+	}
+
+	number, err := h.numberRepo.GetByID(r.Context(), numberID)
+	if err != nil {
+		h.log.Error("Erro ao buscar número do WhatsApp", "whatsapp_phone_number_id", numberID, "error", err)
+		utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar número do WhatsApp")
+		return nil, false
+	}
+	if number == nil {
+		utils.SendError(w, http.StatusNotFound, "Número do WhatsApp não encontrado")
+		return nil, false
+	}
+
+	// Checar se é admin ou dono
+	if !middleware.IsAdminOrOwner(authAccount, number.AccountID) {
+		h.log.Warn("Conta tentou acessar número do WhatsApp de outra conta", "account_id", authAccount.ID, "whatsapp_phone_number_id", numberID)
+		utils.SendError(w, http.StatusForbidden, "Apenas administradores podem acessar números de outras contas")
+		return nil, false
+	}
+
+	return number, true
+}
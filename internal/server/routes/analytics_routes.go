@@ -0,0 +1,27 @@
+// File: /internal/server/routes/analytics_routes.go
+
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/server/handlers"
+)
+
+// RegisterAnalyticsRoutes adiciona as rotas de métricas das campanhas
+func RegisterAnalyticsRoutes(
+	mux *http.ServeMux,
+	authMiddleware func(http.Handler) http.HandlerFunc,
+	campaignRepo db.CampaignRepository,
+	audienceRepo db.CampaignAudienceRepository,
+) {
+
+	handler := handlers.NewAnalyticsHandle(campaignRepo, audienceRepo)
+
+	// 📊 Funil por canal/status e série temporal de uma campanha
+	mux.Handle("GET /campaigns/{campaign_id}/stats", authMiddleware(handler.GetCampaignStatsHandler()))
+
+	// 📊 Comparativo das campanhas da conta em um período
+	mux.Handle("GET /analytics/campaigns", authMiddleware(handler.GetCampaignsAnalyticsHandler()))
+}
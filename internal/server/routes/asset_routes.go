@@ -0,0 +1,31 @@
+// File: /internal/server/routes/asset_routes.go
+
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/server/handlers"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// RegisterAssetRoutes adiciona as rotas de arquivos das contas (imagens dos templates e anexos das campanhas)
+func RegisterAssetRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.HandlerFunc, assets service.AssetService) {
+
+	handler := handlers.NewAssetHandle(assets)
+
+	// 📌 Listar arquivos da conta
+	mux.Handle("GET /assets", authMiddleware(handler.GetAssetsHandler()))
+
+	// 📌 Enviar arquivo (multipart/form-data, campo "file")
+	mux.Handle("POST /assets", authMiddleware(handler.UploadAssetHandler()))
+
+	// 📌 Buscar arquivo (inclui a URL pública)
+	mux.Handle("GET /assets/{asset_id}", authMiddleware(handler.GetAssetHandler()))
+
+	// 📌 Excluir arquivo
+	mux.Handle("DELETE /assets/{asset_id}", authMiddleware(handler.DeleteAssetHandler()))
+
+	// 📌 URL pública dos arquivos no armazenamento local
+	mux.HandleFunc("GET /files/{key...}", handler.ServeAssetHandler())
+}
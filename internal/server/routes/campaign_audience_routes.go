@@ -10,9 +10,9 @@ import (
 )
 
 // RegisterCampaignAudienceRoutes adiciona as rotas relacionadas à audiência de campanhas
-func RegisterCampaignAudienceRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.HandlerFunc, campaignRepo db.CampaignRepository, contactRepo db.ContactRepository, audienceRepo db.CampaignAudienceRepository) {
+func RegisterCampaignAudienceRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.HandlerFunc, campaignRepo db.CampaignRepository, contactRepo db.ContactRepository, audienceRepo db.CampaignAudienceRepository, deliveredMessageRepo db.DeliveredMessageRepository) {
 
-	handler := handlers.NewCampaignAudienceHandle(campaignRepo, contactRepo, audienceRepo)
+	handler := handlers.NewCampaignAudienceHandle(campaignRepo, contactRepo, audienceRepo, deliveredMessageRepo)
 
 	// 📌 Adicionar contatos a uma campanha
 	mux.Handle("POST /campaigns/{campaign_id}/audience", authMiddleware(handler.AddContactsToCampaignHandler()))
@@ -28,6 +28,9 @@ func RegisterCampaignAudienceRoutes(mux *http.ServeMux, authMiddleware func(http
 	// 📌 Delete audiência de uma campanha
 	mux.Handle("DELETE /campaigns/{campaign_id}/audience/{audience_id}", authMiddleware(handler.RemoveContactFromCampaignHandler()))
 
+	// 📌 Conteúdo exato entregue ao destinatário
+	mux.Handle("GET /campaigns/{campaign_id}/audience/{audience_id}/message", authMiddleware(handler.GetDeliveredMessageHandler()))
+
 	// Delete todos os contatos de uma campanha
 	mux.Handle("DELETE /campaigns/{campaign_id}/remove-all-audience", authMiddleware(handler.RemoveAllContactsFromCampaignHandler()))
 
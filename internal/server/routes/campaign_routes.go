@@ -17,9 +17,12 @@ func RegisterCampaignRoutes(
 	campaignRepo db.CampaignRepository,
 	audienceRepo db.CampaignAudienceRepository,
 	campaignProcessor service.CampaignProcessorService,
+	sendPacer service.SendPacerService,
+	campaignState service.CampaignStateService,
+	senderIdentities service.SenderIdentityService,
 ) {
 
-	handler := handlers.NewCampaignHandle(campaignRepo, audienceRepo, campaignProcessor)
+	handler := handlers.NewCampaignHandle(campaignRepo, audienceRepo, campaignProcessor, sendPacer, campaignState, senderIdentities)
 
 	// Criar campanha
 	mux.Handle("POST /campaigns", authMiddleware(handler.CreateCampaignHandler()))
@@ -39,6 +42,15 @@ func RegisterCampaignRoutes(
 	// Atualizar status da campanha
 	mux.Handle("PATCH /campaigns/{campaign_id}/status", authMiddleware(handler.UpdateCampaignStatusHandler()))
 
+	// Linha do tempo de status da campanha
+	mux.Handle("GET /campaigns/{campaign_id}/status-history", authMiddleware(handler.GetCampaignStatusHistoryHandler()))
+
+	// Listar contatos cujo envio foi abandonado (dead-letter)
+	mux.Handle("GET /campaigns/{campaign_id}/dead-letters", authMiddleware(handler.GetDeadLettersHandler()))
+
+	// Reenfileirar dead-letters após corrigir a causa
+	mux.Handle("POST /campaigns/{campaign_id}/dead-letters/retry", authMiddleware(handler.RetryDeadLettersHandler()))
+
 	// Deletar uma campanha
 	mux.Handle("DELETE /campaigns/{campaign_id}", authMiddleware(handler.DeleteCampaignHandler()))
 }
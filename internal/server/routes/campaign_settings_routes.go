@@ -7,6 +7,7 @@ import (
 
 	"github.com/jeancarlosdanese/go-marketing/internal/db"
 	"github.com/jeancarlosdanese/go-marketing/internal/server/handlers"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
 )
 
 // RegisterCampaignSettingsRoutes adiciona as rotas relacionadas às configurações das campanhas
@@ -15,8 +16,12 @@ func RegisterCampaignSettingsRoutes(
 	authMiddleware func(http.Handler) http.HandlerFunc,
 	campaignRepo db.CampaignRepository,
 	settingsRepo db.CampaignSettingsRepository,
+	senderIdentities service.SenderIdentityService,
+	assets service.AssetService,
+	chatRepo db.ChatRepository,
+	whatsappTemplates service.WhatsAppTemplateService,
 ) {
-	handler := handlers.NewCampaignSettingsHandler(settingsRepo, campaignRepo)
+	handler := handlers.NewCampaignSettingsHandler(settingsRepo, campaignRepo, senderIdentities, assets, chatRepo, whatsappTemplates)
 
 	// 📌 Criar configurações para uma campanha
 	mux.Handle("POST /campaigns/{campaign_id}/settings", authMiddleware(handler.CreateSettingsHandler()))
@@ -0,0 +1,22 @@
+// File: /internal/server/routes/chat_media_routes.go
+
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/server/handlers"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// RegisterChatMediaRoutes adiciona as rotas das mídias do atendimento (recebidas e enviadas pelo WhatsApp)
+func RegisterChatMediaRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.HandlerFunc, media service.ChatMediaService) {
+
+	handler := handlers.NewChatMediaHandle(media)
+
+	// 📌 Enviar mídia para uma mensagem do atendente (multipart/form-data, campo "file")
+	mux.Handle("POST /chat-media", authMiddleware(handler.UploadChatMediaHandler()))
+
+	// 📌 Mídia pela URL assinada (file_url das mensagens)
+	mux.HandleFunc("GET /chat-media/{key...}", handler.ServeChatMediaHandler())
+}
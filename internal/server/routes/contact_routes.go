@@ -11,10 +11,10 @@ import (
 )
 
 // RegisterContactRoutes adiciona as rotas relacionadas a contatos
-func RegisterContactRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.HandlerFunc, contactRepo db.ContactRepository, contactImportRepo db.ContactImportRepository, openAIService service.OpenAIService) {
-	handler := handlers.NewContactHandle(contactRepo)
+func RegisterContactRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.HandlerFunc, contactRepo db.ContactRepository, contactImportRepo db.ContactImportRepository, openAIService service.OpenAIService, emailValidator service.EmailValidationService) {
+	handler := handlers.NewContactHandle(contactRepo, emailValidator)
 
-	importContactService := service.NewContactImportService(contactRepo, contactImportRepo, openAIService)
+	importContactService := service.NewContactImportService(contactRepo, contactImportRepo, openAIService, emailValidator)
 
 	// 📌 Importação de CSV
 	importHandler := handlers.NewImportContactHandler(contactImportRepo, importContactService)
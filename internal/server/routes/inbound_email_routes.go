@@ -0,0 +1,26 @@
+// File: /internal/server/routes/inbound_email_routes.go
+
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/server/handlers"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// RegisterInboundEmailRoutes adiciona as rotas de e-mails recebidos (respostas às campanhas)
+func RegisterInboundEmailRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.HandlerFunc, inboundEmailRepo db.InboundEmailRepository, inboundEmails service.InboundEmailService, snsVerifier service.SNSVerifier) {
+
+	handler := handlers.NewInboundEmailHandle(inboundEmailRepo, inboundEmails, snsVerifier)
+
+	// 📌 Listar e-mails recebidos da conta
+	mux.Handle("GET /inbound-emails", authMiddleware(handler.GetInboundEmailsHandler()))
+
+	// 📌 Registrar e-mail recebido a partir do MIME bruto (uso local)
+	mux.Handle("POST /inbound-emails", authMiddleware(handler.UploadInboundEmailHandler()))
+
+	// 📌 Receipt rule do SES via SNS (mensagens assinadas; confirma inscrições automaticamente)
+	mux.Handle("POST /inbound-email/ses", handler.HandleSESInboundEmail())
+}
@@ -29,6 +29,28 @@ func NewRouter(
 	chatRepo db.ChatRepository,
 	chatContactRepo db.ChatContactRepository,
 	chatMessageRepo db.ChatMessageRepository,
+	sendPolicyRepo db.SendPolicyRepository,
+	sendPacer service.SendPacerService,
+	campaignState service.CampaignStateService,
+	engagementRepo db.EngagementRepository,
+	emailTracking service.EmailTrackingService,
+	unsubscribeService service.UnsubscribeService,
+	suppressionRepo db.SuppressionRepository,
+	sesEventService service.SESEventService,
+	snsVerifier service.SNSVerifier,
+	senderIdentityRepo db.SenderIdentityRepository,
+	senderIdentities service.SenderIdentityService,
+	inboundEmailRepo db.InboundEmailRepository,
+	inboundEmails service.InboundEmailService,
+	assets service.AssetService,
+	emailValidator service.EmailValidationService,
+	deliveredMessageRepo db.DeliveredMessageRepository,
+	whatsappProviders service.WhatsAppProviders,
+	whatsappPhoneNumberRepo db.WhatsAppPhoneNumberRepository,
+	whatsappTemplateRepo db.WhatsAppTemplateRepository,
+	whatsappTemplates service.WhatsAppTemplateService,
+	whatsappStatus service.WhatsAppStatusService,
+	chatMedia service.ChatMediaService,
 ) *http.ServeMux {
 	mux := http.NewServeMux()
 
@@ -39,20 +61,28 @@ func NewRouter(
 	RegisterAuthRoutes(mux, authMiddleware, otpRepo)
 	RegisterAccountRoutes(mux, authMiddleware, accountRepo)
 	RegisterAccountSettingsRoutes(mux, authMiddleware, accountSettingsRepo)
-	RegisterContactRoutes(mux, authMiddleware, contactRepo, contactImportRepo, openAIService)
+	RegisterSendPolicyRoutes(mux, authMiddleware, sendPolicyRepo)
+	RegisterContactRoutes(mux, authMiddleware, contactRepo, contactImportRepo, openAIService, emailValidator)
 	RegisterTemplateRoutes(mux, authMiddleware, templateRepo)
-	RegisterCampaignRoutes(mux, authMiddleware, campaignRepo, audienceRepo, campaignProcessor)
-	RegisterCampaignAudienceRoutes(mux, authMiddleware, campaignRepo, contactRepo, audienceRepo)
-	RegisterSESFeedBackRoutes(mux, audienceRepo, contactRepo)
-	RegisterCampaignSettingsRoutes(mux, authMiddleware, campaignRepo, campaignSettingsRepo)
+	RegisterCampaignRoutes(mux, authMiddleware, campaignRepo, audienceRepo, campaignProcessor, sendPacer, campaignState, senderIdentities)
+	RegisterCampaignAudienceRoutes(mux, authMiddleware, campaignRepo, contactRepo, audienceRepo, deliveredMessageRepo)
+	RegisterAnalyticsRoutes(mux, authMiddleware, campaignRepo, audienceRepo)
+	RegisterSESFeedBackRoutes(mux, sesEventService, snsVerifier)
+	RegisterSuppressionRoutes(mux, authMiddleware, suppressionRepo)
+	RegisterSenderIdentityRoutes(mux, authMiddleware, senderIdentityRepo, senderIdentities)
+	RegisterInboundEmailRoutes(mux, authMiddleware, inboundEmailRepo, inboundEmails, snsVerifier)
+	RegisterAssetRoutes(mux, authMiddleware, assets)
+	RegisterTrackingRoutes(mux, engagementRepo, emailTracking)
+	RegisterUnsubscribeRoutes(mux, unsubscribeService)
+	RegisterCampaignSettingsRoutes(mux, authMiddleware, campaignRepo, campaignSettingsRepo, senderIdentities, assets, chatRepo, whatsappTemplates)
 	RegisterCampaignMessageRoutes(mux, authMiddleware, campaignRepo, campaignSettingsRepo, contactRepo, audienceRepo, campaignMessageRepo, campaignProcessor)
 
-	// 🔥 Registrar rotas do WhatsApp
-	// evolutionService := service.NewEvolutionService()
-	baileysService := service.NewWhatsAppBaileysService(os.Getenv("WHATSAPP_API_URL"), os.Getenv("WHATSAPP_API_KEY"))
-	chatService := service.NewChatWhatsAppService(chatRepo, contactRepo, whatsappContactRepo, chatContactRepo, chatMessageRepo, openAIService, baileysService)
+	// 🔥 Registrar rotas do WhatsApp (o provedor de cada chat é escolhido pelo campo provider)
+	chatService := service.NewChatWhatsAppService(chatRepo, contactRepo, whatsappContactRepo, chatContactRepo, chatMessageRepo, openAIService, whatsappProviders, chatMedia)
 	RegisterChatRoutes(mux, authMiddleware, chatRepo, contactRepo, chatContactRepo, chatMessageRepo, openAIService, chatService)
-	RegisterWebhookRoutes(mux, chatService)
+	RegisterChatMediaRoutes(mux, authMiddleware, chatMedia)
+	RegisterWhatsAppTemplateRoutes(mux, authMiddleware, whatsappPhoneNumberRepo, whatsappTemplateRepo, whatsappTemplates)
+	RegisterWebhookRoutes(mux, chatService, whatsappProviders, whatsappStatus, os.Getenv("WHATSAPP_CLOUD_VERIFY_TOKEN"))
 
 	// 🔥 Rota de Health Check
 	mux.Handle("GET /health", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,25 @@
+// File: /internal/server/routes/send_policy_routes.go
+
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/server/handlers"
+)
+
+// RegisterSendPolicyRoutes adiciona as rotas de janela de envio e limites por canal
+func RegisterSendPolicyRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.HandlerFunc, sendPolicyRepo db.SendPolicyRepository) {
+
+	handler := handlers.NewSendPolicyHandle(sendPolicyRepo)
+
+	// 📌 Listar janelas de envio da conta
+	mux.Handle("GET /account-settings/send-policies", authMiddleware(handler.GetSendPoliciesHandler()))
+
+	// 📌 Criar/atualizar a janela de envio de um canal (email ou whatsapp)
+	mux.Handle("PUT /account-settings/send-policies/{channel}", authMiddleware(handler.UpsertSendPolicyHandler()))
+
+	// 📌 Remover a janela de envio de um canal
+	mux.Handle("DELETE /account-settings/send-policies/{channel}", authMiddleware(handler.DeleteSendPolicyHandler()))
+}
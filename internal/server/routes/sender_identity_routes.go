@@ -0,0 +1,35 @@
+// File: /internal/server/routes/sender_identity_routes.go
+
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/server/handlers"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// RegisterSenderIdentityRoutes adiciona as rotas dos remetentes de e-mail da conta
+func RegisterSenderIdentityRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.HandlerFunc, senderIdentityRepo db.SenderIdentityRepository, senderIdentities service.SenderIdentityService) {
+
+	handler := handlers.NewSenderIdentityHandle(senderIdentityRepo, senderIdentities)
+
+	// 📌 Listar remetentes da conta
+	mux.Handle("GET /sender-identities", authMiddleware(handler.GetSenderIdentitiesHandler()))
+
+	// 📌 Cadastrar remetente (e-mail ou domínio) e solicitar verificação
+	mux.Handle("POST /sender-identities", authMiddleware(handler.CreateSenderIdentityHandler()))
+
+	// 📌 Buscar remetente
+	mux.Handle("GET /sender-identities/{sender_identity_id}", authMiddleware(handler.GetSenderIdentityHandler()))
+
+	// 📌 Atualizar nome de exibição, reply-to e Configuration Set
+	mux.Handle("PUT /sender-identities/{sender_identity_id}", authMiddleware(handler.UpdateSenderIdentityHandler()))
+
+	// 📌 Consultar a verificação no provedor
+	mux.Handle("POST /sender-identities/{sender_identity_id}/refresh", authMiddleware(handler.RefreshSenderIdentityHandler()))
+
+	// 📌 Remover remetente
+	mux.Handle("DELETE /sender-identities/{sender_identity_id}", authMiddleware(handler.DeleteSenderIdentityHandler()))
+}
@@ -5,18 +5,15 @@ package routes
 import (
 	"net/http"
 
-	"github.com/jeancarlosdanese/go-marketing/internal/db"
 	"github.com/jeancarlosdanese/go-marketing/internal/server/handlers"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
 )
 
 // RegisterSESFeedBackRoutes adiciona as rotas relacionadas à audiência de campanhas
-func RegisterSESFeedBackRoutes(mux *http.ServeMux, audienceRepo db.CampaignAudienceRepository, contactRepo db.ContactRepository) {
+func RegisterSESFeedBackRoutes(mux *http.ServeMux, sesEventService service.SESEventService, snsVerifier service.SNSVerifier) {
 
-	handler := handlers.NewSESFeedbackHandler(audienceRepo, contactRepo)
+	handler := handlers.NewSESFeedbackHandler(sesEventService, snsVerifier)
 
-	// 📌 Atualiza contactAudience
+	// 📌 Atualiza contactAudience (mensagens SNS assinadas; confirma inscrições automaticamente)
 	mux.Handle("POST /ses-feedback", handler.HandleSESFeedback())
-
-	// SNS confirmend subscription
-	// mux.Handle("POST /ses-feedback", handler.HandleSNSEvent())
 }
@@ -0,0 +1,28 @@
+// File: /internal/server/routes/suppression_routes.go
+
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/server/handlers"
+)
+
+// RegisterSuppressionRoutes adiciona as rotas da lista de supressão (bounces, reclamações e bloqueios manuais)
+func RegisterSuppressionRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.HandlerFunc, suppressionRepo db.SuppressionRepository) {
+
+	handler := handlers.NewSuppressionHandle(suppressionRepo)
+
+	// 📌 Listar supressões da conta
+	mux.Handle("GET /suppressions", authMiddleware(handler.GetSuppressionsHandler()))
+
+	// 📌 Suprimir manualmente um destinatário
+	mux.Handle("POST /suppressions", authMiddleware(handler.CreateSuppressionHandler()))
+
+	// 📌 Buscar supressão
+	mux.Handle("GET /suppressions/{suppression_id}", authMiddleware(handler.GetSuppressionHandler()))
+
+	// 📌 Remover supressão (o destinatário volta a receber)
+	mux.Handle("DELETE /suppressions/{suppression_id}", authMiddleware(handler.DeleteSuppressionHandler()))
+}
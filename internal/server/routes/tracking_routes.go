@@ -0,0 +1,23 @@
+// File: /internal/server/routes/tracking_routes.go
+
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/server/handlers"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// RegisterTrackingRoutes adiciona as rotas públicas de rastreamento de e-mails (validadas por assinatura)
+func RegisterTrackingRoutes(mux *http.ServeMux, engagementRepo db.EngagementRepository, emailTracking service.EmailTrackingService) {
+
+	handler := handlers.NewTrackingHandle(engagementRepo, emailTracking)
+
+	// 👀 Pixel de abertura
+	mux.HandleFunc("GET /track/open/{audience_id}", handler.TrackOpenHandler())
+
+	// 🔗 Redirecionamento de cliques
+	mux.HandleFunc("GET /track/click/{audience_id}", handler.TrackClickHandler())
+}
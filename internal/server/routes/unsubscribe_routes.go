@@ -0,0 +1,22 @@
+// File: /internal/server/routes/unsubscribe_routes.go
+
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/server/handlers"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// RegisterUnsubscribeRoutes adiciona as rotas públicas de descadastro (validadas pelo token assinado)
+func RegisterUnsubscribeRoutes(mux *http.ServeMux, unsubscribeService service.UnsubscribeService) {
+
+	handler := handlers.NewUnsubscribeHandle(unsubscribeService)
+
+	// 🚫 Link de descadastro (página de confirmação, sem descadastrar)
+	mux.HandleFunc("GET /unsubscribe/{token}", handler.ConfirmUnsubscribeHandler())
+
+	// 📬 Confirmação da página ou descadastro em um clique (List-Unsubscribe-Post)
+	mux.HandleFunc("POST /unsubscribe/{token}", handler.UnsubscribeHandler())
+}
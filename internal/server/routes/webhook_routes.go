@@ -9,10 +9,16 @@ import (
 	"github.com/jeancarlosdanese/go-marketing/internal/service"
 )
 
-// RegisterWebhookRoutes registra o endpoint público do webhook
-func RegisterWebhookRoutes(mux *http.ServeMux, chatService service.ChatWhatsAppService) {
-	webhookHandler := handlers.NewWebhookHandler(chatService)
+// RegisterWebhookRoutes registra os endpoints públicos dos webhooks do WhatsApp
+func RegisterWebhookRoutes(mux *http.ServeMux, chatService service.ChatWhatsAppService, providers service.WhatsAppProviders, statusService service.WhatsAppStatusService, cloudVerifyToken string) {
+	webhookHandler := handlers.NewWebhookHandler(chatService, providers, statusService, cloudVerifyToken)
 
-	// 🔓 Webhook é público — não passa por authMiddleware
+	// 🔓 Webhook é público — não passa por authMiddleware (Baileys, mantido por compatibilidade)
 	mux.Handle("POST /webhook", webhookHandler.Handle())
+
+	// 🔓 Webhook por provedor: baileys, evolution ou cloud_api
+	mux.Handle("POST /webhook/{provider}", webhookHandler.Handle())
+
+	// 🔓 Verificação do webhook no app da Meta (Cloud API)
+	mux.Handle("GET /webhook/cloud_api", webhookHandler.VerifyCloudSubscription())
 }
@@ -0,0 +1,38 @@
+// File: /internal/server/routes/whatsapp_template_routes.go
+
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/server/handlers"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// RegisterWhatsAppTemplateRoutes adiciona as rotas dos números e templates da Cloud API
+func RegisterWhatsAppTemplateRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.HandlerFunc, numberRepo db.WhatsAppPhoneNumberRepository, templateRepo db.WhatsAppTemplateRepository, templates service.WhatsAppTemplateService) {
+
+	handler := handlers.NewWhatsAppTemplateHandle(numberRepo, templateRepo, templates)
+
+	// 📌 Listar números da Cloud API da conta
+	mux.Handle("GET /whatsapp/phone-numbers", authMiddleware(handler.GetPhoneNumbersHandler()))
+
+	// 📌 Cadastrar número (phone_number_id + WABA) conferindo no Graph
+	mux.Handle("POST /whatsapp/phone-numbers", authMiddleware(handler.CreatePhoneNumberHandler()))
+
+	// 📌 Buscar número
+	mux.Handle("GET /whatsapp/phone-numbers/{whatsapp_phone_number_id}", authMiddleware(handler.GetPhoneNumberHandler()))
+
+	// 📌 Remover número
+	mux.Handle("DELETE /whatsapp/phone-numbers/{whatsapp_phone_number_id}", authMiddleware(handler.DeletePhoneNumberHandler()))
+
+	// 📌 Sincronizar os templates da WABA do número
+	mux.Handle("POST /whatsapp/phone-numbers/{whatsapp_phone_number_id}/sync-templates", authMiddleware(handler.SyncTemplatesHandler()))
+
+	// 📌 Listar templates sincronizados (?status=APPROVED&business_account_id=...)
+	mux.Handle("GET /whatsapp/templates", authMiddleware(handler.GetTemplatesHandler()))
+
+	// 📌 Buscar template e suas variáveis
+	mux.Handle("GET /whatsapp/templates/{whatsapp_template_id}", authMiddleware(handler.GetTemplateHandler()))
+}
@@ -0,0 +1,366 @@
+// File: /internal/service/asset_service.go
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+var (
+	// ErrInvalidAsset indica arquivo vazio, grande demais ou de tipo não permitido
+	ErrInvalidAsset = errors.New("arquivo inválido")
+	// ErrAssetNotFound indica arquivo inexistente ou de outra conta
+	ErrAssetNotFound = errors.New("arquivo não encontrado")
+	// ErrAssetInUse indica arquivo anexado a alguma campanha (não pode ser excluído)
+	ErrAssetInUse = errors.New("arquivo anexado a campanhas")
+)
+
+const (
+	// MaxEmailAttachments limita a quantidade de anexos por e-mail
+	MaxEmailAttachments = 10
+	// assetAttachmentCacheTTL mantém em memória os anexos usados nos envios de uma campanha
+	assetAttachmentCacheTTL = 10 * time.Minute
+)
+
+// assetType descreve um tipo de arquivo aceito: Content-Type gravado e o tipo detectado pelo conteúdo
+type assetType struct {
+	contentType string
+	sniffed     string // Resultado esperado de http.DetectContentType
+}
+
+// allowedAssetTypes são os tipos aceitos por extensão (SVG e HTML ficam de fora: podem executar scripts)
+var allowedAssetTypes = map[string]assetType{
+	".pdf":  {"application/pdf", "application/pdf"},
+	".png":  {"image/png", "image/png"},
+	".jpg":  {"image/jpeg", "image/jpeg"},
+	".jpeg": {"image/jpeg", "image/jpeg"},
+	".gif":  {"image/gif", "image/gif"},
+	".webp": {"image/webp", "image/webp"},
+	".csv":  {"text/csv", "text/plain"},
+	".txt":  {"text/plain", "text/plain"},
+	".docx": {"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "application/zip"},
+	".xlsx": {"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/zip"},
+}
+
+// AssetService gerencia os arquivos das contas e os anexos das campanhas
+type AssetService interface {
+	// MaxSize retorna o tamanho máximo de um arquivo
+	MaxSize() int64
+	// Upload valida e grava o arquivo enviado pela conta
+	Upload(ctx context.Context, accountID uuid.UUID, filename string, content []byte) (*models.Asset, error)
+	// List lista os arquivos da conta com a URL pública
+	List(ctx context.Context, accountID uuid.UUID, filters map[string]string, page, perPage int) (*models.Paginator, error)
+	// Get busca um arquivo da conta com a URL pública
+	Get(ctx context.Context, accountID, assetID uuid.UUID) (*models.Asset, error)
+	// Delete exclui o arquivo se nenhuma campanha o anexa
+	Delete(ctx context.Context, accountID, assetID uuid.UUID) error
+	// Open retorna o arquivo pela chave pública (GET /files/{key})
+	Open(ctx context.Context, key string) (*models.Asset, []byte, error)
+	// ValidateAttachments verifica se os anexos pertencem à conta e respeitam os limites do e-mail
+	ValidateAttachments(ctx context.Context, accountID uuid.UUID, assetIDs []uuid.UUID) error
+	// Attachments carrega o conteúdo dos anexos para o envio
+	Attachments(ctx context.Context, accountID uuid.UUID, assetIDs []uuid.UUID) ([]EmailAttachment, error)
+}
+
+type assetService struct {
+	log                *slog.Logger
+	repo               db.AssetRepository
+	storage            AssetStorage
+	maxSize            int64 // Tamanho máximo de cada arquivo
+	maxAttachmentsSize int64 // Soma máxima dos anexos de um e-mail
+
+	cacheMu sync.Mutex
+	cache   map[uuid.UUID]cachedAttachment
+}
+
+// cachedAttachment é um anexo em memória (o conteúdo de um arquivo nunca muda depois do upload)
+type cachedAttachment struct {
+	attachment EmailAttachment
+	expiresAt  time.Time
+}
+
+// NewAssetService cria o serviço de arquivos
+func NewAssetService(repo db.AssetRepository, storage AssetStorage, maxSize, maxAttachmentsSize int64) AssetService {
+	return &assetService{
+		log:                logger.GetLogger(),
+		repo:               repo,
+		storage:            storage,
+		maxSize:            maxSize,
+		maxAttachmentsSize: maxAttachmentsSize,
+		cache:              map[uuid.UUID]cachedAttachment{},
+	}
+}
+
+// MaxSize retorna o tamanho máximo de um arquivo
+func (s *assetService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// Upload confere extensão e conteúdo, grava no armazenamento e registra o arquivo
+func (s *assetService) Upload(ctx context.Context, accountID uuid.UUID, filename string, content []byte) (*models.Asset, error) {
+	name := sanitizeAssetName(filename)
+	if name == "" {
+		return nil, fmt.Errorf("%w: nome do arquivo é obrigatório", ErrInvalidAsset)
+	}
+	if len(content) == 0 {
+		return nil, fmt.Errorf("%w: arquivo vazio", ErrInvalidAsset)
+	}
+	if int64(len(content)) > s.maxSize {
+		return nil, fmt.Errorf("%w: o arquivo excede o limite de %d MB", ErrInvalidAsset, s.maxSize>>20)
+	}
+
+	ext := strings.ToLower(filepath.Ext(name))
+	fileType, ok := allowedAssetTypes[ext]
+	if !ok {
+		return nil, fmt.Errorf("%w: tipo de arquivo não permitido (%s)", ErrInvalidAsset, ext)
+	}
+
+	// 🔍 O conteúdo precisa corresponder à extensão (evita HTML/scripts renomeados)
+	sniffed, _, _ := strings.Cut(http.DetectContentType(content), ";")
+	if sniffed != fileType.sniffed {
+		return nil, fmt.Errorf("%w: o conteúdo (%s) não corresponde à extensão %s", ErrInvalidAsset, sniffed, ext)
+	}
+
+	asset := &models.Asset{
+		ID:          uuid.New(),
+		AccountID:   accountID,
+		Name:        name,
+		ContentType: fileType.contentType,
+		SizeBytes:   int64(len(content)),
+	}
+	asset.StorageKey = fmt.Sprintf("%s/%s%s", accountID, asset.ID, ext)
+
+	if err := s.storage.Put(ctx, asset.StorageKey, content, asset.ContentType); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.repo.Create(ctx, asset)
+	if err != nil {
+		if deleteErr := s.storage.Delete(ctx, asset.StorageKey); deleteErr != nil {
+			s.log.Warn("⚠️ Arquivo órfão no armazenamento", "storage_key", asset.StorageKey, "error", deleteErr)
+		}
+		return nil, err
+	}
+
+	saved.URL = s.storage.URL(saved.StorageKey)
+	s.log.Info("📎 Arquivo enviado", "account_id", accountID, "asset_id", saved.ID, "content_type", saved.ContentType, "size_bytes", saved.SizeBytes)
+	return saved, nil
+}
+
+// List lista os arquivos da conta preenchendo a URL pública
+func (s *assetService) List(ctx context.Context, accountID uuid.UUID, filters map[string]string, page, perPage int) (*models.Paginator, error) {
+	paginator, err := s.repo.GetPaginated(ctx, accountID, filters, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	if assets, ok := paginator.Data.([]models.Asset); ok {
+		for i := range assets {
+			assets[i].URL = s.storage.URL(assets[i].StorageKey)
+		}
+	}
+	return paginator, nil
+}
+
+// Get busca um arquivo da conta
+func (s *assetService) Get(ctx context.Context, accountID, assetID uuid.UUID) (*models.Asset, error) {
+	asset, err := s.repo.GetByID(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil || asset.AccountID != accountID {
+		return nil, ErrAssetNotFound
+	}
+
+	asset.URL = s.storage.URL(asset.StorageKey)
+	return asset, nil
+}
+
+// Delete exclui o registro e o conteúdo do arquivo
+func (s *assetService) Delete(ctx context.Context, accountID, assetID uuid.UUID) error {
+	asset, err := s.Get(ctx, accountID, assetID)
+	if err != nil {
+		return err
+	}
+
+	references, err := s.repo.CountCampaignReferences(ctx, assetID)
+	if err != nil {
+		return err
+	}
+	if references > 0 {
+		return fmt.Errorf("%w: remova-o de %d configuração(ões) de campanha antes de excluir", ErrAssetInUse, references)
+	}
+
+	if err := s.repo.DeleteByID(ctx, assetID); err != nil {
+		return err
+	}
+	if err := s.storage.Delete(ctx, asset.StorageKey); err != nil {
+		s.log.Warn("⚠️ Arquivo órfão no armazenamento", "storage_key", asset.StorageKey, "error", err)
+	}
+
+	s.forget(assetID)
+	s.log.Info("🗑️ Arquivo excluído", "account_id", accountID, "asset_id", assetID)
+	return nil
+}
+
+// Open retorna um arquivo registrado pela chave de armazenamento
+func (s *assetService) Open(ctx context.Context, key string) (*models.Asset, []byte, error) {
+	if !validAssetKey(key) {
+		return nil, nil, ErrAssetNotFound
+	}
+
+	asset, err := s.repo.GetByStorageKey(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if asset == nil {
+		return nil, nil, ErrAssetNotFound
+	}
+
+	content, err := s.storage.Get(ctx, key)
+	if errors.Is(err, ErrAssetNotStored) {
+		return nil, nil, ErrAssetNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return asset, content, nil
+}
+
+// ValidateAttachments confere quantidade, propriedade e tamanho total dos anexos
+func (s *assetService) ValidateAttachments(ctx context.Context, accountID uuid.UUID, assetIDs []uuid.UUID) error {
+	_, err := s.attachmentAssets(ctx, accountID, assetIDs)
+	return err
+}
+
+// Attachments carrega os anexos (do cache ou do armazenamento) na ordem configurada
+func (s *assetService) Attachments(ctx context.Context, accountID uuid.UUID, assetIDs []uuid.UUID) ([]EmailAttachment, error) {
+	if len(assetIDs) == 0 {
+		return nil, nil
+	}
+
+	assets, err := s.attachmentAssets(ctx, accountID, assetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]EmailAttachment, 0, len(assets))
+	for _, asset := range assets {
+		if cached, ok := s.cached(asset.ID); ok {
+			attachments = append(attachments, cached)
+			continue
+		}
+
+		content, err := s.storage.Get(ctx, asset.StorageKey)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar anexo %s: %w", asset.Name, err)
+		}
+
+		attachment := EmailAttachment{Filename: asset.Name, ContentType: asset.ContentType, Content: content}
+		s.remember(asset.ID, attachment)
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// attachmentAssets busca os anexos da conta na ordem informada, validando os limites do e-mail
+func (s *assetService) attachmentAssets(ctx context.Context, accountID uuid.UUID, assetIDs []uuid.UUID) ([]models.Asset, error) {
+	if len(assetIDs) > MaxEmailAttachments {
+		return nil, fmt.Errorf("%w: no máximo %d anexos por e-mail", ErrInvalidAsset, MaxEmailAttachments)
+	}
+	if len(assetIDs) == 0 {
+		return nil, nil
+	}
+
+	found, err := s.repo.GetByIDs(ctx, accountID, assetIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]models.Asset, len(found))
+	for _, asset := range found {
+		byID[asset.ID] = asset
+	}
+
+	assets := make([]models.Asset, 0, len(assetIDs))
+	var total int64
+	for _, id := range assetIDs {
+		asset, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: anexo %s", ErrAssetNotFound, id)
+		}
+		total += asset.SizeBytes
+		assets = append(assets, asset)
+	}
+
+	if total > s.maxAttachmentsSize {
+		return nil, fmt.Errorf("%w: os anexos somam %.1f MB (limite de %d MB por e-mail)", ErrInvalidAsset, float64(total)/(1<<20), s.maxAttachmentsSize>>20)
+	}
+	return assets, nil
+}
+
+// cached retorna o anexo em memória se ainda válido
+func (s *assetService) cached(assetID uuid.UUID) (EmailAttachment, bool) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	entry, ok := s.cache[assetID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return EmailAttachment{}, false
+	}
+	return entry.attachment, true
+}
+
+// remember guarda o anexo em memória, descartando os expirados
+func (s *assetService) remember(assetID uuid.UUID, attachment EmailAttachment) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	now := time.Now()
+	for id, entry := range s.cache {
+		if now.After(entry.expiresAt) {
+			delete(s.cache, id)
+		}
+	}
+	s.cache[assetID] = cachedAttachment{attachment: attachment, expiresAt: now.Add(assetAttachmentCacheTTL)}
+}
+
+// forget remove o anexo da memória
+func (s *assetService) forget(assetID uuid.UUID) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	delete(s.cache, assetID)
+}
+
+// sanitizeAssetName mantém apenas o nome do arquivo, sem diretórios nem caracteres de controle
+func sanitizeAssetName(filename string) string {
+	name := filepath.Base(strings.ReplaceAll(filename, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == "/" {
+		return ""
+	}
+	if len(name) > 255 {
+		ext := filepath.Ext(name)
+		name = strings.ToValidUTF8(name[:255-len(ext)], "") + ext
+	}
+	return name
+}
@@ -0,0 +1,100 @@
+// File: /internal/service/asset_storage.go
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrAssetNotStored indica que o conteúdo do arquivo não existe no armazenamento
+var ErrAssetNotStored = errors.New("arquivo não encontrado no armazenamento")
+
+// AssetStorage guarda o conteúdo dos arquivos das contas (disco local ou bucket compatível com S3)
+type AssetStorage interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string // URL pública do arquivo (usada nos templates)
+}
+
+// localAssetStorage grava os arquivos em disco; a URL pública aponta para GET /files/{key} desta API
+type localAssetStorage struct {
+	basePath      string
+	publicBaseURL string
+}
+
+// NewLocalAssetStorage cria o armazenamento em disco (publicBaseURL: ex. https://api.example.com/files)
+func NewLocalAssetStorage(basePath, publicBaseURL string) AssetStorage {
+	return &localAssetStorage{basePath: basePath, publicBaseURL: strings.TrimSuffix(publicBaseURL, "/")}
+}
+
+// Put grava o arquivo criando os diretórios da conta
+func (s *localAssetStorage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	filePath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return fmt.Errorf("erro ao criar diretório do arquivo: %w", err)
+	}
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		return fmt.Errorf("erro ao salvar arquivo: %w", err)
+	}
+	return nil
+}
+
+// Get lê o conteúdo do arquivo
+func (s *localAssetStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	filePath, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrAssetNotStored
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+	return content, nil
+}
+
+// Delete remove o arquivo (ausente não é erro)
+func (s *localAssetStorage) Delete(ctx context.Context, key string) error {
+	filePath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("erro ao excluir arquivo: %w", err)
+	}
+	return nil
+}
+
+// URL monta a URL pública servida por GET /files/{key}
+func (s *localAssetStorage) URL(key string) string {
+	return s.publicBaseURL + "/" + s3EscapeKey(key)
+}
+
+// path resolve a chave dentro do diretório base, recusando caminhos que escapem dele
+func (s *localAssetStorage) path(key string) (string, error) {
+	if !validAssetKey(key) {
+		return "", fmt.Errorf("chave de arquivo inválida: %q", key)
+	}
+	return filepath.Join(s.basePath, filepath.FromSlash(key)), nil
+}
+
+// validAssetKey aceita apenas chaves relativas e limpas ("conta/arquivo.ext")
+func validAssetKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	return path.Clean(key) == key && !strings.HasPrefix(key, "../") && key != ".."
+}
@@ -0,0 +1,95 @@
+// File: /internal/service/asset_storage_s3.go
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+// s3AssetMaxObjectSize limita o download de um arquivo do bucket
+const s3AssetMaxObjectSize = 100 << 20
+
+// S3AssetStorageConfig configura o bucket dos arquivos (AWS S3, MinIO, R2 e outros compatíveis)
+type S3AssetStorageConfig struct {
+	Bucket        string
+	Region        string
+	Endpoint      string // Vazio = AWS
+	PathStyle     bool   // Endpoint/bucket/chave (MinIO)
+	AccessKey     string // Vazio = credenciais padrão da AWS
+	SecretKey     string
+	PublicBaseURL string // CDN ou domínio do bucket; vazio = URL do próprio objeto
+}
+
+// s3AssetStorage grava os arquivos em um bucket compatível com S3
+type s3AssetStorage struct {
+	client        *s3Client
+	bucket        string
+	publicBaseURL string
+}
+
+// NewS3AssetStorage cria o armazenamento em bucket compatível com S3
+func NewS3AssetStorage(ctx context.Context, cfg S3AssetStorageConfig) (AssetStorage, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("bucket dos arquivos não configurado")
+	}
+
+	var options []func(*config.LoadOptions) error
+	if cfg.Region != "" {
+		options = append(options, config.WithRegion(cfg.Region))
+	}
+	if cfg.AccessKey != "" {
+		options = append(options, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, "")))
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar configuração AWS: %w", err)
+	}
+	if awsCfg.Region == "" {
+		awsCfg.Region = "us-east-1" // 🔹 Provedores compatíveis costumam ignorar a região, mas a assinatura exige uma
+	}
+
+	return &s3AssetStorage{
+		client:        newS3Client(awsCfg, cfg.Endpoint, cfg.PathStyle),
+		bucket:        cfg.Bucket,
+		publicBaseURL: strings.TrimSuffix(cfg.PublicBaseURL, "/"),
+	}, nil
+}
+
+// Put envia o arquivo ao bucket
+func (s *s3AssetStorage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	if err := s.client.PutObject(ctx, s.bucket, key, content, contentType); err != nil {
+		return fmt.Errorf("erro ao enviar arquivo ao S3: %w", err)
+	}
+	return nil
+}
+
+// Get baixa o arquivo do bucket
+func (s *s3AssetStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	content, err := s.client.GetObject(ctx, s.bucket, key, s3AssetMaxObjectSize)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao baixar arquivo do S3: %w", err)
+	}
+	return content, nil
+}
+
+// Delete remove o arquivo do bucket
+func (s *s3AssetStorage) Delete(ctx context.Context, key string) error {
+	if err := s.client.DeleteObject(ctx, s.bucket, key); err != nil {
+		return fmt.Errorf("erro ao excluir arquivo do S3: %w", err)
+	}
+	return nil
+}
+
+// URL usa o domínio público configurado (CDN) ou a URL do objeto no bucket
+func (s *s3AssetStorage) URL(key string) string {
+	if s.publicBaseURL != "" {
+		return s.publicBaseURL + "/" + s3EscapeKey(key)
+	}
+	return s.client.objectURL(s.bucket, key)
+}
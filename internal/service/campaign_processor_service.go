@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/db"
 	"github.com/jeancarlosdanese/go-marketing/internal/dto"
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
@@ -20,6 +21,8 @@ import (
 type CampaignProcessorService interface {
 	ProcessCampaign(ctx context.Context, campaign *models.Campaign, audience []dto.CampaignMessageDTO) error
 	GenerateCampaignContent(ctx context.Context, data dto.CampaignMessageFullDTO) (*dto.CampaignContentResult, string, error)
+	// Model retorna o modelo de IA usado para gerar o conteúdo
+	Model() string
 }
 
 type campaignProcessorService struct {
@@ -46,12 +49,27 @@ func (s *campaignProcessorService) ProcessCampaign(ctx context.Context, campaign
 	s.log.Info("📢 Iniciando envio da campanha", "campaign_id", campaign.ID, "total_contatos", len(audience))
 
 	for _, msg := range audience {
+		// 🎟️ Cada enfileiramento tem seu token: cópias de um enfileiramento anterior (pausa e retomada) são descartadas
+		msg.DispatchToken = uuid.New()
+
 		msgJSON, err := json.Marshal(msg)
 		if err != nil {
 			s.log.Error("Erro ao serializar mensagem para o SQS", "contact_id", msg.ContactID, "error", err)
 			continue
 		}
 
+		// 📥 Marca "fila" antes de enfileirar: um worker rápido pode reservar a audiência antes do retorno do SendMessage
+		queued, err := s.audienceRepo.MarkQueued(ctx, msg.ID, msg.DispatchToken)
+		if err != nil {
+			s.log.Error("Erro ao marcar audiência na fila", "audience_id", msg.ID, "error", err)
+			continue
+		}
+		if !queued {
+			// ✅ Reservada ou enviada por uma mensagem anterior depois da busca da audiência
+			s.log.Debug("Audiência já em envio ou enviada, não será enfileirada", "audience_id", msg.ID)
+			continue
+		}
+
 		// 🔄 Tenta enviar a mensagem até 3 vezes antes de desistir
 		retries := 3
 		for i := 0; i < retries; i++ {
@@ -65,9 +83,7 @@ func (s *campaignProcessorService) ProcessCampaign(ctx context.Context, campaign
 
 		if err != nil {
 			s.log.Error("❌ Falha após retries", "contact_id", msg.ContactID, "queue", msg.Type, "error", err)
-			s.audienceRepo.UpdateStatus(ctx, msg.ID, "falha", err.Error(), nil) // Marca erro no banco
-		} else {
-			s.audienceRepo.UpdateStatus(ctx, msg.ID, "na_fila", "", nil) // ✅ Sucesso
+			s.audienceRepo.UpdateStatus(ctx, msg.ID, string(models.AudienceFalhaEnvio), "", map[string]interface{}{"error": err.Error()}) // Marca erro no banco
 		}
 	}
 
@@ -75,6 +91,11 @@ func (s *campaignProcessorService) ProcessCampaign(ctx context.Context, campaign
 	return nil
 }
 
+// Model retorna o modelo de IA usado para gerar o conteúdo
+func (s *campaignProcessorService) Model() string {
+	return s.model
+}
+
 // 🧠 Gerar conteúdo da campanha com IA
 func (s *campaignProcessorService) GenerateCampaignContent(ctx context.Context, data dto.CampaignMessageFullDTO) (*dto.CampaignContentResult, string, error) {
 	prompt := buildPromptFromCampaignData(data)
@@ -0,0 +1,87 @@
+// File: /internal/service/campaign_processor_service_test.go
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// fakeQueuedAudienceRepo aceita o enfileiramento somente das audiências ainda não enviadas
+type fakeQueuedAudienceRepo struct {
+	db.CampaignAudienceRepository
+
+	status map[uuid.UUID]models.AudienceStatus
+	tokens map[uuid.UUID]uuid.UUID
+}
+
+func (r *fakeQueuedAudienceRepo) MarkQueued(ctx context.Context, audienceID uuid.UUID, token uuid.UUID) (bool, error) {
+	switch r.status[audienceID] {
+	case models.AudiencePendente, models.AudienceFila, models.AudienceFalhaEnvio:
+		r.status[audienceID] = models.AudienceFila
+		r.tokens[audienceID] = token
+		return true, nil
+	}
+	return false, nil
+}
+
+// capturingQueue guarda as mensagens publicadas
+type capturingQueue struct {
+	SQSService
+
+	messages []dto.CampaignMessageDTO
+}
+
+func (q *capturingQueue) SendMessage(ctx context.Context, queueName string, message interface{}) error {
+	var msg dto.CampaignMessageDTO
+	if err := json.Unmarshal([]byte(message.(string)), &msg); err != nil {
+		return err
+	}
+	q.messages = append(q.messages, msg)
+	return nil
+}
+
+func (q *capturingQueue) SendMessageWithDelay(ctx context.Context, queueName string, message interface{}, delay time.Duration) error {
+	return q.SendMessage(ctx, queueName, message)
+}
+
+func TestProcessCampaignStampsDispatchToken(t *testing.T) {
+	pending, sent := uuid.New(), uuid.New()
+	repo := &fakeQueuedAudienceRepo{
+		status: map[uuid.UUID]models.AudienceStatus{pending: models.AudiencePendente, sent: models.AudienceEnviado},
+		tokens: map[uuid.UUID]uuid.UUID{},
+	}
+	queue := &capturingQueue{}
+	processor := NewCampaignProcessorService(queue, nil, repo)
+
+	audience := []dto.CampaignMessageDTO{
+		{ID: pending, Type: "email"},
+		{ID: sent, Type: "email"}, // ✅ Enviada por uma mensagem anterior depois da busca da audiência
+	}
+	if err := processor.ProcessCampaign(context.Background(), &models.Campaign{ID: uuid.New()}, audience); err != nil {
+		t.Fatalf("ProcessCampaign: %v", err)
+	}
+
+	if len(queue.messages) != 1 || queue.messages[0].ID != pending {
+		t.Fatalf("mensagens enfileiradas = %+v, esperado apenas a audiência pendente", queue.messages)
+	}
+	token := queue.messages[0].DispatchToken
+	if token == uuid.Nil || repo.tokens[pending] != token {
+		t.Fatalf("token da mensagem = %s, token gravado = %s", token, repo.tokens[pending])
+	}
+
+	// ▶️ Reenfileirar (retomada) troca o token: a cópia anterior deixa de valer
+	if err := processor.ProcessCampaign(context.Background(), &models.Campaign{ID: uuid.New()}, audience[:1]); err != nil {
+		t.Fatalf("ProcessCampaign: %v", err)
+	}
+	if len(queue.messages) != 2 || queue.messages[1].DispatchToken == token || repo.tokens[pending] != queue.messages[1].DispatchToken {
+		t.Fatalf("retomada não gerou um token novo: %+v", queue.messages)
+	}
+}
@@ -0,0 +1,171 @@
+// File: /internal/service/campaign_state_service.go
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// ErrInvalidCampaignTransition indica uma mudança de status não permitida pela máquina de estados
+var ErrInvalidCampaignTransition = errors.New("transição de status da campanha inválida")
+
+// campaignTransitions define, para cada status, os próximos status permitidos
+var campaignTransitions = map[models.CampaignStatus][]models.CampaignStatus{
+	models.StatusPendente:           {models.StatusAgendada, models.StatusProcessando, models.StatusCancelada},
+	models.StatusAgendada:           {models.StatusAgendada, models.StatusPendente, models.StatusProcessando, models.StatusCancelada},
+	models.StatusProcessando:        {models.StatusEnviando, models.StatusPausada, models.StatusConcluida, models.StatusConcluidaComFalhas, models.StatusCancelada},
+	models.StatusEnviando:           {models.StatusPausada, models.StatusConcluida, models.StatusConcluidaComFalhas, models.StatusCancelada},
+	models.StatusPausada:            {models.StatusProcessando, models.StatusCancelada},
+	models.StatusConcluida:          {models.StatusEnviando}, // 🔁 Reprocessamento de dead-letters
+	models.StatusConcluidaComFalhas: {models.StatusEnviando}, // 🔁 Reprocessamento dos contatos com falha
+	models.StatusCancelada:          {},
+}
+
+// schedulerTransitions são exclusivas do agendador: devolvem a campanha reservada quando o disparo não pôde começar
+var schedulerTransitions = map[models.CampaignStatus][]models.CampaignStatus{
+	models.StatusProcessando: {models.StatusAgendada, models.StatusPendente},
+}
+
+// CanTransitionCampaign verifica se a máquina de estados permite ir de `from` para `to` a partir da origem informada
+func CanTransitionCampaign(from, to models.CampaignStatus, source models.StatusChangeSource) bool {
+	if slices.Contains(campaignTransitions[from], to) {
+		return true
+	}
+	return source == models.StatusSourceScheduler && slices.Contains(schedulerTransitions[from], to)
+}
+
+// CampaignStatusChange descreve quem fez a mudança de status e por quê
+type CampaignStatusChange struct {
+	ChangedBy *uuid.UUID
+	Source    models.StatusChangeSource
+	Reason    string
+}
+
+// CampaignStateService é o único ponto de mudança de status das campanhas
+type CampaignStateService interface {
+	// Transition valida e aplica a mudança de status, registrando o histórico. Em caso de sucesso, campaign.Status é atualizado.
+	Transition(ctx context.Context, campaign *models.Campaign, to models.CampaignStatus, change CampaignStatusChange) error
+	// RecordTransition registra no histórico uma transição já aplicada de forma atômica no banco (ex.: reserva do agendador)
+	RecordTransition(ctx context.Context, campaignID uuid.UUID, from, to models.CampaignStatus, change CampaignStatusChange)
+	// CompleteIfFinished conclui a campanha em envio quando não há mais contatos aguardando
+	// ("concluida_com_falhas" se algum contato ficou sem receber a mensagem)
+	CompleteIfFinished(ctx context.Context, campaignID uuid.UUID) (bool, error)
+	// GetHistory retorna a linha do tempo de status da campanha
+	GetHistory(ctx context.Context, campaignID uuid.UUID) ([]models.CampaignStatusHistory, error)
+}
+
+type campaignStateService struct {
+	log          *slog.Logger
+	campaignRepo db.CampaignRepository
+	audienceRepo db.CampaignAudienceRepository
+	historyRepo  db.CampaignStatusHistoryRepository
+}
+
+// NewCampaignStateService cria a máquina de estados das campanhas
+func NewCampaignStateService(
+	campaignRepo db.CampaignRepository,
+	audienceRepo db.CampaignAudienceRepository,
+	historyRepo db.CampaignStatusHistoryRepository,
+) CampaignStateService {
+	return &campaignStateService{
+		log:          logger.GetLogger(),
+		campaignRepo: campaignRepo,
+		audienceRepo: audienceRepo,
+		historyRepo:  historyRepo,
+	}
+}
+
+// Transition valida a transição e troca o status somente se ele ainda for o lido (evita sobrescrever mudanças concorrentes)
+func (s *campaignStateService) Transition(ctx context.Context, campaign *models.Campaign, to models.CampaignStatus, change CampaignStatusChange) error {
+	from := campaign.Status
+	if !CanTransitionCampaign(from, to, change.Source) {
+		return fmt.Errorf("%w: %s → %s", ErrInvalidCampaignTransition, from, to)
+	}
+
+	changed, err := s.campaignRepo.TransitionStatus(ctx, campaign.ID, from, to)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return fmt.Errorf("%w: o status da campanha mudou durante a operação", ErrInvalidCampaignTransition)
+	}
+
+	campaign.Status = to
+	s.RecordTransition(ctx, campaign.ID, from, to, change)
+	return nil
+}
+
+// RecordTransition grava o histórico; a mudança de status já foi aplicada, então uma falha aqui é apenas registrada no log
+func (s *campaignStateService) RecordTransition(ctx context.Context, campaignID uuid.UUID, from, to models.CampaignStatus, change CampaignStatusChange) {
+	entry := &models.CampaignStatusHistory{
+		CampaignID: campaignID,
+		FromStatus: from,
+		ToStatus:   to,
+		ChangedBy:  change.ChangedBy,
+		Source:     change.Source,
+	}
+	if change.Reason != "" {
+		entry.Reason = &change.Reason
+	}
+
+	if err := s.historyRepo.Create(ctx, entry); err != nil {
+		s.log.Error("❌ Erro ao registrar histórico de status", "campaign_id", campaignID, "from", from, "to", to, "error", err)
+		return
+	}
+
+	s.log.Info("🔀 Status da campanha alterado", "campaign_id", campaignID, "from", from, "to", to, "source", change.Source)
+}
+
+// CompleteIfFinished é chamado pelo pipeline após cada mensagem finalizada
+func (s *campaignStateService) CompleteIfFinished(ctx context.Context, campaignID uuid.UUID) (bool, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, campaignID)
+	if err != nil || campaign == nil {
+		return false, err
+	}
+	if campaign.Status != models.StatusProcessando && campaign.Status != models.StatusEnviando {
+		return false, nil
+	}
+
+	pending, err := s.audienceRepo.HasPending(ctx, campaignID)
+	if err != nil || pending {
+		return false, err
+	}
+
+	// ⚠️ Contatos que não receberam a mensagem não podem terminar como sucesso silencioso
+	failed, err := s.audienceRepo.CountUnsentFailures(ctx, campaignID)
+	if err != nil {
+		return false, err
+	}
+
+	to, reason := models.StatusConcluida, "todos os contatos foram processados"
+	if failed > 0 {
+		to, reason = models.StatusConcluidaComFalhas, fmt.Sprintf("%d contato(s) não receberam a mensagem", failed)
+	}
+
+	err = s.Transition(ctx, campaign, to, CampaignStatusChange{
+		Source: models.StatusSourcePipeline,
+		Reason: reason,
+	})
+	if errors.Is(err, ErrInvalidCampaignTransition) {
+		return false, nil // ✅ Outro worker concluiu (ou a campanha foi pausada) ao mesmo tempo
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetHistory retorna a linha do tempo de status da campanha
+func (s *campaignStateService) GetHistory(ctx context.Context, campaignID uuid.UUID) ([]models.CampaignStatusHistory, error) {
+	return s.historyRepo.GetByCampaignID(ctx, campaignID)
+}
@@ -0,0 +1,95 @@
+// File: /internal/service/campaign_state_service_test.go
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// stateCampaignRepo guarda uma única campanha em memória
+type stateCampaignRepo struct {
+	db.CampaignRepository
+
+	campaign models.Campaign
+}
+
+func (r *stateCampaignRepo) GetByID(ctx context.Context, campaignID uuid.UUID) (*models.Campaign, error) {
+	campaign := r.campaign
+	return &campaign, nil
+}
+
+func (r *stateCampaignRepo) TransitionStatus(ctx context.Context, campaignID uuid.UUID, from, to models.CampaignStatus) (bool, error) {
+	if r.campaign.Status != from {
+		return false, nil
+	}
+	r.campaign.Status = to
+	return true, nil
+}
+
+// stateAudienceRepo informa pendências e falhas fixas da audiência
+type stateAudienceRepo struct {
+	db.CampaignAudienceRepository
+
+	pending bool
+	failed  int
+}
+
+func (r *stateAudienceRepo) HasPending(ctx context.Context, campaignID uuid.UUID) (bool, error) {
+	return r.pending, nil
+}
+
+func (r *stateAudienceRepo) CountUnsentFailures(ctx context.Context, campaignID uuid.UUID) (int, error) {
+	return r.failed, nil
+}
+
+// stateHistoryRepo descarta o histórico
+type stateHistoryRepo struct {
+	db.CampaignStatusHistoryRepository
+}
+
+func (r *stateHistoryRepo) Create(ctx context.Context, entry *models.CampaignStatusHistory) error {
+	return nil
+}
+
+func TestCompleteIfFinished(t *testing.T) {
+	tests := []struct {
+		name          string
+		pending       bool
+		failed        int
+		wantCompleted bool
+		wantStatus    models.CampaignStatus
+	}{
+		{name: "envios pendentes mantêm a campanha em envio", pending: true, wantStatus: models.StatusEnviando},
+		{name: "todos enviados conclui", wantCompleted: true, wantStatus: models.StatusConcluida},
+		{name: "contatos com falha concluem com falhas", failed: 2, wantCompleted: true, wantStatus: models.StatusConcluidaComFalhas},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaigns := &stateCampaignRepo{campaign: models.Campaign{ID: uuid.New(), Status: models.StatusEnviando}}
+			state := NewCampaignStateService(campaigns, &stateAudienceRepo{pending: tt.pending, failed: tt.failed}, &stateHistoryRepo{})
+
+			completed, err := state.CompleteIfFinished(context.Background(), campaigns.campaign.ID)
+			if err != nil {
+				t.Fatalf("CompleteIfFinished: %v", err)
+			}
+			if completed != tt.wantCompleted {
+				t.Fatalf("concluída = %v, esperado %v", completed, tt.wantCompleted)
+			}
+			if campaigns.campaign.Status != tt.wantStatus {
+				t.Fatalf("status = %s, esperado %s", campaigns.campaign.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCampaignCompletedWithFailuresCanRetry(t *testing.T) {
+	if !CanTransitionCampaign(models.StatusConcluidaComFalhas, models.StatusEnviando, models.StatusSourceAPI) {
+		t.Fatal("campanha concluída com falhas deve voltar a 'enviando' no reprocessamento")
+	}
+}
@@ -0,0 +1,161 @@
+// File: /internal/service/chat_media_processor.go
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// chatMediaTextLimit limita o texto extraído gravado na mensagem (o histórico inteiro vai para o prompt da sugestão)
+const chatMediaTextLimit = 4000
+
+// chatMediaVisionPrompt orienta a descrição de imagens e documentos recebidos no atendimento
+const chatMediaVisionPrompt = `Você descreve arquivos recebidos de clientes no atendimento por WhatsApp para que o atendente (e a IA que sugere respostas) entenda a mensagem sem abrir o arquivo.
+
+- Descreva de forma objetiva o que o arquivo mostra e o que ele indica sobre o pedido do cliente (produto, problema, comprovante, dúvida...).
+- Transcreva os textos relevantes (OCR): valores, datas, códigos, nomes de produtos, mensagens de erro.
+- Não invente informações que não estejam visíveis.
+- Responda em português, em texto simples, com no máximo 120 palavras.`
+
+// ChatMediaProcessor extrai o conteúdo das mídias recebidas: transcrição dos áudios e descrição (com OCR) de imagens e documentos
+type ChatMediaProcessor interface {
+	// Types retorna os tipos de mensagem que os provedores configurados conseguem processar
+	Types() []string
+	// Process retorna o conteúdo da mensagem com o texto extraído da mídia
+	Process(ctx context.Context, msg *models.ChatMessage) (string, error)
+}
+
+type chatMediaProcessor struct {
+	log          *slog.Logger
+	media        ChatMediaService
+	speechToText SpeechToTextService
+	openai       OpenAIService
+	visionModel  string
+}
+
+// NewChatMediaProcessor cria o processamento das mídias. speechToText nil desativa a transcrição;
+// visionModel vazio desativa a descrição de imagens e documentos.
+func NewChatMediaProcessor(media ChatMediaService, speechToText SpeechToTextService, openai OpenAIService, visionModel string) ChatMediaProcessor {
+	return &chatMediaProcessor{
+		log:          logger.GetLogger(),
+		media:        media,
+		speechToText: speechToText,
+		openai:       openai,
+		visionModel:  visionModel,
+	}
+}
+
+// Types retorna os tipos processáveis (vazio quando nada está configurado)
+func (p *chatMediaProcessor) Types() []string {
+	var types []string
+	if p.speechToText != nil {
+		types = append(types, "audio")
+	}
+	if p.visionModel != "" {
+		types = append(types, "imagem", "documento")
+	}
+	return types
+}
+
+// Process baixa a mídia do armazenamento do atendimento e extrai o texto. A legenda enviada pelo cliente
+// é mantida antes do texto extraído; documentos sem conteúdo legível mantêm apenas a legenda.
+func (p *chatMediaProcessor) Process(ctx context.Context, msg *models.ChatMessage) (string, error) {
+	media, content, err := p.media.Load(ctx, msg.FileURL)
+	if err != nil {
+		if errors.Is(err, ErrChatMediaNotFound) {
+			return "", NewPermanentError("mídia da mensagem %s não encontrada no armazenamento", msg.ID)
+		}
+		return "", fmt.Errorf("erro ao ler mídia da mensagem %s: %w", msg.ID, err)
+	}
+	mimeType := chatMediaMimeType(media.FileName)
+
+	var extracted string
+	switch {
+	case msg.Type == "audio":
+		if p.speechToText == nil {
+			return "", NewPermanentError("transcrição de áudio não configurada")
+		}
+		extracted, err = p.speechToText.Transcribe(ctx, media.FileName, content)
+
+	case strings.HasPrefix(mimeType, "image/"):
+		extracted, err = p.describe(ctx, ChatContentPart{
+			Type:     "image_url",
+			ImageURL: &ChatImageURL{URL: dataURL(mimeType, content)},
+		})
+
+	case mimeType == "application/pdf":
+		extracted, err = p.describe(ctx, ChatContentPart{
+			Type: "file",
+			File: &ChatFile{FileName: media.FileName, FileData: dataURL(mimeType, content)},
+		})
+
+	case strings.HasPrefix(mimeType, "text/") && utf8.Valid(content):
+		extracted = string(content)
+
+	default:
+		p.log.Debug("Documento sem conteúdo legível para processamento", slog.String("message_id", msg.ID.String()), slog.String("mime_type", mimeType))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return joinChatMediaContent(msg.Content, truncateText(strings.TrimSpace(extracted), chatMediaTextLimit)), nil
+}
+
+// describe pede ao modelo de visão a descrição do arquivo
+func (p *chatMediaProcessor) describe(ctx context.Context, file ChatContentPart) (string, error) {
+	if p.visionModel == "" {
+		return "", NewPermanentError("descrição de imagens e documentos não configurada")
+	}
+
+	resp, err := p.openai.CreateChatCompletion(ctx, ChatCompletionRequest{
+		Model: p.visionModel,
+		Messages: []ChatMessage{
+			{Role: "system", Content: chatMediaVisionPrompt},
+			{Role: "user", Parts: []ChatContentPart{{Type: "text", Text: "Descreva o arquivo enviado pelo cliente."}, file}},
+		},
+		Temperature: 0,
+	})
+	if err != nil {
+		return "", fmt.Errorf("erro na descrição da mídia: %w", err)
+	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("resposta da IA vazia na descrição da mídia")
+	}
+	return resp.Choices[0].Message.Content, nil
+}
+
+// joinChatMediaContent mantém a legenda do cliente antes do texto extraído
+func joinChatMediaContent(caption, extracted string) string {
+	caption = strings.TrimSpace(caption)
+	switch {
+	case extracted == "":
+		return caption
+	case caption == "":
+		return extracted
+	}
+	return caption + "\n\n" + extracted
+}
+
+// dataURL codifica o arquivo para envio inline à OpenAI
+func dataURL(mimeType string, content []byte) string {
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(content)
+}
+
+// truncateText corta o texto em `limit` caracteres sem quebrar runas
+func truncateText(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:limit])) + "…"
+}
@@ -0,0 +1,369 @@
+// File: /internal/service/chat_media_service.go
+
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+)
+
+var (
+	// ErrInvalidChatMedia indica mídia vazia, grande demais, de tipo desconhecido ou URL que não é do armazenamento
+	ErrInvalidChatMedia = errors.New("mídia inválida")
+	// ErrChatMediaNotFound indica mídia inexistente ou assinatura inválida
+	ErrChatMediaNotFound = errors.New("mídia não encontrada")
+	// ErrChatMediaDisabled indica armazenamento de mídias sem CHAT_MEDIA_BASE_URL ou CHAT_MEDIA_SECRET
+	ErrChatMediaDisabled = errors.New("armazenamento de mídias do atendimento não configurado")
+)
+
+// chatMediaPath é o caminho (nesta API) que serve as mídias do atendimento
+const chatMediaPath = "/chat-media/"
+
+// chatMediaContentTypes são os tipos exibidos no navegador (inline). Os demais são servidos como download
+// (application/octet-stream) para que HTML ou scripts recebidos como documento nunca executem no domínio da API.
+var chatMediaContentTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".mp4":  "video/mp4",
+	".3gp":  "video/3gpp",
+	".ogg":  "audio/ogg",
+	".opus": "audio/ogg",
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".aac":  "audio/aac",
+	".amr":  "audio/amr",
+	".pdf":  "application/pdf",
+}
+
+// chatMediaExtensions escolhe a extensão pelo Content-Type quando o provedor não informa o nome do arquivo
+var chatMediaExtensions = map[string]string{
+	"image/jpeg":               ".jpg",
+	"image/png":                ".png",
+	"image/gif":                ".gif",
+	"image/webp":               ".webp",
+	"video/mp4":                ".mp4",
+	"video/3gpp":               ".3gp",
+	"audio/ogg":                ".ogg",
+	"audio/opus":               ".ogg",
+	"audio/mpeg":               ".mp3",
+	"audio/mp4":                ".m4a",
+	"audio/aac":                ".aac",
+	"audio/amr":                ".amr",
+	"application/pdf":          ".pdf",
+	"text/plain":               ".txt",
+	"text/csv":                 ".csv",
+	"application/msword":       ".doc",
+	"application/vnd.ms-excel": ".xls",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   ".docx",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         ".xlsx",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": ".pptx",
+}
+
+// ChatMedia é uma mídia guardada no armazenamento do atendimento
+type ChatMedia struct {
+	Key         string `json:"key"`
+	URL         string    `json:"url"`  // URL assinada (file_url das mensagens)
+	Type        string    `json:"type"` // image, video, audio ou document
+	ContentType string    `json:"content_type"`
+	FileName    string    `json:"file_name"`
+	SizeBytes   int64     `json:"size_bytes,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at"` // Validade da URL assinada
+}
+
+// Inline indica se o navegador pode exibir a mídia (imagem, vídeo, áudio ou PDF)
+func (m *ChatMedia) Inline() bool {
+	return m.ContentType != "application/octet-stream"
+}
+
+// ChatMediaService guarda as mídias recebidas e enviadas pelo WhatsApp e gera as URLs assinadas usadas pelo chat
+type ChatMediaService interface {
+	// Enabled indica se o armazenamento está configurado (CHAT_MEDIA_BASE_URL e CHAT_MEDIA_SECRET)
+	Enabled() bool
+	// MaxSize retorna o tamanho máximo de uma mídia
+	MaxSize() int64
+	// Store grava a mídia da conta e retorna a URL assinada (validade curta, para o chat)
+	Store(ctx context.Context, accountID uuid.UUID, mediaType, fileName, contentType string, content []byte) (*ChatMedia, error)
+	// Resolve confere se a URL é uma mídia assinada da conta (mídias enviadas pelos atendentes) e
+	// retorna a mídia com a URL de validade longa usada pelo provedor para baixar o arquivo
+	Resolve(accountID uuid.UUID, fileURL string) (*ChatMedia, error)
+	// Refresh renova a URL assinada gravada na mensagem para exibição no chat; outras URLs voltam inalteradas
+	Refresh(fileURL string) string
+	// Open lê a mídia pela chave, validade e assinatura da URL (GET /chat-media/{key}?exp=...&sig=...)
+	Open(ctx context.Context, key, expires, signature string) (*ChatMedia, []byte, error)
+	// Load lê a mídia pela URL assinada gravada na mensagem, mesmo expirada (processamento de transcrição e visão)
+	Load(ctx context.Context, fileURL string) (*ChatMedia, []byte, error)
+}
+
+type chatMediaService struct {
+	log            *slog.Logger
+	storage        AssetStorage
+	baseURL        string
+	secret         []byte
+	maxSize        int64
+	urlTTL         time.Duration
+	providerURLTTL time.Duration
+	now            func() time.Time
+}
+
+// NewChatMediaService cria o armazenamento das mídias do atendimento (baseURL: URL pública desta API).
+// urlTTL é a validade dos links exibidos no chat; providerURLTTL, a dos links baixados pelo provedor ao enviar a mídia.
+func NewChatMediaService(storage AssetStorage, baseURL, secret string, maxSize int64, urlTTL, providerURLTTL time.Duration) ChatMediaService {
+	return &chatMediaService{
+		log:            logger.GetLogger(),
+		storage:        storage,
+		baseURL:        strings.TrimRight(baseURL, "/"),
+		secret:         []byte(secret),
+		maxSize:        maxSize,
+		urlTTL:         urlTTL,
+		providerURLTTL: providerURLTTL,
+		now:            time.Now,
+	}
+}
+
+// Enabled indica se o armazenamento está configurado
+func (s *chatMediaService) Enabled() bool {
+	return s.baseURL != "" && len(s.secret) > 0
+}
+
+// MaxSize retorna o tamanho máximo de uma mídia
+func (s *chatMediaService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// Store grava a mídia em {conta}/{id}/{nome} para que o download mantenha o nome original do documento
+func (s *chatMediaService) Store(ctx context.Context, accountID uuid.UUID, mediaType, fileName, contentType string, content []byte) (*ChatMedia, error) {
+	if !s.Enabled() {
+		return nil, ErrChatMediaDisabled
+	}
+	if !validChatMediaType(mediaType) {
+		return nil, fmt.Errorf("%w: tipo %q não suportado", ErrInvalidChatMedia, mediaType)
+	}
+	if len(content) == 0 {
+		return nil, fmt.Errorf("%w: arquivo vazio", ErrInvalidChatMedia)
+	}
+	if int64(len(content)) > s.maxSize {
+		return nil, fmt.Errorf("%w: a mídia excede o limite de %d MB", ErrInvalidChatMedia, s.maxSize>>20)
+	}
+
+	contentType, _, _ = strings.Cut(strings.ToLower(strings.TrimSpace(contentType)), ";")
+	name := sanitizeAssetName(fileName)
+	if strings.Trim(filepath.Ext(name), ".") == "" {
+		ext, ok := chatMediaExtensions[strings.TrimSpace(contentType)]
+		if !ok {
+			ext = ".bin"
+		}
+		name = mediaType + ext
+	}
+
+	media := &ChatMedia{
+		Key:         fmt.Sprintf("%s/%s/%s", accountID, uuid.New(), name),
+		Type:        mediaType,
+		ContentType: chatMediaContentType(name),
+		FileName:    name,
+		SizeBytes:   int64(len(content)),
+	}
+	if err := s.storage.Put(ctx, media.Key, content, media.ContentType); err != nil {
+		return nil, err
+	}
+	s.signURL(media, s.urlTTL)
+
+	s.log.Info("📎 Mídia do atendimento armazenada", "account_id", accountID, "type", mediaType, "content_type", media.ContentType, "size_bytes", media.SizeBytes)
+	return media, nil
+}
+
+// Resolve aceita apenas URLs geradas por Store para a própria conta
+func (s *chatMediaService) Resolve(accountID uuid.UUID, fileURL string) (*ChatMedia, error) {
+	if !s.Enabled() {
+		return nil, ErrChatMediaDisabled
+	}
+
+	key, ok := s.authenticKey(fileURL)
+	if !ok || !strings.HasPrefix(key, accountID.String()+"/") {
+		return nil, fmt.Errorf("%w: file_url não é uma mídia enviada por POST /chat-media para esta conta", ErrInvalidChatMedia)
+	}
+
+	return s.signURL(chatMediaFromKey(key), s.providerURLTTL), nil
+}
+
+// Refresh gera uma URL nova para a mídia; a URL gravada pode ter expirado desde o registro da mensagem
+func (s *chatMediaService) Refresh(fileURL string) string {
+	key, ok := s.authenticKey(fileURL)
+	if !ok {
+		return fileURL
+	}
+	return s.signURL(chatMediaFromKey(key), s.urlTTL).URL
+}
+
+// Load confere a assinatura da URL e lê a mídia do armazenamento. A validade não é conferida:
+// a URL vem de uma mensagem gravada pela própria API e o processamento pode ocorrer depois da expiração.
+func (s *chatMediaService) Load(ctx context.Context, fileURL string) (*ChatMedia, []byte, error) {
+	key, ok := s.authenticKey(fileURL)
+	if !ok {
+		return nil, nil, ErrChatMediaNotFound
+	}
+	return s.read(ctx, chatMediaFromKey(key))
+}
+
+// authenticKey retorna a chave de uma URL assinada por este armazenamento, expirada ou não
+func (s *chatMediaService) authenticKey(fileURL string) (string, bool) {
+	if !s.Enabled() {
+		return "", false
+	}
+	key, expires, signature, ok := s.parseURL(fileURL)
+	if !ok || !validAssetKey(key) || !s.verify(key, expires, signature) {
+		return "", false
+	}
+	return key, true
+}
+
+// parseURL extrai chave, validade e assinatura de uma URL gerada por signURL
+func (s *chatMediaService) parseURL(fileURL string) (string, string, string, bool) {
+	parsed, err := url.Parse(fileURL)
+	if err != nil || !strings.HasPrefix(fileURL, s.baseURL+chatMediaPath) {
+		return "", "", "", false
+	}
+	key := strings.TrimPrefix(parsed.Path, strings.TrimSuffix(urlPath(s.baseURL), "/")+chatMediaPath)
+	query := parsed.Query()
+	return key, query.Get("exp"), query.Get("sig"), true
+}
+
+// Open confere a assinatura e a validade da URL antes de ler o armazenamento
+func (s *chatMediaService) Open(ctx context.Context, key, expires, signature string) (*ChatMedia, []byte, error) {
+	if !s.Enabled() || !validAssetKey(key) || !s.verify(key, expires, signature) {
+		return nil, nil, ErrChatMediaNotFound
+	}
+
+	// ⏳ URLs sem validade (gravadas antes da expiração) só são aceitas por Load e Refresh
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !s.now().Before(time.Unix(expiresAt, 0)) {
+		return nil, nil, ErrChatMediaNotFound
+	}
+
+	media := chatMediaFromKey(key)
+	media.ExpiresAt = time.Unix(expiresAt, 0)
+	return s.read(ctx, media)
+}
+
+// read lê o conteúdo da mídia no armazenamento
+func (s *chatMediaService) read(ctx context.Context, media *ChatMedia) (*ChatMedia, []byte, error) {
+	content, err := s.storage.Get(ctx, media.Key)
+	if errors.Is(err, ErrAssetNotStored) {
+		return nil, nil, ErrChatMediaNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	media.SizeBytes = int64(len(content))
+	return media, content, nil
+}
+
+// signURL assina a URL da mídia com validade ttl (exp, em segundos Unix, faz parte da assinatura)
+func (s *chatMediaService) signURL(media *ChatMedia, ttl time.Duration) *ChatMedia {
+	media.ExpiresAt = s.now().Add(ttl).Truncate(time.Second)
+	expires := strconv.FormatInt(media.ExpiresAt.Unix(), 10)
+	media.URL = s.baseURL + chatMediaPath + s3EscapeKey(media.Key) + "?exp=" + expires + "&sig=" + s.sign(media.Key, expires)
+	return media
+}
+
+func (s *chatMediaService) verify(key, expires, signature string) bool {
+	return signature != "" && hmac.Equal([]byte(signature), []byte(s.sign(key, expires)))
+}
+
+// sign gera o HMAC-SHA256 (base64 url-safe) da chave e da validade. Sem validade o HMAC é o mesmo das
+// URLs gravadas antes da expiração, que continuam reconhecidas por Load e Refresh.
+func (s *chatMediaService) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("chat-media\n" + key))
+	if expires != "" {
+		mac.Write([]byte("\n" + expires))
+	}
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// chatMediaFromKey recupera nome, Content-Type e tipo da mídia pela chave
+func chatMediaFromKey(key string) *ChatMedia {
+	name := path.Base(key)
+	contentType := chatMediaContentType(name)
+	return &ChatMedia{
+		Key:         key,
+		Type:        chatMediaTypeFor(contentType),
+		ContentType: contentType,
+		FileName:    name,
+	}
+}
+
+// chatMediaContentType retorna o Content-Type servido para a extensão (download para os tipos não exibíveis)
+func chatMediaContentType(name string) string {
+	if contentType, ok := chatMediaContentTypes[strings.ToLower(filepath.Ext(name))]; ok {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// ChatMediaTypeForFile deduz o tipo da mídia (image, video, audio ou document) pela extensão do arquivo
+func ChatMediaTypeForFile(fileName string) string {
+	return chatMediaTypeFor(chatMediaMimeType(fileName))
+}
+
+// chatMediaMimeType é o tipo real do arquivo (enviado ao WhatsApp), inclusive dos documentos servidos como download
+func chatMediaMimeType(name string) string {
+	ext := strings.ToLower(filepath.Ext(name))
+	if contentType, ok := chatMediaContentTypes[ext]; ok {
+		return contentType
+	}
+	for contentType, known := range chatMediaExtensions {
+		if known == ext {
+			return contentType
+		}
+	}
+	return "application/octet-stream"
+}
+
+// chatMediaTypeFor deduz o tipo da mensagem do WhatsApp pelo Content-Type
+func chatMediaTypeFor(contentType string) string {
+	switch {
+	case strings.HasPrefix(contentType, "image/"):
+		return dto.WhatsAppInboundImage
+	case strings.HasPrefix(contentType, "video/"):
+		return dto.WhatsAppInboundVideo
+	case strings.HasPrefix(contentType, "audio/"):
+		return dto.WhatsAppInboundAudio
+	}
+	return dto.WhatsAppInboundDocument
+}
+
+func validChatMediaType(mediaType string) bool {
+	switch mediaType {
+	case dto.WhatsAppInboundImage, dto.WhatsAppInboundVideo, dto.WhatsAppInboundAudio, dto.WhatsAppInboundDocument:
+		return true
+	}
+	return false
+}
+
+// urlPath retorna o caminho da URL base (ex: https://api.example.com/v1 -> /v1)
+func urlPath(baseURL string) string {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Path
+}
@@ -0,0 +1,264 @@
+// File: /internal/service/chat_media_service_test.go
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+)
+
+func newTestChatMediaService(t *testing.T, baseURL, secret string) *chatMediaService {
+	t.Helper()
+	storage := NewLocalAssetStorage(t.TempDir(), "https://api.example.com/files")
+	return NewChatMediaService(storage, baseURL, secret, 1<<20, time.Hour, 24*time.Hour).(*chatMediaService)
+}
+
+// splitChatMediaURL separa a chave (sem escape), a validade e a assinatura da URL gerada
+func splitChatMediaURL(t *testing.T, svc *chatMediaService, fileURL string) (string, string, string) {
+	t.Helper()
+	key, expires, signature, ok := svc.parseURL(fileURL)
+	if !ok {
+		t.Fatalf("URL fora do armazenamento: %s", fileURL)
+	}
+	return key, expires, signature
+}
+
+func TestChatMediaSignedURLRoundTrip(t *testing.T) {
+	for _, baseURL := range []string{"https://api.example.com", "https://api.example.com/v1/"} {
+		t.Run(baseURL, func(t *testing.T) {
+			svc := newTestChatMediaService(t, baseURL, "segredo")
+			accountID := uuid.New()
+
+			media, err := svc.Store(context.Background(), accountID, dto.WhatsAppInboundDocument, "catálogo de inverno.pdf", "application/pdf", []byte("%PDF-1.4"))
+			if err != nil {
+				t.Fatalf("Store: %v", err)
+			}
+			if !strings.HasPrefix(media.URL, strings.TrimRight(baseURL, "/")+"/chat-media/"+accountID.String()+"/") {
+				t.Fatalf("URL = %s", media.URL)
+			}
+
+			key, expires, signature := splitChatMediaURL(t, svc, media.URL)
+			if key != media.Key {
+				t.Fatalf("chave da URL = %q, esperado %q", key, media.Key)
+			}
+			if expires != strconv.FormatInt(media.ExpiresAt.Unix(), 10) || time.Until(media.ExpiresAt) > time.Hour {
+				t.Fatalf("validade = %s (%v), esperado até 1h", expires, media.ExpiresAt)
+			}
+
+			opened, content, err := svc.Open(context.Background(), key, expires, signature)
+			if err != nil || string(content) != "%PDF-1.4" {
+				t.Fatalf("Open = (%q, %v)", content, err)
+			}
+			if opened.FileName != "catálogo de inverno.pdf" || opened.ContentType != "application/pdf" || !opened.Inline() {
+				t.Fatalf("mídia = %+v", opened)
+			}
+
+			if _, content, err := svc.Load(context.Background(), media.URL); err != nil || string(content) != "%PDF-1.4" {
+				t.Fatalf("Load = (%q, %v)", content, err)
+			}
+			resolved, err := svc.Resolve(accountID, media.URL)
+			if err != nil || resolved.Key != media.Key || resolved.Type != dto.WhatsAppInboundDocument {
+				t.Fatalf("Resolve = (%+v, %v)", resolved, err)
+			}
+			// 📤 O provedor recebe uma URL de validade longa
+			if time.Until(resolved.ExpiresAt) <= time.Hour {
+				t.Fatalf("validade da URL do provedor = %v, esperado 24h", resolved.ExpiresAt)
+			}
+			if _, _, err := svc.Load(context.Background(), resolved.URL); err != nil {
+				t.Fatalf("Load da URL do provedor: %v", err)
+			}
+		})
+	}
+}
+
+func TestChatMediaRejectsInvalidSignatures(t *testing.T) {
+	svc := newTestChatMediaService(t, "https://api.example.com", "segredo")
+	accountID := uuid.New()
+
+	media, err := svc.Store(context.Background(), accountID, dto.WhatsAppInboundImage, "foto.jpg", "image/jpeg", []byte("jpeg"))
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	other, err := svc.Store(context.Background(), uuid.New(), dto.WhatsAppInboundImage, "outra.jpg", "image/jpeg", []byte("outra"))
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	key, expires, signature := splitChatMediaURL(t, svc, media.URL)
+	_, otherExpires, otherSignature := splitChatMediaURL(t, svc, other.URL)
+
+	flipped := []byte(signature)
+	flipped[0] ^= 0x01
+
+	otherSecret := newTestChatMediaService(t, "https://api.example.com", "outro-segredo")
+	extended := strconv.FormatInt(time.Now().Add(365*24*time.Hour).Unix(), 10)
+	expired := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		key       string
+		expires   string
+		signature string
+	}{
+		{name: "assinatura de outra mídia", key: key, expires: otherExpires, signature: otherSignature},
+		{name: "outra mídia com a assinatura original", key: other.Key, expires: expires, signature: signature},
+		{name: "assinatura alterada", key: key, expires: expires, signature: string(flipped)},
+		{name: "validade estendida", key: key, expires: extended, signature: signature},
+		{name: "assinado com outro segredo", key: key, expires: expires, signature: otherSecret.sign(key, expires)},
+		{name: "sem assinatura", key: key, expires: expires, signature: ""},
+		{name: "expirada", key: key, expires: expired, signature: svc.sign(key, expired)},
+		{name: "sem validade", key: key, expires: "", signature: svc.sign(key, "")},
+		{name: "chave fora do armazenamento", key: "../" + key, expires: expires, signature: svc.sign("../"+key, expires)},
+		{name: "chave absoluta", key: "/" + key, expires: expires, signature: svc.sign("/"+key, expires)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := svc.Open(context.Background(), tt.key, tt.expires, tt.signature); !errors.Is(err, ErrChatMediaNotFound) {
+				t.Fatalf("Open = %v, esperado ErrChatMediaNotFound", err)
+			}
+		})
+	}
+}
+
+func TestChatMediaResolveOnlyAcceptsOwnAccount(t *testing.T) {
+	svc := newTestChatMediaService(t, "https://api.example.com", "segredo")
+	accountID := uuid.New()
+
+	media, err := svc.Store(context.Background(), accountID, dto.WhatsAppInboundAudio, "", "audio/ogg; codecs=opus", []byte("ogg"))
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if media.FileName != "audio.ogg" || media.ContentType != "audio/ogg" {
+		t.Fatalf("mídia sem nome = %+v, esperado audio.ogg", media)
+	}
+
+	key, expires, signature := splitChatMediaURL(t, svc, media.URL)
+	flipped := []byte(signature)
+	flipped[len(flipped)-1] ^= 0x01
+	tampered := strings.Replace(media.URL, "sig="+signature, "sig="+url.QueryEscape(string(flipped)), 1)
+
+	tests := map[string]struct {
+		accountID uuid.UUID
+		fileURL   string
+	}{
+		"outra conta":           {accountID: uuid.New(), fileURL: media.URL},
+		"assinatura alterada":   {accountID: accountID, fileURL: tampered},
+		"outro domínio":         {accountID: accountID, fileURL: "https://atacante.example.com/chat-media/" + key + "?exp=" + expires + "&sig=" + signature},
+		"URL externa qualquer":  {accountID: accountID, fileURL: "https://cdn.example.com/foto.jpg"},
+		"sem assinatura na URL": {accountID: accountID, fileURL: strings.Split(media.URL, "?")[0]},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := svc.Resolve(tt.accountID, tt.fileURL); !errors.Is(err, ErrInvalidChatMedia) {
+				t.Fatalf("Resolve = %v, esperado ErrInvalidChatMedia", err)
+			}
+		})
+	}
+}
+
+func TestChatMediaExpiredURLIsRefreshedForTheChat(t *testing.T) {
+	svc := newTestChatMediaService(t, "https://api.example.com", "segredo")
+	accountID := uuid.New()
+
+	media, err := svc.Store(context.Background(), accountID, dto.WhatsAppInboundImage, "foto.jpg", "image/jpeg", []byte("jpeg"))
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	// 🔗 URL gravada antes da expiração (sem exp): não é servida, mas continua sendo da conta
+	legacy := "https://api.example.com/chat-media/" + media.Key + "?sig=" + svc.sign(media.Key, "")
+
+	// ⏳ Duas horas depois a URL do chat expirou
+	later := time.Now().Add(2 * time.Hour)
+	svc.now = func() time.Time { return later }
+
+	for name, fileURL := range map[string]string{"expirada": media.URL, "sem validade": legacy} {
+		t.Run(name, func(t *testing.T) {
+			key, expires, signature := splitChatMediaURL(t, svc, fileURL)
+			if _, _, err := svc.Open(context.Background(), key, expires, signature); !errors.Is(err, ErrChatMediaNotFound) {
+				t.Fatalf("Open = %v, esperado ErrChatMediaNotFound", err)
+			}
+
+			// 🎤 O processamento da mídia lê a URL gravada na mensagem mesmo expirada
+			if _, content, err := svc.Load(context.Background(), fileURL); err != nil || string(content) != "jpeg" {
+				t.Fatalf("Load = (%q, %v)", content, err)
+			}
+
+			refreshed := svc.Refresh(fileURL)
+			key, expires, signature = splitChatMediaURL(t, svc, refreshed)
+			opened, _, err := svc.Open(context.Background(), key, expires, signature)
+			if err != nil {
+				t.Fatalf("Open da URL renovada = %v", err)
+			}
+			if !opened.ExpiresAt.Equal(later.Add(time.Hour).Truncate(time.Second)) {
+				t.Fatalf("validade da URL renovada = %v", opened.ExpiresAt)
+			}
+		})
+	}
+
+	// 🔒 URLs externas ou adulteradas não são assinadas pelo Refresh
+	for _, fileURL := range []string{"https://cdn.example.com/foto.jpg", strings.Replace(media.URL, "foto.jpg", "outra.jpg", 1)} {
+		if refreshed := svc.Refresh(fileURL); refreshed != fileURL {
+			t.Fatalf("Refresh(%s) = %s, esperado inalterada", fileURL, refreshed)
+		}
+	}
+}
+
+func TestChatMediaServesUnknownDocumentsAsDownload(t *testing.T) {
+	svc := newTestChatMediaService(t, "https://api.example.com", "segredo")
+
+	media, err := svc.Store(context.Background(), uuid.New(), dto.WhatsAppInboundDocument, "pagina.html", "text/html", []byte("<script>alert(1)</script>"))
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	// 🔒 HTML recebido como documento não pode ser exibido no domínio da API
+	if media.ContentType != "application/octet-stream" || media.Inline() {
+		t.Fatalf("mídia = %+v, esperado download (application/octet-stream)", media)
+	}
+}
+
+func TestChatMediaDisabledWithoutSecret(t *testing.T) {
+	svc := newTestChatMediaService(t, "https://api.example.com", "")
+
+	if svc.Enabled() {
+		t.Fatal("armazenamento sem segredo não deve ficar habilitado")
+	}
+	if _, err := svc.Store(context.Background(), uuid.New(), dto.WhatsAppInboundImage, "foto.jpg", "image/jpeg", []byte("jpeg")); !errors.Is(err, ErrChatMediaDisabled) {
+		t.Fatalf("Store = %v, esperado ErrChatMediaDisabled", err)
+	}
+	// 🔑 Com segredo vazio a assinatura seria previsível: nada é servido
+	key := uuid.New().String() + "/" + uuid.New().String() + "/foto.jpg"
+	expires := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	if _, _, err := svc.Open(context.Background(), key, expires, svc.sign(key, expires)); !errors.Is(err, ErrChatMediaNotFound) {
+		t.Fatalf("Open = %v, esperado ErrChatMediaNotFound", err)
+	}
+}
+
+func TestChatMediaStoreValidation(t *testing.T) {
+	svc := newTestChatMediaService(t, "https://api.example.com", "segredo")
+
+	tests := map[string]struct {
+		mediaType string
+		content   []byte
+	}{
+		"tipo desconhecido": {mediaType: "sticker", content: []byte("x")},
+		"arquivo vazio":     {mediaType: dto.WhatsAppInboundImage},
+		"acima do limite":   {mediaType: dto.WhatsAppInboundImage, content: make([]byte, 1<<20+1)},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := svc.Store(context.Background(), uuid.New(), tt.mediaType, "foto.jpg", "image/jpeg", tt.content); !errors.Is(err, ErrInvalidChatMedia) {
+				t.Fatalf("Store = %v, esperado ErrInvalidChatMedia", err)
+			}
+		})
+	}
+}
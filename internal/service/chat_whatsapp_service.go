@@ -27,7 +27,7 @@ type ChatWhatsAppService interface {
 	RegistrarMensagemManual(ctx context.Context, accountID, chatID, chatContactID uuid.UUID, chatMessage dto.ChatMessageCreateDTO) (*models.ChatMessage, error)
 	ListarMensagens(ctx context.Context, accountID, chatID, chatContactID uuid.UUID) ([]models.ChatMessage, error)
 	SugestaoRespostaAI(ctx context.Context, accountID, chatID, chatContactID uuid.UUID, message string) (string, error)
-	ProcessarMensagemRecebida(ctx context.Context, webhookBaileysPayload *dto.WebhookBaileysPayload) error
+	ProcessarMensagemRecebida(ctx context.Context, inbound *dto.WhatsAppInboundMessage) error
 
 	IniciarSessaoWhatsApp(ctx context.Context, accountID, chatID uuid.UUID) (*StartSessionResponse, error)
 	ObterQRCodeSessao(ctx context.Context, accountID, chatID uuid.UUID) (*QRCodeResponse, error)
@@ -42,8 +42,8 @@ type chatWhatsAppService struct {
 	chatContactRepo     db.ChatContactRepository
 	chatMessageRepo     db.ChatMessageRepository
 	openaiService       OpenAIService
-	baileysService      WhatsAppBaileysService
-	// evolutionService EvolutionService
+	providers           WhatsAppProviders
+	media               ChatMediaService
 }
 
 func NewChatWhatsAppService(
@@ -53,8 +53,8 @@ func NewChatWhatsAppService(
 	chatContactRepo db.ChatContactRepository,
 	chatMessageRepo db.ChatMessageRepository,
 	openaiService OpenAIService,
-	baileysService WhatsAppBaileysService,
-	// evolution EvolutionService,
+	providers WhatsAppProviders,
+	media ChatMediaService,
 ) ChatWhatsAppService {
 	return &chatWhatsAppService{
 		log:                 logger.GetLogger(),
@@ -64,8 +64,8 @@ func NewChatWhatsAppService(
 		chatContactRepo:     chatContactRepo,
 		chatMessageRepo:     chatMessageRepo,
 		openaiService:       openaiService,
-		baileysService:      baileysService,
-		// evolutionService: evolution,
+		providers:           providers,
+		media:               media,
 	}
 }
 
@@ -91,17 +91,26 @@ func (s *chatWhatsAppService) SugestaoRespostaAI(ctx context.Context, accountID,
 		return "", fmt.Errorf("erro ao buscar ou criar chat_contact: %w", err)
 	}
 
-	// 3. Buscar dados do whatsapp contact
-	whatsappContact, err := s.whatsAppContactRepo.FindByID(ctx, chatContact.WhatsappContactID)
-	if err != nil {
-		return "", fmt.Errorf("erro ao buscar contato do WhatsApp: %w", err)
+	// 3. Identificar o contato no CRM (direto nas conversas por e-mail, via contato do WhatsApp nas demais)
+	var contactID uuid.UUID
+	if chatContact.ContactID != nil {
+		contactID = *chatContact.ContactID
+	} else if chatContact.WhatsappContactID != nil {
+		whatsappContact, err := s.whatsAppContactRepo.FindByID(ctx, *chatContact.WhatsappContactID)
+		if err != nil {
+			return "", fmt.Errorf("erro ao buscar contato do WhatsApp: %w", err)
+		}
+		contactID = whatsappContact.ContactID
 	}
 
 	// 4. Buscar contato no CRM
-	contact, err := s.contactRepo.GetByID(ctx, whatsappContact.ContactID)
+	contact, err := s.contactRepo.GetByID(ctx, contactID)
 	if err != nil {
 		return "", fmt.Errorf("erro ao buscar contato no CRM: %w", err)
 	}
+	if contact == nil {
+		return "", fmt.Errorf("contato do atendimento não encontrado no CRM")
+	}
 
 	// 5. Buscar mensagens anteriores do chat
 	chatMessages, err := s.chatMessageRepo.ListByChatContact(ctx, chatContact.ID)
@@ -152,6 +161,9 @@ func (s *chatWhatsAppService) AtualizarChat(ctx context.Context, accountID, chat
 	chat.PhoneNumber = data.PhoneNumber
 	chat.InstanceName = data.InstanceName
 	chat.WebhookURL = data.WebhookURL
+	if data.Provider != "" {
+		chat.Provider = models.WhatsAppProviderType(data.Provider)
+	}
 	chat.UpdatedAt = time.Now()
 
 	return s.chatRepo.Update(ctx, chat)
@@ -182,7 +194,7 @@ func buildPrompt(c *models.Contact, chatMessages []models.ChatMessage, message s
 		if msg.Actor == "atendente" {
 			autor = "Atendente"
 		}
-		fmt.Fprintf(&b, "%s: %s\n", autor, msg.Content)
+		fmt.Fprintf(&b, "%s%s: %s\n", autor, chatMessageMediaLabel(msg), msg.Content)
 	}
 
 	fmt.Fprintf(&b, "\n📥 MENSAGEM RECEBIDA:\nCliente: %s\n", message)
@@ -195,6 +207,28 @@ func buildPrompt(c *models.Contact, chatMessages []models.ChatMessage, message s
 	return b.String()
 }
 
+// chatMessageMediaLabel indica à IA a origem do texto das mensagens com mídia (transcrição, descrição ou só a legenda)
+func chatMessageMediaLabel(msg models.ChatMessage) string {
+	if msg.Type == "texto" || msg.Type == "email" || msg.Type == "" {
+		return ""
+	}
+	if msg.Actor != "cliente" {
+		return fmt.Sprintf(" (envio de %s)", msg.Type)
+	}
+	if !msg.SourceProcessed || msg.ProcessingError != nil {
+		return fmt.Sprintf(" (%s sem transcrição ou descrição, apenas a legenda)", msg.Type)
+	}
+	switch msg.Type {
+	case "audio":
+		return " (áudio transcrito)"
+	case "imagem":
+		return " (imagem descrita)"
+	case "documento":
+		return " (documento descrito)"
+	}
+	return fmt.Sprintf(" (%s)", msg.Type)
+}
+
 // ListarContatosDoChat retorna todos os contatos de um chat com dados adicionais
 func (s *chatWhatsAppService) ListarContatosDoChat(ctx context.Context, accountID, chatID uuid.UUID) ([]dto.ChatContactFull, error) {
 	chatContacts, err := s.chatContactRepo.ListByChatID(ctx, accountID, chatID)
@@ -219,6 +253,24 @@ func (s *chatWhatsAppService) RegistrarMensagemManual(ctx context.Context, accou
 		return nil, err
 	}
 
+	// 📎 Mídia do atendente: precisa ter sido enviada antes por POST /chat-media
+	var media *ChatMedia
+	if chatMessage.Type != "texto" && chatMessage.Type != "" {
+		if _, ok := whatsAppMediaTypes[chatMessage.Type]; !ok {
+			return nil, fmt.Errorf("%w: tipo de mensagem %q não suportado", ErrInvalidChatMedia, chatMessage.Type)
+		}
+		if chatMessage.FileURL == "" {
+			return nil, fmt.Errorf("%w: file_url é obrigatório para mensagens do tipo %s", ErrInvalidChatMedia, chatMessage.Type)
+		}
+		media, err = s.media.Resolve(accountID, chatMessage.FileURL)
+		if err != nil {
+			return nil, err
+		}
+		if expected := whatsAppMediaTypes[chatMessage.Type]; expected != dto.WhatsAppInboundDocument && media.Type != expected {
+			return nil, fmt.Errorf("%w: o arquivo %s não é do tipo %s (envie como documento)", ErrInvalidChatMedia, media.FileName, chatMessage.Type)
+		}
+	}
+
 	// 🔹 Cria nova mensagem
 	msg := models.ChatMessage{
 		ChatContactID:   chatContact.ID,
@@ -235,14 +287,17 @@ func (s *chatWhatsAppService) RegistrarMensagemManual(ctx context.Context, accou
 	}
 	s.log.Debug("Mensagem registrada com sucesso", slog.Any("mensagem", messageCreated))
 
-	// Após salvar a mensagem no banco
-	if messageCreated.Actor == "atendente" || messageCreated.Actor == "ai" {
+	// Após salvar a mensagem no banco (conversas por e-mail apenas registram a resposta do atendente)
+	if (messageCreated.Actor == "atendente" || messageCreated.Actor == "ai") && chatContact.WhatsappContactID != nil {
 		// s.log.Debug("Mensagem registrada com sucesso", slog.String("mensagem", chatMessage.Content))
 		// Buscar whatsapp contact pelo ID
-		whatsappContact, err := s.whatsAppContactRepo.FindByID(ctx, chatContact.WhatsappContactID)
+		whatsappContact, err := s.whatsAppContactRepo.FindByID(ctx, *chatContact.WhatsappContactID)
 		if err == nil {
-			// err = s.evolutionService.SendTextMessage(chat.InstanceName, *contato.WhatsApp, chatMessage.Content)
-			_, err = s.baileysService.SendTextMessage(chat.InstanceName, whatsappContact.JID, messageCreated.Content)
+			if media != nil {
+				err = s.enviarMidia(ctx, chat, whatsappContact.JID, whatsAppMediaTypes[messageCreated.Type], media, messageCreated.Content)
+			} else {
+				err = s.enviarTexto(ctx, chat, whatsappContact.JID, messageCreated.Content)
+			}
 			if err != nil {
 				s.log.Error("Erro ao enviar mensagem para o WhatsApp", slog.String("numero", whatsappContact.Phone), slog.String("mensagem", messageCreated.Content), slog.Any("erro", err))
 			} else {
@@ -256,24 +311,54 @@ func (s *chatWhatsAppService) RegistrarMensagemManual(ctx context.Context, accou
 
 // ListarMensagens retorna todas as mensagens de um chat
 func (s *chatWhatsAppService) ListarMensagens(ctx context.Context, accountID, chatID, chatContactID uuid.UUID) ([]models.ChatMessage, error) {
+	// 🔹 Verifica se o contato é de um chat da conta (as mensagens trazem links das mídias)
+	if _, err := s.chatContactRepo.FindByID(ctx, accountID, chatID, chatContactID); err != nil {
+		return nil, err
+	}
+
 	// 1. Retorna mensagens ordenadas
-	return s.chatMessageRepo.ListByChatContact(ctx, chatContactID)
+	messages, err := s.chatMessageRepo.ListByChatContact(ctx, chatContactID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🔗 Os links das mídias expiram: cada listagem devolve URLs novas para o chat
+	for i := range messages {
+		if messages[i].FileURL != "" {
+			messages[i].FileURL = s.media.Refresh(messages[i].FileURL)
+		}
+	}
+	return messages, nil
 }
 
-// ProcessarMensagemRecebida processa uma mensagem recebida do WhatsApp
-func (s *chatWhatsAppService) ProcessarMensagemRecebida(ctx context.Context, webhookBaileysPayload *dto.WebhookBaileysPayload) error {
+// ProcessarMensagemRecebida processa uma mensagem recebida do WhatsApp (já normalizada pelo provedor do chat)
+func (s *chatWhatsAppService) ProcessarMensagemRecebida(ctx context.Context, inbound *dto.WhatsAppInboundMessage) error {
+	// 🔸 Mensagens enviadas pela própria sessão e de grupos não abrem atendimento
+	if inbound.FromMe || inbound.IsGroup {
+		s.log.Debug("Mensagem ignorada (enviada pela sessão ou de grupo)", slog.String("message_id", inbound.MessageID))
+		return nil
+	}
+
 	// 🔹 1. Buscar o chat com base na instância da GetActiveByInstanceName
-	chat, err := s.chatRepo.GetActiveByInstanceName(ctx, webhookBaileysPayload.SessionID)
+	chat, err := s.chatRepo.GetActiveByInstanceName(ctx, inbound.SessionID)
 	if err != nil {
-		return fmt.Errorf("nenhum chat ativo com instance_name=%s: %w", webhookBaileysPayload.SessionID, err)
+		return fmt.Errorf("nenhum chat ativo com instance_name=%s: %w", inbound.SessionID, err)
+	}
+	if chat.Provider != "" && string(chat.Provider) != inbound.Provider {
+		return fmt.Errorf("chat %s usa o provedor %s, webhook recebido de %s", chat.ID, chat.Provider, inbound.Provider)
+	}
+
+	provider, err := s.providers.For(chat)
+	if err != nil {
+		return err
 	}
 
 	// 🔹 2. Extrair número do remoteJid (ex: 554999999999@...)
-	normalizedNumber := utils.NormalizeWhatsAppNumber(webhookBaileysPayload.From)
+	normalizedNumber := utils.NormalizeWhatsAppNumber(inbound.From)
 
-	res, err := s.baileysService.ResolveNumber(webhookBaileysPayload.SessionID, normalizedNumber)
+	res, err := provider.ResolveNumber(ctx, chat, normalizedNumber)
 	if err != nil {
-		s.log.Error("Erro ao resolver número via Baileys", slog.String("normalizedNumber", normalizedNumber), slog.Any("erro", err))
+		s.log.Error("Erro ao resolver número", slog.String("provider", string(provider.Name())), slog.String("normalizedNumber", normalizedNumber), slog.Any("erro", err))
 		return err
 	}
 	if res == nil {
@@ -284,12 +369,12 @@ func (s *chatWhatsAppService) ProcessarMensagemRecebida(ctx context.Context, web
 	}
 
 	// 🔹 3. Enriquecer dados com IA
-	enrichedContact, err := s.EnriquecerContatoComIA(ctx, webhookBaileysPayload, res.BusinessProfile)
+	enrichedContact, err := s.EnriquecerContatoComIA(ctx, inbound, res.BusinessProfile)
 	if err != nil {
 		s.log.Warn("IA falhou ao enriquecer contato, usando fallback", slog.Any("erro", err))
 		// fallback mínimo
 		enrichedContact = &models.Contact{
-			Name:     webhookBaileysPayload.PushName,
+			Name:     inbound.PushName,
 			WhatsApp: &normalizedNumber, // Garante que o número normalizado seja usado
 		}
 	}
@@ -305,7 +390,7 @@ func (s *chatWhatsAppService) ProcessarMensagemRecebida(ctx context.Context, web
 		ContactID:       contact.ID,
 		Name:            contact.Name,
 		Phone:           normalizedNumber,
-		JID:             webhookBaileysPayload.From,
+		JID:             inbound.From,
 		IsBusiness:      res.IsBusiness,
 		BusinessProfile: res.BusinessProfile,
 	}
@@ -322,60 +407,118 @@ func (s *chatWhatsAppService) ProcessarMensagemRecebida(ctx context.Context, web
 	}
 
 	// 🔹 6. Salvar a mensagem recebida
-	if webhookBaileysPayload.Type == "text" {
-
-		msg := models.ChatMessage{
-			ChatContactID:   chatContact.ID,
-			Actor:           "cliente",
-			Type:            "texto",
-			Content:         webhookBaileysPayload.Message,
-			FileURL:         "",
-			SourceProcessed: false,
-		}
+	messageType, ok := chatMessageTypes[inbound.Type]
+	if !ok {
+		s.log.Warn("Tipo de mensagem não suportado", slog.String("type", inbound.Type))
+		return fmt.Errorf("tipo de mensagem não suportado: %s", inbound.Type)
+	}
 
-		messageCreated, err := s.chatMessageRepo.Create(ctx, msg)
-		if err != nil {
-			return fmt.Errorf("erro ao registrar mensagem recebida: %w", err)
-		}
-		s.log.Debug("Mensagem recebida registrada com sucesso", slog.Any("mensagem", messageCreated))
-	} else if webhookBaileysPayload.Type == "image" || webhookBaileysPayload.Type == "video" {
-		msg := models.ChatMessage{
-			ChatContactID:   chatContact.ID,
-			Actor:           "cliente",
-			Type:            webhookBaileysPayload.Type,
-			Content:         webhookBaileysPayload.Message,
-			FileURL:         "", // TODO: URL do arquivo enviado
-			SourceProcessed: false,
-		}
+	msg := models.ChatMessage{
+		ChatContactID:   chatContact.ID,
+		Actor:           "cliente",
+		Type:            messageType,
+		Content:         inbound.Message,
+		SourceProcessed: false,
+	}
 
-		messageCreated, err := s.chatMessageRepo.Create(ctx, msg)
-		if err != nil {
-			return fmt.Errorf("erro ao registrar mensagem recebida: %w", err)
-		}
-		s.log.Debug("Mensagem recebida registrada com sucesso", slog.Any("mensagem", messageCreated))
-	} else {
-		s.log.Warn("Tipo de mensagem não suportado", slog.String("type", webhookBaileysPayload.Type))
-		return fmt.Errorf("tipo de mensagem não suportado: %s", webhookBaileysPayload.Type)
+	// 📎 A mídia é guardada no armazenamento do atendimento: as URLs dos provedores expiram ou exigem credenciais
+	if messageType != "texto" {
+		msg.FileURL = s.armazenarMidiaRecebida(ctx, chat, provider, inbound)
 	}
 
+	messageCreated, err := s.chatMessageRepo.Create(ctx, msg)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar mensagem recebida: %w", err)
+	}
+	s.log.Debug("Mensagem recebida registrada com sucesso", slog.Any("mensagem", messageCreated))
+
 	return nil
 }
 
-// IniciarSessaoWhatsApp inicia uma sessão do WhatsApp usando a API Baileys
+// chatMessageTypes converte o tipo normalizado da mensagem recebida para o tipo gravado em chat_messages
+var chatMessageTypes = map[string]string{
+	dto.WhatsAppInboundText:     "texto",
+	dto.WhatsAppInboundImage:    "imagem",
+	dto.WhatsAppInboundVideo:    "video",
+	dto.WhatsAppInboundAudio:    "audio",
+	dto.WhatsAppInboundDocument: "documento",
+}
+
+// whatsAppMediaTypes converte o tipo gravado em chat_messages para o tipo de mídia do WhatsApp
+var whatsAppMediaTypes = map[string]string{
+	"imagem":    dto.WhatsAppInboundImage,
+	"video":     dto.WhatsAppInboundVideo,
+	"audio":     dto.WhatsAppInboundAudio,
+	"documento": dto.WhatsAppInboundDocument,
+}
+
+// armazenarMidiaRecebida baixa a mídia do provedor e retorna a URL assinada. Uma falha não descarta a
+// mensagem: ela é registrada sem arquivo (com a legenda, quando houver).
+func (s *chatWhatsAppService) armazenarMidiaRecebida(ctx context.Context, chat *models.Chat, provider WhatsAppProvider, inbound *dto.WhatsAppInboundMessage) string {
+	if !s.media.Enabled() {
+		s.log.Warn("⚠️ Mídia recebida sem armazenamento configurado (CHAT_MEDIA_BASE_URL/CHAT_MEDIA_SECRET)", slog.String("message_id", inbound.MessageID))
+		return ""
+	}
+
+	content, err := provider.DownloadMedia(ctx, chat, inbound, s.media.MaxSize())
+	if err != nil {
+		s.log.Error("Erro ao baixar mídia recebida", slog.String("provider", string(provider.Name())), slog.String("message_id", inbound.MessageID), slog.Any("erro", err))
+		return ""
+	}
+
+	media, err := s.media.Store(ctx, chat.AccountID, inbound.Type, firstNonEmpty(inbound.FileName, content.FileName), content.MimeType, content.Content)
+	if err != nil {
+		s.log.Error("Erro ao armazenar mídia recebida", slog.String("message_id", inbound.MessageID), slog.Any("erro", err))
+		return ""
+	}
+	return media.URL
+}
+
+// enviarMidia envia uma mídia do armazenamento do atendimento pelo provedor do chat (o provedor baixa pela URL assinada)
+func (s *chatWhatsAppService) enviarMidia(ctx context.Context, chat *models.Chat, to, mediaType string, media *ChatMedia, caption string) error {
+	provider, err := s.providers.For(chat)
+	if err != nil {
+		return err
+	}
+	_, err = provider.SendMedia(ctx, chat, to, WhatsAppMedia{
+		Type:     mediaType,
+		URL:      media.URL,
+		MimeType: chatMediaMimeType(media.FileName),
+		FileName: media.FileName,
+		Caption:  caption,
+	})
+	return err
+}
+
+// enviarTexto envia uma mensagem de texto pelo provedor do chat
+func (s *chatWhatsAppService) enviarTexto(ctx context.Context, chat *models.Chat, to, text string) error {
+	provider, err := s.providers.For(chat)
+	if err != nil {
+		return err
+	}
+	_, err = provider.SendText(ctx, chat, to, text)
+	return err
+}
+
+// IniciarSessaoWhatsApp inicia a sessão do WhatsApp no provedor do chat
 func (s *chatWhatsAppService) IniciarSessaoWhatsApp(ctx context.Context, accountID, chatID uuid.UUID) (*StartSessionResponse, error) {
 	chat, err := s.chatRepo.GetByID(ctx, accountID, chatID)
 	if err != nil {
 		return nil, fmt.Errorf("chat não encontrado: %w", err)
 	}
 
-	s.log.Debug("Iniciando sessão do WhatsApp", slog.String("instance_name", chat.InstanceName), slog.String("webhook_url", chat.WebhookURL))
-	// Verifica se a instância e o webhook estão configurados
+	s.log.Debug("Iniciando sessão do WhatsApp", slog.String("provider", string(chat.Provider)), slog.String("instance_name", chat.InstanceName), slog.String("webhook_url", chat.WebhookURL))
+	// Verifica se a instância e o webhook estão configurados (a Cloud API recebe o webhook pelo app da Meta)
 
-	if chat.InstanceName == "" || chat.WebhookURL == "" {
+	if chat.InstanceName == "" || (chat.WebhookURL == "" && chat.Provider != models.WhatsAppProviderCloudAPI) {
 		return nil, fmt.Errorf("chat está sem instance_name ou webhook_url configurado")
 	}
 
-	return s.baileysService.StartSession(chat.InstanceName, chat.WebhookURL)
+	provider, err := s.providers.For(chat)
+	if err != nil {
+		return nil, err
+	}
+	return provider.StartSession(ctx, chat)
 }
 
 // ObterQRCodeSessao obtém o QR Code para autenticação da sessão do WhatsApp
@@ -385,11 +528,11 @@ func (s *chatWhatsAppService) ObterQRCodeSessao(ctx context.Context, accountID,
 		return nil, fmt.Errorf("chat não encontrado: %w", err)
 	}
 
-	if chat.InstanceName == "" {
-		return nil, fmt.Errorf("chat está sem instance_name configurado")
+	provider, err := s.providers.For(chat)
+	if err != nil {
+		return nil, err
 	}
-
-	return s.baileysService.GetQRCode(chat.InstanceName)
+	return provider.GetQRCode(ctx, chat)
 }
 
 // VerificarSessionStatusViaAPI consulta o status da sessão e atualiza no banco
@@ -399,11 +542,12 @@ func (s *chatWhatsAppService) VerificarSessionStatusViaAPI(ctx context.Context,
 		return nil, fmt.Errorf("chat não encontrado: %w", err)
 	}
 
-	if chat.InstanceName == "" {
-		return nil, fmt.Errorf("chat está sem instance_name configurado")
+	provider, err := s.providers.For(chat)
+	if err != nil {
+		return nil, err
 	}
 
-	sessionStatus, err := s.baileysService.GetSessionState(chat.InstanceName)
+	sessionStatus, err := provider.GetSessionState(ctx, chat)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao obter status da sessão: %w", err)
 	}
@@ -420,7 +564,7 @@ func (s *chatWhatsAppService) VerificarSessionStatusViaAPI(ctx context.Context,
 
 func (s *chatWhatsAppService) EnriquecerContatoComIA(
 	ctx context.Context,
-	payload *dto.WebhookBaileysPayload,
+	payload *dto.WhatsAppInboundMessage,
 	businessProfile *models.BusinessProfile,
 ) (*models.Contact, error) {
 	// Estrutura auxiliar para input do prompt
@@ -35,15 +35,17 @@ type contactImportService struct {
 	contactRepo       db.ContactRepository
 	contactImportRepo db.ContactImportRepository
 	openAIClient      OpenAIService
+	emailValidator    EmailValidationService
 }
 
 // NewContactImportService cria uma nova instância do serviço de importação
-func NewContactImportService(contactRepo db.ContactRepository, contactImportRepo db.ContactImportRepository, openAIClient OpenAIService) ContactImportService {
+func NewContactImportService(contactRepo db.ContactRepository, contactImportRepo db.ContactImportRepository, openAIClient OpenAIService, emailValidator EmailValidationService) ContactImportService {
 	return &contactImportService{
 		log:               logger.GetLogger(),
 		contactRepo:       contactRepo,
 		contactImportRepo: contactImportRepo,
 		openAIClient:      openAIClient,
+		emailValidator:    emailValidator,
 	}
 }
 
@@ -191,6 +193,21 @@ func (s *contactImportService) processRecord(ctx context.Context, record []strin
 	// Normaliza os dados
 	contactDTO.Normalize()
 
+	// ✉️ Verifica o e-mail antes da busca por duplicados (o endereço normalizado é o que fica gravado).
+	// Endereços inválidos são mantidos com status "invalid" para não perder o contato de WhatsApp.
+	var emailValidation *EmailValidation
+	if contactDTO.Email != nil {
+		validation := s.emailValidator.Validate(ctx, *contactDTO.Email)
+		emailValidation = &validation
+		contactDTO.Email = &validation.Email
+		if validation.Status != models.EmailStatusValid {
+			s.log.Info("E-mail do registro não verificado como válido",
+				slog.String("log_id", logID),
+				slog.String("email_status", string(validation.Status)),
+				slog.String("reason", validation.Reason))
+		}
+	}
+
 	// 🔹 Verifica se o contato já existe
 	existingContact, _ := s.contactRepo.FindByEmailOrWhatsApp(ctx, accountID, contactDTO.Email, contactDTO.WhatsApp)
 	if existingContact != nil {
@@ -213,6 +230,11 @@ func (s *contactImportService) processRecord(ctx context.Context, record []strin
 		History:   contactDTO.History,
 	}
 
+	if emailValidation != nil {
+		contact.EmailStatus = &emailValidation.Status
+		contact.EmailStatusReason = emailValidation.ReasonPtr()
+	}
+
 	if contactDTO.BirthDate != nil {
 		birthDate, err := time.Parse("2006-01-02", *contactDTO.BirthDate)
 		if err == nil {
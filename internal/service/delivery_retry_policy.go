@@ -0,0 +1,115 @@
+// File: /internal/service/delivery_retry_policy.go
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ses/types"
+	"github.com/jeancarlosdanese/go-marketing/config"
+)
+
+// maxQueueDelay é o maior atraso aceito pelo SQS (DelaySeconds) e vale para todos os backends
+const maxQueueDelay = 15 * time.Minute
+
+// RetryPolicy define quantas vezes e com qual intervalo um envio de campanha é repetido
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// NewRetryPolicy carrega a política do canal a partir do .env
+// (ex.: EMAIL_RETRY_MAX_ATTEMPTS, EMAIL_RETRY_BASE_DELAY e EMAIL_RETRY_MAX_DELAY, em segundos)
+func NewRetryPolicy(channel string) RetryPolicy {
+	defaults := map[string]RetryPolicy{
+		"email":    {MaxAttempts: 5, BaseDelay: 30 * time.Second, MaxDelay: maxQueueDelay},
+		"whatsapp": {MaxAttempts: 3, BaseDelay: 60 * time.Second, MaxDelay: maxQueueDelay},
+	}
+
+	policy, ok := defaults[channel]
+	if !ok {
+		policy = defaults["email"]
+	}
+
+	prefix := strings.ToUpper(channel)
+	policy.MaxAttempts = config.GetEnvInt(prefix+"_RETRY_MAX_ATTEMPTS", policy.MaxAttempts)
+	policy.BaseDelay = time.Duration(config.GetEnvInt(prefix+"_RETRY_BASE_DELAY", int(policy.BaseDelay.Seconds()))) * time.Second
+	policy.MaxDelay = min(time.Duration(config.GetEnvInt(prefix+"_RETRY_MAX_DELAY", int(policy.MaxDelay.Seconds())))*time.Second, maxQueueDelay)
+
+	return policy
+}
+
+// Backoff retorna o atraso antes da próxima tentativa (exponencial com jitter).
+// `attempt` é o número de tentativas que já falharam (1 = primeira falha).
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, p.MaxDelay)
+
+	// 🎲 Metade fixa + metade aleatória evita que falhas simultâneas voltem todas juntas
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + rand.N(half)
+}
+
+// PermanentError marca uma falha que não adianta repetir (ex.: contato sem e-mail)
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// NewPermanentError cria um erro permanente com mensagem formatada
+func NewPermanentError(format string, args ...interface{}) error {
+	return &PermanentError{Err: fmt.Errorf(format, args...)}
+}
+
+// IsRetryableError classifica o erro de envio: falhas temporárias (timeouts, throttling, IA) são
+// repetidas; erros permanentes e rejeições definitivas do SES/SMTP vão direto para dead-letter.
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var permanentErr *PermanentError
+	if errors.As(err, &permanentErr) {
+		return false
+	}
+
+	// 📧 Erros do SES que não mudam com uma nova tentativa
+	var messageRejected *types.MessageRejected
+	var mailFromNotVerified *types.MailFromDomainNotVerifiedException
+	var configSetNotFound *types.ConfigurationSetDoesNotExistException
+	var sendingPaused *types.AccountSendingPausedException
+	switch {
+	case errors.As(err, &messageRejected),
+		errors.As(err, &mailFromNotVerified),
+		errors.As(err, &configSetNotFound),
+		errors.As(err, &sendingPaused):
+		return false
+	}
+
+	// 📮 Respostas SMTP 5xx são rejeições definitivas (destinatário inexistente, autenticação recusada...)
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) && smtpErr.Code >= 500 {
+		return false
+	}
+
+	return true
+}
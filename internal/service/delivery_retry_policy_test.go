@@ -0,0 +1,80 @@
+// File: /internal/service/delivery_retry_policy_test.go
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ses/types"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 30 * time.Second, MaxDelay: 5 * time.Minute}
+
+	tests := []struct {
+		attempt int
+		full    time.Duration // Atraso antes do jitter: o resultado fica em [full/2, full)
+	}{
+		{attempt: 1, full: 30 * time.Second},
+		{attempt: 2, full: time.Minute},
+		{attempt: 3, full: 2 * time.Minute},
+		{attempt: 4, full: 4 * time.Minute},
+		{attempt: 5, full: 5 * time.Minute},  // Limitado ao MaxDelay
+		{attempt: 20, full: 5 * time.Minute}, // Não estoura com muitas tentativas
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("tentativa %d", tt.attempt), func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				delay := policy.Backoff(tt.attempt)
+				if delay < tt.full/2 || delay >= tt.full {
+					t.Fatalf("Backoff(%d) = %s, esperado entre %s e %s", tt.attempt, delay, tt.full/2, tt.full)
+				}
+			}
+		})
+	}
+}
+
+func TestNewRetryPolicyFromEnv(t *testing.T) {
+	t.Setenv("WHATSAPP_RETRY_MAX_ATTEMPTS", "7")
+	t.Setenv("WHATSAPP_RETRY_BASE_DELAY", "10")
+	t.Setenv("WHATSAPP_RETRY_MAX_DELAY", "3600") // Acima do máximo da fila
+
+	policy := NewRetryPolicy("whatsapp")
+	if policy.MaxAttempts != 7 || policy.BaseDelay != 10*time.Second || policy.MaxDelay != maxQueueDelay {
+		t.Fatalf("política inesperada: %+v", policy)
+	}
+
+	if email := NewRetryPolicy("email"); email.MaxAttempts != 5 || email.BaseDelay != 30*time.Second {
+		t.Fatalf("padrão do e-mail inesperado: %+v", email)
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "erro genérico", err: errors.New("timeout"), want: true},
+		{name: "permanente", err: NewPermanentError("contato sem e-mail"), want: false},
+		{name: "permanente encapsulado", err: fmt.Errorf("envio: %w", NewPermanentError("x")), want: false},
+		{name: "SES MessageRejected", err: &types.MessageRejected{}, want: false},
+		{name: "SES envio pausado", err: fmt.Errorf("ses: %w", &types.AccountSendingPausedException{}), want: false},
+		{name: "SMTP 550", err: &textproto.Error{Code: 550, Msg: "mailbox unavailable"}, want: false},
+		{name: "SMTP 421", err: &textproto.Error{Code: 421, Msg: "try again later"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryableError(tt.err); got != tt.want {
+				t.Fatalf("IsRetryableError(%v) = %v, esperado %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// File: /internal/service/email_mime.go
+
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"time"
+)
+
+// emailHeader é um cabeçalho adicional da mensagem (a ordem é preservada)
+type emailHeader struct {
+	Name  string
+	Value string
+}
+
+// EmailAttachment é um arquivo anexado à mensagem
+type EmailAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// rawEmail representa uma mensagem MIME (multipart/alternative, dentro de multipart/mixed quando há anexos)
+// entregue pelo EmailSender
+type rawEmail struct {
+	From        string
+	To          string
+	ReplyTo     string
+	Subject     string
+	Headers     []emailHeader
+	HTML        string
+	Text        string
+	Attachments []EmailAttachment
+}
+
+// Bytes monta a mensagem no formato RFC 5322 com partes texto e HTML em quoted-printable e anexos em base64
+func (m rawEmail) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", formatAddress(m.From))
+	fmt.Fprintf(&buf, "To: %s\r\n", formatAddress(m.To))
+	if m.ReplyTo != "" {
+		fmt.Fprintf(&buf, "Reply-To: %s\r\n", formatAddress(m.ReplyTo))
+	}
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", m.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	for _, header := range m.Headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", textproto.CanonicalMIMEHeaderKey(header.Name), mime.QEncoding.Encode("UTF-8", header.Value))
+	}
+
+	if len(m.Attachments) == 0 {
+		body := multipart.NewWriter(&buf)
+		fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", body.Boundary())
+		if err := m.writeAlternative(body); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	// 📎 Com anexos: multipart/mixed com o corpo (texto + HTML) seguido dos arquivos
+	mixed := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mixed.Boundary())
+
+	var alternative bytes.Buffer
+	body := multipart.NewWriter(&alternative)
+	if err := m.writeAlternative(body); err != nil {
+		return nil, err
+	}
+	alternativePart, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {fmt.Sprintf("multipart/alternative; boundary=%q", body.Boundary())},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar parte MIME: %w", err)
+	}
+	if _, err := alternativePart.Write(alternative.Bytes()); err != nil {
+		return nil, fmt.Errorf("erro ao montar mensagem MIME: %w", err)
+	}
+
+	for _, attachment := range m.Attachments {
+		if err := writeAttachment(mixed, attachment); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mixed.Close(); err != nil {
+		return nil, fmt.Errorf("erro ao finalizar mensagem MIME: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeAlternative escreve as partes texto e HTML e fecha o multipart/alternative
+func (m rawEmail) writeAlternative(body *multipart.Writer) error {
+	// 📄 Texto antes do HTML: clientes exibem a última parte que suportam
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", m.Text},
+		{"text/html; charset=UTF-8", m.HTML},
+	} {
+		writer, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return fmt.Errorf("erro ao criar parte MIME: %w", err)
+		}
+
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return fmt.Errorf("erro ao codificar parte MIME: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return fmt.Errorf("erro ao codificar parte MIME: %w", err)
+		}
+	}
+
+	if err := body.Close(); err != nil {
+		return fmt.Errorf("erro ao finalizar mensagem MIME: %w", err)
+	}
+	return nil
+}
+
+// writeAttachment escreve o arquivo em base64 (linhas de 76 caracteres, RFC 2045)
+func writeAttachment(mixed *multipart.Writer, attachment EmailAttachment) error {
+	writer, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(attachment.ContentType, map[string]string{"name": attachment.Filename})},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao criar anexo MIME: %w", err)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+	for len(encoded) > 76 {
+		if _, err := io.WriteString(writer, encoded[:76]+"\r\n"); err != nil {
+			return fmt.Errorf("erro ao codificar anexo MIME: %w", err)
+		}
+		encoded = encoded[76:]
+	}
+	if _, err := io.WriteString(writer, encoded+"\r\n"); err != nil {
+		return fmt.Errorf("erro ao codificar anexo MIME: %w", err)
+	}
+	return nil
+}
+
+// formatAddress codifica o nome de exibição (acentos) mantendo o endereço; valores inválidos seguem como vieram
+func formatAddress(address string) string {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return address
+	}
+	return parsed.String()
+}
@@ -0,0 +1,197 @@
+// File: /internal/service/email_mime_test.go
+
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+// mimePart é uma parte decodificada da mensagem
+type mimePart struct {
+	contentType string
+	disposition string
+	body        []byte
+}
+
+// readMultipart lê as partes de um multipart, decodificando quoted-printable e base64
+func readMultipart(t *testing.T, contentType string, body io.Reader) []mimePart {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		t.Fatalf("Content-Type %q não é multipart (%v)", contentType, err)
+	}
+
+	var parts []mimePart
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatalf("erro ao ler parte MIME: %v", err)
+		}
+
+		var content io.Reader = part
+		switch part.Header.Get("Content-Transfer-Encoding") {
+		case "quoted-printable":
+			content = quotedprintable.NewReader(part)
+		case "base64":
+			content = base64.NewDecoder(base64.StdEncoding, part)
+		}
+		data, err := io.ReadAll(content)
+		if err != nil {
+			t.Fatalf("erro ao decodificar parte MIME: %v", err)
+		}
+
+		parts = append(parts, mimePart{
+			contentType: part.Header.Get("Content-Type"),
+			disposition: part.Header.Get("Content-Disposition"),
+			body:        data,
+		})
+	}
+}
+
+func buildTestEmail(t *testing.T, email rawEmail) *mail.Message {
+	t.Helper()
+
+	data, err := email.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("mensagem inválida: %v\n%s", err, data)
+	}
+	return msg
+}
+
+func TestRawEmailHeaders(t *testing.T) {
+	msg := buildTestEmail(t, rawEmail{
+		From:    "Loja São João <loja@example.com>",
+		To:      "cliente@example.com",
+		ReplyTo: "Atendimento <atendimento@example.com>",
+		Subject: "Promoção de inverno ❄️",
+		Headers: []emailHeader{
+			{Name: "list-unsubscribe", Value: "<https://api.example.com/unsubscribe/abc>"},
+			{Name: "X-Campanha", Value: "Coleção"},
+		},
+		HTML: "<p>Olá</p>",
+		Text: "Olá\n",
+	})
+
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil || from.Name != "Loja São João" || from.Address != "loja@example.com" {
+		t.Fatalf("From = %q (%v)", msg.Header.Get("From"), err)
+	}
+	if replyTo, err := mail.ParseAddress(msg.Header.Get("Reply-To")); err != nil || replyTo.Address != "atendimento@example.com" {
+		t.Fatalf("Reply-To = %q (%v)", msg.Header.Get("Reply-To"), err)
+	}
+
+	decoder := new(mime.WordDecoder)
+	if subject, err := decoder.DecodeHeader(msg.Header.Get("Subject")); err != nil || subject != "Promoção de inverno ❄️" {
+		t.Fatalf("Subject = %q (%v)", subject, err)
+	}
+	if got := msg.Header.Get("List-Unsubscribe"); got != "<https://api.example.com/unsubscribe/abc>" {
+		t.Fatalf("List-Unsubscribe = %q", got)
+	}
+	if custom, _ := decoder.DecodeHeader(msg.Header.Get("X-Campanha")); custom != "Coleção" {
+		t.Fatalf("X-Campanha = %q", custom)
+	}
+	if msg.Header.Get("MIME-Version") != "1.0" || msg.Header.Get("Date") == "" {
+		t.Fatalf("cabeçalhos obrigatórios ausentes: %v", msg.Header)
+	}
+}
+
+func TestRawEmailWithoutReplyToOmitsHeader(t *testing.T) {
+	msg := buildTestEmail(t, rawEmail{From: "loja@example.com", To: "cliente@example.com", Subject: "Oi", HTML: "<p>Oi</p>", Text: "Oi\n"})
+
+	if _, ok := msg.Header["Reply-To"]; ok {
+		t.Fatal("Reply-To vazio não deve ser enviado")
+	}
+}
+
+func TestRawEmailAlternativeParts(t *testing.T) {
+	longLine := strings.Repeat("ação ", 40) // linhas longas e acentos exigem quoted-printable
+	msg := buildTestEmail(t, rawEmail{
+		From:    "loja@example.com",
+		To:      "cliente@example.com",
+		Subject: "Oi",
+		HTML:    "<p>" + longLine + "</p>",
+		Text:    longLine + "\n",
+	})
+
+	parts := readMultipart(t, msg.Header.Get("Content-Type"), msg.Body)
+	if len(parts) != 2 {
+		t.Fatalf("partes = %d, esperado 2 (texto e HTML)", len(parts))
+	}
+
+	// 📄 Texto antes do HTML: clientes exibem a última parte que suportam (quebras de linha viram CRLF)
+	if !strings.HasPrefix(parts[0].contentType, "text/plain") || string(parts[0].body) != longLine+"\r\n" {
+		t.Fatalf("parte texto = %q %q", parts[0].contentType, parts[0].body)
+	}
+	if !strings.HasPrefix(parts[1].contentType, "text/html") || string(parts[1].body) != "<p>"+longLine+"</p>" {
+		t.Fatalf("parte HTML = %q %q", parts[1].contentType, parts[1].body)
+	}
+}
+
+func TestRawEmailAttachments(t *testing.T) {
+	pdf := bytes.Repeat([]byte("%PDF-1.4 conteúdo binário \x00\x01\x02"), 20)
+	msg := buildTestEmail(t, rawEmail{
+		From:    "loja@example.com",
+		To:      "cliente@example.com",
+		Subject: "Catálogo",
+		HTML:    "<p>Segue o catálogo</p>",
+		Text:    "Segue o catálogo\n",
+		Attachments: []EmailAttachment{
+			{Filename: "catálogo 2026.pdf", ContentType: "application/pdf", Content: pdf},
+		},
+	})
+
+	if mediaType, _, _ := mime.ParseMediaType(msg.Header.Get("Content-Type")); mediaType != "multipart/mixed" {
+		t.Fatalf("Content-Type = %q, esperado multipart/mixed", msg.Header.Get("Content-Type"))
+	}
+
+	parts := readMultipart(t, msg.Header.Get("Content-Type"), msg.Body)
+	if len(parts) != 2 {
+		t.Fatalf("partes = %d, esperado 2 (corpo e anexo)", len(parts))
+	}
+
+	body := readMultipart(t, parts[0].contentType, bytes.NewReader(parts[0].body))
+	if len(body) != 2 || !strings.HasPrefix(body[0].contentType, "text/plain") || !strings.HasPrefix(body[1].contentType, "text/html") {
+		t.Fatalf("corpo = %+v, esperado texto e HTML", body)
+	}
+
+	attachment := parts[1]
+	if !bytes.Equal(attachment.body, pdf) {
+		t.Fatal("conteúdo do anexo diferente do original")
+	}
+	_, params, err := mime.ParseMediaType(attachment.disposition)
+	if err != nil || params["filename"] != "catálogo 2026.pdf" {
+		t.Fatalf("Content-Disposition = %q (%v)", attachment.disposition, err)
+	}
+}
+
+func TestWriteAttachmentWrapsBase64Lines(t *testing.T) {
+	var buf bytes.Buffer
+	mixed := multipart.NewWriter(&buf)
+	if err := writeAttachment(mixed, EmailAttachment{Filename: "a.bin", ContentType: "application/octet-stream", Content: bytes.Repeat([]byte{0xff}, 300)}); err != nil {
+		t.Fatalf("writeAttachment: %v", err)
+	}
+	mixed.Close()
+
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > 76 {
+			t.Fatalf("linha com %d caracteres (máximo 76): %q", len(line), line)
+		}
+	}
+}
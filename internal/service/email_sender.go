@@ -0,0 +1,83 @@
+// File: /internal/service/email_sender.go
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// EmailSender entrega uma mensagem MIME já montada usando o provedor configurado na conta.
+// Implementações: Amazon SES (sesEmailSender) e servidor SMTP próprio (smtpEmailSender).
+type EmailSender interface {
+	Send(ctx context.Context, settings models.AccountSettings, email OutgoingEmail) (*EmailSendResult, error)
+}
+
+// OutgoingEmail é a mensagem pronta para entrega (envelope + conteúdo RFC 5322)
+type OutgoingEmail struct {
+	From             string // Remetente (pode conter nome de exibição)
+	To               string
+	MessageID        string // Message-ID gerado pela aplicação (sem < >), usado quando o provedor não gera o seu
+	Raw              []byte
+	ConfigurationSet string // Configuration Set do SES do remetente (vazio = padrão)
+}
+
+// EmailSendResult identifica a mensagem entregue, independente do provedor
+type EmailSendResult struct {
+	Provider  models.EmailProvider
+	MessageID string            // 🔗 Gravado em campaigns_audience.message_id para correlacionar eventos
+	Content   *SentEmailContent // Preenchido pelo EmailService para o registro da mensagem entregue
+}
+
+// SentEmailContent é o conteúdo exato recebido pelo destinatário
+type SentEmailContent struct {
+	Subject         string
+	HTML            string // Com preheader e rastreamento
+	Text            string
+	TemplateID      uuid.UUID
+	TemplateVersion string // SHA-256 do arquivo do template
+}
+
+// providerEmailSender escolhe o provedor de cada envio a partir das configurações da conta
+type providerEmailSender struct {
+	log     *slog.Logger
+	senders map[models.EmailProvider]EmailSender
+}
+
+// NewEmailSender cria o envio de e-mails com SES e SMTP disponíveis (padrão: SES).
+// `defaultConfigurationSet` é usado nos envios SES de remetentes sem Configuration Set próprio.
+func NewEmailSender(defaultConfigurationSet string) EmailSender {
+	return &providerEmailSender{
+		log: logger.GetLogger(),
+		senders: map[models.EmailProvider]EmailSender{
+			models.EmailProviderSES:  newSESEmailSender(defaultConfigurationSet),
+			models.EmailProviderSMTP: newSMTPEmailSender(),
+		},
+	}
+}
+
+// Send encaminha a mensagem para o provedor da conta
+func (s *providerEmailSender) Send(ctx context.Context, settings models.AccountSettings, email OutgoingEmail) (*EmailSendResult, error) {
+	provider := settings.EmailProvider
+	if provider == "" {
+		provider = models.EmailProviderSES // 🔄 Contas anteriores à escolha de provedor
+	}
+
+	sender, ok := s.senders[provider]
+	if !ok {
+		s.log.Error("❌ Provedor de e-mail desconhecido", "account_id", settings.AccountID, "provider", provider)
+		return nil, NewPermanentError("provedor de e-mail desconhecido: %s", provider)
+	}
+
+	result, err := sender.Send(ctx, settings, email)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao enviar e-mail via %s: %w", provider, err)
+	}
+
+	return result, nil
+}
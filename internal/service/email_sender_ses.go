@@ -0,0 +1,53 @@
+// File: /internal/service/email_sender_ses.go
+
+package service
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/ses"
+	"github.com/aws/aws-sdk-go-v2/service/ses/types"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// sesEmailSender envia e-mails pelo Amazon SES com as credenciais da própria conta
+type sesEmailSender struct {
+	configurationSet string
+}
+
+// newSESEmailSender cria o envio via SES vinculado ao Configuration Set padrão de bounces/entregas
+func newSESEmailSender(configurationSet string) *sesEmailSender {
+	return &sesEmailSender{configurationSet: configurationSet}
+}
+
+// Send envia a mensagem com SendRawEmail; o Message-ID retornado é o mesmo dos eventos do SES
+func (s *sesEmailSender) Send(ctx context.Context, settings models.AccountSettings, email OutgoingEmail) (*EmailSendResult, error) {
+	configurationSet := email.ConfigurationSet
+	if configurationSet == "" {
+		configurationSet = s.configurationSet
+	}
+
+	output, err := newSESClient(settings).SendRawEmail(ctx, &ses.SendRawEmailInput{
+		RawMessage:           &types.RawMessage{Data: email.Raw},
+		Destinations:         []string{email.To},
+		Source:               aws.String(email.From),       // Remetente
+		ConfigurationSetName: aws.String(configurationSet), // 🔹 Vinculando ao Configuration Set
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmailSendResult{Provider: models.EmailProviderSES, MessageID: aws.ToString(output.MessageId)}, nil
+}
+
+// newSESClient cria o cliente do SES com as credenciais da conta
+func newSESClient(settings models.AccountSettings) *ses.Client {
+	return ses.NewFromConfig(aws.Config{
+		Region: settings.AWSRegion,
+		Credentials: aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(
+			settings.AWSAccessKeyID, settings.AWSSecretAccessKey, ""),
+		),
+	})
+}
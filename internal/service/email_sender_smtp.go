@@ -0,0 +1,112 @@
+// File: /internal/service/email_sender_smtp.go
+
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// smtpEmailSender envia e-mails por um servidor SMTP da conta (ou MailHog/smtp4dev em desenvolvimento)
+type smtpEmailSender struct {
+	timeout time.Duration
+}
+
+// newSMTPEmailSender cria o envio via SMTP com tempo máximo por mensagem
+func newSMTPEmailSender() *smtpEmailSender {
+	return &smtpEmailSender{timeout: 30 * time.Second}
+}
+
+// Send abre uma conexão por mensagem: conecta (TLS/STARTTLS), autentica e entrega ao servidor.
+// O SMTP não devolve identificador, então o Message-ID gerado pela aplicação é o resultado.
+func (s *smtpEmailSender) Send(ctx context.Context, settings models.AccountSettings, email OutgoingEmail) (*EmailSendResult, error) {
+	if settings.SMTPHost == "" {
+		return nil, NewPermanentError("servidor SMTP não configurado para a conta %s", settings.AccountID)
+	}
+
+	from, err := mail.ParseAddress(email.From)
+	if err != nil {
+		return nil, NewPermanentError("remetente inválido (%s): %w", email.From, err)
+	}
+
+	conn, err := s.dial(ctx, settings)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao conectar ao servidor SMTP: %w", err)
+	}
+
+	// ⏱️ Servidor lento ou aplicação encerrando: a conexão é fechada e o envio falha
+	deadline := time.Now().Add(s.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, settings.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("erro ao iniciar sessão SMTP: %w", err)
+	}
+	defer client.Close()
+
+	if settings.SMTPTLS == models.SMTPTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return nil, NewPermanentError("servidor SMTP %s não suporta STARTTLS", settings.SMTPHost)
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: settings.SMTPHost}); err != nil {
+			return nil, fmt.Errorf("erro ao iniciar STARTTLS: %w", err)
+		}
+	}
+
+	if settings.SMTPUsername != "" {
+		auth := smtp.PlainAuth("", settings.SMTPUsername, settings.SMTPPassword, settings.SMTPHost)
+		if err := client.Auth(auth); err != nil {
+			return nil, fmt.Errorf("erro ao autenticar no servidor SMTP: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return nil, fmt.Errorf("erro no remetente SMTP: %w", err)
+	}
+	if err := client.Rcpt(email.To); err != nil {
+		return nil, fmt.Errorf("erro no destinatário SMTP: %w", err)
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar envio SMTP: %w", err)
+	}
+	if _, err := writer.Write(email.Raw); err != nil {
+		return nil, fmt.Errorf("erro ao transmitir mensagem SMTP: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("erro ao finalizar mensagem SMTP: %w", err)
+	}
+
+	// ✅ Mensagem aceita pelo servidor: falha no QUIT não deve gerar reenvio
+	client.Quit()
+
+	return &EmailSendResult{Provider: models.EmailProviderSMTP, MessageID: email.MessageID}, nil
+}
+
+// dial abre a conexão TCP, já com TLS quando o modo for implícito (porta 465)
+func (s *smtpEmailSender) dial(ctx context.Context, settings models.AccountSettings) (net.Conn, error) {
+	address := net.JoinHostPort(settings.SMTPHost, strconv.Itoa(settings.SMTPPort))
+	dialer := &net.Dialer{Timeout: s.timeout}
+
+	if settings.SMTPTLS == models.SMTPTLSImplicit {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: settings.SMTPHost}}
+		return tlsDialer.DialContext(ctx, "tcp", address)
+	}
+
+	return dialer.DialContext(ctx, "tcp", address)
+}
@@ -5,17 +5,21 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"log/slog"
+	"maps"
+	"net/mail"
 	"os"
+	"slices"
 	"strings"
 	"text/template"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/credentials"
-	"github.com/aws/aws-sdk-go-v2/service/ses"
-	"github.com/aws/aws-sdk-go-v2/service/ses/types"
+	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/dto"
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
@@ -24,18 +28,22 @@ import (
 
 type EmailService interface {
 	CreateEmailWithAI(ctx context.Context, contact models.Contact, campaign models.Campaign, campaignSettings models.CampaignSettings) (*dto.EmailData, error)
-	SendEmail(account models.Account, accountSettings models.AccountSettings, campaign models.Campaign, campaignSettings models.CampaignSettings, contact models.Contact, emailData dto.EmailData) (*ses.SendEmailOutput, error)
+	SendEmail(ctx context.Context, account models.Account, accountSettings models.AccountSettings, campaign models.Campaign, campaignSettings models.CampaignSettings, sender models.SenderIdentity, contact models.Contact, audienceID uuid.UUID, emailData dto.EmailData) (*EmailSendResult, error)
 }
 
 type emailService struct {
-	log    *slog.Logger
-	openAI OpenAIService
+	log         *slog.Logger
+	openAI      OpenAIService
+	tracking    EmailTrackingService
+	unsubscribe UnsubscribeService
+	sender      EmailSender
+	assets      AssetService
 }
 
-func NewEmailService(openAIService OpenAIService) EmailService {
+func NewEmailService(openAIService OpenAIService, tracking EmailTrackingService, unsubscribe UnsubscribeService, sender EmailSender, assets AssetService) EmailService {
 	log := logger.GetLogger()
 
-	return &emailService{log: log, openAI: openAIService}
+	return &emailService{log: log, openAI: openAIService, tracking: tracking, unsubscribe: unsubscribe, sender: sender, assets: assets}
 }
 
 // 🔹 Envia o prompt para a OpenAI e recebe a resposta usando OpenAIService
@@ -71,72 +79,153 @@ func (s *emailService) CreateEmailWithAI(ctx context.Context, contact models.Con
 		return nil, fmt.Errorf("erro ao converter JSON para DTO: %w", err)
 	}
 
+	emailDTO.AIModel, emailDTO.AIPrompt = request.Model, prompt
+
 	s.log.Debug("✅ Email criado e formatado com sucesso pela OpenAI", "email_data", emailDTO)
 
 	return &emailDTO, nil
 }
 
-// SendEmail envia um e-mail usando SES
+// SendEmail monta a mensagem MIME (com cabeçalhos de descadastro) e envia pelo provedor da conta.
+// `sender` é a identidade verificada que autoriza o email_from (nome de exibição, Reply-To e Configuration Set).
 func (s *emailService) SendEmail(
+	ctx context.Context,
 	account models.Account,
 	accountSettings models.AccountSettings,
 	campaign models.Campaign,
 	campaignSettings models.CampaignSettings,
+	sender models.SenderIdentity,
 	contact models.Contact,
+	audienceID uuid.UUID,
 	emailData dto.EmailData,
-) (*ses.SendEmailOutput, error) {
+) (*EmailSendResult, error) {
 	s.log.Debug("Enviando e-mail", "from", campaignSettings.EmailFrom, "to", contact.Email, "subject", campaignSettings.Subject)
 
+	// 🚫 Link de descadastro disponível no template como {{.UnsubscribeURL}}
+	unsubscribeURL := s.unsubscribe.URL(audienceID)
+	emailData.UnsubscribeURL = unsubscribeURL
+
 	channel := campaign.Channels["email"]
-	conteudoEmail, err := s.carregarTemplateEmail(channel.TemplateID.String(), emailData)
+	conteudoEmail, templateVersion, err := s.carregarTemplateEmail(channel.TemplateID.String(), emailData)
 	if err != nil {
-		return nil, fmt.Errorf("ERROR: Erro ao renderizar template de e-mail: %w", err)
+		return nil, NewPermanentError("ERROR: Erro ao renderizar template de e-mail: %w", err) // 🔥 Template ausente ou inválido não se resolve com retry
 	}
 
-	// aqui
-	// Enviar e-mail
-	input := &ses.SendEmailInput{
-		Destination: &types.Destination{
-			ToAddresses: []string{strings.ToLower(*contact.Email)},
-		},
-		Message: &types.Message{
-			Body: &types.Body{
-				Html: &types.Content{
-					Charset: aws.String("UTF-8"),
-					Data:    aws.String(conteudoEmail), // Usa o conteúdo renderizado do template
-				},
-				Text: &types.Content{
-					Charset: aws.String("UTF-8"),
-					Data:    aws.String(conteudoEmail), // Usa o conteúdo renderizado do template
-				},
-			},
-			Subject: &types.Content{
-				Charset: aws.String("UTF-8"),
-				Data:    aws.String(campaignSettings.Subject),
-			},
-		},
-		Source:               aws.String(campaignSettings.EmailFrom), // Remetente
-		ConfigurationSetName: aws.String("SES-Bounce-Config"),        // 🔹 Vinculando ao Configuration Set
+	// 📄 Parte texto gerada do HTML sem rastreamento (links legíveis como notas de rodapé)
+	conteudoTexto := htmlToText(conteudoEmail)
+
+	// 👀 Preheader, pixel de abertura e links rastreados (somente na parte HTML)
+	var preheader string
+	if campaignSettings.EmailPreheader != nil {
+		preheader = *campaignSettings.EmailPreheader
 	}
+	conteudoHTML := s.tracking.Instrument(withPreheader(conteudoEmail, preheader), audienceID)
 
-	// Configure ses client from account settings
-	awsConfig := aws.Config{
-		Region: accountSettings.AWSRegion,
-		Credentials: aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(
-			accountSettings.AWSAccessKeyID, accountSettings.AWSSecretAccessKey, ""),
-		),
+	// ✉️ Remetente com o nome de exibição da identidade; Reply-To da campanha ou o padrão do remetente
+	from := campaignSettings.EmailFrom
+	if sender.DisplayName != nil && *sender.DisplayName != "" {
+		from = (&mail.Address{Name: *sender.DisplayName, Address: campaignSettings.EmailFrom}).String()
+	}
+	replyTo := campaignSettings.EmailReply
+	if replyTo == "" && sender.ReplyTo != nil {
+		replyTo = *sender.ReplyTo
 	}
-	ses := ses.NewFromConfig(awsConfig)
 
-	// 🚀 Enviar e-mail via SES
-	sesEmailOutput, err := ses.SendEmail(context.TODO(), input)
+	// 📎 Anexos da campanha (arquivo removido ou acima do limite não se resolve com retry)
+	attachments, err := s.assets.Attachments(ctx, campaign.AccountID, campaignSettings.EmailAttachments)
+	if errors.Is(err, ErrInvalidAsset) || errors.Is(err, ErrAssetNotFound) {
+		return nil, NewPermanentError("ERROR: Anexos da campanha inválidos: %w", err)
+	}
 	if err != nil {
-		s.log.Error("Erro ao enviar e-mail", "error", err)
+		return nil, fmt.Errorf("erro ao carregar anexos da campanha: %w", err)
+	}
+
+	to := strings.ToLower(*contact.Email)
+	messageID := newMessageID(campaignSettings.EmailFrom)
+	message := rawEmail{
+		From:        from,
+		To:          to,
+		ReplyTo:     replyTo,
+		Subject:     campaignSettings.Subject,
+		HTML:        conteudoHTML,
+		Text:        conteudoTexto,
+		Headers:     []emailHeader{{Name: "Message-ID", Value: "<" + messageID + ">"}},
+		Attachments: attachments,
+	}
+
+	// 🏷️ Cabeçalhos adicionais da campanha (ordem estável entre envios)
+	for _, name := range slices.Sorted(maps.Keys(campaignSettings.EmailHeaders)) {
+		message.Headers = append(message.Headers, emailHeader{Name: name, Value: campaignSettings.EmailHeaders[name]})
+	}
+
+	// 📬 Descadastro em um clique (RFC 8058), exigido por Gmail/Yahoo para remetentes em massa
+	if unsubscribeURL != "" {
+		message.Headers = append(message.Headers,
+			emailHeader{Name: "List-Unsubscribe", Value: "<" + unsubscribeURL + ">"},
+			emailHeader{Name: "List-Unsubscribe-Post", Value: "List-Unsubscribe=One-Click"},
+		)
+	}
+
+	rawMessage, err := message.Bytes()
+	if err != nil {
+		return nil, NewPermanentError("ERROR: Erro ao montar mensagem de e-mail: %w", err)
+	}
+
+	// 🚀 Enviar e-mail pelo provedor configurado na conta (SES ou SMTP)
+	outgoing := OutgoingEmail{From: from, To: to, MessageID: messageID, Raw: rawMessage}
+	if sender.ConfigurationSet != nil {
+		outgoing.ConfigurationSet = *sender.ConfigurationSet
+	}
+	result, err := s.sender.Send(ctx, accountSettings, outgoing)
+	if err != nil {
+		s.log.Error("Erro ao enviar e-mail", "provider", accountSettings.EmailProvider, "error", err)
 		return nil, err
 	}
 
-	s.log.Info("E-mail enviado com sucesso", "from", campaignSettings.EmailFrom, "to", contact.Email, "message_id", *sesEmailOutput.MessageId)
-	return sesEmailOutput, nil
+	// 🗄️ Conteúdo exato entregue, para o registro da mensagem
+	result.Content = &SentEmailContent{
+		Subject:         campaignSettings.Subject,
+		HTML:            conteudoHTML,
+		Text:            conteudoTexto,
+		TemplateID:      channel.TemplateID,
+		TemplateVersion: templateVersion,
+	}
+
+	s.log.Info("E-mail enviado com sucesso", "from", campaignSettings.EmailFrom, "to", contact.Email, "provider", result.Provider, "message_id", result.MessageID)
+	return result, nil
+}
+
+// newMessageID gera um Message-ID único no domínio do remetente (o SES substitui pelo seu)
+func newMessageID(from string) string {
+	domain := "localhost"
+	if address, err := mail.ParseAddress(from); err == nil {
+		if at := strings.LastIndex(address.Address, "@"); at >= 0 {
+			domain = address.Address[at+1:]
+		}
+	}
+	return uuid.NewString() + "@" + domain
+}
+
+// withPreheader insere o texto de pré-visualização oculto logo após a abertura do <body>.
+// O preenchimento com espaços invisíveis evita que o cliente complete a prévia com o corpo do e-mail.
+func withPreheader(htmlContent, preheader string) string {
+	if preheader == "" {
+		return htmlContent
+	}
+
+	hidden := fmt.Sprintf(
+		`<div style="display:none;font-size:1px;line-height:1px;max-height:0;max-width:0;opacity:0;overflow:hidden;mso-hide:all;">%s%s</div>`,
+		html.EscapeString(preheader), strings.Repeat("&#847;&zwnj;&nbsp;", 40),
+	)
+
+	lower := strings.ToLower(htmlContent)
+	if start := strings.Index(lower, "<body"); start >= 0 {
+		if end := strings.IndexByte(lower[start:], '>'); end >= 0 {
+			at := start + end + 1
+			return htmlContent[:at] + hidden + htmlContent[at:]
+		}
+	}
+	return hidden + htmlContent
 }
 
 // GenerateEmailPromptForAI gera um prompt dinâmico para a IA criar um e-mail personalizado.
@@ -204,26 +293,27 @@ func (s *emailService) generateEmailPromptForAI(contact models.Contact, campaign
 	return prompt
 }
 
-// carregarTemplateEmail usa o conteúdo embutido do template
-func (s *emailService) carregarTemplateEmail(templateID string, dados dto.EmailData) (string, error) {
+// carregarTemplateEmail usa o conteúdo embutido do template e retorna também a versão (SHA-256 do arquivo)
+func (s *emailService) carregarTemplateEmail(templateID string, dados dto.EmailData) (string, string, error) {
 	var emailTemplate string
 
 	filePath := fmt.Sprintf("uploads/templates/email/%s.html", templateID)
 	content, err := os.ReadFile(filePath)
 	if err != nil {
-		return "", fmt.Errorf("ERROR: Erro ao carregar template de e-mail: %w", err)
+		return "", "", fmt.Errorf("ERROR: Erro ao carregar template de e-mail: %w", err)
 	}
 	emailTemplate = string(content)
+	sum := sha256.Sum256(content)
 
 	tmpl, err := template.New("emailTemplate").Parse(emailTemplate)
 	if err != nil {
-		return "", fmt.Errorf("ERROR: Erro ao carregar template de e-mail embutido: %w", err)
+		return "", "", fmt.Errorf("ERROR: Erro ao carregar template de e-mail embutido: %w", err)
 	}
 
 	var body bytes.Buffer
 	if err := tmpl.Execute(&body, dados); err != nil {
-		return "", fmt.Errorf("ERROR: Erro ao renderizar template de e-mail: %w", err)
+		return "", "", fmt.Errorf("ERROR: Erro ao renderizar template de e-mail: %w", err)
 	}
 
-	return body.String(), nil
+	return body.String(), hex.EncodeToString(sum[:]), nil
 }
@@ -0,0 +1,157 @@
+// File: /internal/service/email_text.go
+
+package service
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// 🔍 Atributos de tags (href, alt) com aspas duplas, simples ou sem aspas
+	tagAttrPattern = regexp.MustCompile(`(?is)\b([a-z-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	blankLines     = regexp.MustCompile(`\n{3,}`)
+	spaceRuns      = regexp.MustCompile(`[ \t\r\n\f]+`)
+)
+
+// Tags cujo conteúdo não aparece no texto
+var textSkippedTags = map[string]bool{"head": true, "title": true, "style": true, "script": true}
+
+// Tags de bloco: quebram linha (parágrafos e títulos separam com uma linha em branco)
+var textBlockTags = map[string]string{
+	"p": "\n\n", "h1": "\n\n", "h2": "\n\n", "h3": "\n\n", "h4": "\n\n", "h5": "\n\n", "h6": "\n\n",
+	"table": "\n\n", "ul": "\n\n", "ol": "\n\n", "blockquote": "\n\n",
+	"div": "\n", "tr": "\n", "section": "\n", "article": "\n", "header": "\n", "footer": "\n", "center": "\n",
+}
+
+// htmlToText gera a parte text/plain a partir do HTML renderizado.
+// Links viram referências numeradas ([1]) listadas ao final da mensagem, como notas de rodapé.
+func htmlToText(content string) string {
+	var out strings.Builder
+	var links []string
+	linkStart, linkHref := -1, ""
+
+	for i := 0; i < len(content); {
+		if content[i] != '<' {
+			next := strings.IndexByte(content[i:], '<')
+			if next < 0 {
+				next = len(content) - i
+			}
+			out.WriteString(spaceRuns.ReplaceAllString(html.UnescapeString(content[i:i+next]), " "))
+			i += next
+			continue
+		}
+
+		// 💬 Comentários (inclusive condicionais do Outlook)
+		if strings.HasPrefix(content[i:], "<!--") {
+			end := strings.Index(content[i:], "-->")
+			if end < 0 {
+				break
+			}
+			i += end + len("-->")
+			continue
+		}
+
+		end := strings.IndexByte(content[i:], '>')
+		if end < 0 {
+			break
+		}
+		tag := content[i+1 : i+end]
+		i += end + 1
+
+		closing := strings.HasPrefix(tag, "/")
+		fields := strings.Fields(strings.TrimPrefix(tag, "/"))
+		if len(fields) == 0 {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSuffix(fields[0], "/"))
+
+		switch {
+		case textSkippedTags[name] && !closing:
+			closeAt := strings.Index(strings.ToLower(content[i:]), "</"+name)
+			if closeAt < 0 {
+				i = len(content)
+				continue
+			}
+			i += closeAt
+		case name == "br":
+			out.WriteString("\n")
+		case name == "hr":
+			out.WriteString("\n----------\n")
+		case name == "li" && !closing:
+			out.WriteString("\n- ")
+		case (name == "td" || name == "th") && closing:
+			out.WriteString(" ")
+		case name == "img" && !closing:
+			if alt := tagAttr(tag, "alt"); alt != "" {
+				out.WriteString(alt)
+			}
+		case name == "a" && !closing:
+			linkStart, linkHref = out.Len(), tagAttr(tag, "href")
+		case name == "a" && closing:
+			if linkStart >= 0 {
+				out.WriteString(linkFootnote(&links, linkHref, out.String()[linkStart:]))
+			}
+			linkStart, linkHref = -1, ""
+		default:
+			if separator, ok := textBlockTags[name]; ok {
+				out.WriteString(separator)
+			}
+		}
+	}
+
+	// 🧹 Remove espaços nas pontas das linhas e limita as linhas em branco seguidas
+	lines := strings.Split(out.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text := strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+
+	if len(links) > 0 {
+		var footnotes strings.Builder
+		footnotes.WriteString("\n\n")
+		for i, link := range links {
+			fmt.Fprintf(&footnotes, "[%d] %s\n", i+1, link)
+		}
+		text += strings.TrimRight(footnotes.String(), "\n")
+	}
+
+	return text + "\n"
+}
+
+// linkFootnote registra o link e retorna a referência a ser colocada após o texto âncora.
+// Âncoras vazias, links internos (#) e textos que já são o próprio endereço não geram nota.
+func linkFootnote(links *[]string, href, anchorText string) string {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		return ""
+	}
+
+	anchorText = strings.TrimSpace(anchorText)
+	if anchorText == "" {
+		return href
+	}
+	if anchorText == href || anchorText == strings.TrimPrefix(href, "mailto:") {
+		return ""
+	}
+
+	for i, link := range *links {
+		if link == href {
+			return fmt.Sprintf(" [%d]", i+1)
+		}
+	}
+	*links = append(*links, href)
+	return fmt.Sprintf(" [%d]", len(*links))
+}
+
+// tagAttr retorna o valor (sem entidades HTML) de um atributo da tag
+func tagAttr(tag, name string) string {
+	for _, match := range tagAttrPattern.FindAllStringSubmatch(tag, -1) {
+		if strings.EqualFold(match[1], name) {
+			return strings.TrimSpace(html.UnescapeString(match[2] + match[3] + match[4]))
+		}
+	}
+	return ""
+}
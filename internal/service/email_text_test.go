@@ -0,0 +1,57 @@
+// File: /internal/service/email_text_test.go
+
+package service
+
+import "testing"
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "parágrafos e quebras",
+			html: "<p>Olá,   Maria</p><p>Linha 1<br>Linha 2</p>",
+			want: "Olá, Maria\n\nLinha 1\nLinha 2\n",
+		},
+		{
+			name: "head, estilos e comentários são ignorados",
+			html: "<html><head><title>T</title><style>p{color:red}</style></head><body><!--[if mso]>x<![endif]--><p>Corpo</p></body></html>",
+			want: "Corpo\n",
+		},
+		{
+			name: "links viram notas de rodapé sem repetir",
+			html: `<p><a href="https://loja.example.com">Loja</a> e <a href='https://loja.example.com'>site</a> e <a href=https://outro.example.com>outro</a></p>`,
+			want: "Loja [1] e site [1] e outro [2]\n\n[1] https://loja.example.com\n[2] https://outro.example.com\n",
+		},
+		{
+			name: "link cujo texto é o endereço não gera nota",
+			html: `<p><a href="mailto:contato@example.com">contato@example.com</a> <a href="#topo">topo</a></p>`,
+			want: "contato@example.com topo\n",
+		},
+		{
+			name: "âncora vazia mostra o endereço",
+			html: `<a href="https://example.com/promo"><img src="x.png"></a>`,
+			want: "https://example.com/promo\n",
+		},
+		{
+			name: "listas, imagens com alt e entidades",
+			html: `<ul><li>Um</li><li>Dois &amp; três</li></ul><img alt="Logo &quot;Loja&quot;" src="l.png"><hr>`,
+			want: "- Um\n- Dois & três\n\nLogo \"Loja\"\n----------\n",
+		},
+		{
+			name: "células separadas por espaço e linhas da tabela em blocos",
+			html: "<table><tr><td>A</td><td>B</td></tr><tr><td>C</td></tr></table>",
+			want: "A B\n\nC\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := htmlToText(tt.html); got != tt.want {
+				t.Errorf("htmlToText =\n%q\nesperado\n%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// File: /internal/service/email_tracking_service.go
+
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// EmailTrackingService instrumenta o HTML dos e-mails com pixel de abertura e links de clique assinados
+type EmailTrackingService interface {
+	// Enabled indica se o rastreamento está configurado (TRACKING_BASE_URL e TRACKING_SECRET)
+	Enabled() bool
+	// Instrument injeta o pixel de abertura e reescreve os links para o redirecionamento assinado
+	Instrument(htmlContent string, audienceID uuid.UUID) string
+	// VerifyOpen valida a assinatura do pixel de abertura
+	VerifyOpen(audienceID uuid.UUID, signature string) bool
+	// VerifyClick valida a assinatura do redirecionamento para `target`
+	VerifyClick(audienceID uuid.UUID, target, signature string) bool
+}
+
+type emailTrackingService struct {
+	baseURL string
+	secret  []byte
+}
+
+// hrefPattern encontra os links absolutos (http/https) do HTML renderizado, com o valor entre aspas
+// duplas, aspas simples ou sem aspas (o regexp do Go não tem referência a grupo, daí uma alternativa por forma)
+var hrefPattern = regexp.MustCompile(`(?i)href\s*=\s*(?:"(https?://[^"]+)"|'(https?://[^']+)'|(https?://[^\s"'<>=` + "`" + `]+))`)
+
+// NewEmailTrackingService cria o rastreamento de e-mails; sem baseURL ou secret o rastreamento fica desligado
+func NewEmailTrackingService(baseURL, secret string) EmailTrackingService {
+	return &emailTrackingService{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		secret:  []byte(secret),
+	}
+}
+
+// Enabled indica se o rastreamento está configurado
+func (s *emailTrackingService) Enabled() bool {
+	return s.baseURL != "" && len(s.secret) > 0
+}
+
+// Instrument reescreve os links e adiciona o pixel antes de </body> (ou ao final do HTML)
+func (s *emailTrackingService) Instrument(htmlContent string, audienceID uuid.UUID) string {
+	if !s.Enabled() {
+		return htmlContent
+	}
+
+	// 🔗 Links da própria API (ex.: descadastro) não são reescritos
+	instrumented := hrefPattern.ReplaceAllStringFunc(htmlContent, func(match string) string {
+		groups := hrefPattern.FindStringSubmatch(match)
+		target := html.UnescapeString(groups[1] + groups[2] + groups[3])
+		if strings.HasPrefix(target, s.baseURL) {
+			return match
+		}
+		return fmt.Sprintf(`href="%s"`, html.EscapeString(s.clickURL(audienceID, target)))
+	})
+
+	// 👀 Pixel de abertura
+	pixel := fmt.Sprintf(`<img src="%s" width="1" height="1" alt="" style="display:none" />`, html.EscapeString(s.openURL(audienceID)))
+	if i := strings.LastIndex(strings.ToLower(instrumented), "</body>"); i >= 0 {
+		return instrumented[:i] + pixel + instrumented[i:]
+	}
+	return instrumented + pixel
+}
+
+// VerifyOpen valida a assinatura do pixel de abertura
+func (s *emailTrackingService) VerifyOpen(audienceID uuid.UUID, signature string) bool {
+	return s.Enabled() && hmac.Equal([]byte(signature), []byte(s.sign("open", audienceID.String())))
+}
+
+// VerifyClick valida a assinatura do redirecionamento (impede redirecionamento aberto)
+func (s *emailTrackingService) VerifyClick(audienceID uuid.UUID, target, signature string) bool {
+	return s.Enabled() && hmac.Equal([]byte(signature), []byte(s.sign("click", audienceID.String(), target)))
+}
+
+// openURL monta a URL do pixel de abertura
+func (s *emailTrackingService) openURL(audienceID uuid.UUID) string {
+	return fmt.Sprintf("%s/track/open/%s?sig=%s", s.baseURL, audienceID, s.sign("open", audienceID.String()))
+}
+
+// clickURL monta a URL de redirecionamento assinada para o link original
+func (s *emailTrackingService) clickURL(audienceID uuid.UUID, target string) string {
+	query := url.Values{}
+	query.Set("url", target)
+	query.Set("sig", s.sign("click", audienceID.String(), target))
+	return fmt.Sprintf("%s/track/click/%s?%s", s.baseURL, audienceID, query.Encode())
+}
+
+// sign gera o HMAC-SHA256 (base64 url-safe) das partes informadas
+func (s *emailTrackingService) sign(parts ...string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(strings.Join(parts, "\n")))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,64 @@
+// File: /internal/service/email_tracking_service_test.go
+
+package service
+
+import (
+	"html"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestEmailTrackingRewritesQuotedAndUnquotedLinks(t *testing.T) {
+	svc := NewEmailTrackingService("https://api.example.com", "segredo").(*emailTrackingService)
+	audienceID := uuid.New()
+
+	tests := []struct {
+		name   string
+		html   string
+		target string
+	}{
+		{name: "aspas duplas", html: `<a href="https://loja.example.com/a?x=1&amp;y=2">ver</a>`, target: "https://loja.example.com/a?x=1&y=2"},
+		{name: "aspas simples", html: `<a href='https://loja.example.com/b'>ver</a>`, target: "https://loja.example.com/b"},
+		{name: "sem aspas", html: `<a href=https://loja.example.com/c>ver</a>`, target: "https://loja.example.com/c"},
+		{name: "espaços e maiúsculas", html: `<a HREF = 'http://loja.example.com/d' class="btn">ver</a>`, target: "http://loja.example.com/d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instrumented := svc.Instrument(tt.html, audienceID)
+			groups := hrefPattern.FindStringSubmatch(instrumented)
+			if groups == nil || !strings.HasPrefix(groups[1], "https://api.example.com/track/click/") {
+				t.Fatalf("link não rastreado: %s", instrumented)
+			}
+			clickURL, err := url.Parse(html.UnescapeString(groups[1]))
+			if err != nil {
+				t.Fatalf("URL de clique inválida: %v", err)
+			}
+			query := clickURL.Query()
+			if query.Get("url") != tt.target {
+				t.Fatalf("destino = %q, esperado %q", query.Get("url"), tt.target)
+			}
+			if !svc.VerifyClick(audienceID, tt.target, query.Get("sig")) {
+				t.Fatal("assinatura do clique inválida")
+			}
+		})
+	}
+}
+
+func TestEmailTrackingKeepsOwnLinks(t *testing.T) {
+	svc := NewEmailTrackingService("https://api.example.com", "segredo")
+
+	for _, link := range []string{
+		`<a href="https://api.example.com/unsubscribe/abc">sair</a>`,
+		`<a href='https://api.example.com/unsubscribe/abc'>sair</a>`,
+		`<a href=https://api.example.com/unsubscribe/abc>sair</a>`,
+		`<a href="mailto:contato@example.com">contato</a>`,
+	} {
+		if instrumented := svc.Instrument(link, uuid.New()); !strings.HasPrefix(instrumented, link) {
+			t.Fatalf("link reescrito: %s", instrumented)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+// File: /internal/service/email_validation_service.go
+
+package service
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"errors"
+	"log/slog"
+	"net"
+	"net/mail"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// Motivos registrados em email_status_reason
+const (
+	EmailReasonSyntax         = "syntax"           // Endereço mal formado
+	EmailReasonDomainNotFound = "domain_not_found" // Domínio não existe
+	EmailReasonNullMX         = "null_mx"          // Domínio declara que não recebe e-mails (RFC 7505)
+	EmailReasonNoMailServer   = "no_mail_server"   // Domínio sem MX nem endereço
+	EmailReasonDisposable     = "disposable"       // Provedor de e-mail temporário
+	EmailReasonRoleAccount    = "role_account"     // Caixa de setor (contato@, vendas@)
+	EmailReasonDNSError       = "dns_error"        // DNS indisponível no momento da verificação
+)
+
+// emailReasonMessages descreve os motivos para as respostas da API
+var emailReasonMessages = map[string]string{
+	EmailReasonSyntax:         "formato de e-mail inválido",
+	EmailReasonDomainNotFound: "o domínio do e-mail não existe",
+	EmailReasonNullMX:         "o domínio do e-mail não recebe mensagens",
+	EmailReasonNoMailServer:   "o domínio do e-mail não possui servidor de e-mail",
+	EmailReasonDisposable:     "e-mail descartável",
+	EmailReasonRoleAccount:    "e-mail de setor (conta de função)",
+	EmailReasonDNSError:       "não foi possível consultar o domínio do e-mail",
+}
+
+var (
+	//go:embed email_lists/disposable_domains.txt
+	disposableDomainsList string
+	//go:embed email_lists/role_accounts.txt
+	roleAccountsList string
+
+	disposableDomains = loadEmailList(disposableDomainsList)
+	roleAccounts      = loadEmailList(roleAccountsList)
+
+	// 🔍 Caracteres permitidos na parte local (RFC 5322, forma sem aspas)
+	emailLocalPattern = regexp.MustCompile("^[a-z0-9!#$%&'*+/=?^_`{|}~.-]+$")
+	// 🔍 Rótulo de domínio (letras, números e hífen, sem hífen nas pontas)
+	emailLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+	// 🔍 TLD alfabético ou IDN (xn--)
+	emailTLDPattern = regexp.MustCompile(`^([a-z]{2,63}|xn--[a-z0-9-]{1,59})$`)
+)
+
+// emailDomainCacheTTL evita repetir a consulta de DNS do mesmo domínio (importações têm milhares de gmail.com)
+const emailDomainCacheTTL = time.Hour
+
+// DNSResolver consulta os registros do domínio (net.Resolver atende; injetável para testes e ambientes sem DNS)
+type DNSResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// EmailValidation é o resultado da verificação de um endereço
+type EmailValidation struct {
+	Email  string             // Endereço normalizado
+	Status models.EmailStatus // valid, risky, invalid ou unknown
+	Reason string             // Vazio quando válido
+}
+
+// Message descreve o motivo do status
+func (v EmailValidation) Message() string {
+	if message, ok := emailReasonMessages[v.Reason]; ok {
+		return message
+	}
+	return string(v.Status)
+}
+
+// ReasonPtr retorna o motivo para gravação (nil quando válido)
+func (v EmailValidation) ReasonPtr() *string {
+	return optionalString(v.Reason)
+}
+
+// EmailValidationService verifica endereços antes de gravá-los nos contatos
+type EmailValidationService interface {
+	// Validate normaliza e classifica o endereço (sintaxe, descartável, conta de função e MX)
+	Validate(ctx context.Context, email string) EmailValidation
+}
+
+type emailValidationService struct {
+	log      *slog.Logger
+	resolver DNSResolver
+	timeout  time.Duration
+
+	cacheMu sync.Mutex
+	cache   map[string]cachedDomainCheck
+}
+
+// cachedDomainCheck guarda o resultado da consulta de DNS de um domínio
+type cachedDomainCheck struct {
+	status    models.EmailStatus
+	reason    string
+	expiresAt time.Time
+}
+
+// NewEmailValidationService cria o serviço (resolver nil = DNS do sistema)
+func NewEmailValidationService(resolver DNSResolver, timeout time.Duration) EmailValidationService {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &emailValidationService{
+		log:      logger.GetLogger(),
+		resolver: resolver,
+		timeout:  timeout,
+		cache:    map[string]cachedDomainCheck{},
+	}
+}
+
+// Validate classifica o endereço: sintaxe e DNS definem invalid/unknown; descartável e conta de função, risky
+func (s *emailValidationService) Validate(ctx context.Context, email string) EmailValidation {
+	normalized := normalizeEmailAddress(email)
+	result := EmailValidation{Email: normalized, Status: models.EmailStatusValid}
+
+	local, domain, ok := splitEmailAddress(normalized)
+	if !ok {
+		result.Status, result.Reason = models.EmailStatusInvalid, EmailReasonSyntax
+		return result
+	}
+
+	status, reason := s.checkDomain(ctx, domain)
+	if status == models.EmailStatusInvalid {
+		result.Status, result.Reason = status, reason
+		return result
+	}
+
+	switch {
+	case isDisposableDomain(domain):
+		result.Status, result.Reason = models.EmailStatusRisky, EmailReasonDisposable
+	case roleAccounts[strings.SplitN(local, "+", 2)[0]]:
+		result.Status, result.Reason = models.EmailStatusRisky, EmailReasonRoleAccount
+	case status == models.EmailStatusUnknown:
+		result.Status, result.Reason = status, reason
+	}
+
+	return result
+}
+
+// checkDomain consulta o MX do domínio (ou o endereço, que serve de MX implícito pela RFC 5321)
+func (s *emailValidationService) checkDomain(ctx context.Context, domain string) (models.EmailStatus, string) {
+	if cached, ok := s.cachedDomain(domain); ok {
+		return cached.status, cached.reason
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	status, reason := models.EmailStatusValid, ""
+	records, err := s.resolver.LookupMX(lookupCtx, domain)
+	switch {
+	case err == nil && len(records) == 1 && (records[0].Host == "." || records[0].Host == ""):
+		status, reason = models.EmailStatusInvalid, EmailReasonNullMX
+	case err == nil && len(records) > 0:
+		// ✅ Domínio com servidor de e-mail
+	case err == nil || isDNSNotFound(err):
+		// 🔙 Sem MX: o domínio ainda recebe e-mails se tiver endereço (MX implícito)
+		if _, hostErr := s.resolver.LookupHost(lookupCtx, domain); hostErr != nil {
+			if isDNSNotFound(hostErr) {
+				status, reason = models.EmailStatusInvalid, EmailReasonDomainNotFound
+				if err == nil {
+					reason = EmailReasonNoMailServer
+				}
+			} else {
+				status, reason = models.EmailStatusUnknown, EmailReasonDNSError
+			}
+		}
+	default:
+		status, reason = models.EmailStatusUnknown, EmailReasonDNSError
+	}
+
+	if status == models.EmailStatusUnknown {
+		s.log.Warn("⚠️ Não foi possível consultar o DNS do domínio", "domain", domain, "error", err)
+		return status, reason // 🔄 Falhas temporárias não ficam em cache
+	}
+
+	s.rememberDomain(domain, cachedDomainCheck{status: status, reason: reason, expiresAt: time.Now().Add(emailDomainCacheTTL)})
+	return status, reason
+}
+
+// cachedDomain retorna o resultado ainda válido da consulta do domínio
+func (s *emailValidationService) cachedDomain(domain string) (cachedDomainCheck, bool) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	entry, ok := s.cache[domain]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return cachedDomainCheck{}, false
+	}
+	return entry, true
+}
+
+// rememberDomain guarda o resultado da consulta, descartando os expirados
+func (s *emailValidationService) rememberDomain(domain string, entry cachedDomainCheck) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	now := time.Now()
+	for key, cached := range s.cache {
+		if now.After(cached.expiresAt) {
+			delete(s.cache, key)
+		}
+	}
+	s.cache[domain] = entry
+}
+
+// isDNSNotFound indica resposta definitiva de inexistência (NXDOMAIN ou sem registros)
+func isDNSNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// normalizeEmailAddress corrige o que costuma vir de planilhas e formulários:
+// "mailto:", nome de exibição ("Fulano <f@x.com>"), espaços, maiúsculas, vírgula no domínio e ponto final
+func normalizeEmailAddress(email string) string {
+	email = strings.TrimSpace(email)
+	if len(email) >= 7 && strings.EqualFold(email[:7], "mailto:") {
+		email = email[7:]
+	}
+	if strings.ContainsAny(email, "<>") {
+		if address, err := mail.ParseAddress(email); err == nil {
+			email = address.Address
+		}
+	}
+	email = strings.ToLower(strings.Join(strings.Fields(email), ""))
+	email = strings.Trim(email, "<>\"'")
+
+	if at := strings.LastIndex(email, "@"); at >= 0 {
+		domain := strings.ReplaceAll(email[at+1:], ",", ".")
+		email = email[:at+1] + strings.TrimRight(domain, ".")
+	}
+	return email
+}
+
+// splitEmailAddress valida a sintaxe (RFC 5321/5322, sem aspas nem IP literal) e separa parte local e domínio
+func splitEmailAddress(email string) (string, string, bool) {
+	if len(email) > 254 || strings.Count(email, "@") != 1 {
+		return "", "", false
+	}
+
+	local, domain, _ := strings.Cut(email, "@")
+	if local == "" || len(local) > 64 || !emailLocalPattern.MatchString(local) ||
+		strings.HasPrefix(local, ".") || strings.HasSuffix(local, ".") || strings.Contains(local, "..") {
+		return "", "", false
+	}
+
+	labels := strings.Split(domain, ".")
+	if len(domain) > 253 || len(labels) < 2 || !emailTLDPattern.MatchString(labels[len(labels)-1]) {
+		return "", "", false
+	}
+	for _, label := range labels {
+		if len(label) > 63 || !emailLabelPattern.MatchString(label) {
+			return "", "", false
+		}
+	}
+
+	return local, domain, true
+}
+
+// isDisposableDomain verifica o domínio e seus domínios pais na lista de descartáveis
+func isDisposableDomain(domain string) bool {
+	for {
+		if disposableDomains[domain] {
+			return true
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
+
+// loadEmailList lê uma lista embutida (um item por linha, # para comentários)
+func loadEmailList(content string) map[string]bool {
+	items := map[string]bool{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if line != "" && !strings.HasPrefix(line, "#") {
+			items[line] = true
+		}
+	}
+	return items
+}
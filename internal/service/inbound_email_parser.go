@@ -0,0 +1,239 @@
+// File: /internal/service/inbound_email_parser.go
+
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// 🔍 Identificadores entre < > (In-Reply-To e References podem trazer vários)
+	messageIDPattern = regexp.MustCompile(`<([^<>\s]+)>`)
+	// ✂️ Cabeçalho da mensagem citada ("Em ..., Fulano escreveu:" / "On ..., X wrote:")
+	quoteHeaderPattern = regexp.MustCompile(`(?i)(escreveu|wrote)\s*:\s*$`)
+	// ✂️ Separadores do Outlook e de clientes que encaminham a mensagem original
+	quoteSeparatorPattern = regexp.MustCompile(`(?i)^(-{2,}\s*(mensagem original|original message)\s*-{2,}|_{10,})`)
+)
+
+// inboundEmailMaxDepth limita o aninhamento de partes multipart percorridas
+const inboundEmailMaxDepth = 5
+
+// parsedInboundEmail é o resultado da leitura de um e-mail recebido
+type parsedInboundEmail struct {
+	MessageID   string
+	InReplyTo   string
+	References  []string
+	FromAddress string
+	FromName    string
+	ToAddress   string
+	Subject     string
+	Text        string // Texto da resposta, sem o conteúdo citado
+	Date        time.Time
+}
+
+// parseInboundEmail lê o MIME bruto: cabeçalhos, remetente e o texto da resposta
+// (text/plain preferencialmente; HTML é convertido em texto)
+func parseInboundEmail(raw []byte) (*parsedInboundEmail, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler e-mail: %w", err)
+	}
+
+	from, err := msg.Header.AddressList("From")
+	if err != nil || len(from) == 0 {
+		return nil, fmt.Errorf("e-mail sem remetente válido")
+	}
+
+	parsed := &parsedInboundEmail{
+		MessageID:   firstMessageID(msg.Header.Get("Message-ID")),
+		InReplyTo:   firstMessageID(msg.Header.Get("In-Reply-To")),
+		References:  messageIDs(msg.Header.Get("References")),
+		FromAddress: strings.ToLower(from[0].Address),
+		FromName:    from[0].Name,
+		Subject:     decodeHeader(msg.Header.Get("Subject")),
+		Date:        time.Now(),
+	}
+	if to, err := msg.Header.AddressList("To"); err == nil && len(to) > 0 {
+		parsed.ToAddress = strings.ToLower(to[0].Address)
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		parsed.Date = date
+	}
+
+	plain, htmlBody, err := readMessageBody(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	text := plain
+	if strings.TrimSpace(text) == "" && htmlBody != "" {
+		text = htmlToText(htmlBody)
+	}
+	parsed.Text = stripQuotedReply(text)
+
+	return parsed, nil
+}
+
+// readMessageBody percorre as partes do e-mail e retorna o primeiro text/plain e o primeiro text/html
+// (anexos são ignorados)
+func readMessageBody(contentType, transferEncoding string, body io.Reader, depth int) (string, string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if depth >= inboundEmailMaxDepth || params["boundary"] == "" {
+			return "", "", nil
+		}
+
+		var plain, htmlBody string
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return "", "", fmt.Errorf("erro ao ler parte do e-mail: %w", err)
+			}
+			if disposition, _, _ := mime.ParseMediaType(part.Header.Get("Content-Disposition")); disposition == "attachment" {
+				continue
+			}
+
+			partPlain, partHTML, err := readMessageBody(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part, depth+1)
+			if err != nil {
+				return "", "", err
+			}
+			if plain == "" {
+				plain = partPlain
+			}
+			if htmlBody == "" {
+				htmlBody = partHTML
+			}
+		}
+		return plain, htmlBody, nil
+	}
+
+	if mediaType != "text/plain" && mediaType != "text/html" {
+		return "", "", nil
+	}
+
+	content, err := io.ReadAll(decodeTransferEncoding(body, transferEncoding))
+	if err != nil {
+		return "", "", fmt.Errorf("erro ao decodificar corpo do e-mail: %w", err)
+	}
+	text := decodeCharset(content, params["charset"])
+
+	if mediaType == "text/html" {
+		return "", text, nil
+	}
+	return text, "", nil
+}
+
+// decodeTransferEncoding trata quoted-printable e base64 (demais codificações são lidas como estão)
+func decodeTransferEncoding(body io.Reader, encoding string) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, newBase64Cleaner(body))
+	default:
+		return body
+	}
+}
+
+// newBase64Cleaner remove quebras de linha e espaços do conteúdo base64
+func newBase64Cleaner(body io.Reader) io.Reader {
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return bytes.NewReader(nil)
+	}
+	return strings.NewReader(strings.Join(strings.Fields(string(content)), ""))
+}
+
+// decodeCharset converte para UTF-8 os charsets latinos mais comuns (ISO-8859-1/Windows-1252)
+func decodeCharset(content []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252", "cp1252":
+		runes := make([]rune, len(content))
+		for i, b := range content {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	default:
+		return string(content)
+	}
+}
+
+// decodeHeader decodifica palavras codificadas (=?UTF-8?B?...?=) em cabeçalhos
+func decodeHeader(value string) string {
+	decoded, err := new(mime.WordDecoder).DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+// messageIDs extrai os identificadores de um cabeçalho (sem < >)
+func messageIDs(header string) []string {
+	var ids []string
+	for _, match := range messageIDPattern.FindAllStringSubmatch(header, -1) {
+		ids = append(ids, match[1])
+	}
+	return ids
+}
+
+// firstMessageID retorna o primeiro identificador do cabeçalho (ou o valor sem < > se não houver)
+func firstMessageID(header string) string {
+	if ids := messageIDs(header); len(ids) > 0 {
+		return ids[0]
+	}
+	return strings.Trim(strings.TrimSpace(header), "<>")
+}
+
+// stripQuotedReply mantém apenas o texto novo da resposta, removendo a mensagem citada e a assinatura
+func stripQuotedReply(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	var kept []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "--" || quoteSeparatorPattern.MatchString(trimmed) {
+			break
+		}
+		if quoteHeaderPattern.MatchString(trimmed) {
+			// ↩️ Cabeçalho quebrado em duas linhas ("Em ..., Loja <x@y>" + "escreveu:")
+			if n := len(kept); n > 0 && isQuoteIntro(kept[n-1]) && !isQuoteIntro(trimmed) {
+				kept = kept[:n-1]
+			}
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	reply := strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(kept, "\n"), "\n\n"))
+	if reply == "" {
+		return strings.TrimSpace(text) // 🔙 Sem texto novo identificável: mantém o conteúdo completo
+	}
+	return reply
+}
+
+// isQuoteIntro indica se a linha inicia um cabeçalho de citação ("Em ..." / "On ...")
+func isQuoteIntro(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.HasPrefix(line, "Em ") || strings.HasPrefix(line, "On ")
+}
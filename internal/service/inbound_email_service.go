@@ -0,0 +1,312 @@
+// File: /internal/service/inbound_email_service.go
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// MaxInboundEmailSize limita o tamanho do MIME aceito (upload ou objeto no S3)
+const MaxInboundEmailSize int64 = 10 << 20
+
+// inboundHistoryMaxRunes limita o trecho da resposta gravado no histórico do contato
+const inboundHistoryMaxRunes = 500
+
+// ErrInvalidInboundEmail indica MIME ou notificação que não pôde ser lida
+var ErrInvalidInboundEmail = errors.New("e-mail recebido inválido")
+
+// ErrInboundEmailUnmatched indica e-mail que não responde a nenhuma campanha nem vem de um contato conhecido
+var ErrInboundEmailUnmatched = errors.New("e-mail recebido sem campanha ou contato correspondente")
+
+// sesInboundNotification é a notificação "Received" de uma receipt rule do SES (ação SNS ou S3)
+type sesInboundNotification struct {
+	NotificationType string `json:"notificationType"`
+	Receipt          struct {
+		Action struct {
+			Type       string `json:"type"`       // "SNS" (conteúdo na notificação) ou "S3"
+			Encoding   string `json:"encoding"`   // UTF8 ou BASE64 (ação SNS)
+			BucketName string `json:"bucketName"` // Ação S3
+			ObjectKey  string `json:"objectKey"`  // Ação S3
+		} `json:"action"`
+	} `json:"receipt"`
+	Content string `json:"content,omitempty"`
+}
+
+// InboundEmailService registra respostas recebidas por e-mail: identifica a campanha de origem
+// (In-Reply-To/References), o contato, grava no histórico e abre a conversa no chat de e-mail da conta
+type InboundEmailService interface {
+	// Ingest processa o MIME bruto; accountID restringe à conta (upload autenticado) e nil aceita a conta da campanha respondida
+	Ingest(ctx context.Context, raw []byte, source models.InboundEmailSource, accountID *uuid.UUID) (*models.InboundEmail, error)
+	// IngestSESNotification processa a notificação do SES (conteúdo na própria mensagem ou objeto no S3)
+	IngestSESNotification(ctx context.Context, message []byte) (*models.InboundEmail, error)
+}
+
+type inboundEmailService struct {
+	log             *slog.Logger
+	repo            db.InboundEmailRepository
+	audienceRepo    db.CampaignAudienceRepository
+	contactRepo     db.ContactRepository
+	chatRepo        db.ChatRepository
+	chatContactRepo db.ChatContactRepository
+	chatMessageRepo db.ChatMessageRepository
+	fetchS3         S3ObjectFetcher
+}
+
+// NewInboundEmailService cria o processamento de e-mails recebidos.
+// `fetchS3` nil usa as credenciais padrão da AWS para ler os objetos gravados pela receipt rule.
+func NewInboundEmailService(
+	repo db.InboundEmailRepository,
+	audienceRepo db.CampaignAudienceRepository,
+	contactRepo db.ContactRepository,
+	chatRepo db.ChatRepository,
+	chatContactRepo db.ChatContactRepository,
+	chatMessageRepo db.ChatMessageRepository,
+	fetchS3 S3ObjectFetcher,
+) InboundEmailService {
+	if fetchS3 == nil {
+		fetchS3 = awsS3ObjectFetcher(MaxInboundEmailSize)
+	}
+
+	return &inboundEmailService{
+		log:             logger.GetLogger(),
+		repo:            repo,
+		audienceRepo:    audienceRepo,
+		contactRepo:     contactRepo,
+		chatRepo:        chatRepo,
+		chatContactRepo: chatContactRepo,
+		chatMessageRepo: chatMessageRepo,
+		fetchS3:         fetchS3,
+	}
+}
+
+// IngestSESNotification extrai o MIME da notificação do SES e processa a resposta
+func (s *inboundEmailService) IngestSESNotification(ctx context.Context, message []byte) (*models.InboundEmail, error) {
+	var notification sesInboundNotification
+	if err := json.Unmarshal(message, &notification); err != nil {
+		return nil, fmt.Errorf("%w: erro ao decodificar notificação: %v", ErrInvalidInboundEmail, err)
+	}
+	if notification.NotificationType != "Received" {
+		return nil, fmt.Errorf("%w: notificação do SES não é de e-mail recebido (%q)", ErrInvalidInboundEmail, notification.NotificationType)
+	}
+
+	var raw []byte
+	action := notification.Receipt.Action
+	switch {
+	case notification.Content != "" && strings.EqualFold(action.Encoding, "BASE64"):
+		decoded, err := base64.StdEncoding.DecodeString(notification.Content)
+		if err != nil {
+			return nil, fmt.Errorf("%w: conteúdo não está em base64", ErrInvalidInboundEmail)
+		}
+		raw = decoded
+	case notification.Content != "":
+		raw = []byte(notification.Content)
+	case action.BucketName != "" && action.ObjectKey != "":
+		content, err := s.fetchS3(ctx, action.BucketName, action.ObjectKey)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao baixar e-mail recebido do S3: %w", err)
+		}
+		raw = content
+	default:
+		return nil, fmt.Errorf("%w: notificação sem conteúdo nem objeto no S3 (ação %s)", ErrInvalidInboundEmail, action.Type)
+	}
+
+	return s.Ingest(ctx, raw, models.InboundEmailSourceSES, nil)
+}
+
+// Ingest lê o e-mail, identifica campanha e contato e registra a resposta (reentregas são ignoradas)
+func (s *inboundEmailService) Ingest(ctx context.Context, raw []byte, source models.InboundEmailSource, accountID *uuid.UUID) (*models.InboundEmail, error) {
+	parsed, err := parseInboundEmail(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInboundEmail, err)
+	}
+	if parsed.MessageID == "" {
+		return nil, fmt.Errorf("%w: e-mail sem Message-ID", ErrInvalidInboundEmail)
+	}
+
+	inbound := &models.InboundEmail{
+		MessageID:   parsed.MessageID,
+		InReplyTo:   optionalString(parsed.InReplyTo),
+		FromAddress: parsed.FromAddress,
+		FromName:    optionalString(parsed.FromName),
+		ToAddress:   optionalString(parsed.ToAddress),
+		Subject:     optionalString(parsed.Subject),
+		TextBody:    optionalString(parsed.Text),
+		Source:      source,
+		ReceivedAt:  parsed.Date,
+	}
+
+	// 🔗 1. Campanha respondida: o Message-ID enviado volta no In-Reply-To/References
+	origin, err := s.findOrigin(ctx, parsed, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if origin != nil {
+		inbound.AccountID = origin.AccountID
+		inbound.CampaignID = &origin.CampaignID
+		inbound.AudienceID = &origin.ID
+		inbound.ContactID = &origin.ContactID
+	} else if accountID != nil {
+		inbound.AccountID = *accountID
+	} else {
+		s.log.Warn("⚠️ E-mail recebido não responde a nenhuma campanha", "message_id", parsed.MessageID, "from", parsed.FromAddress)
+		return nil, ErrInboundEmailUnmatched
+	}
+
+	// 👤 2. Sem campanha de origem: procura o remetente entre os contatos da conta
+	if inbound.ContactID == nil {
+		contact, err := s.contactRepo.FindByEmailOrWhatsApp(ctx, inbound.AccountID, &parsed.FromAddress, nil)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao buscar contato do remetente: %w", err)
+		}
+		if contact == nil {
+			s.log.Warn("⚠️ E-mail recebido de remetente desconhecido", "account_id", inbound.AccountID, "from", parsed.FromAddress)
+			return nil, ErrInboundEmailUnmatched
+		}
+		inbound.ContactID = &contact.ID
+	}
+
+	saved, err := s.repo.Create(ctx, inbound)
+	if err != nil {
+		return nil, err
+	}
+	if saved == nil {
+		s.log.Info("🔁 E-mail recebido já registrado", "message_id", parsed.MessageID)
+		return s.repo.GetByMessageID(ctx, inbound.AccountID, parsed.MessageID)
+	}
+
+	s.log.Info("📥 Resposta por e-mail registrada", "account_id", saved.AccountID, "message_id", saved.MessageID,
+		"campaign_id", saved.CampaignID, "contact_id", saved.ContactID)
+
+	// 📝 3. Histórico do contato e conversa no chat de e-mail (falhas não descartam o e-mail já gravado)
+	if err := s.contactRepo.AppendHistory(ctx, *saved.ContactID, historyEntry(parsed)); err != nil {
+		s.log.Error("❌ Erro ao registrar e-mail no histórico do contato", "contact_id", saved.ContactID, "error", err)
+	}
+	if err := s.registerChatMessage(ctx, saved, parsed); err != nil {
+		s.log.Error("❌ Erro ao registrar e-mail na conversa", "contact_id", saved.ContactID, "error", err)
+	}
+
+	return saved, nil
+}
+
+// findOrigin procura a audiência cujo Message-ID é respondido pelo e-mail.
+// O SES troca o Message-ID por <id@região.amazonses.com>, mas grava apenas o id; por isso as duas formas são consultadas.
+func (s *inboundEmailService) findOrigin(ctx context.Context, parsed *parsedInboundEmail, accountID *uuid.UUID) (*dto.CampaignMessageDTO, error) {
+	candidates := []string{}
+	if parsed.InReplyTo != "" {
+		candidates = append(candidates, parsed.InReplyTo)
+	}
+	for i := len(parsed.References) - 1; i >= 0; i-- {
+		candidates = append(candidates, parsed.References[i])
+	}
+
+	seen := map[string]bool{}
+	for _, candidate := range candidates {
+		for _, messageID := range []string{candidate, strings.SplitN(candidate, "@", 2)[0]} {
+			if seen[messageID] {
+				continue
+			}
+			seen[messageID] = true
+
+			msg, err := s.audienceRepo.GetMessageByMessageID(ctx, messageID)
+			if err != nil {
+				return nil, err
+			}
+			if msg == nil || (accountID != nil && msg.AccountID != *accountID) {
+				continue
+			}
+			return msg, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// registerChatMessage adiciona o e-mail à conversa do contato no chat de e-mail da conta
+func (s *inboundEmailService) registerChatMessage(ctx context.Context, inbound *models.InboundEmail, parsed *parsedInboundEmail) error {
+	chat, err := s.emailChat(ctx, inbound.AccountID)
+	if err != nil {
+		return err
+	}
+	if chat == nil {
+		return nil // 🔕 Chat de e-mail desativado pela conta
+	}
+
+	chatContact, err := s.chatContactRepo.FindOrCreateByContact(ctx, inbound.AccountID, chat.ID, *inbound.ContactID)
+	if err != nil {
+		return err
+	}
+
+	content := parsed.Text
+	if parsed.Subject != "" {
+		content = fmt.Sprintf("Assunto: %s\n\n%s", parsed.Subject, parsed.Text)
+	}
+
+	message, err := s.chatMessageRepo.Create(ctx, models.ChatMessage{
+		ChatContactID: chatContact.ID,
+		Actor:         "cliente",
+		Type:          "email",
+		Content:       content,
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao registrar mensagem recebida: %w", err)
+	}
+
+	return s.repo.SetChatMessage(ctx, inbound.ID, message.ID)
+}
+
+// emailChat retorna o chat de e-mail da conta, criando-o no primeiro e-mail recebido (nil se estiver inativo)
+func (s *inboundEmailService) emailChat(ctx context.Context, accountID uuid.UUID) (*models.Chat, error) {
+	chats, err := s.chatRepo.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar chats da conta: %w", err)
+	}
+	for _, chat := range chats {
+		if chat.Department != models.ChatDepartmentEmail {
+			continue
+		}
+		if chat.Status != "ativo" {
+			return nil, nil
+		}
+		return chat, nil
+	}
+
+	chat, err := s.chatRepo.Insert(ctx, &models.Chat{
+		AccountID:    accountID,
+		Department:   models.ChatDepartmentEmail,
+		Title:        "E-mail",
+		Instructions: "Atendimento das respostas recebidas por e-mail às campanhas.",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar chat de e-mail: %w", err)
+	}
+
+	s.log.Info("💌 Chat de e-mail criado para a conta", "account_id", accountID, "chat_id", chat.ID)
+	return chat, nil
+}
+
+// historyEntry resume o e-mail para o histórico do contato
+func historyEntry(parsed *parsedInboundEmail) string {
+	text := strings.Join(strings.Fields(parsed.Text), " ")
+	if utf8.RuneCountInString(text) > inboundHistoryMaxRunes {
+		text = string([]rune(text)[:inboundHistoryMaxRunes]) + "…"
+	}
+
+	entry := fmt.Sprintf("[%s] 📧 E-mail recebido", parsed.Date.Format("02/01/2006 15:04"))
+	if parsed.Subject != "" {
+		entry += fmt.Sprintf(" (%s)", parsed.Subject)
+	}
+	return entry + ": " + text
+}
@@ -4,11 +4,15 @@ package service
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"html/template"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	texttemplate "text/template"
 
 	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/config"
@@ -17,6 +21,13 @@ import (
 
 // RenderWithTemplateFile aplica os dados ao template salvo no disco
 func RenderWithTemplateFile(content *dto.CampaignContentResult, templateID uuid.UUID, channel string) (string, error) {
+	rendered, _, err := RenderWithTemplateFileVersion(content, templateID, channel)
+	return rendered, err
+}
+
+// RenderWithTemplateFileVersion aplica os dados ao template e retorna também a versão (SHA-256 do arquivo).
+// O template do WhatsApp é texto (markdown do WhatsApp) e não passa pelo escape de HTML.
+func RenderWithTemplateFileVersion(content *dto.CampaignContentResult, templateID uuid.UUID, channel string) (string, string, error) {
 	templateBasePath := config.GetEnvVar("TEMPLATE_STORAGE_PATH")
 	var filename string
 	switch channel {
@@ -25,25 +36,33 @@ func RenderWithTemplateFile(content *dto.CampaignContentResult, templateID uuid.
 	case "whatsapp":
 		filename = filepath.Join(templateBasePath, "whatsapp", templateID.String()+".md")
 	default:
-		return "", fmt.Errorf("canal inválido: %s", channel)
+		return "", "", fmt.Errorf("canal inválido: %s", channel)
 	}
 
 	templateBytes, err := os.ReadFile(filename)
 	if err != nil {
-		return "", fmt.Errorf("erro ao ler template: %w", err)
+		return "", "", fmt.Errorf("erro ao ler template: %w", err)
 	}
+	sum := sha256.Sum256(templateBytes)
 
-	tpl, err := template.New("msg").Parse(string(templateBytes))
+	var tpl interface {
+		Execute(wr io.Writer, data any) error
+	}
+	if channel == "whatsapp" {
+		tpl, err = texttemplate.New("msg").Parse(string(templateBytes))
+	} else {
+		tpl, err = template.New("msg").Parse(string(templateBytes))
+	}
 	if err != nil {
-		return "", fmt.Errorf("erro ao parsear template: %w", err)
+		return "", "", fmt.Errorf("erro ao parsear template: %w", err)
 	}
 
 	var buf bytes.Buffer
 	if err := tpl.Execute(&buf, content); err != nil {
-		return "", fmt.Errorf("erro ao aplicar template: %w", err)
+		return "", "", fmt.Errorf("erro ao aplicar template: %w", err)
 	}
 
-	return buf.String(), nil
+	return buf.String(), hex.EncodeToString(sum[:]), nil
 }
 
 // RenderMessagePreview gera a mensagem final formatada com base no canal (email ou whatsapp)
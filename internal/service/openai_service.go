@@ -22,8 +22,38 @@ type OpenAIService interface {
 
 // 🔹 Estruturas para Comunicação com a OpenAI
 type ChatMessage struct {
-	Role    string `json:"role"`    // "system", "user", ou "assistant"
-	Content string `json:"content"` // Conteúdo da mensagem
+	Role    string            `json:"role"`    // "system", "user", ou "assistant"
+	Content string            `json:"content"` // Conteúdo da mensagem
+	Parts   []ChatContentPart `json:"-"`       // Conteúdo multimodal (imagem, PDF); substitui Content no envio
+}
+
+// ChatContentPart é uma parte de mensagem multimodal (texto, imagem ou arquivo)
+type ChatContentPart struct {
+	Type     string        `json:"type"` // "text", "image_url" ou "file"
+	Text     string        `json:"text,omitempty"`
+	ImageURL *ChatImageURL `json:"image_url,omitempty"`
+	File     *ChatFile     `json:"file,omitempty"`
+}
+
+type ChatImageURL struct {
+	URL string `json:"url"` // URL pública ou data URL (data:image/png;base64,...)
+}
+
+type ChatFile struct {
+	FileName string `json:"filename"`
+	FileData string `json:"file_data"` // data URL (data:application/pdf;base64,...)
+}
+
+// MarshalJSON envia `content` como lista de partes quando a mensagem é multimodal
+func (m ChatMessage) MarshalJSON() ([]byte, error) {
+	if len(m.Parts) == 0 {
+		type plainMessage ChatMessage
+		return json.Marshal(plainMessage(m))
+	}
+	return json.Marshal(struct {
+		Role    string            `json:"role"`
+		Content []ChatContentPart `json:"content"`
+	}{Role: m.Role, Content: m.Parts})
 }
 
 type ChatCompletionRequest struct {
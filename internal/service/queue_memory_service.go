@@ -0,0 +1,153 @@
+// File: /internal/service/queue_memory_service.go
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+)
+
+// memoryQueueService implementa SQSService com channels em memória (desenvolvimento, CI e nó único).
+// As mensagens não sobrevivem a um reinício do processo.
+type memoryQueueService struct {
+	log          *slog.Logger
+	queues       map[string]chan memoryQueueMessage
+	redeliveryIn time.Duration
+	maxReceives  int
+}
+
+// memoryQueueMessage guarda o corpo da mensagem e quantas vezes ela já foi entregue
+type memoryQueueMessage struct {
+	body     []byte
+	receives int
+}
+
+// NewMemoryQueueService inicializa as filas em memória.
+// Mensagens entregues maxReceives vezes sem sucesso são descartadas (dead-letter).
+func NewMemoryQueueService(maxReceives int) *memoryQueueService {
+	queues := make(map[string]chan memoryQueueMessage, len(allowedQueues))
+	for name := range allowedQueues {
+		queues[name] = make(chan memoryQueueMessage, 10000)
+	}
+
+	return &memoryQueueService{
+		log:          logger.GetLogger(),
+		queues:       queues,
+		redeliveryIn: 30 * time.Second,
+		maxReceives:  max(maxReceives, 1),
+	}
+}
+
+// SendMessage publica a mensagem no channel da fila (bloqueia se a fila estiver cheia)
+func (s *memoryQueueService) SendMessage(ctx context.Context, queueName string, message interface{}) error {
+	if err := validateQueueName(queueName); err != nil {
+		s.log.Warn("Nome de fila inválido", "queueName", queueName)
+		return err
+	}
+
+	messageBody, err := json.Marshal(message)
+	if err != nil {
+		s.log.Error("Erro ao serializar mensagem para a fila", "error", err)
+		return err
+	}
+
+	select {
+	case s.queues[queueName] <- memoryQueueMessage{body: messageBody}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	s.log.Info("Mensagem enviada para a fila em memória com sucesso", "queue", queueName)
+	return nil
+}
+
+// SendMessageWithDelay publica a mensagem no channel da fila somente após `delay`
+func (s *memoryQueueService) SendMessageWithDelay(ctx context.Context, queueName string, message interface{}, delay time.Duration) error {
+	if delay <= 0 {
+		return s.SendMessage(ctx, queueName, message)
+	}
+
+	if err := validateQueueName(queueName); err != nil {
+		s.log.Warn("Nome de fila inválido", "queueName", queueName)
+		return err
+	}
+
+	messageBody, err := json.Marshal(message)
+	if err != nil {
+		s.log.Error("Erro ao serializar mensagem para a fila", "error", err)
+		return err
+	}
+
+	s.enqueueAfter(context.WithoutCancel(ctx), s.queues[queueName], memoryQueueMessage{body: messageBody}, delay)
+	return nil
+}
+
+// ReceiveMessages consome o channel da fila até o contexto ser cancelado, com no máximo
+// `concurrency` mensagens em processamento simultâneo.
+// Mensagens cujo handler falha são reenfileiradas após redeliveryIn, como o visibility timeout do SQS,
+// até maxReceives entregas; mensagens que não decodificam são descartadas de imediato.
+func (s *memoryQueueService) ReceiveMessages(ctx context.Context, queueName string, concurrency int, handler QueueMessageHandler) error {
+	if err := validateQueueName(queueName); err != nil {
+		s.log.Warn("Nome da fila inválido", "queueName", queueName)
+		return err
+	}
+
+	queue := s.queues[queueName]
+	pool := newQueueWorkerPool(concurrency)
+	defer pool.Wait() // ⏳ Aguarda as mensagens em andamento antes de encerrar
+
+	for {
+		// 🔒 Só retira novas mensagens do channel quando há vaga no pool
+		if !pool.WaitAvailable(ctx) {
+			s.log.Info("Encerrando consumo de mensagens da fila em memória", "queue", queueName)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			s.log.Info("Encerrando consumo de mensagens da fila em memória", "queue", queueName)
+			return nil
+		case message := <-queue:
+			message.receives++
+
+			campaignMessage, err := decodeCampaignMessage(message.body)
+			if err != nil {
+				s.log.Error("☠️ Mensagem descartada: erro ao decodificar", "queue", queueName, "error", err)
+				continue
+			}
+
+			pool.Go(func() {
+				err := handler(ctx, *campaignMessage)
+				if err == nil {
+					return
+				}
+				if message.receives >= s.maxReceives {
+					s.log.Error("☠️ Mensagem descartada: entregas esgotadas", "queue", queueName, "audience_id", campaignMessage.ID, "receives", message.receives, "error", err)
+					return
+				}
+
+				s.log.Error("Erro ao processar mensagem, será reentregue", "queue", queueName, "audience_id", campaignMessage.ID, "receives", message.receives, "error", err)
+				s.redeliver(ctx, queue, message)
+			})
+		}
+	}
+}
+
+// redeliver devolve a mensagem para a fila após o tempo de reentrega
+func (s *memoryQueueService) redeliver(ctx context.Context, queue chan memoryQueueMessage, message memoryQueueMessage) {
+	s.enqueueAfter(ctx, queue, message, s.redeliveryIn)
+}
+
+// enqueueAfter publica a mensagem no channel após o atraso informado
+func (s *memoryQueueService) enqueueAfter(ctx context.Context, queue chan memoryQueueMessage, message memoryQueueMessage, delay time.Duration) {
+	time.AfterFunc(delay, func() {
+		select {
+		case queue <- message:
+		case <-ctx.Done():
+		}
+	})
+}
@@ -0,0 +1,65 @@
+// File: /internal/service/queue_memory_service_test.go
+
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+)
+
+func TestMemoryQueueStopsRedeliveringAfterMaxReceives(t *testing.T) {
+	queue := NewMemoryQueueService(3)
+	queue.redeliveryIn = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int32
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = queue.ReceiveMessages(ctx, "email", 1, func(ctx context.Context, msg dto.CampaignMessageDTO) error {
+			calls.Add(1)
+			return errors.New("falha")
+		})
+	}()
+
+	if err := queue.SendMessage(ctx, "email", dto.CampaignMessageDTO{ID: uuid.New()}); err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+
+	time.Sleep(500 * time.Millisecond)
+	cancel()
+	<-done
+
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("handler chamado %d vezes, esperado 3", got)
+	}
+}
+
+func TestMemoryQueueDropsUndecodableMessages(t *testing.T) {
+	queue := NewMemoryQueueService(3)
+	queue.queues["email"] <- memoryQueueMessage{body: []byte("{invalido")}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	var calls atomic.Int32
+	_ = queue.ReceiveMessages(ctx, "email", 1, func(ctx context.Context, msg dto.CampaignMessageDTO) error {
+		calls.Add(1)
+		return nil
+	})
+
+	if got := calls.Load(); got != 0 {
+		t.Fatalf("handler chamado %d vezes para mensagem inválida", got)
+	}
+	if pending := len(queue.queues["email"]); pending != 0 {
+		t.Fatalf("mensagem inválida voltou para a fila (%d pendentes)", pending)
+	}
+}
@@ -0,0 +1,156 @@
+// File: /internal/service/queue_postgres_service.go
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// postgresQueueService implementa SQSService sobre a tabela queue_jobs (SELECT ... FOR UPDATE SKIP LOCKED)
+type postgresQueueService struct {
+	log               *slog.Logger
+	repo              db.QueueJobRepository
+	pollInterval      time.Duration
+	batchSize         int
+	visibilityTimeout time.Duration
+	maxReceives       int
+}
+
+// NewPostgresQueueService inicializa a fila baseada em PostgreSQL.
+// Mensagens entregues maxReceives vezes sem sucesso são movidas para dead-letter.
+func NewPostgresQueueService(repo db.QueueJobRepository, visibilityTimeout time.Duration, maxReceives int) *postgresQueueService {
+	return &postgresQueueService{
+		log:               logger.GetLogger(),
+		repo:              repo,
+		pollInterval:      2 * time.Second,
+		batchSize:         10,
+		visibilityTimeout: visibilityTimeout,
+		maxReceives:       max(maxReceives, 1),
+	}
+}
+
+// SendMessage grava a mensagem na tabela de filas
+func (s *postgresQueueService) SendMessage(ctx context.Context, queueName string, message interface{}) error {
+	return s.SendMessageWithDelay(ctx, queueName, message, 0)
+}
+
+// SendMessageWithDelay grava a mensagem na tabela de filas, visível somente após `delay`
+func (s *postgresQueueService) SendMessageWithDelay(ctx context.Context, queueName string, message interface{}, delay time.Duration) error {
+	if err := validateQueueName(queueName); err != nil {
+		s.log.Warn("Nome de fila inválido", "queueName", queueName)
+		return err
+	}
+
+	messageBody, err := json.Marshal(message)
+	if err != nil {
+		s.log.Error("Erro ao serializar mensagem para a fila", "error", err)
+		return err
+	}
+
+	if err := s.repo.Enqueue(ctx, queueName, messageBody, delay); err != nil {
+		s.log.Error("Erro ao enviar mensagem para a fila", "queue", queueName, "error", err)
+		return err
+	}
+
+	s.log.Info("Mensagem enviada para a fila com sucesso", "queue", queueName)
+	return nil
+}
+
+// ReceiveMessages consome a fila com no máximo `concurrency` mensagens em processamento simultâneo.
+// A mensagem só é removida após o handler retornar sucesso; em caso de erro ela volta a ficar
+// visível após o visibility timeout. Enquanto o handler roda, a visibilidade é estendida.
+// Mensagens que não decodificam ou excedem maxReceives entregas vão para dead-letter.
+func (s *postgresQueueService) ReceiveMessages(ctx context.Context, queueName string, concurrency int, handler QueueMessageHandler) error {
+	if err := validateQueueName(queueName); err != nil {
+		s.log.Warn("Nome da fila inválido", "queueName", queueName)
+		return err
+	}
+
+	pool := newQueueWorkerPool(concurrency)
+	defer pool.Wait() // ⏳ Aguarda as mensagens em andamento antes de encerrar
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("Encerrando consumo de mensagens da fila", "queue", queueName)
+			return nil
+		case <-ticker.C:
+			available := min(pool.Available(), s.batchSize)
+			if available == 0 {
+				continue
+			}
+
+			jobs, err := s.repo.Dequeue(ctx, queueName, available, s.visibilityTimeout)
+			if err != nil {
+				s.log.Error("Erro ao receber mensagens da fila", "queue", queueName, "error", err)
+				continue
+			}
+
+			for _, job := range jobs {
+				s.log.Info("📩 Mensagem recebida da fila", "queue", queueName, "job_id", job.ID, "attempts", job.Attempts)
+
+				campaignMessage, err := decodeCampaignMessage(job.Payload)
+				if err != nil {
+					s.log.Error("Erro ao decodificar mensagem da fila", "error", err, "job_id", job.ID)
+					s.deadLetter(ctx, queueName, job.ID, err.Error())
+					continue
+				}
+
+				// ☠️ Entregas esgotadas (ex.: o processo caiu durante o processamento em todas elas)
+				if job.Attempts > s.maxReceives {
+					s.deadLetter(ctx, queueName, job.ID, fmt.Sprintf("limite de %d entregas excedido", s.maxReceives))
+					continue
+				}
+
+				pool.Go(func() {
+					s.handleMessage(ctx, queueName, job, *campaignMessage, handler)
+				})
+			}
+		}
+	}
+}
+
+// handleMessage executa o handler estendendo a visibilidade e remove a mensagem somente em caso de sucesso.
+// Na última entrega permitida, uma falha move a mensagem para dead-letter em vez de reentregá-la.
+func (s *postgresQueueService) handleMessage(ctx context.Context, queueName string, job models.QueueJob, campaignMessage dto.CampaignMessageDTO, handler QueueMessageHandler) {
+	stopHeartbeat := keepInvisible(ctx, s.log, s.visibilityTimeout, func(ctx context.Context) error {
+		return s.repo.ExtendVisibility(ctx, job.ID, s.visibilityTimeout)
+	})
+	defer stopHeartbeat()
+
+	if err := handler(ctx, campaignMessage); err != nil {
+		if job.Attempts >= s.maxReceives && ctx.Err() == nil {
+			s.deadLetter(ctx, queueName, job.ID, err.Error())
+			return
+		}
+		s.log.Error("Erro ao processar mensagem, será reentregue", "queue", queueName, "job_id", job.ID, "attempts", job.Attempts, "error", err)
+		return
+	}
+
+	// 🗑️ Remover mensagem da fila após processamento
+	if err := s.repo.Delete(context.WithoutCancel(ctx), job.ID); err != nil {
+		s.log.Error("Erro ao deletar mensagem da fila", "queue", queueName, "error", err)
+	}
+}
+
+// deadLetter retira a mensagem do consumo; se falhar, ela volta após o visibility timeout e a tentativa se repete
+func (s *postgresQueueService) deadLetter(ctx context.Context, queueName string, jobID uuid.UUID, reason string) {
+	s.log.Error("☠️ Mensagem movida para dead-letter", "queue", queueName, "job_id", jobID, "reason", reason)
+
+	if err := s.repo.MoveToDeadLetter(context.WithoutCancel(ctx), jobID, reason); err != nil {
+		s.log.Error("Erro ao mover mensagem para dead-letter", "queue", queueName, "job_id", jobID, "error", err)
+	}
+}
@@ -0,0 +1,135 @@
+// File: /internal/service/queue_postgres_service_test.go
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// fakeQueueJobRepo simula a tabela queue_jobs em memória
+type fakeQueueJobRepo struct {
+	mu          sync.Mutex
+	jobs        map[uuid.UUID]*models.QueueJob
+	deleted     map[uuid.UUID]bool
+	deadLetters map[uuid.UUID]string
+}
+
+func newFakeQueueJobRepo() *fakeQueueJobRepo {
+	return &fakeQueueJobRepo{
+		jobs:        map[uuid.UUID]*models.QueueJob{},
+		deleted:     map[uuid.UUID]bool{},
+		deadLetters: map[uuid.UUID]string{},
+	}
+}
+
+func (r *fakeQueueJobRepo) add(payload []byte) uuid.UUID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := &models.QueueJob{ID: uuid.New(), Queue: "email", Payload: payload}
+	r.jobs[job.ID] = job
+	return job.ID
+}
+
+func (r *fakeQueueJobRepo) Enqueue(ctx context.Context, queue string, payload []byte, delay time.Duration) error {
+	r.add(payload)
+	return nil
+}
+
+func (r *fakeQueueJobRepo) Dequeue(ctx context.Context, queue string, limit int, visibilityTimeout time.Duration) ([]models.QueueJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var jobs []models.QueueJob
+	for id, job := range r.jobs {
+		if r.deleted[id] || r.deadLetters[id] != "" || time.Now().Before(job.VisibleAt) || len(jobs) == limit {
+			continue
+		}
+		job.Attempts++
+		job.VisibleAt = time.Now().Add(visibilityTimeout)
+		jobs = append(jobs, *job)
+	}
+	return jobs, nil
+}
+
+func (r *fakeQueueJobRepo) ExtendVisibility(ctx context.Context, jobID uuid.UUID, visibilityTimeout time.Duration) error {
+	return nil
+}
+
+func (r *fakeQueueJobRepo) Delete(ctx context.Context, jobID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deleted[jobID] = true
+	return nil
+}
+
+func (r *fakeQueueJobRepo) MoveToDeadLetter(ctx context.Context, jobID uuid.UUID, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deadLetters[jobID] = reason
+	return nil
+}
+
+func (r *fakeQueueJobRepo) deadLettered(jobID uuid.UUID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.deadLetters[jobID] != ""
+}
+
+func TestPostgresQueueDeadLetters(t *testing.T) {
+	message, _ := json.Marshal(dto.CampaignMessageDTO{ID: uuid.New()})
+
+	tests := []struct {
+		name      string
+		payload   []byte
+		handler   QueueMessageHandler
+		wantCalls int
+	}{
+		{
+			name:    "mensagem que não decodifica",
+			payload: []byte("{invalido"),
+			handler: func(ctx context.Context, msg dto.CampaignMessageDTO) error { return nil },
+		},
+		{
+			name:      "falha em todas as entregas",
+			payload:   message,
+			handler:   func(ctx context.Context, msg dto.CampaignMessageDTO) error { return errors.New("falha") },
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeQueueJobRepo()
+			jobID := repo.add(tt.payload)
+
+			queue := NewPostgresQueueService(repo, 10*time.Millisecond, 2)
+			queue.pollInterval = 5 * time.Millisecond
+
+			var mu sync.Mutex
+			calls := 0
+			ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+			defer cancel()
+			_ = queue.ReceiveMessages(ctx, "email", 1, func(ctx context.Context, msg dto.CampaignMessageDTO) error {
+				mu.Lock()
+				calls++
+				mu.Unlock()
+				return tt.handler(ctx, msg)
+			})
+
+			if !repo.deadLettered(jobID) {
+				t.Fatalf("mensagem não foi movida para dead-letter")
+			}
+			if calls != tt.wantCalls {
+				t.Fatalf("handler chamado %d vezes, esperado %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
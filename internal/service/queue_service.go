@@ -0,0 +1,77 @@
+// File: /internal/service/queue_service.go
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/config"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+)
+
+// Backends de fila disponíveis (definidos em QUEUE_DRIVER)
+const (
+	QueueDriverSQS      = "sqs"
+	QueueDriverPostgres = "postgres"
+	QueueDriverMemory   = "memory"
+)
+
+// Filas conhecidas pelo pipeline de campanhas
+var allowedQueues = map[string]bool{"email": true, "whatsapp": true}
+
+// NewQueueService inicializa o backend de filas com base no .env (QUEUE_DRIVER: sqs, postgres ou memory).
+// QUEUE_VISIBILITY_TIMEOUT (segundos) define por quanto tempo uma mensagem recebida fica invisível.
+// QUEUE_MAX_RECEIVES limita as entregas de uma mensagem nos backends postgres e memory antes do
+// dead-letter (no SQS, o equivalente é o maxReceiveCount da redrive policy da fila).
+func NewQueueService(queueJobRepo db.QueueJobRepository) (SQSService, error) {
+	driver := strings.ToLower(strings.TrimSpace(os.Getenv("QUEUE_DRIVER")))
+	visibilityTimeout := time.Duration(config.GetEnvInt("QUEUE_VISIBILITY_TIMEOUT", 60)) * time.Second
+	maxReceives := config.GetEnvInt("QUEUE_MAX_RECEIVES", 10)
+
+	switch driver {
+	case "", QueueDriverSQS: // 🔥 SQS continua sendo o padrão
+		sqsService, err := NewSQSService(os.Getenv("SQS_EMAIL_URL"), os.Getenv("SQS_WHATSAPP_URL"), visibilityTimeout)
+		if err != nil {
+			return nil, err
+		}
+		return sqsService, nil
+	case QueueDriverPostgres:
+		return NewPostgresQueueService(queueJobRepo, visibilityTimeout, maxReceives), nil
+	case QueueDriverMemory:
+		return NewMemoryQueueService(maxReceives), nil
+	default:
+		return nil, fmt.Errorf("backend de fila não suportado: %s", driver)
+	}
+}
+
+// validateQueueName garante que a fila pertence ao pipeline de campanhas
+func validateQueueName(queueName string) error {
+	if !allowedQueues[queueName] {
+		return fmt.Errorf("nome de fila inválido: %s", queueName)
+	}
+	return nil
+}
+
+// decodeCampaignMessage desserializa o corpo de uma mensagem da fila.
+// O CampaignProcessorService envia o JSON já serializado como string, então o corpo pode vir aninhado.
+func decodeCampaignMessage(body []byte) (*dto.CampaignMessageDTO, error) {
+	rawMessage := body
+
+	// 🔍 Remover aspas extras caso existam
+	var nested string
+	if err := json.Unmarshal(body, &nested); err == nil {
+		rawMessage = []byte(nested)
+	}
+
+	var campaignMessage dto.CampaignMessageDTO
+	if err := json.Unmarshal(rawMessage, &campaignMessage); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar mensagem da fila: %w", err)
+	}
+
+	return &campaignMessage, nil
+}
@@ -0,0 +1,83 @@
+// File: /internal/service/queue_worker_pool.go
+
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// queueWorkerPool limita quantas mensagens de uma fila são processadas ao mesmo tempo
+type queueWorkerPool struct {
+	slots chan struct{}
+	wg    sync.WaitGroup
+}
+
+// newQueueWorkerPool cria um pool com no máximo `size` mensagens em processamento
+func newQueueWorkerPool(size int) *queueWorkerPool {
+	if size < 1 {
+		size = 1
+	}
+	return &queueWorkerPool{slots: make(chan struct{}, size)}
+}
+
+// Available retorna quantas mensagens ainda podem ser processadas agora
+func (p *queueWorkerPool) Available() int {
+	return cap(p.slots) - len(p.slots)
+}
+
+// WaitAvailable bloqueia até haver vaga no pool ou o contexto ser cancelado
+func (p *queueWorkerPool) WaitAvailable(ctx context.Context) bool {
+	for p.Available() == 0 {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	return true
+}
+
+// Go executa fn em uma goroutine ocupando uma vaga do pool
+func (p *queueWorkerPool) Go(fn func()) {
+	p.slots <- struct{}{}
+	p.wg.Add(1)
+	go func() {
+		defer func() {
+			<-p.slots
+			p.wg.Done()
+		}()
+		fn()
+	}()
+}
+
+// Wait aguarda todas as mensagens em processamento terminarem
+func (p *queueWorkerPool) Wait() {
+	p.wg.Wait()
+}
+
+// keepInvisible estende periodicamente a visibilidade de uma mensagem enquanto ela é processada
+// (ex.: chamadas longas à OpenAI). Retorna a função que encerra a extensão.
+func keepInvisible(ctx context.Context, log *slog.Logger, visibilityTimeout time.Duration, extend func(ctx context.Context) error) func() {
+	heartbeatCtx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		ticker := time.NewTicker(visibilityTimeout / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-heartbeatCtx.Done():
+				return
+			case <-ticker.C:
+				if err := extend(heartbeatCtx); err != nil && heartbeatCtx.Err() == nil {
+					log.Warn("Erro ao estender visibilidade da mensagem", "error", err)
+				}
+			}
+		}
+	}()
+
+	return cancel
+}
@@ -0,0 +1,198 @@
+// File: /internal/service/s3_client.go
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// S3ObjectFetcher baixa um objeto do S3 (bucket e chave)
+type S3ObjectFetcher func(ctx context.Context, bucket, key string) ([]byte, error)
+
+// s3Client acessa o S3 (ou provedores compatíveis) direto pela API REST com assinatura SigV4,
+// sem depender do SDK completo do S3
+type s3Client struct {
+	httpClient  *http.Client
+	signer      *v4.Signer
+	credentials aws.CredentialsProvider
+	region      string
+	endpoint    string // Vazio = AWS (https://<bucket>.s3.<região>.amazonaws.com)
+	pathStyle   bool   // Endpoint/bucket/chave (MinIO e outros compatíveis)
+}
+
+// newS3Client cria o cliente a partir da configuração da AWS
+func newS3Client(cfg aws.Config, endpoint string, pathStyle bool) *s3Client {
+	return &s3Client{
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		// 🔑 O S3 assina o caminho exatamente como enviado (sem escapar de novo)
+		signer:      v4.NewSigner(func(o *v4.SignerOptions) { o.DisableURIPathEscaping = true }),
+		credentials: cfg.Credentials,
+		region:      cfg.Region,
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		pathStyle:   pathStyle,
+	}
+}
+
+// GetObject baixa o objeto inteiro (até maxBytes)
+func (c *s3Client) GetObject(ctx context.Context, bucket, key string, maxBytes int64) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, bucket, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, s3ResponseError(resp)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler objeto do S3: %w", err)
+	}
+	if int64(len(content)) > maxBytes {
+		return nil, fmt.Errorf("objeto do S3 excede o limite de %d bytes", maxBytes)
+	}
+	return content, nil
+}
+
+// PutObject grava o objeto com o Content-Type informado
+func (c *s3Client) PutObject(ctx context.Context, bucket, key string, content []byte, contentType string) error {
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+
+	resp, err := c.do(ctx, http.MethodPut, bucket, key, content, header)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return s3ResponseError(resp)
+	}
+	return nil
+}
+
+// DeleteObject remove o objeto (o S3 responde 204 mesmo se ele não existir)
+func (c *s3Client) DeleteObject(ctx context.Context, bucket, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, bucket, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return s3ResponseError(resp)
+	}
+	return nil
+}
+
+// do assina e executa a requisição ao objeto
+func (c *s3Client) do(ctx context.Context, method, bucket, key string, body []byte, header http.Header) (*http.Response, error) {
+	if c.credentials == nil {
+		return nil, fmt.Errorf("credenciais da AWS não configuradas para o S3")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.objectURL(bucket, key), nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+	}
+
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	creds, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter credenciais da AWS: %w", err)
+	}
+	if err := c.signer.SignHTTP(ctx, creds, req, payloadHash, "s3", c.region, time.Now()); err != nil {
+		return nil, fmt.Errorf("erro ao assinar requisição ao S3: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("erro na requisição ao S3: %w", err)
+	}
+	return resp, nil
+}
+
+// objectURL monta a URL do objeto (virtual-hosted na AWS, path-style nos compatíveis)
+func (c *s3Client) objectURL(bucket, key string) string {
+	path := s3EscapeKey(key)
+	switch {
+	case c.endpoint == "":
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucket, c.region, path)
+	case c.pathStyle:
+		return fmt.Sprintf("%s/%s/%s", c.endpoint, bucket, path)
+	default:
+		endpoint, err := url.Parse(c.endpoint)
+		if err != nil {
+			return fmt.Sprintf("%s/%s/%s", c.endpoint, bucket, path)
+		}
+		return fmt.Sprintf("%s://%s.%s/%s", endpoint.Scheme, bucket, endpoint.Host, path)
+	}
+}
+
+// s3EscapeKey codifica a chave como o S3 espera na assinatura (tudo exceto caracteres não reservados e "/")
+func s3EscapeKey(key string) string {
+	var builder strings.Builder
+	for _, b := range []byte(key) {
+		switch {
+		case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~', b == '/':
+			builder.WriteByte(b)
+		default:
+			fmt.Fprintf(&builder, "%%%02X", b)
+		}
+	}
+	return builder.String()
+}
+
+// s3ResponseError resume a resposta de erro do S3 (XML com Code e Message)
+func s3ResponseError(resp *http.Response) error {
+	content, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+	return fmt.Errorf("resposta inesperada do S3: %s: %s", resp.Status, strings.TrimSpace(string(content)))
+}
+
+// awsS3ObjectFetcher baixa objetos com as credenciais padrão da AWS (carregadas no primeiro uso)
+func awsS3ObjectFetcher(maxBytes int64) S3ObjectFetcher {
+	var once sync.Once
+	var client *s3Client
+	var loadErr error
+
+	return func(ctx context.Context, bucket, key string) ([]byte, error) {
+		once.Do(func() {
+			cfg, err := config.LoadDefaultConfig(context.WithoutCancel(ctx))
+			if err != nil {
+				loadErr = fmt.Errorf("erro ao carregar configuração AWS: %w", err)
+				return
+			}
+			client = newS3Client(cfg, "", false)
+		})
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		return client.GetObject(ctx, bucket, key, maxBytes)
+	}
+}
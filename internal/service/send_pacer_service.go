@@ -0,0 +1,258 @@
+// File: /internal/service/send_pacer_service.go
+
+package service
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// SendPacerService controla janela de envio, ritmo por minuto e limite diário de cada conta/canal
+type SendPacerService interface {
+	// Reserve reserva um envio agora; se não for permitido, retorna o atraso até a próxima oportunidade.
+	// Deve ser chamado imediatamente antes do envio: a vaga conta no ritmo por minuto e no limite diário.
+	Reserve(ctx context.Context, accountID uuid.UUID, channel string) (*SendReservation, time.Duration, error)
+	// Release devolve a vaga de um envio reservado que não aconteceu (descartado ou com falha)
+	Release(ctx context.Context, reservation *SendReservation) error
+	// ProjectFinish estima quando os envios pendentes (por canal) terminam respeitando as políticas
+	ProjectFinish(ctx context.Context, accountID uuid.UUID, pending map[string]int) (*time.Time, error)
+}
+
+// SendReservation identifica os contadores consumidos por um envio reservado
+type SendReservation struct {
+	AccountID uuid.UUID
+	Channel   string
+	Minute    *time.Time // Bucket do ritmo por minuto (nil se a conta não limita o ritmo)
+	Day       *time.Time // Bucket do limite diário (nil se a conta não tem limite diário)
+}
+
+type sendPacerService struct {
+	log        *slog.Logger
+	repo       db.SendPolicyRepository
+	cacheTTL   time.Duration
+	mu         sync.Mutex
+	cache      map[string]cachedSendPolicy
+	lastPurge  time.Time
+	purgeEvery time.Duration
+}
+
+// cachedSendPolicy evita consultar o banco a cada mensagem
+type cachedSendPolicy struct {
+	policy    *models.SendPolicy
+	expiresAt time.Time
+}
+
+// NewSendPacerService cria o controle de ritmo de envios
+func NewSendPacerService(repo db.SendPolicyRepository) SendPacerService {
+	return &sendPacerService{
+		log:        logger.GetLogger(),
+		repo:       repo,
+		cacheTTL:   time.Minute,
+		cache:      map[string]cachedSendPolicy{},
+		purgeEvery: time.Hour,
+	}
+}
+
+// policyFor busca a política da conta/canal com cache curto
+func (s *sendPacerService) policyFor(ctx context.Context, accountID uuid.UUID, channel string) (*models.SendPolicy, error) {
+	key := accountID.String() + ":" + channel
+
+	s.mu.Lock()
+	cached, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.policy, nil
+	}
+
+	policy, err := s.repo.GetByAccountAndChannel(ctx, accountID, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[key] = cachedSendPolicy{policy: policy, expiresAt: time.Now().Add(s.cacheTTL)}
+	s.mu.Unlock()
+
+	return policy, nil
+}
+
+// Reserve verifica a janela de envio e consome uma vaga do ritmo por minuto e do limite diário
+func (s *sendPacerService) Reserve(ctx context.Context, accountID uuid.UUID, channel string) (*SendReservation, time.Duration, error) {
+	s.purgeOldCounters(ctx)
+
+	reservation := &SendReservation{AccountID: accountID, Channel: channel}
+
+	policy, err := s.policyFor(ctx, accountID, channel)
+	if err != nil {
+		return nil, 0, err
+	}
+	if policy == nil {
+		return reservation, 0, nil // ✅ Conta sem restrições para o canal
+	}
+
+	now := time.Now()
+
+	// 🌙 Fora da janela (horário de silêncio ou dia bloqueado): adiar até a próxima abertura
+	next := policy.NextAllowed(now)
+	if next.IsZero() {
+		s.log.Warn("⚠️ Política de envio sem dias liberados", "account_id", accountID, "channel", channel)
+		return nil, maxQueueDelay, nil
+	}
+	if next.After(now) {
+		return nil, next.Sub(now) + spread(time.Minute), nil
+	}
+
+	// ⏱️ Ritmo por minuto: cada envio pega uma "senha"; quem passar do limite vai para o minuto em que caberia
+	if policy.RatePerMinute > 0 {
+		minute := now.UTC().Truncate(time.Minute)
+		ticket, _, err := s.repo.IncrementCounter(ctx, accountID, channel, db.SendCounterMinute, minute, 0)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ticket > policy.RatePerMinute {
+			slot := minute.Add(time.Duration((ticket-1)/policy.RatePerMinute) * time.Minute)
+			return nil, slot.Sub(now) + spread(time.Minute), nil
+		}
+		reservation.Minute = &minute
+	}
+
+	// 📅 Limite diário: ao atingir, adiar para a janela do próximo dia
+	if policy.DailyCap > 0 {
+		day := policy.DayBucket(now)
+		_, ok, err := s.repo.IncrementCounter(ctx, accountID, channel, db.SendCounterDay, day, policy.DailyCap)
+		if err != nil {
+			s.Release(ctx, reservation)
+			return nil, 0, err
+		}
+		if !ok {
+			s.Release(ctx, reservation) // 🔙 A senha do minuto não será usada hoje
+			next := policy.NextAllowed(policy.StartOfNextDay(now))
+			if next.IsZero() {
+				return nil, maxQueueDelay, nil
+			}
+			return nil, next.Sub(now) + spread(10*time.Minute), nil
+		}
+		reservation.Day = &day
+	}
+
+	return reservation, 0, nil
+}
+
+// Release devolve as vagas consumidas pela reserva (o envio foi descartado ou falhou)
+func (s *sendPacerService) Release(ctx context.Context, reservation *SendReservation) error {
+	if reservation == nil {
+		return nil
+	}
+
+	if reservation.Minute != nil {
+		if err := s.repo.DecrementCounter(ctx, reservation.AccountID, reservation.Channel, db.SendCounterMinute, *reservation.Minute); err != nil {
+			return err
+		}
+		reservation.Minute = nil
+	}
+	if reservation.Day != nil {
+		if err := s.repo.DecrementCounter(ctx, reservation.AccountID, reservation.Channel, db.SendCounterDay, *reservation.Day); err != nil {
+			return err
+		}
+		reservation.Day = nil
+	}
+
+	return nil
+}
+
+// ProjectFinish simula o envio do que está pendente, dia a dia, respeitando janela, ritmo e limite diário.
+// Retorna nil quando não há pendências.
+func (s *sendPacerService) ProjectFinish(ctx context.Context, accountID uuid.UUID, pending map[string]int) (*time.Time, error) {
+	var finish *time.Time
+	now := time.Now()
+
+	for channel, remaining := range pending {
+		if remaining <= 0 {
+			continue
+		}
+
+		policy, err := s.policyFor(ctx, accountID, channel)
+		if err != nil {
+			return nil, err
+		}
+
+		channelFinish := now
+		if policy != nil {
+			sentToday := 0
+			if policy.DailyCap > 0 {
+				sentToday, err = s.repo.GetCounter(ctx, accountID, channel, db.SendCounterDay, policy.DayBucket(now))
+				if err != nil {
+					return nil, err
+				}
+			}
+			channelFinish = projectChannelFinish(policy, now, remaining, sentToday)
+		}
+
+		if finish == nil || channelFinish.After(*finish) {
+			finish = &channelFinish
+		}
+	}
+
+	return finish, nil
+}
+
+// projectChannelFinish estima o término de `remaining` envios de um canal a partir de `now`
+func projectChannelFinish(policy *models.SendPolicy, now time.Time, remaining, sentToday int) time.Time {
+	today := policy.DayBucket(now)
+	cursor := policy.NextAllowed(now)
+
+	for day := 0; day < 366 && !cursor.IsZero(); day++ {
+		if !policy.DayBucket(cursor).Equal(today) {
+			sentToday = 0 // 🌅 Janela em outro dia: o limite diário recomeça
+		}
+
+		capacity := remaining
+		if policy.RatePerMinute > 0 {
+			minutes := int(policy.WindowEnd(cursor).Sub(cursor).Minutes())
+			capacity = minutes * policy.RatePerMinute
+		}
+		if policy.DailyCap > 0 {
+			capacity = min(capacity, max(policy.DailyCap-sentToday, 0))
+		}
+
+		if remaining <= capacity {
+			if policy.RatePerMinute > 0 {
+				return cursor.Add(time.Duration(remaining) * time.Minute / time.Duration(policy.RatePerMinute))
+			}
+			return cursor
+		}
+
+		remaining -= capacity
+		cursor = policy.NextAllowed(policy.StartOfNextDay(cursor))
+	}
+
+	return cursor
+}
+
+// purgeOldCounters remove contadores antigos no máximo uma vez por hora
+func (s *sendPacerService) purgeOldCounters(ctx context.Context) {
+	s.mu.Lock()
+	if time.Since(s.lastPurge) < s.purgeEvery {
+		s.mu.Unlock()
+		return
+	}
+	s.lastPurge = time.Now()
+	s.mu.Unlock()
+
+	if err := s.repo.PurgeCounters(ctx, time.Now().UTC().AddDate(0, 0, -2)); err != nil {
+		s.log.Warn("Erro ao limpar contadores de envio", "error", err)
+	}
+}
+
+// spread adiciona um atraso aleatório para que as mensagens adiadas não voltem todas juntas
+func spread(window time.Duration) time.Duration {
+	return rand.N(window)
+}
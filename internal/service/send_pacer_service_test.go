@@ -0,0 +1,219 @@
+// File: /internal/service/send_pacer_service_test.go
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// fakeSendPolicyRepo guarda a política e os contadores em memória
+type fakeSendPolicyRepo struct {
+	db.SendPolicyRepository
+
+	mu       sync.Mutex
+	policy   *models.SendPolicy
+	counters map[string]int
+}
+
+func newFakeSendPolicyRepo(policy *models.SendPolicy) *fakeSendPolicyRepo {
+	return &fakeSendPolicyRepo{policy: policy, counters: map[string]int{}}
+}
+
+func counterKey(period string, bucket time.Time) string {
+	return period + ":" + bucket.UTC().Format(time.RFC3339)
+}
+
+func (r *fakeSendPolicyRepo) GetByAccountAndChannel(ctx context.Context, accountID uuid.UUID, channel string) (*models.SendPolicy, error) {
+	return r.policy, nil
+}
+
+func (r *fakeSendPolicyRepo) IncrementCounter(ctx context.Context, accountID uuid.UUID, channel, period string, bucket time.Time, limit int) (int, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := counterKey(period, bucket)
+	if limit > 0 && r.counters[key] >= limit {
+		return r.counters[key], false, nil
+	}
+	r.counters[key]++
+	return r.counters[key], true, nil
+}
+
+func (r *fakeSendPolicyRepo) DecrementCounter(ctx context.Context, accountID uuid.UUID, channel, period string, bucket time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := counterKey(period, bucket)
+	r.counters[key] = max(r.counters[key]-1, 0)
+	return nil
+}
+
+func (r *fakeSendPolicyRepo) GetCounter(ctx context.Context, accountID uuid.UUID, channel, period string, bucket time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.counters[counterKey(period, bucket)], nil
+}
+
+func (r *fakeSendPolicyRepo) PurgeCounters(ctx context.Context, before time.Time) error {
+	return nil
+}
+
+func (r *fakeSendPolicyRepo) total(period string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sum := 0
+	for key, count := range r.counters {
+		if len(key) > len(period) && key[:len(period)] == period {
+			sum += count
+		}
+	}
+	return sum
+}
+
+// middayTimezone retorna um fuso fixo (Etc/GMT±N) em que agora é por volta do meio-dia,
+// para que a janela 08:00–18:00 esteja sempre aberta durante o teste
+func middayTimezone() string {
+	offset := 12 - time.Now().UTC().Hour() // UTC+offset
+	if offset >= 0 {
+		return fmt.Sprintf("Etc/GMT-%d", offset)
+	}
+	return fmt.Sprintf("Etc/GMT+%d", -offset)
+}
+
+func openPolicy(ratePerMinute, dailyCap int) *models.SendPolicy {
+	return &models.SendPolicy{
+		Channel:       models.ChannelType("email"),
+		Timezone:      middayTimezone(),
+		Weekdays:      []int{0, 1, 2, 3, 4, 5, 6},
+		StartTime:     "08:00",
+		EndTime:       "18:00",
+		RatePerMinute: ratePerMinute,
+		DailyCap:      dailyCap,
+	}
+}
+
+func TestSendPacerReserveWithoutPolicy(t *testing.T) {
+	pacer := NewSendPacerService(newFakeSendPolicyRepo(nil))
+
+	reservation, delay, err := pacer.Reserve(context.Background(), uuid.New(), "email")
+	if err != nil || delay != 0 || reservation == nil {
+		t.Fatalf("Reserve = (%v, %v, %v), want reservation without delay", reservation, delay, err)
+	}
+	if err := pacer.Release(context.Background(), reservation); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+}
+
+func TestSendPacerReleaseGivesSlotBack(t *testing.T) {
+	repo := newFakeSendPolicyRepo(openPolicy(1, 100))
+	pacer := NewSendPacerService(repo)
+	accountID := uuid.New()
+
+	first, delay, err := pacer.Reserve(context.Background(), accountID, "email")
+	if err != nil || delay != 0 {
+		t.Fatalf("first Reserve = (%v, %v), want no delay", delay, err)
+	}
+
+	// 🔙 Envio descartado: a vaga volta e o próximo envio do mesmo minuto não é adiado
+	if err := pacer.Release(context.Background(), first); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if got := repo.total(db.SendCounterMinute) + repo.total(db.SendCounterDay); got != 0 {
+		t.Fatalf("counters after Release = %d, want 0", got)
+	}
+
+	if _, delay, err := pacer.Reserve(context.Background(), accountID, "email"); err != nil || delay != 0 {
+		t.Fatalf("Reserve after Release = (%v, %v), want no delay", delay, err)
+	}
+	if got := repo.total(db.SendCounterDay); got != 1 {
+		t.Fatalf("day counter = %d, want 1", got)
+	}
+}
+
+func TestSendPacerDefersAboveRatePerMinute(t *testing.T) {
+	repo := newFakeSendPolicyRepo(openPolicy(2, 0))
+	pacer := NewSendPacerService(repo)
+	accountID := uuid.New()
+
+	for i := 0; i < 2; i++ {
+		if _, delay, err := pacer.Reserve(context.Background(), accountID, "email"); err != nil || delay != 0 {
+			t.Fatalf("Reserve %d = (%v, %v), want no delay", i, delay, err)
+		}
+	}
+
+	reservation, delay, err := pacer.Reserve(context.Background(), accountID, "email")
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if reservation != nil || delay <= 0 || delay > 2*time.Minute {
+		t.Fatalf("third Reserve = (%v, %v), want deferral to the next minute", reservation, delay)
+	}
+}
+
+func TestSendPacerDailyCapReturnsMinuteTicket(t *testing.T) {
+	repo := newFakeSendPolicyRepo(openPolicy(100, 1))
+	pacer := NewSendPacerService(repo)
+	accountID := uuid.New()
+
+	if _, delay, err := pacer.Reserve(context.Background(), accountID, "email"); err != nil || delay != 0 {
+		t.Fatalf("first Reserve = (%v, %v), want no delay", delay, err)
+	}
+
+	reservation, delay, err := pacer.Reserve(context.Background(), accountID, "email")
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if reservation != nil || delay < time.Hour {
+		t.Fatalf("Reserve over cap = (%v, %v), want deferral to the next day", reservation, delay)
+	}
+	if got := repo.total(db.SendCounterMinute); got != 1 {
+		t.Fatalf("minute counter = %d, want 1 (deferred send must not keep its ticket)", got)
+	}
+	if got := repo.total(db.SendCounterDay); got != 1 {
+		t.Fatalf("day counter = %d, want 1", got)
+	}
+}
+
+func TestProjectChannelFinish(t *testing.T) {
+	policy := &models.SendPolicy{
+		Timezone:      "UTC",
+		Weekdays:      []int{1, 2, 3, 4, 5}, // segunda a sexta
+		StartTime:     "08:00",
+		EndTime:       "18:00",
+		RatePerMinute: 10,
+		DailyCap:      1000,
+	}
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2026, time.March, 2, hour, minute, 0, 0, time.UTC) // segunda-feira
+	}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		remaining int
+		sentToday int
+		want      time.Time
+	}{
+		{"cabe no ritmo de hoje", monday(10, 0), 500, 0, monday(10, 50)},
+		{"limite diário empurra para amanhã", monday(10, 0), 1500, 0, monday(10, 0).AddDate(0, 0, 1).Add(-2*time.Hour + 50*time.Minute)},
+		{"enviados hoje reduzem a capacidade", monday(10, 0), 300, 800, monday(8, 0).AddDate(0, 0, 1).Add(10 * time.Minute)},
+		{"fora da janela começa na próxima abertura", monday(19, 0), 100, 0, monday(8, 10).AddDate(0, 0, 1)},
+		{"fim de semana pula para segunda", monday(20, 0).AddDate(0, 0, 4), 100, 0, monday(8, 10).AddDate(0, 0, 7)},
+		{"janela de hoje limita pelo ritmo", monday(17, 0), 1000, 0, monday(8, 40).AddDate(0, 0, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := projectChannelFinish(policy, tt.now, tt.remaining, tt.sentToday)
+			if !got.Equal(tt.want) {
+				t.Errorf("projectChannelFinish = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
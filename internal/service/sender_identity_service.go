@@ -0,0 +1,190 @@
+// File: /internal/service/sender_identity_service.go
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/service/ses"
+	"github.com/aws/aws-sdk-go-v2/service/ses/types"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// ErrSenderNotVerified indica remetente (email_from) sem identidade verificada na conta
+var ErrSenderNotVerified = errors.New("remetente não verificado")
+
+// SenderIdentityService cadastra, verifica e resolve os remetentes de e-mail das contas
+type SenderIdentityService interface {
+	// Create cadastra o remetente e inicia a verificação no provedor da conta
+	Create(ctx context.Context, identity *models.SenderIdentity) (*models.SenderIdentity, error)
+	// Refresh consulta o provedor e atualiza o estado de verificação
+	Refresh(ctx context.Context, identity *models.SenderIdentity) (*models.SenderIdentity, error)
+	// Resolve retorna a identidade verificada que autoriza o email_from das configurações da campanha
+	Resolve(ctx context.Context, accountID uuid.UUID, settings models.CampaignSettings) (*models.SenderIdentity, error)
+	// CheckCampaign garante que uma campanha com canal de e-mail tem remetente verificado antes do disparo
+	CheckCampaign(ctx context.Context, campaign *models.Campaign) error
+}
+
+type senderIdentityService struct {
+	log                  *slog.Logger
+	repo                 db.SenderIdentityRepository
+	accountSettingsRepo  db.AccountSettingsRepository
+	campaignSettingsRepo db.CampaignSettingsRepository
+}
+
+// NewSenderIdentityService cria o serviço de remetentes
+func NewSenderIdentityService(repo db.SenderIdentityRepository, accountSettingsRepo db.AccountSettingsRepository, campaignSettingsRepo db.CampaignSettingsRepository) SenderIdentityService {
+	return &senderIdentityService{
+		log:                  logger.GetLogger(),
+		repo:                 repo,
+		accountSettingsRepo:  accountSettingsRepo,
+		campaignSettingsRepo: campaignSettingsRepo,
+	}
+}
+
+// Create grava o remetente como pendente e solicita a verificação.
+// Se o provedor falhar, o remetente continua salvo como pendente e a verificação é repetida em Refresh.
+func (s *senderIdentityService) Create(ctx context.Context, identity *models.SenderIdentity) (*models.SenderIdentity, error) {
+	identity.Status = models.SenderIdentityPending
+	saved, err := s.repo.Create(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Refresh(ctx, saved)
+}
+
+// Refresh consulta o status de verificação no SES, solicitando a verificação se o SES ainda não conhece a identidade
+func (s *senderIdentityService) Refresh(ctx context.Context, identity *models.SenderIdentity) (*models.SenderIdentity, error) {
+	settings, err := s.accountSettings(ctx, identity.AccountID)
+	if err != nil {
+		return identity, err
+	}
+
+	// 📧 SMTP próprio: o servidor da conta é responsável pelo remetente, não há verificação no provedor
+	if settings.EmailProvider == models.EmailProviderSMTP {
+		return s.repo.UpdateVerification(ctx, identity.ID, models.SenderIdentityVerified, nil)
+	}
+
+	client := newSESClient(*settings)
+	output, err := client.GetIdentityVerificationAttributes(ctx, &ses.GetIdentityVerificationAttributesInput{
+		Identities: []string{identity.Identity},
+	})
+	if err != nil {
+		return identity, fmt.Errorf("erro ao consultar verificação no SES: %w", err)
+	}
+
+	status := models.SenderIdentityPending
+	var token *string
+	if attributes, ok := output.VerificationAttributes[identity.Identity]; ok {
+		status = mapSESVerificationStatus(attributes.VerificationStatus)
+		token = attributes.VerificationToken
+	} else if token, err = s.requestVerification(ctx, client, identity); err != nil {
+		return identity, err
+	}
+
+	updated, err := s.repo.UpdateVerification(ctx, identity.ID, status, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if updated.Status != identity.Status {
+		s.log.Info("🔄 Status do remetente atualizado", "identity", identity.Identity, "from", identity.Status, "to", updated.Status)
+	}
+	return updated, nil
+}
+
+// requestVerification pede ao SES a verificação do remetente: e-mail de confirmação para endereços,
+// token para o TXT _amazonses.<domínio> nos domínios
+func (s *senderIdentityService) requestVerification(ctx context.Context, client *ses.Client, identity *models.SenderIdentity) (*string, error) {
+	s.log.Info("✉️ Solicitando verificação de remetente", "account_id", identity.AccountID, "identity", identity.Identity, "kind", identity.Kind)
+
+	if identity.Kind == models.SenderIdentityDomain {
+		output, err := client.VerifyDomainIdentity(ctx, &ses.VerifyDomainIdentityInput{Domain: &identity.Identity})
+		if err != nil {
+			return nil, fmt.Errorf("erro ao solicitar verificação do domínio no SES: %w", err)
+		}
+		return output.VerificationToken, nil
+	}
+
+	if _, err := client.VerifyEmailIdentity(ctx, &ses.VerifyEmailIdentityInput{EmailAddress: &identity.Identity}); err != nil {
+		return nil, fmt.Errorf("erro ao solicitar verificação do e-mail no SES: %w", err)
+	}
+	return nil, nil
+}
+
+// Resolve usa a identidade referenciada nas configurações ou, nas configurações antigas,
+// a identidade da conta que cobre o email_from
+func (s *senderIdentityService) Resolve(ctx context.Context, accountID uuid.UUID, settings models.CampaignSettings) (*models.SenderIdentity, error) {
+	var identity *models.SenderIdentity
+	var err error
+	if settings.SenderIdentityID != nil {
+		identity, err = s.repo.GetByID(ctx, *settings.SenderIdentityID)
+	} else {
+		identity, err = s.repo.FindForAddress(ctx, accountID, settings.EmailFrom)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if identity == nil || identity.AccountID != accountID || !identity.Covers(settings.EmailFrom) {
+		return nil, fmt.Errorf("%w: nenhuma identidade da conta autoriza %s", ErrSenderNotVerified, settings.EmailFrom)
+	}
+	if !identity.Verified() {
+		return nil, fmt.Errorf("%w: %s está com status '%s'", ErrSenderNotVerified, identity.Identity, identity.Status)
+	}
+
+	return identity, nil
+}
+
+// CheckCampaign valida o remetente com as mesmas configurações que o worker de e-mail usará
+func (s *senderIdentityService) CheckCampaign(ctx context.Context, campaign *models.Campaign) error {
+	if _, ok := campaign.Channels["email"]; !ok {
+		return nil // ✅ Campanha sem e-mail não depende de remetente
+	}
+
+	settings, err := s.campaignSettingsRepo.GetSettingsByCampaignID(ctx, campaign.ID)
+	if err == nil && settings == nil {
+		// 🔄 Mesmo fallback do worker: última configuração usada pela conta
+		settings, err = s.campaignSettingsRepo.GetLastSettings(ctx, campaign.AccountID)
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao buscar configurações da campanha: %w", err)
+	}
+	if settings == nil {
+		return fmt.Errorf("%w: campanha sem configurações de envio", ErrSenderNotVerified)
+	}
+
+	_, err = s.Resolve(ctx, campaign.AccountID, *settings)
+	return err
+}
+
+// accountSettings busca as configurações da conta (credenciais e provedor de e-mail)
+func (s *senderIdentityService) accountSettings(ctx context.Context, accountID uuid.UUID) (*models.AccountSettings, error) {
+	settings, err := s.accountSettingsRepo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar configurações da conta: %w", err)
+	}
+	if settings == nil {
+		return nil, fmt.Errorf("configurações da conta não encontradas (account_id: %s)", accountID)
+	}
+	return settings, nil
+}
+
+// mapSESVerificationStatus converte o status de verificação do SES
+func mapSESVerificationStatus(status types.VerificationStatus) models.SenderIdentityStatus {
+	switch status {
+	case types.VerificationStatusSuccess:
+		return models.SenderIdentityVerified
+	case types.VerificationStatusFailed:
+		return models.SenderIdentityFailed
+	default:
+		return models.SenderIdentityPending // Pending, TemporaryFailure ou NotStarted
+	}
+}
@@ -0,0 +1,196 @@
+// File: /internal/service/ses_event_service.go
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// SESNotification estrutura do evento SES
+type SESNotification struct {
+	EventType string `json:"eventType"`
+	Mail      struct {
+		MessageID string `json:"messageId"`
+	} `json:"mail"`
+	Bounce struct {
+		BounceType string `json:"bounceType,omitempty"`
+	} `json:"bounce,omitempty"`
+	Complaint struct {
+		ComplaintFeedbackType string `json:"complaintFeedbackType,omitempty"`
+	} `json:"complaint,omitempty"`
+	Open struct {
+		IpAddress string `json:"ipAddress,omitempty"`
+		UserAgent string `json:"userAgent,omitempty"`
+	} `json:"open,omitempty"`
+	Click struct {
+		IpAddress string `json:"ipAddress,omitempty"`
+		Link      string `json:"link,omitempty"`
+		UserAgent string `json:"userAgent,omitempty"`
+	} `json:"click,omitempty"`
+}
+
+// SESEventService aplica os eventos do SES (entrega, bounce, reclamação, abertura, clique) às audiências.
+// Usado pelo webhook /ses-feedback e pelo consumidor da fila SQS de eventos, com o mesmo resultado.
+type SESEventService interface {
+	Process(ctx context.Context, sesEvent SESNotification) error
+}
+
+type sesEventService struct {
+	log            *slog.Logger
+	audienceRepo   db.CampaignAudienceRepository
+	engagementRepo db.EngagementRepository
+	suppression    SuppressionService
+}
+
+// NewSESEventService cria o processamento de eventos do SES
+func NewSESEventService(audienceRepo db.CampaignAudienceRepository, engagementRepo db.EngagementRepository, suppression SuppressionService) SESEventService {
+	return &sesEventService{
+		log:            logger.GetLogger(),
+		audienceRepo:   audienceRepo,
+		engagementRepo: engagementRepo,
+		suppression:    suppression,
+	}
+}
+
+// Process registra engajamento ou atualiza o status da audiência; erros de banco devem gerar nova tentativa
+func (s *sesEventService) Process(ctx context.Context, sesEvent SESNotification) error {
+	// 👀 Abertura e clique não alteram o status da audiência, apenas registram engajamento
+	if event := mapSESEventToEngagement(sesEvent); event != nil {
+		if _, err := s.engagementRepo.RecordByMessageID(ctx, sesEvent.Mail.MessageID, event); err != nil {
+			return fmt.Errorf("erro ao registrar engajamento do SES: %w", err)
+		}
+
+		s.log.Info("👀 Engajamento SES registrado", "event", sesEvent.EventType, "message_id", sesEvent.Mail.MessageID)
+		return nil
+	}
+
+	status, feedback := mapSESEventToAudienceStatus(sesEvent)
+	if status == "" {
+		s.log.Warn("⚠️ Evento SES ignorado", "event", sesEvent.EventType, "message_id", sesEvent.Mail.MessageID)
+		return nil
+	}
+
+	s.log.Info("📩 Evento SES recebido", "event", sesEvent.EventType, "message_id", sesEvent.Mail.MessageID)
+
+	updated, err := s.audienceRepo.UpdateStatusByMessageID(ctx, sesEvent.Mail.MessageID, status, feedback)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar status no banco: %w", err)
+	}
+	if !updated {
+		// ⏪ Evento repetido, fora de ordem (ex.: "Send" depois de "Delivery") ou message_id desconhecido:
+		// a supressão já foi registrada na primeira entrega do evento
+		s.log.Debug("Evento SES não alterou o status", "event", sesEvent.EventType, "message_id", sesEvent.Mail.MessageID, "status", status)
+		return nil
+	}
+
+	// 🛑 Bounces e reclamações alimentam a lista de supressão uma única vez por mensagem: só o evento que
+	// avançou o status registra (falha na supressão não impede a confirmação do evento)
+	s.registerSuppression(ctx, sesEvent)
+
+	s.log.Info("✅ Status atualizado com sucesso!", "message_id", sesEvent.Mail.MessageID, "status", status)
+	return nil
+}
+
+// registerSuppression adiciona à lista de supressão o destinatário de bounces e reclamações
+func (s *sesEventService) registerSuppression(ctx context.Context, sesEvent SESNotification) {
+	var err error
+	switch sesEvent.EventType {
+	case "Bounce":
+		err = s.suppression.RegisterBounce(ctx, sesEvent.Mail.MessageID, sesEvent.Bounce.BounceType == "Permanent")
+	case "Complaint":
+		err = s.suppression.RegisterComplaint(ctx, sesEvent.Mail.MessageID)
+	default:
+		return
+	}
+
+	if err != nil {
+		s.log.Error("❌ Erro ao registrar supressão", "event", sesEvent.EventType, "message_id", sesEvent.Mail.MessageID, "error", err)
+	}
+}
+
+// DecodeSESQueueMessage lê um evento do SES vindo do SQS: envelope do SNS (padrão) ou
+// o próprio evento, quando a inscrição SNS→SQS usa entrega bruta (raw message delivery)
+func DecodeSESQueueMessage(body []byte) (*SESNotification, error) {
+	var envelope SNSEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar mensagem da fila de eventos: %w", err)
+	}
+	if envelope.Type == SNSTypeNotification && envelope.Message != "" {
+		body = []byte(envelope.Message)
+	}
+
+	var sesEvent SESNotification
+	if err := json.Unmarshal(body, &sesEvent); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar evento do SES: %w", err)
+	}
+	if sesEvent.EventType == "" || sesEvent.Mail.MessageID == "" {
+		return nil, fmt.Errorf("mensagem da fila não é um evento do SES")
+	}
+
+	return &sesEvent, nil
+}
+
+// mapSESEventToAudienceStatus mapeia eventos do SES para status de audiência e o feedback (objeto JSON) do evento
+func mapSESEventToAudienceStatus(sesEvent SESNotification) (string, map[string]interface{}) {
+	var status string
+	var feedback map[string]interface{}
+	switch sesEvent.EventType {
+	case "Send":
+		status = "enviado"
+	case "Rendering Failure":
+		status = "falha_renderizacao"
+	case "Reject":
+		status = "rejeitado"
+	case "Delivery":
+		status = "entregue"
+	case "Bounce":
+		status = "devolvido"
+		feedback = map[string]interface{}{"bounce_type": sesEvent.Bounce.BounceType}
+	case "Complaint":
+		status = "reclamado"
+		feedback = map[string]interface{}{"complaint_feedback_type": sesEvent.Complaint.ComplaintFeedbackType}
+	case "DeliveryDelay":
+		status = "atrasado"
+	case "SubscriptionUpdate":
+		status = "atualizou_assinatura"
+	default:
+		status = ""
+	}
+	return status, feedback
+}
+
+// mapSESEventToEngagement converte eventos Open/Click do SES em eventos de engajamento (nil para os demais)
+func mapSESEventToEngagement(sesEvent SESNotification) *models.EngagementEvent {
+	event := &models.EngagementEvent{Source: models.EngagementSourceSES}
+
+	switch sesEvent.EventType {
+	case "Open":
+		event.Type = models.EngagementOpen
+		event.IPAddress = optionalString(sesEvent.Open.IpAddress)
+		event.UserAgent = optionalString(sesEvent.Open.UserAgent)
+	case "Click":
+		event.Type = models.EngagementClick
+		event.URL = optionalString(sesEvent.Click.Link)
+		event.IPAddress = optionalString(sesEvent.Click.IpAddress)
+		event.UserAgent = optionalString(sesEvent.Click.UserAgent)
+	default:
+		return nil
+	}
+
+	return event
+}
+
+// optionalString retorna nil para strings vazias
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,192 @@
+// File: /internal/service/ses_event_service_test.go
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// sesAudienceRepo conhece uma única mensagem enviada e registra as atualizações de status
+type sesAudienceRepo struct {
+	db.CampaignAudienceRepository
+
+	message   dto.CampaignMessageDTO
+	messageID string
+	updateErr error
+	updates   int
+	status    string
+	feedback  map[string]interface{}
+}
+
+func (r *sesAudienceRepo) UpdateStatusByMessageID(ctx context.Context, messageID string, status string, feedback map[string]interface{}) (bool, error) {
+	if r.updateErr != nil {
+		return false, r.updateErr
+	}
+	r.updates++
+	if messageID != r.messageID || r.status == string(models.AudienceDevolvido) || r.status == string(models.AudienceReclamado) {
+		return false, nil // ⏪ Bounce e reclamação são finais, como no repositório
+	}
+	r.status, r.feedback = status, feedback
+	return true, nil
+}
+
+func (r *sesAudienceRepo) GetMessageByMessageID(ctx context.Context, messageID string) (*dto.CampaignMessageDTO, error) {
+	if messageID != r.messageID {
+		return nil, nil
+	}
+	msg := r.message
+	return &msg, nil
+}
+
+// sesContactRepo devolve o contato da mensagem
+type sesContactRepo struct {
+	db.ContactRepository
+
+	contact *models.Contact
+}
+
+func (r *sesContactRepo) GetByID(ctx context.Context, contactID uuid.UUID) (*models.Contact, error) {
+	return r.contact, nil
+}
+
+// sesSuppressionRepo guarda as supressões gravadas
+type sesSuppressionRepo struct {
+	db.SuppressionRepository
+
+	rows []models.Suppression
+}
+
+func (r *sesSuppressionRepo) Upsert(ctx context.Context, suppression *models.Suppression) (*models.Suppression, error) {
+	r.rows = append(r.rows, *suppression)
+	return suppression, nil
+}
+
+func (r *sesSuppressionRepo) RegisterSoftBounce(ctx context.Context, suppression *models.Suppression, limit int, expiresAt time.Time) (*models.Suppression, error) {
+	suppression.Reason = models.SuppressionSoftBounce
+	suppression.BounceCount = 1
+	r.rows = append(r.rows, *suppression)
+	return suppression, nil
+}
+
+func newTestSESEventService(updateErr error) (SESEventService, *sesAudienceRepo, *sesSuppressionRepo) {
+	email := "Destinatario@Example.com"
+	audiences := &sesAudienceRepo{
+		message:   dto.CampaignMessageDTO{ID: uuid.New(), AccountID: uuid.New(), CampaignID: uuid.New(), ContactID: uuid.New(), Type: "email"},
+		messageID: "ses-message-1",
+		updateErr: updateErr,
+	}
+	suppressions := &sesSuppressionRepo{}
+	suppression := NewSuppressionService(suppressions, audiences, &sesContactRepo{contact: &models.Contact{Email: &email}}, 3, time.Hour)
+	return NewSESEventService(audiences, nil, suppression), audiences, suppressions
+}
+
+func decodeSESEvent(t *testing.T, body string) SESNotification {
+	t.Helper()
+	var sesEvent SESNotification
+	if err := json.Unmarshal([]byte(body), &sesEvent); err != nil {
+		t.Fatalf("evento inválido: %v", err)
+	}
+	return sesEvent
+}
+
+func TestSESBounceRegistersSuppression(t *testing.T) {
+	svc, audiences, suppressions := newTestSESEventService(nil)
+	bounce := decodeSESEvent(t, `{"eventType":"Bounce","mail":{"messageId":"ses-message-1"},"bounce":{"bounceType":"Permanent"}}`)
+
+	if err := svc.Process(context.Background(), bounce); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	if audiences.status != string(models.AudienceDevolvido) {
+		t.Fatalf("status = %q, esperado devolvido", audiences.status)
+	}
+	// 🧾 feedback_api é JSONB: o feedback precisa ser um objeto JSON, não a string crua do SES
+	data, err := json.Marshal(audiences.feedback)
+	if err != nil || string(data) != `{"bounce_type":"Permanent"}` {
+		t.Fatalf("feedback = %s (%v), esperado {\"bounce_type\":\"Permanent\"}", data, err)
+	}
+
+	if len(suppressions.rows) != 1 {
+		t.Fatalf("supressões gravadas = %d, esperado 1", len(suppressions.rows))
+	}
+	row := suppressions.rows[0]
+	if row.Reason != models.SuppressionHardBounce || row.Address != "destinatario@example.com" || row.AccountID != audiences.message.AccountID {
+		t.Fatalf("supressão = %+v", row)
+	}
+}
+
+func TestSESComplaintFeedbackIsJSONObject(t *testing.T) {
+	svc, audiences, suppressions := newTestSESEventService(nil)
+	complaint := decodeSESEvent(t, `{"eventType":"Complaint","mail":{"messageId":"ses-message-1"},"complaint":{"complaintFeedbackType":"abuse"}}`)
+
+	if err := svc.Process(context.Background(), complaint); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	if audiences.feedback["complaint_feedback_type"] != "abuse" {
+		t.Fatalf("feedback = %v", audiences.feedback)
+	}
+	if len(suppressions.rows) != 1 || suppressions.rows[0].Reason != models.SuppressionComplaint {
+		t.Fatalf("supressões = %+v, esperado uma reclamação", suppressions.rows)
+	}
+}
+
+func TestSESBounceNotSuppressedWhenStatusUpdateFails(t *testing.T) {
+	svc, _, suppressions := newTestSESEventService(errors.New("banco indisponível"))
+	bounce := decodeSESEvent(t, `{"eventType":"Bounce","mail":{"messageId":"ses-message-1"},"bounce":{"bounceType":"Transient"}}`)
+
+	if err := svc.Process(context.Background(), bounce); err == nil {
+		t.Fatal("falha na atualização do status deve ser retornada para nova tentativa")
+	}
+	// 🔁 A nova entrega do evento registra a supressão; registrar agora contaria o soft bounce duas vezes
+	if len(suppressions.rows) != 0 {
+		t.Fatalf("supressões gravadas = %d, esperado 0", len(suppressions.rows))
+	}
+}
+
+func TestSESDuplicateBounceRegistersSuppressionOnce(t *testing.T) {
+	svc, audiences, suppressions := newTestSESEventService(nil)
+	bounce := decodeSESEvent(t, `{"eventType":"Bounce","mail":{"messageId":"ses-message-1"},"bounce":{"bounceType":"Transient"}}`)
+
+	for i := 0; i < 2; i++ {
+		if err := svc.Process(context.Background(), bounce); err != nil {
+			t.Fatalf("Process: %v", err)
+		}
+	}
+
+	if audiences.updates != 2 {
+		t.Fatalf("atualizações = %d, esperado 2", audiences.updates)
+	}
+	if len(suppressions.rows) != 1 || suppressions.rows[0].Reason != models.SuppressionSoftBounce {
+		t.Fatalf("supressões = %+v, esperado um único soft bounce", suppressions.rows)
+	}
+}
+
+func TestSESDeliveryKeepsPreviousFeedback(t *testing.T) {
+	svc, audiences, suppressions := newTestSESEventService(nil)
+	delivery := decodeSESEvent(t, `{"eventType":"Delivery","mail":{"messageId":"ses-message-1"}}`)
+
+	if err := svc.Process(context.Background(), delivery); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	if audiences.updates != 1 || audiences.status != string(models.AudienceEntregue) {
+		t.Fatalf("atualizações = %d, status = %q, esperado entregue", audiences.updates, audiences.status)
+	}
+	// 🧾 Feedback nil mantém o feedback_api gravado por eventos anteriores
+	if audiences.feedback != nil {
+		t.Fatalf("feedback = %v, esperado nil", audiences.feedback)
+	}
+	if len(suppressions.rows) != 0 {
+		t.Fatalf("entrega não deve gerar supressão: %+v", suppressions.rows)
+	}
+}
@@ -0,0 +1,298 @@
+// File: /internal/service/sns_verifier.go
+
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+)
+
+// Tipos de mensagem entregues pelo SNS em endpoints HTTP/HTTPS
+const (
+	SNSTypeNotification             = "Notification"
+	SNSTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	SNSTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// ErrInvalidSNSSignature indica mensagem sem assinatura, com certificado fora da AWS ou assinatura inválida
+var ErrInvalidSNSSignature = errors.New("assinatura SNS inválida")
+
+// 🔒 Certificados e confirmações só são baixados de hosts do próprio SNS (ex.: sns.us-east-1.amazonaws.com)
+var snsHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// SNSEnvelope é o corpo JSON enviado pelo SNS ao endpoint
+type SNSEnvelope struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token,omitempty"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject,omitempty"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL,omitempty"`
+	UnsubscribeURL   string `json:"UnsubscribeURL,omitempty"`
+}
+
+// SNSFetcher baixa uma URL do SNS (certificado de assinatura ou confirmação de inscrição)
+type SNSFetcher func(ctx context.Context, rawURL string) ([]byte, error)
+
+// SNSVerifier valida mensagens do SNS e confirma inscrições de tópicos
+type SNSVerifier interface {
+	// Enabled indica se há tópicos permitidos (SNS_TOPIC_ARNS); sem eles, todas as mensagens são recusadas
+	Enabled() bool
+	Verify(ctx context.Context, envelope *SNSEnvelope) error
+	ConfirmSubscription(ctx context.Context, envelope *SNSEnvelope) error
+}
+
+type snsVerifier struct {
+	log       *slog.Logger
+	fetch     SNSFetcher
+	topicARNs []string
+	mu        sync.RWMutex
+	certs     map[string]*x509.Certificate
+}
+
+// NewSNSVerifier cria o verificador de assinaturas do SNS.
+// `fetch` nil usa HTTP; `topicARNs` vazio recusa todas as mensagens (qualquer conta AWS assina mensagens válidas
+// dos próprios tópicos e poderia inscrevê-los no endpoint).
+func NewSNSVerifier(fetch SNSFetcher, topicARNs []string) SNSVerifier {
+	if fetch == nil {
+		fetch = httpSNSFetcher(&http.Client{
+			Timeout: 10 * time.Second,
+			// 🔒 Sem redirecionamentos: a URL validada é a única acessada
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		})
+	}
+
+	return &snsVerifier{
+		log:       logger.GetLogger(),
+		fetch:     fetch,
+		topicARNs: topicARNs,
+		certs:     map[string]*x509.Certificate{},
+	}
+}
+
+// Enabled indica se há tópicos permitidos
+func (v *snsVerifier) Enabled() bool {
+	return len(v.topicARNs) > 0
+}
+
+// Verify confere tópico, certificado e assinatura (SignatureVersion 1 = SHA1, 2 = SHA256)
+func (v *snsVerifier) Verify(ctx context.Context, envelope *SNSEnvelope) error {
+	if envelope.Signature == "" || envelope.SigningCertURL == "" {
+		return fmt.Errorf("%w: mensagem sem assinatura", ErrInvalidSNSSignature)
+	}
+	if err := v.checkTopic(envelope.TopicArn); err != nil {
+		return err
+	}
+
+	var hash crypto.Hash
+	switch envelope.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return fmt.Errorf("%w: SignatureVersion não suportada (%s)", ErrInvalidSNSSignature, envelope.SignatureVersion)
+	}
+
+	stringToSign, err := snsStringToSign(envelope)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSNSSignature, err)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(envelope.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: assinatura não está em base64", ErrInvalidSNSSignature)
+	}
+
+	cert, err := v.certificate(ctx, envelope.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%w: certificado sem chave RSA", ErrInvalidSNSSignature)
+	}
+
+	if err := rsa.VerifyPKCS1v15(publicKey, hash, snsDigest(hash, stringToSign), signature); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSNSSignature, err)
+	}
+
+	return nil
+}
+
+// ConfirmSubscription acessa o SubscribeURL (somente hosts do SNS) para ativar a inscrição do endpoint
+func (v *snsVerifier) ConfirmSubscription(ctx context.Context, envelope *SNSEnvelope) error {
+	if err := v.checkTopic(envelope.TopicArn); err != nil {
+		return err
+	}
+	if err := validateSNSURL(envelope.SubscribeURL, ""); err != nil {
+		return fmt.Errorf("SubscribeURL inválida: %w", err)
+	}
+
+	if _, err := v.fetch(ctx, envelope.SubscribeURL); err != nil {
+		return fmt.Errorf("erro ao confirmar inscrição SNS: %w", err)
+	}
+
+	v.log.Info("✅ Inscrição no SNS confirmada com sucesso!", "topic_arn", envelope.TopicArn)
+	return nil
+}
+
+// checkTopic aceita somente os tópicos de SNS_TOPIC_ARNS (nenhum, se a lista estiver vazia)
+func (v *snsVerifier) checkTopic(topicARN string) error {
+	if !v.Enabled() {
+		return fmt.Errorf("%w: nenhum tópico permitido (SNS_TOPIC_ARNS)", ErrInvalidSNSSignature)
+	}
+	if !slices.Contains(v.topicARNs, topicARN) {
+		return fmt.Errorf("%w: tópico não permitido (%s)", ErrInvalidSNSSignature, topicARN)
+	}
+	return nil
+}
+
+// certificate busca o certificado de assinatura, mantendo em cache até expirar
+func (v *snsVerifier) certificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	if err := validateSNSURL(certURL, ".pem"); err != nil {
+		return nil, fmt.Errorf("%w: SigningCertURL inválida: %v", ErrInvalidSNSSignature, err)
+	}
+
+	v.mu.RLock()
+	cert, ok := v.certs[certURL]
+	v.mu.RUnlock()
+	if ok && time.Now().Before(cert.NotAfter) {
+		return cert, nil
+	}
+
+	content, err := v.fetch(ctx, certURL)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao baixar certificado SNS: %w", err)
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("%w: certificado SNS não está em PEM", ErrInvalidSNSSignature)
+	}
+	cert, err = x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: erro ao ler certificado SNS: %v", ErrInvalidSNSSignature, err)
+	}
+
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return nil, fmt.Errorf("%w: certificado SNS fora da validade", ErrInvalidSNSSignature)
+	}
+
+	v.mu.Lock()
+	v.certs[certURL] = cert
+	v.mu.Unlock()
+
+	v.log.Debug("🔐 Certificado SNS carregado", "url", certURL, "expires_at", cert.NotAfter)
+	return cert, nil
+}
+
+// snsStringToSign monta o texto assinado pelo SNS (campos em ordem alfabética, cada um "Nome\nValor\n")
+func snsStringToSign(envelope *SNSEnvelope) (string, error) {
+	var fields [][2]string
+
+	switch envelope.Type {
+	case SNSTypeNotification:
+		fields = [][2]string{{"Message", envelope.Message}, {"MessageId", envelope.MessageID}}
+		if envelope.Subject != "" {
+			fields = append(fields, [2]string{"Subject", envelope.Subject})
+		}
+		fields = append(fields,
+			[2]string{"Timestamp", envelope.Timestamp},
+			[2]string{"TopicArn", envelope.TopicArn},
+			[2]string{"Type", envelope.Type},
+		)
+	case SNSTypeSubscriptionConfirmation, SNSTypeUnsubscribeConfirmation:
+		fields = [][2]string{
+			{"Message", envelope.Message},
+			{"MessageId", envelope.MessageID},
+			{"SubscribeURL", envelope.SubscribeURL},
+			{"Timestamp", envelope.Timestamp},
+			{"Token", envelope.Token},
+			{"TopicArn", envelope.TopicArn},
+			{"Type", envelope.Type},
+		}
+	default:
+		return "", fmt.Errorf("tipo de mensagem SNS desconhecido: %q", envelope.Type)
+	}
+
+	var builder strings.Builder
+	for _, field := range fields {
+		builder.WriteString(field[0] + "\n" + field[1] + "\n")
+	}
+	return builder.String(), nil
+}
+
+// snsDigest calcula o hash do texto assinado
+func snsDigest(hash crypto.Hash, content string) []byte {
+	if hash == crypto.SHA1 {
+		sum := sha1.Sum([]byte(content))
+		return sum[:]
+	}
+	sum := sha256.Sum256([]byte(content))
+	return sum[:]
+}
+
+// validateSNSURL exige HTTPS em um host do SNS (e a extensão informada, se houver)
+func validateSNSURL(rawURL, suffix string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme != "https" {
+		return fmt.Errorf("esquema não permitido: %q", parsed.Scheme)
+	}
+	if !snsHostPattern.MatchString(parsed.Hostname()) {
+		return fmt.Errorf("host não permitido: %q", parsed.Hostname())
+	}
+	if suffix != "" && !strings.HasSuffix(parsed.Path, suffix) {
+		return fmt.Errorf("caminho não permitido: %q", parsed.Path)
+	}
+	return nil
+}
+
+// httpSNSFetcher baixa URLs do SNS via HTTP (limite de 64 KB por resposta)
+func httpSNSFetcher(client *http.Client) SNSFetcher {
+	return func(ctx context.Context, rawURL string) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("resposta inesperada do SNS: %s", resp.Status)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	}
+}
@@ -0,0 +1,332 @@
+// File: /internal/service/sns_verifier_test.go
+
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+const (
+	testSNSCertURL   = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-teste.pem"
+	testSNSTopicArn  = "arn:aws:sns:us-east-1:123456789012:ses-eventos"
+	testSNSSubscribe = "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&TopicArn=" + testSNSTopicArn + "&Token=abc"
+)
+
+// snsTestSigner simula o SNS: chave RSA própria e certificado autoassinado servido pelo fetcher
+type snsTestSigner struct {
+	key     *rsa.PrivateKey
+	certPEM []byte
+	fetched []string
+}
+
+func newSNSTestSigner(t *testing.T) *snsTestSigner {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("erro ao gerar chave: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("erro ao criar certificado: %v", err)
+	}
+
+	return &snsTestSigner{key: key, certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (s *snsTestSigner) fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	s.fetched = append(s.fetched, rawURL)
+	if rawURL == testSNSCertURL {
+		return s.certPEM, nil
+	}
+	return []byte("<ConfirmSubscriptionResponse/>"), nil
+}
+
+// sign preenche Signature conforme a SignatureVersion do envelope
+func (s *snsTestSigner) sign(t *testing.T, envelope *SNSEnvelope) {
+	t.Helper()
+
+	hash := crypto.SHA256
+	if envelope.SignatureVersion == "1" {
+		hash = crypto.SHA1
+	}
+	stringToSign, err := snsStringToSign(envelope)
+	if err != nil {
+		t.Fatalf("snsStringToSign: %v", err)
+	}
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, hash, snsDigest(hash, stringToSign))
+	if err != nil {
+		t.Fatalf("erro ao assinar: %v", err)
+	}
+	envelope.Signature = base64.StdEncoding.EncodeToString(signature)
+}
+
+func testSNSNotification(version string) *SNSEnvelope {
+	return &SNSEnvelope{
+		Type:             SNSTypeNotification,
+		MessageID:        "22b80b92-fdea-4c2c-8f9d-bdfb0c7bf324",
+		TopicArn:         testSNSTopicArn,
+		Subject:          "Amazon SES Email Event Notification",
+		Message:          `{"eventType":"Delivery","mail":{"messageId":"ses-message-1"}}`,
+		Timestamp:        "2026-10-16T12:00:00.000Z",
+		SignatureVersion: version,
+		SigningCertURL:   testSNSCertURL,
+	}
+}
+
+func TestSNSVerify(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, signer *snsTestSigner) *SNSEnvelope
+		wantErr bool
+	}{
+		{
+			name: "notificação válida com SignatureVersion 1 (SHA1)",
+			prepare: func(t *testing.T, signer *snsTestSigner) *SNSEnvelope {
+				envelope := testSNSNotification("1")
+				signer.sign(t, envelope)
+				return envelope
+			},
+		},
+		{
+			name: "notificação válida com SignatureVersion 2 (SHA256)",
+			prepare: func(t *testing.T, signer *snsTestSigner) *SNSEnvelope {
+				envelope := testSNSNotification("2")
+				signer.sign(t, envelope)
+				return envelope
+			},
+		},
+		{
+			name: "notificação sem Subject",
+			prepare: func(t *testing.T, signer *snsTestSigner) *SNSEnvelope {
+				envelope := testSNSNotification("2")
+				envelope.Subject = ""
+				signer.sign(t, envelope)
+				return envelope
+			},
+		},
+		{
+			name: "SubscriptionConfirmation válida",
+			prepare: func(t *testing.T, signer *snsTestSigner) *SNSEnvelope {
+				envelope := &SNSEnvelope{
+					Type:             SNSTypeSubscriptionConfirmation,
+					MessageID:        "165545c9-2a5c-472c-8df2-7ff2be2b3b1b",
+					Token:            "abc",
+					TopicArn:         testSNSTopicArn,
+					Message:          "You have chosen to subscribe to the topic",
+					Timestamp:        "2026-10-16T12:00:00.000Z",
+					SignatureVersion: "1",
+					SigningCertURL:   testSNSCertURL,
+					SubscribeURL:     testSNSSubscribe,
+				}
+				signer.sign(t, envelope)
+				return envelope
+			},
+		},
+		{
+			name: "mensagem alterada após a assinatura",
+			prepare: func(t *testing.T, signer *snsTestSigner) *SNSEnvelope {
+				envelope := testSNSNotification("2")
+				signer.sign(t, envelope)
+				envelope.Message = `{"eventType":"Bounce","mail":{"messageId":"ses-message-1"}}`
+				return envelope
+			},
+			wantErr: true,
+		},
+		{
+			name: "SubscribeURL alterada após a assinatura",
+			prepare: func(t *testing.T, signer *snsTestSigner) *SNSEnvelope {
+				envelope := &SNSEnvelope{
+					Type:             SNSTypeSubscriptionConfirmation,
+					MessageID:        "165545c9-2a5c-472c-8df2-7ff2be2b3b1b",
+					Token:            "abc",
+					TopicArn:         testSNSTopicArn,
+					Timestamp:        "2026-10-16T12:00:00.000Z",
+					SignatureVersion: "2",
+					SigningCertURL:   testSNSCertURL,
+					SubscribeURL:     testSNSSubscribe,
+				}
+				signer.sign(t, envelope)
+				envelope.SubscribeURL = "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=outro"
+				return envelope
+			},
+			wantErr: true,
+		},
+		{
+			name: "versão trocada de 2 para 1",
+			prepare: func(t *testing.T, signer *snsTestSigner) *SNSEnvelope {
+				envelope := testSNSNotification("2")
+				signer.sign(t, envelope)
+				envelope.SignatureVersion = "1"
+				return envelope
+			},
+			wantErr: true,
+		},
+		{
+			name: "SigningCertURL fora do SNS",
+			prepare: func(t *testing.T, signer *snsTestSigner) *SNSEnvelope {
+				envelope := testSNSNotification("2")
+				envelope.SigningCertURL = "https://sns.us-east-1.amazonaws.com.atacante.example.com/cert.pem"
+				signer.sign(t, envelope)
+				return envelope
+			},
+			wantErr: true,
+		},
+		{
+			name: "SigningCertURL via HTTP",
+			prepare: func(t *testing.T, signer *snsTestSigner) *SNSEnvelope {
+				envelope := testSNSNotification("2")
+				envelope.SigningCertURL = "http://sns.us-east-1.amazonaws.com/SimpleNotificationService-teste.pem"
+				signer.sign(t, envelope)
+				return envelope
+			},
+			wantErr: true,
+		},
+		{
+			name: "SignatureVersion desconhecida",
+			prepare: func(t *testing.T, signer *snsTestSigner) *SNSEnvelope {
+				envelope := testSNSNotification("2")
+				signer.sign(t, envelope)
+				envelope.SignatureVersion = "3"
+				return envelope
+			},
+			wantErr: true,
+		},
+		{
+			name: "tópico não permitido",
+			prepare: func(t *testing.T, signer *snsTestSigner) *SNSEnvelope {
+				envelope := testSNSNotification("2")
+				envelope.TopicArn = "arn:aws:sns:us-east-1:999999999999:outro"
+				signer.sign(t, envelope)
+				return envelope
+			},
+			wantErr: true,
+		},
+		{
+			name: "sem assinatura",
+			prepare: func(t *testing.T, signer *snsTestSigner) *SNSEnvelope {
+				return testSNSNotification("2")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := newSNSTestSigner(t)
+			verifier := NewSNSVerifier(signer.fetch, []string{testSNSTopicArn})
+
+			err := verifier.Verify(context.Background(), tt.prepare(t, signer))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSNSSignature) {
+					t.Fatalf("Verify = %v, esperado ErrInvalidSNSSignature", err)
+				}
+				for _, fetched := range signer.fetched {
+					if fetched != testSNSCertURL {
+						t.Fatalf("URL fora do SNS acessada: %s", fetched)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+		})
+	}
+}
+
+func TestSNSVerifyCachesCertificate(t *testing.T) {
+	signer := newSNSTestSigner(t)
+	verifier := NewSNSVerifier(signer.fetch, []string{testSNSTopicArn})
+
+	for i := 0; i < 3; i++ {
+		envelope := testSNSNotification("2")
+		signer.sign(t, envelope)
+		if err := verifier.Verify(context.Background(), envelope); err != nil {
+			t.Fatalf("Verify: %v", err)
+		}
+	}
+	if len(signer.fetched) != 1 {
+		t.Fatalf("certificado baixado %d vezes, esperado 1", len(signer.fetched))
+	}
+}
+
+func TestSNSConfirmSubscription(t *testing.T) {
+	tests := []struct {
+		name         string
+		topicArn     string
+		subscribeURL string
+		wantErr      bool
+	}{
+		{name: "host do SNS", subscribeURL: testSNSSubscribe},
+		{name: "host fora do SNS", subscribeURL: "https://atacante.example.com/?Action=ConfirmSubscription", wantErr: true},
+		{name: "HTTP", subscribeURL: "http://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription", wantErr: true},
+		{name: "tópico não permitido", topicArn: "arn:aws:sns:us-east-1:999999999999:outro", subscribeURL: testSNSSubscribe, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := newSNSTestSigner(t)
+			verifier := NewSNSVerifier(signer.fetch, []string{testSNSTopicArn})
+
+			topicArn := testSNSTopicArn
+			if tt.topicArn != "" {
+				topicArn = tt.topicArn
+			}
+			err := verifier.ConfirmSubscription(context.Background(), &SNSEnvelope{
+				Type:         SNSTypeSubscriptionConfirmation,
+				TopicArn:     topicArn,
+				SubscribeURL: tt.subscribeURL,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConfirmSubscription = %v, esperado erro: %v", err, tt.wantErr)
+			}
+			if tt.wantErr && len(signer.fetched) != 0 {
+				t.Fatalf("URL inválida acessada: %v", signer.fetched)
+			}
+			if !tt.wantErr && (len(signer.fetched) != 1 || signer.fetched[0] != tt.subscribeURL) {
+				t.Fatalf("URLs acessadas = %v, esperado o SubscribeURL", signer.fetched)
+			}
+		})
+	}
+}
+
+func TestSNSVerifierWithoutAllowedTopicsRejectsEverything(t *testing.T) {
+	signer := newSNSTestSigner(t)
+	verifier := NewSNSVerifier(signer.fetch, nil)
+	if verifier.Enabled() {
+		t.Fatal("verificador sem tópicos permitidos não deve ficar habilitado")
+	}
+
+	// 🔒 Qualquer conta AWS assina mensagens válidas dos próprios tópicos
+	envelope := testSNSNotification("2")
+	signer.sign(t, envelope)
+	if err := verifier.Verify(context.Background(), envelope); !errors.Is(err, ErrInvalidSNSSignature) {
+		t.Fatalf("Verify = %v, esperado ErrInvalidSNSSignature", err)
+	}
+
+	err := verifier.ConfirmSubscription(context.Background(), &SNSEnvelope{Type: SNSTypeSubscriptionConfirmation, TopicArn: testSNSTopicArn, SubscribeURL: testSNSSubscribe})
+	if !errors.Is(err, ErrInvalidSNSSignature) {
+		t.Fatalf("ConfirmSubscription = %v, esperado ErrInvalidSNSSignature", err)
+	}
+	if len(signer.fetched) != 0 {
+		t.Fatalf("URLs acessadas = %v, esperado nenhuma", signer.fetched)
+	}
+}
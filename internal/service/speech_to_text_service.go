@@ -0,0 +1,132 @@
+// File: /internal/service/speech_to_text_service.go
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+)
+
+// Provedores de transcrição (definidos em CHAT_TRANSCRIPTION_PROVIDER)
+const (
+	SpeechToTextOpenAI     = "openai"      // OpenAI Whisper (/v1/audio/transcriptions)
+	SpeechToTextWhisperCpp = "whisper_cpp" // Servidor HTTP do whisper.cpp (/inference)
+)
+
+// SpeechToTextService transcreve áudios (notas de voz) em texto
+type SpeechToTextService interface {
+	Transcribe(ctx context.Context, fileName string, audio []byte) (string, error)
+}
+
+// whisperSpeechToText fala o formulário multipart do Whisper, aceito pela OpenAI e pelo servidor do whisper.cpp
+type whisperSpeechToText struct {
+	log        *slog.Logger
+	httpClient *http.Client
+	name       string
+	endpoint   string
+	apiKey     string
+	fields     map[string]string
+}
+
+// NewOpenAISpeechToText cria a transcrição pela API da OpenAI (model: whisper-1, gpt-4o-mini-transcribe...)
+func NewOpenAISpeechToText(apiKey, model, language string) SpeechToTextService {
+	if model == "" {
+		model = "whisper-1"
+	}
+	return newWhisperSpeechToText(SpeechToTextOpenAI, "https://api.openai.com/v1/audio/transcriptions", apiKey, map[string]string{
+		"model":           model,
+		"language":        language,
+		"response_format": "json",
+	})
+}
+
+// NewWhisperCppSpeechToText cria a transcrição por um servidor local do whisper.cpp (ex: http://localhost:8178).
+// O servidor precisa ser iniciado com --convert (ffmpeg) para aceitar as notas de voz em OGG/Opus.
+func NewWhisperCppSpeechToText(baseURL, language string) SpeechToTextService {
+	return newWhisperSpeechToText(SpeechToTextWhisperCpp, strings.TrimRight(baseURL, "/")+"/inference", "", map[string]string{
+		"language":        language,
+		"response_format": "json",
+		"temperature":     "0",
+	})
+}
+
+func newWhisperSpeechToText(name, endpoint, apiKey string, fields map[string]string) *whisperSpeechToText {
+	return &whisperSpeechToText{
+		log:        logger.GetLogger(),
+		httpClient: &http.Client{Timeout: 2 * time.Minute},
+		name:       name,
+		endpoint:   endpoint,
+		apiKey:     apiKey,
+		fields:     fields,
+	}
+}
+
+// Transcribe envia o áudio e retorna o texto reconhecido
+func (s *whisperSpeechToText) Transcribe(ctx context.Context, fileName string, audio []byte) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for key, value := range s.fields {
+		if value == "" {
+			continue
+		}
+		if err := writer.WriteField(key, value); err != nil {
+			return "", fmt.Errorf("erro ao montar formulário da transcrição: %w", err)
+		}
+	}
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return "", fmt.Errorf("erro ao montar formulário da transcrição: %w", err)
+	}
+	if _, err := part.Write(audio); err != nil {
+		return "", fmt.Errorf("erro ao montar formulário da transcrição: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("erro ao montar formulário da transcrição: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, &body)
+	if err != nil {
+		return "", fmt.Errorf("erro ao criar requisição de transcrição: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	s.log.Info("🎤 Enviando áudio para transcrição", "provider", s.name, "file_name", fileName, "size_bytes", len(audio))
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("erro ao enviar áudio para transcrição (%s): %w", s.name, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusRequestEntityTooLarge {
+			return "", NewPermanentError("transcrição (%s) recusou o áudio com status %d: %s", s.name, resp.StatusCode, string(respBody))
+		}
+		return "", fmt.Errorf("transcrição (%s) respondeu com status %d: %s", s.name, resp.StatusCode, string(respBody))
+	}
+
+	var result struct {
+		Text  string `json:"text"`
+		Error string `json:"error"` // whisper.cpp responde 200 com {"error": ...} quando não consegue ler o áudio
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("erro ao decodificar resposta da transcrição (%s): %w", s.name, err)
+	}
+	if result.Error != "" {
+		return "", NewPermanentError("transcrição (%s) falhou: %s", s.name, result.Error)
+	}
+	return strings.TrimSpace(result.Text), nil
+}
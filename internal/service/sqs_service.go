@@ -16,22 +16,31 @@ import (
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
 )
 
-// SQSService define as operações para interagir com o Amazon SQS
+// SQSService define as operações de fila usadas pelo pipeline de campanhas.
+// Implementações: Amazon SQS (sqsService), PostgreSQL (postgresQueueService) e memória (memoryQueueService).
 type SQSService interface {
 	SendMessage(ctx context.Context, queueName string, message interface{}) error
-	ReceiveMessages(ctx context.Context, queueName string, handler func(msg dto.CampaignMessageDTO) error) error
+	SendMessageWithDelay(ctx context.Context, queueName string, message interface{}, delay time.Duration) error
+	ReceiveMessages(ctx context.Context, queueName string, concurrency int, handler QueueMessageHandler) error
 }
 
+// QueueMessageHandler processa uma mensagem da fila; retornar erro mantém a mensagem na fila para reentrega
+type QueueMessageHandler func(ctx context.Context, msg dto.CampaignMessageDTO) error
+
+// RawQueueMessageHandler processa o corpo bruto de uma mensagem (filas fora do pipeline de campanhas)
+type RawQueueMessageHandler func(ctx context.Context, body []byte) error
+
 // sqsService gerencia a comunicação com o Amazon SQS
 type sqsService struct {
-	log              *slog.Logger
-	client           *sqs.Client
-	emailQueueURL    string
-	whatsappQueueURL string
+	log               *slog.Logger
+	client            *sqs.Client
+	emailQueueURL     string
+	whatsappQueueURL  string
+	visibilityTimeout time.Duration
 }
 
 // NewSQSService inicializa o serviço de filas SQS
-func NewSQSService(emailQueueURL, whatsappQueueURL string) (*sqsService, error) {
+func NewSQSService(emailQueueURL, whatsappQueueURL string, visibilityTimeout time.Duration) (*sqsService, error) {
 	log := logger.GetLogger()
 
 	cfg, err := config.LoadDefaultConfig(context.TODO())
@@ -43,15 +52,21 @@ func NewSQSService(emailQueueURL, whatsappQueueURL string) (*sqsService, error)
 	client := sqs.NewFromConfig(cfg)
 
 	return &sqsService{
-		log:              log,
-		client:           client,
-		emailQueueURL:    emailQueueURL,
-		whatsappQueueURL: whatsappQueueURL,
+		log:               log,
+		client:            client,
+		emailQueueURL:     emailQueueURL,
+		whatsappQueueURL:  whatsappQueueURL,
+		visibilityTimeout: visibilityTimeout,
 	}, nil
 }
 
 // SendMessage envia uma mensagem para a fila correta (email ou whatsapp)
 func (s *sqsService) SendMessage(ctx context.Context, queueName string, message interface{}) error {
+	return s.SendMessageWithDelay(ctx, queueName, message, 0)
+}
+
+// SendMessageWithDelay envia uma mensagem que só fica visível após `delay` (máximo de 15 minutos no SQS)
+func (s *sqsService) SendMessageWithDelay(ctx context.Context, queueName string, message interface{}, delay time.Duration) error {
 	var queueURL string
 
 	if queueName == "email" {
@@ -72,8 +87,9 @@ func (s *sqsService) SendMessage(ctx context.Context, queueName string, message
 
 	// 🔥 Envia sem converter para string (evita JSON aninhado)
 	_, err = s.client.SendMessage(context.TODO(), &sqs.SendMessageInput{
-		QueueUrl:    aws.String(queueURL),
-		MessageBody: aws.String(string(messageBody)), // <---- Mantém JSON puro
+		QueueUrl:     aws.String(queueURL),
+		MessageBody:  aws.String(string(messageBody)), // <---- Mantém JSON puro
+		DelaySeconds: int32(min(delay, maxQueueDelay).Seconds()),
 	})
 	if err != nil {
 		s.log.Error("Erro ao enviar mensagem para SQS", "queue", queueName, "error", err)
@@ -84,8 +100,10 @@ func (s *sqsService) SendMessage(ctx context.Context, queueName string, message
 	return nil
 }
 
-// ReceiveMessages processa mensagens de uma fila específica e passa para um handler
-func (s *sqsService) ReceiveMessages(ctx context.Context, queueName string, handler func(msg dto.CampaignMessageDTO) error) error {
+// ReceiveMessages consome a fila com no máximo `concurrency` mensagens em processamento simultâneo.
+// A mensagem só é removida do SQS após o handler retornar sucesso; em caso de erro ela volta
+// a ficar visível após o visibility timeout. Enquanto o handler roda, a visibilidade é estendida.
+func (s *sqsService) ReceiveMessages(ctx context.Context, queueName string, concurrency int, handler QueueMessageHandler) error {
 	var queueURL string
 
 	if queueName == "email" {
@@ -97,76 +115,128 @@ func (s *sqsService) ReceiveMessages(ctx context.Context, queueName string, hand
 		return fmt.Errorf("nome da fila inválida: %s", queueName)
 	}
 
-	ticker := time.NewTicker(10 * time.Second) // 🔥 Controla as execuções para evitar loop infinito sem pausas
-	defer ticker.Stop()
+	return s.receive(ctx, queueName, queueURL, concurrency, func(ctx context.Context, body []byte) error {
+		// 🔄 Desserializar JSON da mensagem diretamente para a estrutura correta
+		campaignMessage, err := decodeCampaignMessage(body)
+		if err != nil {
+			s.log.Error("Erro ao decodificar mensagem do SQS", "error", err, "raw_msg", string(body))
+			return err
+		}
+		return handler(ctx, *campaignMessage)
+	})
+}
+
+// receive faz o long polling de uma fila SQS, entregando o corpo bruto de cada mensagem ao handler
+func (s *sqsService) receive(ctx context.Context, queueName, queueURL string, concurrency int, handler RawQueueMessageHandler) error {
+	pool := newQueueWorkerPool(concurrency)
+	defer pool.Wait() // ⏳ Aguarda as mensagens em andamento antes de encerrar
 
 	retries := 0
 	maxRetries := 5
 
 	for {
-		select {
-		case <-ctx.Done(): // 🔥 Permite encerrar a rotina corretamente quando a aplicação for desligada
+		// 🔒 Só busca novas mensagens quando há vaga no pool
+		if !pool.WaitAvailable(ctx) {
 			s.log.Info("Encerrando consumo de mensagens do SQS", "queue", queueName)
 			return nil
-		case <-ticker.C: // 🔄 Aguarda o tempo definido antes de rodar novamente
-			msgResult, err := s.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
-				QueueUrl:            aws.String(queueURL),
-				MaxNumberOfMessages: 10,
-				WaitTimeSeconds:     10, // 🔥 Long Polling (reduz consumo de CPU e requisições)
-			})
+		}
 
-			if err != nil {
-				s.log.Error("Erro ao receber mensagens do SQS", "queue", queueName, "error", err)
-
-				// 🔄 Aguarda antes de tentar novamente
-				retries++
-				if retries > maxRetries {
-					s.log.Error(fmt.Sprintf("Número máximo (%d) de tentativas excedido, abandonando tentativa de conexão", maxRetries))
-					// TODO: Adicionar um alerta ou notificação para o time de DevOps (email, WhatsApp, etc)
-					return fmt.Errorf("número máximo (%d) de tentativas excedido, abandonando tentativa de conexão", maxRetries)
-				}
-				time.Sleep(time.Duration(retries) * time.Second)
-				continue
+		msgResult, err := s.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(queueURL),
+			MaxNumberOfMessages: int32(min(pool.Available(), 10)),
+			VisibilityTimeout:   int32(s.visibilityTimeout.Seconds()),
+			WaitTimeSeconds:     10, // 🔥 Long Polling (reduz consumo de CPU e requisições)
+		})
+
+		if err != nil {
+			if ctx.Err() != nil { // 🔥 Permite encerrar a rotina corretamente quando a aplicação for desligada
+				s.log.Info("Encerrando consumo de mensagens do SQS", "queue", queueName)
+				return nil
 			}
 
-			// 🔄 Sempre resetamos o contador de retries ao final da execução
-			retries = 0
-
-			for _, message := range msgResult.Messages {
-				s.log.Info("📩 Mensagem recebida do SQS", "queue", queueName, "message_id", *message.MessageId)
-
-				// 🔍 Remover aspas extras caso existam
-				var rawMessage *string
-				if err := json.Unmarshal([]byte(*message.Body), &rawMessage); err == nil {
-					s.log.Debug("Mensagem estava aninhada, extraindo JSON real...")
-				} else {
-					rawMessage = message.Body
-				}
-
-				// 🔄 Desserializar JSON da mensagem diretamente para a estrutura correta
-				var campaignMessage dto.CampaignMessageDTO
-				if err := json.Unmarshal([]byte(*rawMessage), &campaignMessage); err != nil {
-					s.log.Error("Erro ao decodificar mensagem do SQS", "error", err, "raw_msg", rawMessage)
-					continue
-				}
-
-				s.log.Info("📦 Mensagem decodificada com sucesso", "queue", queueName, "contact_id", campaignMessage.ContactID)
-
-				// 🚀 Chama a função de processamento do worker
-				if err := handler(campaignMessage); err != nil {
-					s.log.Error("Erro ao processar mensagem", "error", err)
-					continue
-				}
-
-				// 🗑️ Remover mensagem da fila após processamento
-				_, err := s.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
-					QueueUrl:      aws.String(queueURL),
-					ReceiptHandle: message.ReceiptHandle,
-				})
-				if err != nil {
-					s.log.Error("Erro ao deletar mensagem do SQS", "queue", queueName, "error", err)
-				}
+			s.log.Error("Erro ao receber mensagens do SQS", "queue", queueName, "error", err)
+
+			// 🔄 Aguarda antes de tentar novamente
+			retries++
+			if retries > maxRetries {
+				s.log.Error(fmt.Sprintf("Número máximo (%d) de tentativas excedido, abandonando tentativa de conexão", maxRetries))
+				// TODO: Adicionar um alerta ou notificação para o time de DevOps (email, WhatsApp, etc)
+				return fmt.Errorf("número máximo (%d) de tentativas excedido, abandonando tentativa de conexão", maxRetries)
 			}
+			time.Sleep(time.Duration(retries) * time.Second)
+			continue
+		}
+
+		// 🔄 Sempre resetamos o contador de retries ao final da execução
+		retries = 0
+
+		for _, message := range msgResult.Messages {
+			s.log.Info("📩 Mensagem recebida do SQS", "queue", queueName, "message_id", *message.MessageId)
+
+			receiptHandle := message.ReceiptHandle
+			body := []byte(aws.ToString(message.Body))
+			pool.Go(func() {
+				s.handleMessage(ctx, queueName, queueURL, receiptHandle, body, handler)
+			})
 		}
 	}
 }
+
+// handleMessage executa o handler estendendo a visibilidade e remove a mensagem somente em caso de sucesso
+func (s *sqsService) handleMessage(ctx context.Context, queueName, queueURL string, receiptHandle *string, body []byte, handler RawQueueMessageHandler) {
+	stopHeartbeat := keepInvisible(ctx, s.log, s.visibilityTimeout, func(ctx context.Context) error {
+		_, err := s.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+			QueueUrl:          aws.String(queueURL),
+			ReceiptHandle:     receiptHandle,
+			VisibilityTimeout: int32(s.visibilityTimeout.Seconds()),
+		})
+		return err
+	})
+	defer stopHeartbeat()
+
+	// 🚀 Chama a função de processamento do worker
+	if err := handler(ctx, body); err != nil {
+		s.log.Error("Erro ao processar mensagem, será reentregue", "queue", queueName, "error", err)
+		return
+	}
+
+	// 🗑️ Remover mensagem da fila após processamento
+	_, err := s.client.DeleteMessage(context.WithoutCancel(ctx), &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queueURL),
+		ReceiptHandle: receiptHandle,
+	})
+	if err != nil {
+		s.log.Error("Erro ao deletar mensagem do SQS", "queue", queueName, "error", err)
+	}
+}
+
+// SQSConsumer consome uma fila SQS avulsa, fora do pipeline de campanhas (ex.: eventos do SES via SNS)
+type SQSConsumer interface {
+	Consume(ctx context.Context, concurrency int, handler RawQueueMessageHandler) error
+}
+
+// sqsConsumer reaproveita o long polling, o pool e a extensão de visibilidade do sqsService
+type sqsConsumer struct {
+	sqs       *sqsService
+	queueName string
+	queueURL  string
+}
+
+// NewSQSConsumer inicializa o consumo de uma fila SQS pela URL (`queueName` identifica a fila nos logs)
+func NewSQSConsumer(queueName, queueURL string, visibilityTimeout time.Duration) (SQSConsumer, error) {
+	if queueURL == "" {
+		return nil, fmt.Errorf("URL da fila %s não configurada", queueName)
+	}
+
+	sqsService, err := NewSQSService("", "", visibilityTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqsConsumer{sqs: sqsService, queueName: queueName, queueURL: queueURL}, nil
+}
+
+// Consume processa as mensagens até o contexto ser cancelado; a mensagem só é removida se o handler não falhar
+func (c *sqsConsumer) Consume(ctx context.Context, concurrency int, handler RawQueueMessageHandler) error {
+	return c.sqs.receive(ctx, c.queueName, c.queueURL, concurrency, handler)
+}
@@ -0,0 +1,133 @@
+// File: /internal/service/suppression_service.go
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// SuppressionService alimenta e consulta a lista de supressão das contas
+type SuppressionService interface {
+	// RegisterBounce suprime o destinatário da mensagem: hard bounce é permanente, soft bounce conta até o limite
+	RegisterBounce(ctx context.Context, messageID string, permanent bool) error
+	// RegisterComplaint suprime permanentemente o destinatário que marcou a mensagem como spam
+	RegisterComplaint(ctx context.Context, messageID string) error
+	// Check retorna a supressão vigente do destinatário no canal (nil se puder receber)
+	Check(ctx context.Context, accountID uuid.UUID, channel models.ChannelType, address string) (*models.Suppression, error)
+}
+
+type suppressionService struct {
+	log             *slog.Logger
+	repo            db.SuppressionRepository
+	audienceRepo    db.CampaignAudienceRepository
+	contactRepo     db.ContactRepository
+	softBounceLimit int
+	softBounceTTL   time.Duration
+}
+
+// NewSuppressionService cria o serviço de supressão; soft bounces suprimem por softBounceTTL após softBounceLimit ocorrências
+func NewSuppressionService(repo db.SuppressionRepository, audienceRepo db.CampaignAudienceRepository, contactRepo db.ContactRepository, softBounceLimit int, softBounceTTL time.Duration) SuppressionService {
+	if softBounceLimit < 1 {
+		softBounceLimit = 1
+	}
+
+	return &suppressionService{
+		log:             logger.GetLogger(),
+		repo:            repo,
+		audienceRepo:    audienceRepo,
+		contactRepo:     contactRepo,
+		softBounceLimit: softBounceLimit,
+		softBounceTTL:   softBounceTTL,
+	}
+}
+
+// RegisterBounce registra o bounce do destinatário da mensagem
+func (s *suppressionService) RegisterBounce(ctx context.Context, messageID string, permanent bool) error {
+	suppression, err := s.recipientOf(ctx, messageID)
+	if err != nil || suppression == nil {
+		return err
+	}
+
+	if permanent {
+		suppression.Reason = models.SuppressionHardBounce
+		suppression.BounceCount = 1
+		suppression, err = s.repo.Upsert(ctx, suppression)
+	} else {
+		suppression, err = s.repo.RegisterSoftBounce(ctx, suppression, s.softBounceLimit, time.Now().Add(s.softBounceTTL))
+	}
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("🛑 Bounce registrado na lista de supressão",
+		"account_id", suppression.AccountID, "reason", suppression.Reason, "bounce_count", suppression.BounceCount,
+		"active", suppression.Active(time.Now()), "message_id", messageID)
+	return nil
+}
+
+// RegisterComplaint registra a reclamação do destinatário da mensagem
+func (s *suppressionService) RegisterComplaint(ctx context.Context, messageID string) error {
+	suppression, err := s.recipientOf(ctx, messageID)
+	if err != nil || suppression == nil {
+		return err
+	}
+
+	suppression.Reason = models.SuppressionComplaint
+	if suppression, err = s.repo.Upsert(ctx, suppression); err != nil {
+		return err
+	}
+
+	s.log.Info("🛑 Reclamação registrada na lista de supressão", "account_id", suppression.AccountID, "message_id", messageID)
+	return nil
+}
+
+// Check consulta a supressão vigente do destinatário
+func (s *suppressionService) Check(ctx context.Context, accountID uuid.UUID, channel models.ChannelType, address string) (*models.Suppression, error) {
+	return s.repo.GetActive(ctx, accountID, channel, dto.NormalizeSuppressionAddress(channel, address))
+}
+
+// recipientOf monta a supressão (conta, canal, destinatário e campanha) a partir do message_id.
+// Retorna nil quando a mensagem não pertence a nenhuma campanha conhecida.
+func (s *suppressionService) recipientOf(ctx context.Context, messageID string) (*models.Suppression, error) {
+	msg, err := s.audienceRepo.GetMessageByMessageID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil {
+		s.log.Warn("⚠️ Mensagem não encontrada para supressão", "message_id", messageID)
+		return nil, nil
+	}
+
+	contact, err := s.contactRepo.GetByID(ctx, msg.ContactID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar contato da supressão: %w", err)
+	}
+
+	channel := models.ChannelType(msg.Type)
+	var address *string
+	if contact != nil && channel == models.EmailChannel {
+		address = contact.Email
+	} else if contact != nil && channel == models.WhatsappChannel {
+		address = contact.WhatsApp
+	}
+	if address == nil || *address == "" {
+		s.log.Warn("⚠️ Contato sem destinatário para supressão", "contact_id", msg.ContactID, "channel", channel)
+		return nil, nil
+	}
+
+	return &models.Suppression{
+		AccountID:  msg.AccountID,
+		Channel:    channel,
+		Address:    dto.NormalizeSuppressionAddress(channel, *address),
+		CampaignID: &msg.CampaignID,
+	}, nil
+}
@@ -0,0 +1,151 @@
+// File: /internal/service/unsubscribe_service.go
+
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// ErrInvalidUnsubscribeToken indica token de descadastro adulterado ou de audiência inexistente
+var ErrInvalidUnsubscribeToken = errors.New("token de descadastro inválido")
+
+// unsubscribeSignatureSize é o tamanho (bytes) do HMAC truncado no token
+const unsubscribeSignatureSize = 16
+
+// UnsubscribeService gera os links de descadastro (List-Unsubscribe) e processa o opt-out do destinatário
+type UnsubscribeService interface {
+	// Enabled indica se o descadastro está configurado (UNSUBSCRIBE_BASE_URL e UNSUBSCRIBE_SECRET)
+	Enabled() bool
+	// URL retorna o link público e assinado de descadastro da audiência ("" quando desativado)
+	URL(audienceID uuid.UUID) string
+	// Check valida o token sem descadastrar, retornando o canal da audiência (página de confirmação)
+	Check(ctx context.Context, token string) (models.ChannelType, error)
+	// Unsubscribe valida o token e descadastra o contato do canal da audiência
+	Unsubscribe(ctx context.Context, token string) (models.ChannelType, error)
+}
+
+type unsubscribeService struct {
+	log          *slog.Logger
+	baseURL      string
+	secret       []byte
+	audienceRepo db.CampaignAudienceRepository
+	contactRepo  db.ContactRepository
+}
+
+// NewUnsubscribeService cria o serviço de descadastro; sem baseURL ou secret os links não são gerados
+func NewUnsubscribeService(baseURL, secret string, audienceRepo db.CampaignAudienceRepository, contactRepo db.ContactRepository) UnsubscribeService {
+	return &unsubscribeService{
+		log:          logger.GetLogger(),
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		secret:       []byte(secret),
+		audienceRepo: audienceRepo,
+		contactRepo:  contactRepo,
+	}
+}
+
+// Enabled indica se o descadastro está configurado
+func (s *unsubscribeService) Enabled() bool {
+	return s.baseURL != "" && len(s.secret) > 0
+}
+
+// URL monta o link de descadastro com o token (id da audiência + assinatura)
+func (s *unsubscribeService) URL(audienceID uuid.UUID) string {
+	if !s.Enabled() {
+		return ""
+	}
+	return fmt.Sprintf("%s/unsubscribe/%s", s.baseURL, s.token(audienceID))
+}
+
+// Check valida o token e a audiência sem efeitos colaterais: scanners de links e pré-carregamento
+// dos provedores de e-mail abrem o link (GET) sem que o destinatário tenha pedido o descadastro
+func (s *unsubscribeService) Check(ctx context.Context, token string) (models.ChannelType, error) {
+	audience, err := s.audienceFromToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return audience.Type, nil
+}
+
+// Unsubscribe registra o opt-out do contato no canal e cancela os envios ainda pendentes para ele
+func (s *unsubscribeService) Unsubscribe(ctx context.Context, token string) (models.ChannelType, error) {
+	audience, err := s.audienceFromToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.contactRepo.OptOut(ctx, audience.ContactID, audience.Type); err != nil {
+		return "", err
+	}
+
+	cancelled, err := s.audienceRepo.CancelUnsentByContact(ctx, audience.ContactID, audience.Type)
+	if err != nil {
+		return "", err
+	}
+
+	s.log.Info("🚫 Descadastro realizado pelo destinatário",
+		"contact_id", audience.ContactID, "campaign_id", audience.CampaignID, "channel", audience.Type, "cancelled", cancelled)
+
+	return audience.Type, nil
+}
+
+// audienceFromToken valida a assinatura do token e busca a audiência
+func (s *unsubscribeService) audienceFromToken(ctx context.Context, token string) (*models.CampaignAudience, error) {
+	audienceID, ok := s.parseToken(token)
+	if !ok {
+		return nil, ErrInvalidUnsubscribeToken
+	}
+
+	audience, err := s.audienceRepo.GetByID(ctx, audienceID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar audiência do descadastro: %w", err)
+	}
+	if audience == nil {
+		return nil, ErrInvalidUnsubscribeToken
+	}
+
+	return audience, nil
+}
+
+// token codifica o id da audiência seguido do HMAC truncado (base64 url-safe)
+func (s *unsubscribeService) token(audienceID uuid.UUID) string {
+	payload := append(audienceID[:], s.sign(audienceID)...)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// parseToken valida a assinatura e retorna o id da audiência
+func (s *unsubscribeService) parseToken(token string) (uuid.UUID, bool) {
+	if !s.Enabled() {
+		return uuid.Nil, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(payload) != len(uuid.Nil)+unsubscribeSignatureSize {
+		return uuid.Nil, false
+	}
+
+	audienceID, err := uuid.FromBytes(payload[:len(uuid.Nil)])
+	if err != nil || !hmac.Equal(payload[len(uuid.Nil):], s.sign(audienceID)) {
+		return uuid.Nil, false
+	}
+
+	return audienceID, true
+}
+
+// sign gera o HMAC-SHA256 truncado do id da audiência
+func (s *unsubscribeService) sign(audienceID uuid.UUID) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("unsubscribe\n" + audienceID.String()))
+	return mac.Sum(nil)[:unsubscribeSignatureSize]
+}
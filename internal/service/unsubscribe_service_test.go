@@ -0,0 +1,140 @@
+// File: /internal/service/unsubscribe_service_test.go
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// unsubscribeAudienceRepo guarda uma audiência e os cancelamentos feitos pelo descadastro
+type unsubscribeAudienceRepo struct {
+	db.CampaignAudienceRepository
+
+	audience  *models.CampaignAudience
+	cancelled int
+}
+
+func (r *unsubscribeAudienceRepo) GetByID(ctx context.Context, audienceID uuid.UUID) (*models.CampaignAudience, error) {
+	if r.audience == nil || r.audience.ID != audienceID {
+		return nil, nil
+	}
+	return r.audience, nil
+}
+
+func (r *unsubscribeAudienceRepo) CancelUnsentByContact(ctx context.Context, contactID uuid.UUID, channel models.ChannelType) (int64, error) {
+	r.cancelled++
+	return 1, nil
+}
+
+// unsubscribeContactRepo registra os opt-outs
+type unsubscribeContactRepo struct {
+	db.ContactRepository
+
+	optOuts []models.ChannelType
+}
+
+func (r *unsubscribeContactRepo) OptOut(ctx context.Context, contactID uuid.UUID, channel models.ChannelType) error {
+	r.optOuts = append(r.optOuts, channel)
+	return nil
+}
+
+func newTestUnsubscribeService(secret string) (*unsubscribeService, *unsubscribeAudienceRepo, *unsubscribeContactRepo) {
+	audiences := &unsubscribeAudienceRepo{audience: &models.CampaignAudience{ID: uuid.New(), ContactID: uuid.New(), Type: models.EmailChannel}}
+	contacts := &unsubscribeContactRepo{}
+	svc := NewUnsubscribeService("https://api.example.com/", secret, audiences, contacts).(*unsubscribeService)
+	return svc, audiences, contacts
+}
+
+func TestUnsubscribeTokenRoundTrip(t *testing.T) {
+	svc, audiences, _ := newTestUnsubscribeService("segredo")
+	audienceID := audiences.audience.ID
+
+	url := svc.URL(audienceID)
+	if !strings.HasPrefix(url, "https://api.example.com/unsubscribe/") {
+		t.Fatalf("URL = %s", url)
+	}
+
+	token := strings.TrimPrefix(url, "https://api.example.com/unsubscribe/")
+	got, ok := svc.parseToken(token)
+	if !ok || got != audienceID {
+		t.Fatalf("parseToken = (%s, %v), esperado (%s, true)", got, ok, audienceID)
+	}
+}
+
+func TestUnsubscribeTokenRejectsTampering(t *testing.T) {
+	svc, audiences, _ := newTestUnsubscribeService("segredo")
+	token := svc.token(audiences.audience.ID)
+
+	payload, _ := base64.RawURLEncoding.DecodeString(token)
+	otherAudience := uuid.New()
+	forged := base64.RawURLEncoding.EncodeToString(append(otherAudience[:], payload[len(uuid.Nil):]...))
+
+	flipped := append([]byte{}, payload...)
+	flipped[len(flipped)-1] ^= 0xff
+
+	otherSecret, _, _ := newTestUnsubscribeService("outro-segredo")
+
+	tests := map[string]string{
+		"outra audiência com a assinatura original": forged,
+		"assinatura alterada":                       base64.RawURLEncoding.EncodeToString(flipped),
+		"assinado com outro segredo":                otherSecret.token(audiences.audience.ID),
+		"truncado":                                  token[:len(token)-4],
+		"base64 inválido":                           "%%%",
+		"vazio":                                     "",
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, ok := svc.parseToken(token); ok {
+				t.Fatal("token adulterado aceito")
+			}
+		})
+	}
+}
+
+func TestUnsubscribeDisabledWithoutSecret(t *testing.T) {
+	svc, audiences, _ := newTestUnsubscribeService("")
+
+	if svc.Enabled() || svc.URL(audiences.audience.ID) != "" {
+		t.Fatal("descadastro sem segredo não deve gerar links")
+	}
+	if _, err := svc.Unsubscribe(context.Background(), svc.token(audiences.audience.ID)); !errors.Is(err, ErrInvalidUnsubscribeToken) {
+		t.Fatalf("Unsubscribe = %v, esperado ErrInvalidUnsubscribeToken", err)
+	}
+}
+
+func TestUnsubscribeCheckHasNoSideEffects(t *testing.T) {
+	svc, audiences, contacts := newTestUnsubscribeService("segredo")
+	token := svc.token(audiences.audience.ID)
+
+	channel, err := svc.Check(context.Background(), token)
+	if err != nil || channel != models.EmailChannel {
+		t.Fatalf("Check = (%s, %v), esperado (email, nil)", channel, err)
+	}
+	if len(contacts.optOuts) != 0 || audiences.cancelled != 0 {
+		t.Fatal("Check não deve descadastrar o contato")
+	}
+
+	if _, err := svc.Unsubscribe(context.Background(), token); err != nil {
+		t.Fatalf("Unsubscribe: %v", err)
+	}
+	if len(contacts.optOuts) != 1 || contacts.optOuts[0] != models.EmailChannel || audiences.cancelled != 1 {
+		t.Fatalf("opt-outs = %v, cancelamentos = %d", contacts.optOuts, audiences.cancelled)
+	}
+}
+
+func TestUnsubscribeUnknownAudience(t *testing.T) {
+	svc, _, _ := newTestUnsubscribeService("segredo")
+
+	if _, err := svc.Check(context.Background(), svc.token(uuid.New())); !errors.Is(err, ErrInvalidUnsubscribeToken) {
+		t.Fatalf("Check = %v, esperado ErrInvalidUnsubscribeToken", err)
+	}
+}
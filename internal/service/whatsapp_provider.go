@@ -0,0 +1,312 @@
+// File: /internal/service/whatsapp_provider.go
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+var (
+	// ErrWhatsAppNotSupported indica uma operação que o provedor do chat não oferece (ex: QR Code na Cloud API)
+	ErrWhatsAppNotSupported = errors.New("operação não suportada pelo provedor do WhatsApp")
+	// ErrWhatsAppProviderNotConfigured indica um provedor sem integração configurada no servidor
+	ErrWhatsAppProviderNotConfigured = errors.New("provedor do WhatsApp não configurado")
+	// ErrInvalidWhatsAppWebhook indica um webhook com assinatura ou payload inválido
+	ErrInvalidWhatsAppWebhook = errors.New("webhook do WhatsApp inválido")
+)
+
+// Status da sessão do chat (mesmos valores gravados em chats.session_status)
+const (
+	SessionStatusUnknown      = "desconhecido"
+	SessionStatusWaitingQR    = "aguardando_qr"
+	SessionStatusConnected    = "conectado"
+	SessionStatusDisconnected = "desconectado"
+	SessionStatusError        = "erro"
+)
+
+// WhatsAppProvider é a integração com o WhatsApp usada por um chat (sessão, envio, números e webhooks)
+type WhatsAppProvider interface {
+	Name() models.WhatsAppProviderType
+
+	// Sessão
+	StartSession(ctx context.Context, chat *models.Chat) (*StartSessionResponse, error)
+	GetQRCode(ctx context.Context, chat *models.Chat) (*QRCodeResponse, error)
+	GetSessionState(ctx context.Context, chat *models.Chat) (*dto.SessionStatusDTO, error)
+
+	// Envio (to aceita o JID ou apenas o número com DDI)
+	SendText(ctx context.Context, chat *models.Chat, to, text string) (*WhatsAppSendResult, error)
+	SendMedia(ctx context.Context, chat *models.Chat, to string, media WhatsAppMedia) (*WhatsAppSendResult, error)
+	ResolveNumber(ctx context.Context, chat *models.Chat, normalizedNumber string) (*dto.ResolveNumberResponse, error)
+
+	// DownloadMedia baixa a mídia de uma mensagem recebida (até maxBytes)
+	DownloadMedia(ctx context.Context, chat *models.Chat, message *dto.WhatsAppInboundMessage, maxBytes int64) (*WhatsAppMediaContent, error)
+
+	// Webhook: valida a origem e converte o payload para mensagens e status normalizados
+	VerifyWebhook(header http.Header, body []byte) error
+	ParseWebhook(body []byte) ([]dto.WhatsAppInboundMessage, error)
+	ParseStatuses(body []byte) ([]dto.WhatsAppStatusEvent, error)
+}
+
+// WhatsAppTemplateProvider é implementado pelos provedores que enviam templates aprovados (HSM) da Meta
+type WhatsAppTemplateProvider interface {
+	WhatsAppProvider
+
+	// GetPhoneNumber consulta o número (phone_number_id) no Graph
+	GetPhoneNumber(ctx context.Context, phoneNumberID string) (*WhatsAppPhoneNumberInfo, error)
+	// ListMessageTemplates lista os templates da WABA (todas as páginas)
+	ListMessageTemplates(ctx context.Context, businessAccountID string) ([]WhatsAppTemplateInfo, error)
+	SendTemplate(ctx context.Context, chat *models.Chat, to string, message WhatsAppTemplateMessage) (*WhatsAppSendResult, error)
+}
+
+// WhatsAppPhoneNumberInfo são os dados do número no Graph
+type WhatsAppPhoneNumberInfo struct {
+	ID                 string `json:"id"`
+	DisplayPhoneNumber string `json:"display_phone_number"`
+	VerifiedName       string `json:"verified_name"`
+	QualityRating      string `json:"quality_rating"`
+}
+
+// WhatsAppTemplateInfo é um template como retornado pelo Graph
+type WhatsAppTemplateInfo struct {
+	ID              string          `json:"id"`
+	Name            string          `json:"name"`
+	Language        string          `json:"language"`
+	Status          string          `json:"status"`
+	Category        string          `json:"category"`
+	ParameterFormat string          `json:"parameter_format"`
+	Components      json.RawMessage `json:"components"`
+}
+
+// WhatsAppTemplateMessage é o objeto "template" do envio, com as variáveis preenchidas
+type WhatsAppTemplateMessage struct {
+	Name       string                      `json:"name"`
+	Language   WhatsAppTemplateLanguage    `json:"language"`
+	Components []WhatsAppTemplateComponent `json:"components,omitempty"`
+}
+
+// WhatsAppTemplateLanguage identifica o idioma do template (ex: pt_BR)
+type WhatsAppTemplateLanguage struct {
+	Code string `json:"code"`
+}
+
+// WhatsAppTemplateComponent traz os parâmetros de um componente (header, body ou button)
+type WhatsAppTemplateComponent struct {
+	Type       string                      `json:"type"`
+	SubType    string                      `json:"sub_type,omitempty"` // Botões: url ou copy_code
+	Index      string                      `json:"index,omitempty"`    // Botões: posição do botão
+	Parameters []WhatsAppTemplateParameter `json:"parameters"`
+}
+
+// WhatsAppTemplateParameter é o valor de uma variável do template
+type WhatsAppTemplateParameter struct {
+	Type          string                     `json:"type"`                     // text, image, video, document ou coupon_code
+	ParameterName string                     `json:"parameter_name,omitempty"` // Templates com parâmetros nomeados
+	Text          string                     `json:"text,omitempty"`
+	CouponCode    string                     `json:"coupon_code,omitempty"`
+	Image         *WhatsAppTemplateMediaLink `json:"image,omitempty"`
+	Video         *WhatsAppTemplateMediaLink `json:"video,omitempty"`
+	Document      *WhatsAppTemplateMediaLink `json:"document,omitempty"`
+}
+
+// WhatsAppTemplateMediaLink aponta a mídia do cabeçalho pela URL pública
+type WhatsAppTemplateMediaLink struct {
+	Link string `json:"link"`
+}
+
+// StartSessionResponse é a resposta ao iniciar a sessão do chat
+type StartSessionResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+}
+
+// QRCodeResponse traz o QR Code para conectar a sessão
+type QRCodeResponse struct {
+	QRCode string `json:"qrCode,omitempty"`
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// WhatsAppSendResult é o resultado de um envio
+type WhatsAppSendResult struct {
+	MessageID string `json:"message_id,omitempty"` // ID da mensagem no WhatsApp
+	Status    string `json:"status,omitempty"`
+}
+
+// WhatsAppMedia descreve uma mídia a enviar (pela URL pública do arquivo)
+type WhatsAppMedia struct {
+	Type     string // image, video, audio ou document
+	URL      string
+	MimeType string
+	FileName string
+	Caption  string
+}
+
+// WhatsAppMediaContent é uma mídia recebida, já baixada do provedor
+type WhatsAppMediaContent struct {
+	Content  []byte
+	MimeType string
+	FileName string
+}
+
+// WhatsAppProviders escolhe o provedor de cada chat
+type WhatsAppProviders interface {
+	For(chat *models.Chat) (WhatsAppProvider, error)
+	Get(name models.WhatsAppProviderType) (WhatsAppProvider, error)
+}
+
+type whatsAppProviders struct {
+	providers map[models.WhatsAppProviderType]WhatsAppProvider
+}
+
+// NewWhatsAppProviders registra os provedores disponíveis no servidor
+func NewWhatsAppProviders(providers ...WhatsAppProvider) WhatsAppProviders {
+	registry := &whatsAppProviders{providers: make(map[models.WhatsAppProviderType]WhatsAppProvider, len(providers))}
+	for _, provider := range providers {
+		registry.providers[provider.Name()] = provider
+	}
+	return registry
+}
+
+// For retorna o provedor configurado no chat (chats antigos, sem provedor, usam Baileys)
+func (r *whatsAppProviders) For(chat *models.Chat) (WhatsAppProvider, error) {
+	if chat.InstanceName == "" {
+		return nil, fmt.Errorf("chat está sem instance_name configurado")
+	}
+	name := chat.Provider
+	if name == "" {
+		name = models.WhatsAppProviderBaileys
+	}
+	return r.Get(name)
+}
+
+// Get retorna o provedor pelo nome
+func (r *whatsAppProviders) Get(name models.WhatsAppProviderType) (WhatsAppProvider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrWhatsAppProviderNotConfigured, name)
+	}
+	return provider, nil
+}
+
+// whatsAppJID monta o JID de um número (o número é mantido quando já é um JID)
+func whatsAppJID(number string) string {
+	if strings.Contains(number, "@") {
+		return number
+	}
+	return number + "@s.whatsapp.net"
+}
+
+// whatsAppAPIError é a resposta de erro (HTTP >= 400) da API do provedor
+type whatsAppAPIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *whatsAppAPIError) Error() string {
+	return fmt.Sprintf("falha na API (%d): %s", e.StatusCode, e.Body)
+}
+
+// whatsAppRequest envia uma requisição JSON à API do provedor e decodifica a resposta em out (quando não nil).
+// Retorna o status HTTP para o provedor interpretar erros específicos.
+func whatsAppRequest(ctx context.Context, client *http.Client, method, url string, headers map[string]string, payload, out any) (int, error) {
+	return whatsAppRequestLimit(ctx, client, method, url, headers, payload, out, 1<<20)
+}
+
+// whatsAppRequestLimit é o whatsAppRequest para respostas maiores (ex: mídia em base64)
+func whatsAppRequestLimit(ctx context.Context, client *http.Client, method, url string, headers map[string]string, payload, out any, maxBytes int64) (int, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return 0, fmt.Errorf("erro ao serializar payload: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao enviar requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes))
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("erro ao ler resposta: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return resp.StatusCode, &whatsAppAPIError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(respBody))}
+	}
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return resp.StatusCode, fmt.Errorf("erro ao decodificar resposta: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// whatsAppMediaClient baixa as mídias (vídeos e documentos passam do tempo limite das chamadas da API)
+var whatsAppMediaClient = &http.Client{Timeout: 2 * time.Minute}
+
+// whatsAppDownload baixa o conteúdo de uma mídia, recusando arquivos maiores que maxBytes
+func whatsAppDownload(ctx context.Context, url string, headers map[string]string, maxBytes int64) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := whatsAppMediaClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("erro ao baixar mídia: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return nil, "", &whatsAppAPIError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("erro ao ler mídia: %w", err)
+	}
+	if int64(len(content)) > maxBytes {
+		return nil, "", fmt.Errorf("%w: a mídia excede o limite de %d MB", ErrInvalidChatMedia, maxBytes>>20)
+	}
+	return content, resp.Header.Get("Content-Type"), nil
+}
+
+// firstNonEmpty retorna o primeiro valor preenchido
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,186 @@
+// File: /internal/service/whatsapp_provider_baileys.go
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+// baileysProvider fala com a API própria de sessões Baileys (uma sessão por chat, identificada pelo instance_name)
+type baileysProvider struct {
+	log    *slog.Logger
+	apiURL string
+	apiKey string
+	client *http.Client
+}
+
+// NewBaileysProvider cria o provedor das sessões Baileys
+func NewBaileysProvider(apiURL, apiKey string) WhatsAppProvider {
+	return &baileysProvider{
+		log:    logger.GetLogger(),
+		apiURL: apiURL,
+		apiKey: apiKey,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// baileysSendRequest é o corpo dos envios da API Baileys
+type baileysSendRequest struct {
+	Number   string `json:"number"`
+	Text     string `json:"text,omitempty"`
+	Type     string `json:"type,omitempty"` // Mídia: image, video, audio ou document
+	URL      string `json:"url,omitempty"`
+	MimeType string `json:"mimetype,omitempty"`
+	FileName string `json:"fileName,omitempty"`
+	Caption  string `json:"caption,omitempty"`
+}
+
+// baileysSendResponse é a resposta dos envios da API Baileys
+type baileysSendResponse struct {
+	Status    string `json:"status,omitempty"`
+	Message   string `json:"message,omitempty"`
+	MessageID string `json:"messageId,omitempty"` // ID da mensagem no WhatsApp (key.id)
+	Error     string `json:"error,omitempty"`
+}
+
+func (p *baileysProvider) Name() models.WhatsAppProviderType {
+	return models.WhatsAppProviderBaileys
+}
+
+// sessionURL monta a URL de uma operação da sessão
+func (p *baileysProvider) sessionURL(chat *models.Chat, path string) string {
+	return fmt.Sprintf("%s/sessions/%s/%s", p.apiURL, url.PathEscape(chat.InstanceName), path)
+}
+
+func (p *baileysProvider) headers() map[string]string {
+	return map[string]string{"X-API-Key": p.apiKey}
+}
+
+// StartSession inicia a sessão do chat registrando o webhook das mensagens recebidas
+func (p *baileysProvider) StartSession(ctx context.Context, chat *models.Chat) (*StartSessionResponse, error) {
+	p.log.Debug("Iniciando sessão do WhatsApp Baileys", slog.String("sessionID", chat.InstanceName), slog.String("webhookURL", chat.WebhookURL))
+
+	var res StartSessionResponse
+	body := map[string]string{"webhookUrl": chat.WebhookURL}
+	if _, err := whatsAppRequest(ctx, p.client, http.MethodPost, p.sessionURL(chat, "start"), p.headers(), body, &res); err != nil {
+		return &res, err
+	}
+	return &res, nil
+}
+
+// GetQRCode obtém o QR Code para autenticação da sessão
+func (p *baileysProvider) GetQRCode(ctx context.Context, chat *models.Chat) (*QRCodeResponse, error) {
+	var res QRCodeResponse
+	if _, err := whatsAppRequest(ctx, p.client, http.MethodGet, p.sessionURL(chat, "qrcode"), p.headers(), nil, &res); err != nil {
+		return &res, err
+	}
+	return &res, nil
+}
+
+// GetSessionState consulta o estado da sessão (a API já responde com os status do chat)
+func (p *baileysProvider) GetSessionState(ctx context.Context, chat *models.Chat) (*dto.SessionStatusDTO, error) {
+	var sessionStatus *dto.SessionStatusDTO
+	status, err := whatsAppRequest(ctx, p.client, http.MethodGet, p.sessionURL(chat, "state"), p.headers(), nil, &sessionStatus)
+	if status == http.StatusNotFound {
+		return &dto.SessionStatusDTO{Status: SessionStatusDisconnected, Message: "Sessão não encontrada na API"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sessionStatus == nil {
+		return nil, fmt.Errorf("resposta vazia ao consultar a sessão %s", chat.InstanceName)
+	}
+	return sessionStatus, nil
+}
+
+// SendText envia uma mensagem de texto
+func (p *baileysProvider) SendText(ctx context.Context, chat *models.Chat, to, text string) (*WhatsAppSendResult, error) {
+	return p.send(ctx, chat, "send", baileysSendRequest{Number: utils.GetWhatsAppOnlyNumber(to), Text: text})
+}
+
+// SendMedia envia uma mídia pela URL do arquivo
+func (p *baileysProvider) SendMedia(ctx context.Context, chat *models.Chat, to string, media WhatsAppMedia) (*WhatsAppSendResult, error) {
+	return p.send(ctx, chat, "send-media", baileysSendRequest{
+		Number:   utils.GetWhatsAppOnlyNumber(to),
+		Type:     media.Type,
+		URL:      media.URL,
+		MimeType: media.MimeType,
+		FileName: media.FileName,
+		Caption:  media.Caption,
+	})
+}
+
+func (p *baileysProvider) send(ctx context.Context, chat *models.Chat, path string, payload baileysSendRequest) (*WhatsAppSendResult, error) {
+	var res baileysSendResponse
+	if _, err := whatsAppRequest(ctx, p.client, http.MethodPost, p.sessionURL(chat, path), p.headers(), payload, &res); err != nil {
+		return nil, err
+	}
+	return &WhatsAppSendResult{MessageID: res.MessageID, Status: res.Status}, nil
+}
+
+// ResolveNumber consulta o número na sessão e retorna JID e perfil comercial
+func (p *baileysProvider) ResolveNumber(ctx context.Context, chat *models.Chat, normalizedNumber string) (*dto.ResolveNumberResponse, error) {
+	var data dto.ResolveNumberResponse
+	if _, err := whatsAppRequest(ctx, p.client, http.MethodGet, p.sessionURL(chat, "resolve-number/"+url.PathEscape(normalizedNumber)), p.headers(), nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DownloadMedia baixa a mídia pela referência enviada no webhook (caminho na API Baileys ou URL absoluta)
+func (p *baileysProvider) DownloadMedia(ctx context.Context, chat *models.Chat, message *dto.WhatsAppInboundMessage, maxBytes int64) (*WhatsAppMediaContent, error) {
+	if message.MediaURL == "" {
+		return nil, fmt.Errorf("mensagem %s sem referência de mídia", message.MessageID)
+	}
+
+	mediaURL := message.MediaURL
+	if strings.HasPrefix(mediaURL, "/") {
+		mediaURL = strings.TrimSuffix(p.apiURL, "/") + mediaURL
+	}
+
+	// 🔒 A chave da API só vai para a própria API Baileys
+	var headers map[string]string
+	if p.apiURL != "" && strings.HasPrefix(mediaURL, strings.TrimSuffix(p.apiURL, "/")+"/") {
+		headers = p.headers()
+	}
+
+	content, contentType, err := whatsAppDownload(ctx, mediaURL, headers, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &WhatsAppMediaContent{Content: content, MimeType: firstNonEmpty(message.MimeType, contentType), FileName: message.FileName}, nil
+}
+
+// VerifyWebhook não exige assinatura (a API Baileys roda na rede interna)
+func (p *baileysProvider) VerifyWebhook(header http.Header, body []byte) error {
+	return nil
+}
+
+// ParseStatuses não se aplica: a API Baileys não envia o status das mensagens
+func (p *baileysProvider) ParseStatuses(body []byte) ([]dto.WhatsAppStatusEvent, error) {
+	return nil, nil
+}
+
+// ParseWebhook converte o payload da API Baileys (uma mensagem por chamada)
+func (p *baileysProvider) ParseWebhook(body []byte) ([]dto.WhatsAppInboundMessage, error) {
+	var payload dto.WebhookBaileysPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWhatsAppWebhook, err)
+	}
+	if payload.SessionID == "" || payload.From == "" {
+		return nil, nil // Eventos sem mensagem (ex: status da sessão)
+	}
+	return []dto.WhatsAppInboundMessage{payload.ToInbound()}, nil
+}
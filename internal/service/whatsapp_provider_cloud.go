@@ -0,0 +1,457 @@
+// File: /internal/service/whatsapp_provider_cloud.go
+
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+// DefaultCloudAPIURL é a Graph API usada quando WHATSAPP_CLOUD_API_URL não é informada
+const DefaultCloudAPIURL = "https://graph.facebook.com/v21.0"
+
+// cloudAPIProvider fala com a API oficial do WhatsApp (Cloud API da Meta).
+// O instance_name do chat guarda o phone_number_id; não há sessão nem QR Code.
+type cloudAPIProvider struct {
+	log         *slog.Logger
+	apiURL      string
+	accessToken string
+	appSecret   string
+	client      *http.Client
+}
+
+// NewCloudAPIProvider cria o provedor da Cloud API. appSecret valida a assinatura dos webhooks (X-Hub-Signature-256)
+// e é obrigatório para recebê-los.
+func NewCloudAPIProvider(apiURL, accessToken, appSecret string) WhatsAppTemplateProvider {
+	if apiURL == "" {
+		apiURL = DefaultCloudAPIURL
+	}
+	return &cloudAPIProvider{
+		log:         logger.GetLogger(),
+		apiURL:      strings.TrimSuffix(apiURL, "/"),
+		accessToken: accessToken,
+		appSecret:   appSecret,
+		client:      &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// cloudMedia cobre os campos das mídias recebidas (a mídia é baixada depois pelo ID)
+type cloudMedia struct {
+	ID       string `json:"id"`
+	MimeType string `json:"mime_type"`
+	Caption  string `json:"caption,omitempty"`
+	FileName string `json:"filename,omitempty"`
+}
+
+// cloudWebhook é o payload dos webhooks da Cloud API (objeto whatsapp_business_account)
+type cloudWebhook struct {
+	Object string `json:"object"`
+	Entry  []struct {
+		Changes []struct {
+			Field string `json:"field"`
+			Value struct {
+				Metadata struct {
+					PhoneNumberID string `json:"phone_number_id"`
+				} `json:"metadata"`
+				Contacts []struct {
+					WaID    string `json:"wa_id"`
+					Profile struct {
+						Name string `json:"name"`
+					} `json:"profile"`
+				} `json:"contacts"`
+				Messages []struct {
+					From      string `json:"from"`
+					ID        string `json:"id"`
+					Timestamp string `json:"timestamp"`
+					Type      string `json:"type"`
+					Text      *struct {
+						Body string `json:"body"`
+					} `json:"text,omitempty"`
+					Image    *cloudMedia `json:"image,omitempty"`
+					Video    *cloudMedia `json:"video,omitempty"`
+					Audio    *cloudMedia `json:"audio,omitempty"`
+					Voice    *cloudMedia `json:"voice,omitempty"`
+					Document *cloudMedia `json:"document,omitempty"`
+				} `json:"messages"`
+				Statuses []struct {
+					ID          string `json:"id"`
+					Status      string `json:"status"`
+					Timestamp   string `json:"timestamp"`
+					RecipientID string `json:"recipient_id"`
+					Errors      []struct {
+						Code      int    `json:"code"`
+						Title     string `json:"title"`
+						Message   string `json:"message"`
+						ErrorData struct {
+							Details string `json:"details"`
+						} `json:"error_data"`
+					} `json:"errors"`
+				} `json:"statuses"`
+			} `json:"value"`
+		} `json:"changes"`
+	} `json:"entry"`
+}
+
+func (p *cloudAPIProvider) Name() models.WhatsAppProviderType {
+	return models.WhatsAppProviderCloudAPI
+}
+
+func (p *cloudAPIProvider) headers() map[string]string {
+	return map[string]string{"Authorization": "Bearer " + p.accessToken}
+}
+
+// phoneURL monta a URL de um recurso do número (phone_number_id)
+func (p *cloudAPIProvider) phoneURL(chat *models.Chat, path string) string {
+	if path == "" {
+		return fmt.Sprintf("%s/%s", p.apiURL, url.PathEscape(chat.InstanceName))
+	}
+	return fmt.Sprintf("%s/%s/%s", p.apiURL, url.PathEscape(chat.InstanceName), path)
+}
+
+// StartSession não se aplica: o número já é registrado no WhatsApp Business da Meta
+func (p *cloudAPIProvider) StartSession(ctx context.Context, chat *models.Chat) (*StartSessionResponse, error) {
+	if p.accessToken == "" {
+		return nil, fmt.Errorf("%w: WHATSAPP_CLOUD_ACCESS_TOKEN não informado", ErrWhatsAppProviderNotConfigured)
+	}
+	return &StartSessionResponse{Status: "ok", Message: "A Cloud API não usa sessão: o webhook é configurado no app da Meta"}, nil
+}
+
+// GetQRCode não se aplica à Cloud API
+func (p *cloudAPIProvider) GetQRCode(ctx context.Context, chat *models.Chat) (*QRCodeResponse, error) {
+	return nil, fmt.Errorf("%w: a Cloud API não usa QR Code", ErrWhatsAppNotSupported)
+}
+
+// GetSessionState consulta o número no Graph: respondendo, o chat está apto a enviar
+func (p *cloudAPIProvider) GetSessionState(ctx context.Context, chat *models.Chat) (*dto.SessionStatusDTO, error) {
+	info, status, err := p.phoneNumber(ctx, chat.InstanceName)
+	if status == http.StatusUnauthorized || status == http.StatusForbidden || status == http.StatusNotFound {
+		return &dto.SessionStatusDTO{Status: SessionStatusError, Message: "Número ou token da Cloud API inválido"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SessionStatusDTO{
+		Status:    SessionStatusConnected,
+		Connected: true,
+		Message:   fmt.Sprintf("%s (%s), qualidade: %s", info.VerifiedName, info.DisplayPhoneNumber, info.QualityRating),
+	}, nil
+}
+
+// GetPhoneNumber consulta os dados do número (phone_number_id) no Graph
+func (p *cloudAPIProvider) GetPhoneNumber(ctx context.Context, phoneNumberID string) (*WhatsAppPhoneNumberInfo, error) {
+	info, _, err := p.phoneNumber(ctx, phoneNumberID)
+	return info, err
+}
+
+func (p *cloudAPIProvider) phoneNumber(ctx context.Context, phoneNumberID string) (*WhatsAppPhoneNumberInfo, int, error) {
+	if p.accessToken == "" {
+		return nil, 0, fmt.Errorf("%w: WHATSAPP_CLOUD_ACCESS_TOKEN não informado", ErrWhatsAppProviderNotConfigured)
+	}
+
+	var info WhatsAppPhoneNumberInfo
+	endpoint := fmt.Sprintf("%s/%s?fields=id,display_phone_number,verified_name,quality_rating", p.apiURL, url.PathEscape(phoneNumberID))
+	status, err := whatsAppRequest(ctx, p.client, http.MethodGet, endpoint, p.headers(), nil, &info)
+	if err != nil {
+		return nil, status, err
+	}
+	return &info, status, nil
+}
+
+// cloudTemplatesMaxPages limita a paginação da listagem de templates (100 por página)
+const cloudTemplatesMaxPages = 50
+
+// ListMessageTemplates lista os templates da WABA seguindo a paginação do Graph
+func (p *cloudAPIProvider) ListMessageTemplates(ctx context.Context, businessAccountID string) ([]WhatsAppTemplateInfo, error) {
+	if p.accessToken == "" {
+		return nil, fmt.Errorf("%w: WHATSAPP_CLOUD_ACCESS_TOKEN não informado", ErrWhatsAppProviderNotConfigured)
+	}
+
+	next := fmt.Sprintf("%s/%s/message_templates?fields=id,name,language,status,category,parameter_format,components&limit=100",
+		p.apiURL, url.PathEscape(businessAccountID))
+
+	var templates []WhatsAppTemplateInfo
+	for page := 0; next != "" && page < cloudTemplatesMaxPages; page++ {
+		var res struct {
+			Data   []WhatsAppTemplateInfo `json:"data"`
+			Paging struct {
+				Next string `json:"next"`
+			} `json:"paging"`
+		}
+		if _, err := whatsAppRequest(ctx, p.client, http.MethodGet, next, p.headers(), nil, &res); err != nil {
+			return nil, fmt.Errorf("erro ao listar templates da WABA %s: %w", businessAccountID, err)
+		}
+		templates = append(templates, res.Data...)
+		next = res.Paging.Next
+	}
+	return templates, nil
+}
+
+// SendText envia uma mensagem de texto (fora da janela de 24h a Meta exige template aprovado)
+func (p *cloudAPIProvider) SendText(ctx context.Context, chat *models.Chat, to, text string) (*WhatsAppSendResult, error) {
+	payload := map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"recipient_type":    "individual",
+		"to":                utils.GetWhatsAppOnlyNumber(to),
+		"type":              "text",
+		"text":              map[string]interface{}{"body": text, "preview_url": false},
+	}
+	return p.send(ctx, chat, payload)
+}
+
+// SendMedia envia uma mídia pela URL pública do arquivo
+func (p *cloudAPIProvider) SendMedia(ctx context.Context, chat *models.Chat, to string, media WhatsAppMedia) (*WhatsAppSendResult, error) {
+	object := map[string]string{"link": media.URL}
+	if media.Caption != "" && media.Type != dto.WhatsAppInboundAudio {
+		object["caption"] = media.Caption
+	}
+	if media.Type == dto.WhatsAppInboundDocument && media.FileName != "" {
+		object["filename"] = media.FileName
+	}
+
+	payload := map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"recipient_type":    "individual",
+		"to":                utils.GetWhatsAppOnlyNumber(to),
+		"type":              media.Type,
+		media.Type:          object,
+	}
+	return p.send(ctx, chat, payload)
+}
+
+// SendTemplate envia um template aprovado (HSM), a única forma de iniciar conversa fora da janela de 24h
+func (p *cloudAPIProvider) SendTemplate(ctx context.Context, chat *models.Chat, to string, message WhatsAppTemplateMessage) (*WhatsAppSendResult, error) {
+	payload := map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"recipient_type":    "individual",
+		"to":                utils.GetWhatsAppOnlyNumber(to),
+		"type":              "template",
+		"template":          message,
+	}
+	return p.send(ctx, chat, payload)
+}
+
+func (p *cloudAPIProvider) send(ctx context.Context, chat *models.Chat, payload any) (*WhatsAppSendResult, error) {
+	var res struct {
+		Messages []struct {
+			ID            string `json:"id"`
+			MessageStatus string `json:"message_status"`
+		} `json:"messages"`
+	}
+	if _, err := whatsAppRequest(ctx, p.client, http.MethodPost, p.phoneURL(chat, "messages"), p.headers(), payload, &res); err != nil {
+		return nil, classifyCloudAPIError(err)
+	}
+	if len(res.Messages) == 0 {
+		return nil, fmt.Errorf("resposta da Cloud API sem ID da mensagem")
+	}
+	return &WhatsAppSendResult{MessageID: res.Messages[0].ID, Status: res.Messages[0].MessageStatus}, nil
+}
+
+// ResolveNumber não consulta a Meta (não há endpoint de verificação): o número é aceito e a entrega
+// é confirmada pelo webhook de status
+func (p *cloudAPIProvider) ResolveNumber(ctx context.Context, chat *models.Chat, normalizedNumber string) (*dto.ResolveNumberResponse, error) {
+	number := utils.GetWhatsAppOnlyNumber(normalizedNumber)
+	return &dto.ResolveNumberResponse{
+		Found:          number != "",
+		Input:          normalizedNumber,
+		ResolvedNumber: number,
+		RegisteredJID:  whatsAppJID(number),
+	}, nil
+}
+
+// DownloadMedia consulta a URL temporária da mídia pelo ID e baixa o arquivo com o token da aplicação
+func (p *cloudAPIProvider) DownloadMedia(ctx context.Context, chat *models.Chat, message *dto.WhatsAppInboundMessage, maxBytes int64) (*WhatsAppMediaContent, error) {
+	if message.MediaID == "" {
+		return nil, fmt.Errorf("mensagem %s sem ID de mídia", message.MessageID)
+	}
+
+	var media struct {
+		URL      string `json:"url"`
+		MimeType string `json:"mime_type"`
+		FileSize int64  `json:"file_size"`
+	}
+	endpoint := fmt.Sprintf("%s/%s", p.apiURL, url.PathEscape(message.MediaID))
+	if _, err := whatsAppRequest(ctx, p.client, http.MethodGet, endpoint, p.headers(), nil, &media); err != nil {
+		return nil, err
+	}
+	if media.URL == "" {
+		return nil, fmt.Errorf("mídia %s sem URL de download", message.MediaID)
+	}
+	if media.FileSize > maxBytes {
+		return nil, fmt.Errorf("%w: a mídia excede o limite de %d MB", ErrInvalidChatMedia, maxBytes>>20)
+	}
+
+	content, contentType, err := whatsAppDownload(ctx, media.URL, p.headers(), maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &WhatsAppMediaContent{
+		Content:  content,
+		MimeType: firstNonEmpty(message.MimeType, media.MimeType, contentType),
+		FileName: message.FileName,
+	}, nil
+}
+
+// VerifyWebhook confere a assinatura HMAC-SHA256 do corpo com o app secret. Sem app secret todos os
+// webhooks são recusados: status forjados alterariam a audiência das campanhas.
+func (p *cloudAPIProvider) VerifyWebhook(header http.Header, body []byte) error {
+	if p.appSecret == "" {
+		return fmt.Errorf("%w: WHATSAPP_CLOUD_APP_SECRET não informado", ErrInvalidWhatsAppWebhook)
+	}
+
+	signature := strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+	expected, err := hex.DecodeString(signature)
+	if err != nil || signature == "" {
+		return fmt.Errorf("%w: assinatura ausente ou malformada", ErrInvalidWhatsAppWebhook)
+	}
+
+	mac := hmac.New(sha256.New, []byte(p.appSecret))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return fmt.Errorf("%w: assinatura não confere", ErrInvalidWhatsAppWebhook)
+	}
+	return nil
+}
+
+// cloudRetryableErrors são os códigos do Graph que mudam com uma nova tentativa (limites de taxa e indisponibilidade)
+var cloudRetryableErrors = map[int]bool{
+	1: true, 2: true, 4: true, 80007: true, 130429: true, 131000: true, 131016: true, 131048: true, 131056: true, 133004: true,
+}
+
+// classifyCloudAPIError marca como permanentes as rejeições do envio (parâmetros inválidos, template pausado,
+// número inválido...): a Meta responde 400 também para limites de taxa, então o código do erro decide
+func classifyCloudAPIError(err error) error {
+	var apiErr *whatsAppAPIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		return err
+	}
+
+	var body struct {
+		Error struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	if json.Unmarshal([]byte(apiErr.Body), &body) == nil && cloudRetryableErrors[body.Error.Code] {
+		return err
+	}
+	return &PermanentError{Err: err}
+}
+
+// ParseWebhook converte as mensagens recebidas; os status das mensagens enviadas são lidos por ParseStatuses
+func (p *cloudAPIProvider) ParseWebhook(body []byte) ([]dto.WhatsAppInboundMessage, error) {
+	var payload cloudWebhook
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWhatsAppWebhook, err)
+	}
+	if payload.Object != "whatsapp_business_account" {
+		return nil, fmt.Errorf("%w: objeto %q não suportado", ErrInvalidWhatsAppWebhook, payload.Object)
+	}
+
+	var inbound []dto.WhatsAppInboundMessage
+	for _, entry := range payload.Entry {
+		for _, change := range entry.Changes {
+			if change.Field != "messages" {
+				continue
+			}
+
+			names := make(map[string]string, len(change.Value.Contacts))
+			for _, contact := range change.Value.Contacts {
+				names[contact.WaID] = contact.Profile.Name
+			}
+
+			for _, message := range change.Value.Messages {
+				timestamp, _ := strconv.ParseInt(message.Timestamp, 10, 64)
+				item := dto.WhatsAppInboundMessage{
+					Provider:  string(models.WhatsAppProviderCloudAPI),
+					SessionID: change.Value.Metadata.PhoneNumberID,
+					From:      whatsAppJID(message.From),
+					Phone:     message.From,
+					Timestamp: timestamp,
+					Type:      message.Type,
+					MessageID: message.ID,
+					PushName:  names[message.From],
+				}
+
+				var media *cloudMedia
+				switch {
+				case message.Text != nil:
+					item.Type, item.Message = dto.WhatsAppInboundText, message.Text.Body
+				case message.Image != nil:
+					item.Type, media = dto.WhatsAppInboundImage, message.Image
+				case message.Video != nil:
+					item.Type, media = dto.WhatsAppInboundVideo, message.Video
+				case message.Audio != nil:
+					item.Type, media = dto.WhatsAppInboundAudio, message.Audio
+				case message.Voice != nil:
+					item.Type, media = dto.WhatsAppInboundAudio, message.Voice
+				case message.Document != nil:
+					item.Type, media = dto.WhatsAppInboundDocument, message.Document
+				}
+				if media != nil {
+					item.Message, item.MediaID, item.MimeType, item.FileName = media.Caption, media.ID, media.MimeType, media.FileName
+				}
+
+				inbound = append(inbound, item)
+			}
+		}
+	}
+	return inbound, nil
+}
+
+// ParseStatuses converte os status (sent, delivered, read, failed) das mensagens enviadas pelo número
+func (p *cloudAPIProvider) ParseStatuses(body []byte) ([]dto.WhatsAppStatusEvent, error) {
+	var payload cloudWebhook
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWhatsAppWebhook, err)
+	}
+	if payload.Object != "whatsapp_business_account" {
+		return nil, fmt.Errorf("%w: objeto %q não suportado", ErrInvalidWhatsAppWebhook, payload.Object)
+	}
+
+	var events []dto.WhatsAppStatusEvent
+	for _, entry := range payload.Entry {
+		for _, change := range entry.Changes {
+			if change.Field != "messages" {
+				continue
+			}
+
+			for _, status := range change.Value.Statuses {
+				timestamp, _ := strconv.ParseInt(status.Timestamp, 10, 64)
+				event := dto.WhatsAppStatusEvent{
+					Provider:  string(models.WhatsAppProviderCloudAPI),
+					SessionID: change.Value.Metadata.PhoneNumberID,
+					MessageID: status.ID,
+					Status:    status.Status,
+					Recipient: status.RecipientID,
+					Timestamp: timestamp,
+				}
+				for _, statusErr := range status.Errors {
+					event.Errors = append(event.Errors, dto.WhatsAppStatusError{
+						Code:    statusErr.Code,
+						Title:   statusErr.Title,
+						Message: statusErr.Message,
+						Details: statusErr.ErrorData.Details,
+					})
+				}
+				events = append(events, event)
+			}
+		}
+	}
+	return events, nil
+}
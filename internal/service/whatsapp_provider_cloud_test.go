@@ -0,0 +1,337 @@
+// File: /internal/service/whatsapp_provider_cloud_test.go
+
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+const testCloudToken = "token-graph"
+
+// fakeGraph simula a Graph API: templates paginados da WABA, dados do número e envio de mensagens
+type fakeGraph struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	sent      []map[string]interface{}
+	sendError string // Corpo de erro devolvido com 400 no envio
+}
+
+func newFakeGraph(t *testing.T) *fakeGraph {
+	t.Helper()
+
+	graph := &fakeGraph{}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /waba-1/message_templates", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("after") == "" {
+			fmt.Fprintf(w, `{"data":[%s,%s],"paging":{"next":"%s/waba-1/message_templates?after=2"}}`,
+				promoTemplateJSON, `{"id":"3","name":"quebrado","language":"pt_BR","status":"APPROVED","components":{"type":"BODY"}}`, graph.URL)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"id":"2","name":"boas_vindas","language":"pt_BR","status":"pending","category":"UTILITY","parameter_format":"named",
+			"components":[{"type":"BODY","text":"Oi {{nome}}!","example":{"body_text_named_params":[{"param_name":"nome","example":"Ana"}]}}]}],"paging":{}}`)
+	})
+
+	mux.HandleFunc("GET /phone-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"phone-1","display_phone_number":"+55 11 4000-0000","verified_name":"Loja Exemplo","quality_rating":"GREEN"}`)
+	})
+
+	mux.HandleFunc("POST /phone-1/messages", func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		graph.mu.Lock()
+		defer graph.mu.Unlock()
+		if graph.sendError != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, graph.sendError)
+			return
+		}
+		graph.sent = append(graph.sent, payload)
+		fmt.Fprintf(w, `{"messaging_product":"whatsapp","messages":[{"id":"wamid.%d","message_status":"accepted"}]}`, len(graph.sent))
+	})
+
+	graph.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testCloudToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"error":{"message":"Invalid OAuth access token","code":190}}`)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(graph.Close)
+	return graph
+}
+
+// promoTemplateJSON tem cabeçalho de imagem, corpo com duas variáveis e botão de URL dinâmica
+const promoTemplateJSON = `{"id":"1","name":"promo_inverno","language":"pt_BR","status":"APPROVED","category":"MARKETING",
+	"components":[
+		{"type":"HEADER","format":"IMAGE","example":{"header_handle":["https://cdn.example.com/exemplo.jpg"]}},
+		{"type":"BODY","text":"Olá {{1}}! {{2}}","example":{"body_text":[["Maria","Até 50% de desconto"]]}},
+		{"type":"FOOTER","text":"Loja Exemplo"},
+		{"type":"BUTTONS","buttons":[{"type":"QUICK_REPLY","text":"Parar"},{"type":"URL","text":"Ver","url":"https://loja.example.com/{{1}}","example":["inverno"]}]}
+	]}`
+
+// templateNumbersRepo guarda o número cadastrado e a última atualização
+type templateNumbersRepo struct {
+	db.WhatsAppPhoneNumberRepository
+
+	updated *models.WhatsAppPhoneNumber
+}
+
+func (r *templateNumbersRepo) Update(ctx context.Context, number *models.WhatsAppPhoneNumber) (*models.WhatsAppPhoneNumber, error) {
+	r.updated = number
+	return number, nil
+}
+
+// templatesRepo devolve os templates recebidos na sincronização
+type templatesRepo struct {
+	db.WhatsAppTemplateRepository
+
+	synced []models.WhatsAppTemplate
+}
+
+func (r *templatesRepo) Sync(ctx context.Context, accountID uuid.UUID, businessAccountID string, templates []models.WhatsAppTemplate) ([]models.WhatsAppTemplate, error) {
+	for i := range templates {
+		templates[i].AccountID, templates[i].BusinessAccountID = accountID, businessAccountID
+	}
+	r.synced = templates
+	return templates, nil
+}
+
+func TestCloudAPISyncTemplates(t *testing.T) {
+	graph := newFakeGraph(t)
+	numbers, templates := &templateNumbersRepo{}, &templatesRepo{}
+	svc := NewWhatsAppTemplateService(NewWhatsAppProviders(NewCloudAPIProvider(graph.URL, testCloudToken, "")), numbers, templates)
+
+	number := &models.WhatsAppPhoneNumber{ID: uuid.New(), AccountID: uuid.New(), PhoneNumberID: "phone-1", BusinessAccountID: "waba-1"}
+	updated, synced, err := svc.SyncTemplates(context.Background(), number)
+	if err != nil {
+		t.Fatalf("SyncTemplates: %v", err)
+	}
+
+	// 📄 Duas páginas; o template com componentes inválidos é ignorado
+	if len(synced) != 2 || synced[0].Name != "promo_inverno" || synced[1].Name != "boas_vindas" {
+		t.Fatalf("templates sincronizados = %+v", synced)
+	}
+
+	promo := synced[0]
+	if !promo.Approved() || promo.ParameterFormat != models.WhatsAppTemplatePositional || promo.AccountID != number.AccountID {
+		t.Fatalf("promo_inverno = %+v", promo)
+	}
+	wantSlots := []string{"header.media", "body.1", "body.2", "button.1.1"}
+	if len(promo.Slots) != len(wantSlots) {
+		t.Fatalf("variáveis = %+v, esperado %v", promo.Slots, wantSlots)
+	}
+	for i, key := range wantSlots {
+		if promo.Slots[i].Key != key {
+			t.Fatalf("variável %d = %s, esperado %s", i, promo.Slots[i].Key, key)
+		}
+	}
+	if promo.Slots[0].Type != "image" || promo.Slots[1].Example != "Maria" || promo.Slots[3].ButtonType != "url" || promo.Slots[3].Example != "inverno" {
+		t.Fatalf("variáveis = %+v", promo.Slots)
+	}
+
+	welcome := synced[1]
+	if welcome.Status != "PENDING" || welcome.Approved() || welcome.ParameterFormat != models.WhatsAppTemplateNamed {
+		t.Fatalf("boas_vindas = %+v", welcome)
+	}
+	if len(welcome.Slots) != 1 || welcome.Slots[0].Key != "body.nome" || welcome.Slots[0].Example != "Ana" {
+		t.Fatalf("variáveis de boas_vindas = %+v", welcome.Slots)
+	}
+
+	if updated != numbers.updated || updated.TemplatesSyncedAt == nil || updated.VerifiedName == nil || *updated.VerifiedName != "Loja Exemplo" {
+		t.Fatalf("número atualizado = %+v", updated)
+	}
+}
+
+func TestCloudAPISyncTemplatesWithInvalidToken(t *testing.T) {
+	graph := newFakeGraph(t)
+	svc := NewWhatsAppTemplateService(NewWhatsAppProviders(NewCloudAPIProvider(graph.URL, "token-expirado", "")), &templateNumbersRepo{}, &templatesRepo{})
+
+	_, _, err := svc.SyncTemplates(context.Background(), &models.WhatsAppPhoneNumber{PhoneNumberID: "phone-1", BusinessAccountID: "waba-1"})
+	var apiErr *whatsAppAPIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("SyncTemplates = %v, esperado 401 do Graph", err)
+	}
+}
+
+func TestCloudAPISendTemplate(t *testing.T) {
+	graph := newFakeGraph(t)
+	provider := NewCloudAPIProvider(graph.URL, testCloudToken, "")
+
+	slots, err := ParseWhatsAppTemplateSlots(json.RawMessage(mustTemplateComponents(t, promoTemplateJSON)))
+	if err != nil {
+		t.Fatalf("ParseWhatsAppTemplateSlots: %v", err)
+	}
+	template := &models.WhatsAppTemplate{Name: "promo_inverno", Language: "pt_BR", ParameterFormat: models.WhatsAppTemplatePositional, Slots: slots}
+
+	params := map[string]string{
+		"header.media": "valor:https://cdn.example.com/inverno.jpg",
+		"body.1":       models.WhatsAppParamContactName,
+		"body.2":       models.WhatsAppParamCorpo,
+		"button.1.1":   "valor:inverno",
+	}
+	values := WhatsAppTemplateValues(&dto.CampaignContentResult{Corpo: "Até 50% de desconto\n\tem toda a loja"}, &models.Contact{Name: "Maria"}, &models.CampaignSettings{Brand: "Loja"})
+	message, err := BuildWhatsAppTemplateMessage(template, params, values)
+	if err != nil {
+		t.Fatalf("BuildWhatsAppTemplateMessage: %v", err)
+	}
+
+	result, err := provider.SendTemplate(context.Background(), &models.Chat{InstanceName: "phone-1"}, "5511999990000@s.whatsapp.net", *message)
+	if err != nil {
+		t.Fatalf("SendTemplate: %v", err)
+	}
+	if result.MessageID != "wamid.1" {
+		t.Fatalf("MessageID = %q, esperado wamid.1", result.MessageID)
+	}
+
+	if len(graph.sent) != 1 {
+		t.Fatalf("mensagens recebidas pelo Graph = %d, esperado 1", len(graph.sent))
+	}
+	got, _ := json.Marshal(graph.sent[0])
+	want := `{"messaging_product":"whatsapp","recipient_type":"individual","template":{"components":[` +
+		`{"parameters":[{"image":{"link":"https://cdn.example.com/inverno.jpg"},"type":"image"}],"type":"header"},` +
+		`{"parameters":[{"text":"Maria","type":"text"},{"text":"Até 50% de desconto em toda a loja","type":"text"}],"type":"body"},` +
+		`{"index":"1","parameters":[{"text":"inverno","type":"text"}],"sub_type":"url","type":"button"}],` +
+		`"language":{"code":"pt_BR"},"name":"promo_inverno"},"to":"5511999990000","type":"template"}`
+	if string(got) != want {
+		t.Fatalf("payload enviado =\n%s\nesperado\n%s", got, want)
+	}
+}
+
+// mustTemplateComponents extrai os componentes do template no formato do Graph
+func mustTemplateComponents(t *testing.T, templateJSON string) []byte {
+	t.Helper()
+	var info WhatsAppTemplateInfo
+	if err := json.Unmarshal([]byte(templateJSON), &info); err != nil {
+		t.Fatalf("template inválido: %v", err)
+	}
+	return info.Components
+}
+
+func TestCloudAPISendErrorClassification(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		wantRetryable bool
+	}{
+		{name: "limite de taxa", body: `{"error":{"message":"Rate limit hit","code":130429}}`, wantRetryable: true},
+		{name: "erro temporário da Meta", body: `{"error":{"message":"Something went wrong","code":131000}}`, wantRetryable: true},
+		{name: "template inexistente", body: `{"error":{"message":"Template name does not exist in the translation","code":132001}}`},
+		{name: "parâmetros inválidos", body: `{"error":{"message":"Number of parameters does not match","code":132000}}`},
+		{name: "resposta sem código", body: `bad request`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := newFakeGraph(t)
+			graph.sendError = tt.body
+			provider := NewCloudAPIProvider(graph.URL, testCloudToken, "")
+
+			_, err := provider.SendTemplate(context.Background(), &models.Chat{InstanceName: "phone-1"}, "5511999990000",
+				WhatsAppTemplateMessage{Name: "promo_inverno", Language: WhatsAppTemplateLanguage{Code: "pt_BR"}})
+			if err == nil {
+				t.Fatal("erro do Graph não retornado")
+			}
+			if got := IsRetryableError(err); got != tt.wantRetryable {
+				t.Fatalf("IsRetryableError = %v, esperado %v (%v)", got, tt.wantRetryable, err)
+			}
+		})
+	}
+}
+
+func TestCloudAPIParseStatuses(t *testing.T) {
+	provider := NewCloudAPIProvider("", testCloudToken, "")
+	body := `{"object":"whatsapp_business_account","entry":[{"id":"waba-1","changes":[{"field":"messages","value":{
+		"messaging_product":"whatsapp","metadata":{"phone_number_id":"phone-1"},
+		"statuses":[
+			{"id":"wamid.1","status":"sent","timestamp":"1760616000","recipient_id":"5511999990000"},
+			{"id":"wamid.1","status":"delivered","timestamp":"1760616005","recipient_id":"5511999990000"},
+			{"id":"wamid.2","status":"failed","timestamp":"1760616010","recipient_id":"5511888880000",
+			 "errors":[{"code":131026,"title":"Message undeliverable","message":"Message undeliverable","error_data":{"details":"Receiver is incapable of receiving this message"}}]}
+		]}}]}]}`
+
+	events, err := provider.ParseStatuses([]byte(body))
+	if err != nil {
+		t.Fatalf("ParseStatuses: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("eventos = %d, esperado 3", len(events))
+	}
+
+	first := events[0]
+	if first.Provider != string(models.WhatsAppProviderCloudAPI) || first.SessionID != "phone-1" || first.MessageID != "wamid.1" ||
+		first.Status != dto.WhatsAppStatusSent || first.Recipient != "5511999990000" || first.Timestamp != 1760616000 {
+		t.Fatalf("evento = %+v", first)
+	}
+
+	failed := events[2]
+	if failed.Status != dto.WhatsAppStatusFailed || len(failed.Errors) != 1 || failed.Errors[0].Code != 131026 ||
+		!strings.Contains(failed.Errors[0].Details, "incapable") {
+		t.Fatalf("evento de falha = %+v", failed)
+	}
+
+	if _, err := provider.ParseStatuses([]byte(`{"object":"page","entry":[]}`)); !errors.Is(err, ErrInvalidWhatsAppWebhook) {
+		t.Fatalf("objeto desconhecido = %v, esperado ErrInvalidWhatsAppWebhook", err)
+	}
+}
+
+func TestCloudAPIVerifyWebhook(t *testing.T) {
+	body := []byte(`{"object":"whatsapp_business_account","entry":[]}`)
+	mac := hmac.New(sha256.New, []byte("app-secret"))
+	mac.Write(body)
+	valid := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name      string
+		appSecret string
+		signature string
+		wantErr   bool
+	}{
+		{name: "assinatura válida", appSecret: "app-secret", signature: valid},
+		{name: "assinatura de outro app", appSecret: "outro-secret", signature: valid, wantErr: true},
+		{name: "sem assinatura", appSecret: "app-secret", wantErr: true},
+		{name: "assinatura malformada", appSecret: "app-secret", signature: "sha256=zz", wantErr: true},
+		{name: "sem app secret recusa tudo", signature: valid, wantErr: true},
+		{name: "sem app secret e sem assinatura", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.signature != "" {
+				header.Set("X-Hub-Signature-256", tt.signature)
+			}
+
+			err := NewCloudAPIProvider("", testCloudToken, tt.appSecret).VerifyWebhook(header, body)
+			if tt.wantErr && !errors.Is(err, ErrInvalidWhatsAppWebhook) {
+				t.Fatalf("VerifyWebhook = %v, esperado ErrInvalidWhatsAppWebhook", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("VerifyWebhook: %v", err)
+			}
+		})
+	}
+}
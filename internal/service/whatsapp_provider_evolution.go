@@ -0,0 +1,332 @@
+// File: /internal/service/whatsapp_provider_evolution.go
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
+)
+
+// evolutionProvider fala com a Evolution API v2 (uma instância por chat, identificada pelo instance_name)
+type evolutionProvider struct {
+	log    *slog.Logger
+	apiURL string
+	apiKey string
+	client *http.Client
+}
+
+// NewEvolutionProvider cria o provedor da Evolution API v2
+func NewEvolutionProvider(apiURL, apiKey string) WhatsAppProvider {
+	return &evolutionProvider{
+		log:    logger.GetLogger(),
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		apiKey: apiKey,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// evolutionWebhookEvents são os eventos assinados ao criar a instância
+var evolutionWebhookEvents = []string{"MESSAGES_UPSERT", "CONNECTION_UPDATE"}
+
+// evolutionKey identifica a mensagem na Evolution API
+type evolutionKey struct {
+	RemoteJID   string `json:"remoteJid"`
+	FromMe      bool   `json:"fromMe"`
+	ID          string `json:"id"`
+	Participant string `json:"participant,omitempty"`
+}
+
+// evolutionMediaMessage cobre os campos comuns das mensagens de mídia
+type evolutionMediaMessage struct {
+	URL      string `json:"url,omitempty"`
+	MimeType string `json:"mimetype,omitempty"`
+	Caption  string `json:"caption,omitempty"`
+	FileName string `json:"fileName,omitempty"`
+}
+
+// evolutionMessage é o evento messages.upsert enviado ao webhook
+type evolutionMessage struct {
+	Key         evolutionKey `json:"key"`
+	PushName    string       `json:"pushName"`
+	MessageType string       `json:"messageType"`
+	Timestamp   int64        `json:"messageTimestamp"`
+	Message     struct {
+		Conversation        string `json:"conversation,omitempty"`
+		ExtendedTextMessage *struct {
+			Text string `json:"text"`
+		} `json:"extendedTextMessage,omitempty"`
+		ImageMessage    *evolutionMediaMessage `json:"imageMessage,omitempty"`
+		VideoMessage    *evolutionMediaMessage `json:"videoMessage,omitempty"`
+		AudioMessage    *evolutionMediaMessage `json:"audioMessage,omitempty"`
+		DocumentMessage *evolutionMediaMessage `json:"documentMessage,omitempty"`
+	} `json:"message"`
+}
+
+// evolutionWebhook é o envelope dos eventos da Evolution API
+type evolutionWebhook struct {
+	Event    string          `json:"event"`
+	Instance string          `json:"instance"`
+	Data     json.RawMessage `json:"data"`
+}
+
+func (p *evolutionProvider) Name() models.WhatsAppProviderType {
+	return models.WhatsAppProviderEvolution
+}
+
+func (p *evolutionProvider) headers() map[string]string {
+	return map[string]string{"apikey": p.apiKey}
+}
+
+// instanceURL monta a URL de uma operação da instância do chat
+func (p *evolutionProvider) instanceURL(path string, chat *models.Chat) string {
+	return fmt.Sprintf("%s/%s/%s", p.apiURL, path, url.PathEscape(chat.InstanceName))
+}
+
+// StartSession cria a instância com o webhook do chat; se ela já existir, apenas atualiza o webhook
+func (p *evolutionProvider) StartSession(ctx context.Context, chat *models.Chat) (*StartSessionResponse, error) {
+	webhook := map[string]interface{}{
+		"enabled":  true,
+		"url":      chat.WebhookURL,
+		"byEvents": false,
+		"base64":   false,
+		"events":   evolutionWebhookEvents,
+	}
+	create := map[string]interface{}{
+		"instanceName": chat.InstanceName,
+		"integration":  "WHATSAPP-BAILEYS",
+		"qrcode":       true,
+		"webhook":      webhook,
+	}
+
+	status, err := whatsAppRequest(ctx, p.client, http.MethodPost, p.apiURL+"/instance/create", p.headers(), create, nil)
+	if err == nil {
+		return &StartSessionResponse{Status: "created", Message: "Instância criada, leia o QR Code para conectar"}, nil
+	}
+	if status != http.StatusForbidden && status != http.StatusConflict {
+		return nil, err
+	}
+
+	// 🔁 Instância já existente (nome em uso): mantém a sessão e garante o webhook
+	p.log.Debug("Instância já existe na Evolution API, atualizando webhook", slog.String("instance", chat.InstanceName))
+	if _, err := whatsAppRequest(ctx, p.client, http.MethodPost, p.instanceURL("webhook/set", chat), p.headers(),
+		map[string]interface{}{"webhook": webhook}, nil); err != nil {
+		return nil, err
+	}
+	return &StartSessionResponse{Status: "started", Message: "Instância existente, webhook atualizado"}, nil
+}
+
+// GetQRCode pede a conexão da instância e retorna o QR Code em base64
+func (p *evolutionProvider) GetQRCode(ctx context.Context, chat *models.Chat) (*QRCodeResponse, error) {
+	var res struct {
+		Base64 string `json:"base64"`
+		Code   string `json:"code"`
+	}
+	if _, err := whatsAppRequest(ctx, p.client, http.MethodGet, p.instanceURL("instance/connect", chat), p.headers(), nil, &res); err != nil {
+		return nil, err
+	}
+	if res.Base64 == "" {
+		return &QRCodeResponse{Status: SessionStatusConnected}, nil // Instância já conectada
+	}
+	return &QRCodeResponse{QRCode: res.Base64, Status: SessionStatusWaitingQR}, nil
+}
+
+// GetSessionState converte o estado da instância (open, connecting, close) para o status do chat
+func (p *evolutionProvider) GetSessionState(ctx context.Context, chat *models.Chat) (*dto.SessionStatusDTO, error) {
+	var res struct {
+		Instance struct {
+			State string `json:"state"`
+		} `json:"instance"`
+	}
+	status, err := whatsAppRequest(ctx, p.client, http.MethodGet, p.instanceURL("instance/connectionState", chat), p.headers(), nil, &res)
+	if status == http.StatusNotFound {
+		return &dto.SessionStatusDTO{Status: SessionStatusDisconnected, Message: "Instância não encontrada na Evolution API"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.Instance.State {
+	case "open":
+		return &dto.SessionStatusDTO{Status: SessionStatusConnected, Connected: true, Message: "Sessão conectada"}, nil
+	case "connecting":
+		return &dto.SessionStatusDTO{Status: SessionStatusWaitingQR, QRCodeAvailable: true, Message: "Aguardando leitura do QR Code"}, nil
+	case "close":
+		return &dto.SessionStatusDTO{Status: SessionStatusDisconnected, Message: "Sessão desconectada"}, nil
+	default:
+		return &dto.SessionStatusDTO{Status: SessionStatusUnknown, Message: "Estado da instância: " + res.Instance.State}, nil
+	}
+}
+
+// SendText envia uma mensagem de texto
+func (p *evolutionProvider) SendText(ctx context.Context, chat *models.Chat, to, text string) (*WhatsAppSendResult, error) {
+	payload := map[string]string{"number": utils.GetWhatsAppOnlyNumber(to), "text": text}
+	return p.send(ctx, chat, "message/sendText", payload)
+}
+
+// SendMedia envia uma mídia pela URL do arquivo
+func (p *evolutionProvider) SendMedia(ctx context.Context, chat *models.Chat, to string, media WhatsAppMedia) (*WhatsAppSendResult, error) {
+	payload := map[string]string{
+		"number":    utils.GetWhatsAppOnlyNumber(to),
+		"mediatype": media.Type,
+		"mimetype":  media.MimeType,
+		"media":     media.URL,
+		"fileName":  media.FileName,
+		"caption":   media.Caption,
+	}
+	return p.send(ctx, chat, "message/sendMedia", payload)
+}
+
+func (p *evolutionProvider) send(ctx context.Context, chat *models.Chat, path string, payload any) (*WhatsAppSendResult, error) {
+	var res struct {
+		Key    evolutionKey `json:"key"`
+		Status string       `json:"status"`
+	}
+	if _, err := whatsAppRequest(ctx, p.client, http.MethodPost, p.instanceURL(path, chat), p.headers(), payload, &res); err != nil {
+		return nil, err
+	}
+	return &WhatsAppSendResult{MessageID: res.Key.ID, Status: res.Status}, nil
+}
+
+// ResolveNumber verifica se o número tem WhatsApp (a Evolution não retorna o perfil comercial nesta consulta)
+func (p *evolutionProvider) ResolveNumber(ctx context.Context, chat *models.Chat, normalizedNumber string) (*dto.ResolveNumberResponse, error) {
+	var res []struct {
+		Exists bool   `json:"exists"`
+		JID    string `json:"jid"`
+		Number string `json:"number"`
+	}
+	payload := map[string][]string{"numbers": {normalizedNumber}}
+	if _, err := whatsAppRequest(ctx, p.client, http.MethodPost, p.instanceURL("chat/whatsappNumbers", chat), p.headers(), payload, &res); err != nil {
+		return nil, err
+	}
+
+	resolved := &dto.ResolveNumberResponse{Input: normalizedNumber}
+	if len(res) > 0 && res[0].Exists {
+		resolved.Found = true
+		resolved.RegisteredJID = res[0].JID
+		resolved.ResolvedNumber = utils.GetWhatsAppOnlyNumber(res[0].JID)
+	}
+	return resolved, nil
+}
+
+// DownloadMedia pede à Evolution a mídia já descriptografada (a URL do webhook aponta para o arquivo criptografado do WhatsApp)
+func (p *evolutionProvider) DownloadMedia(ctx context.Context, chat *models.Chat, message *dto.WhatsAppInboundMessage, maxBytes int64) (*WhatsAppMediaContent, error) {
+	payload := map[string]interface{}{
+		"message":      map[string]interface{}{"key": map[string]string{"id": message.MessageID}},
+		"convertToMp4": false,
+	}
+	var res struct {
+		Base64   string `json:"base64"`
+		MimeType string `json:"mimetype"`
+		FileName string `json:"fileName"`
+	}
+
+	client := &http.Client{Timeout: whatsAppMediaClient.Timeout}
+	if _, err := whatsAppRequestLimit(ctx, client, http.MethodPost, p.instanceURL("chat/getBase64FromMediaMessage", chat), p.headers(), payload, &res, maxBytes*4/3+(1<<20)); err != nil {
+		return nil, err
+	}
+
+	content, err := base64.StdEncoding.DecodeString(res.Base64)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao decodificar mídia: %w", err)
+	}
+	if int64(len(content)) > maxBytes {
+		return nil, fmt.Errorf("%w: a mídia excede o limite de %d MB", ErrInvalidChatMedia, maxBytes>>20)
+	}
+	return &WhatsAppMediaContent{
+		Content:  content,
+		MimeType: firstNonEmpty(message.MimeType, res.MimeType),
+		FileName: firstNonEmpty(message.FileName, res.FileName),
+	}, nil
+}
+
+// VerifyWebhook confere a apikey enviada pela Evolution API no corpo do evento (quando presente)
+func (p *evolutionProvider) VerifyWebhook(header http.Header, body []byte) error {
+	var envelope struct {
+		APIKey string `json:"apikey"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWhatsAppWebhook, err)
+	}
+	if envelope.APIKey != "" && p.apiKey != "" && envelope.APIKey != p.apiKey {
+		return fmt.Errorf("%w: apikey não confere", ErrInvalidWhatsAppWebhook)
+	}
+	return nil
+}
+
+// ParseStatuses não se aplica: o webhook da instância assina apenas evolutionWebhookEvents (sem MESSAGES_UPDATE)
+func (p *evolutionProvider) ParseStatuses(body []byte) ([]dto.WhatsAppStatusEvent, error) {
+	return nil, nil
+}
+
+// ParseWebhook converte o evento messages.upsert; os demais eventos são ignorados
+func (p *evolutionProvider) ParseWebhook(body []byte) ([]dto.WhatsAppInboundMessage, error) {
+	var envelope evolutionWebhook
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWhatsAppWebhook, err)
+	}
+	if !strings.EqualFold(strings.ReplaceAll(envelope.Event, "_", "."), "messages.upsert") {
+		return nil, nil
+	}
+
+	// 🔹 A Evolution envia um objeto por mensagem, mas aceita lista em algumas versões
+	var messages []evolutionMessage
+	if err := json.Unmarshal(envelope.Data, &messages); err != nil {
+		var single evolutionMessage
+		if err := json.Unmarshal(envelope.Data, &single); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidWhatsAppWebhook, err)
+		}
+		messages = []evolutionMessage{single}
+	}
+
+	inbound := make([]dto.WhatsAppInboundMessage, 0, len(messages))
+	for _, message := range messages {
+		item := dto.WhatsAppInboundMessage{
+			Provider:    string(models.WhatsAppProviderEvolution),
+			SessionID:   envelope.Instance,
+			From:        message.Key.RemoteJID,
+			Phone:       utils.GetWhatsAppOnlyNumber(message.Key.RemoteJID),
+			Timestamp:   message.Timestamp,
+			MessageID:   message.Key.ID,
+			PushName:    message.PushName,
+			FromMe:      message.Key.FromMe,
+			IsGroup:     strings.HasSuffix(message.Key.RemoteJID, "@g.us"),
+			Participant: message.Key.Participant,
+			Type:        message.MessageType,
+		}
+
+		content := message.Message
+		var media *evolutionMediaMessage
+		switch {
+		case content.Conversation != "":
+			item.Type, item.Message = dto.WhatsAppInboundText, content.Conversation
+		case content.ExtendedTextMessage != nil:
+			item.Type, item.Message = dto.WhatsAppInboundText, content.ExtendedTextMessage.Text
+		case content.ImageMessage != nil:
+			item.Type, media = dto.WhatsAppInboundImage, content.ImageMessage
+		case content.VideoMessage != nil:
+			item.Type, media = dto.WhatsAppInboundVideo, content.VideoMessage
+		case content.AudioMessage != nil:
+			item.Type, media = dto.WhatsAppInboundAudio, content.AudioMessage
+		case content.DocumentMessage != nil:
+			item.Type, media = dto.WhatsAppInboundDocument, content.DocumentMessage
+		}
+		if media != nil {
+			item.Message, item.MediaURL, item.MimeType, item.FileName = media.Caption, media.URL, media.MimeType, media.FileName
+		}
+
+		inbound = append(inbound, item)
+	}
+	return inbound, nil
+}
@@ -0,0 +1,82 @@
+// File: /internal/service/whatsapp_status_service.go
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// WhatsAppStatusService aplica às audiências os status das mensagens enviadas pelo WhatsApp
+// (sent, delivered, read e failed do webhook da Cloud API)
+type WhatsAppStatusService interface {
+	Process(ctx context.Context, event dto.WhatsAppStatusEvent) error
+}
+
+type whatsAppStatusService struct {
+	log            *slog.Logger
+	audienceRepo   db.CampaignAudienceRepository
+	engagementRepo db.EngagementRepository
+}
+
+// NewWhatsAppStatusService cria o processamento dos status do WhatsApp
+func NewWhatsAppStatusService(audienceRepo db.CampaignAudienceRepository, engagementRepo db.EngagementRepository) WhatsAppStatusService {
+	return &whatsAppStatusService{
+		log:            logger.GetLogger(),
+		audienceRepo:   audienceRepo,
+		engagementRepo: engagementRepo,
+	}
+}
+
+// Process atualiza o status da audiência pelo message_id. A leitura também conta como entrega
+// e é registrada como abertura (mesma métrica dos e-mails).
+func (s *whatsAppStatusService) Process(ctx context.Context, event dto.WhatsAppStatusEvent) error {
+	status, feedback := mapWhatsAppStatusToAudience(event)
+	if status == "" {
+		s.log.Warn("⚠️ Status do WhatsApp ignorado", "status", event.Status, "message_id", event.MessageID)
+		return nil
+	}
+
+	updated, err := s.audienceRepo.AdvanceStatusByMessageID(ctx, event.MessageID, status, feedback)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar status no banco: %w", err)
+	}
+	if !updated {
+		// 💬 Mensagens do atendimento (chat) também geram status e não pertencem a campanhas
+		s.log.Debug("Status do WhatsApp sem audiência correspondente", "status", event.Status, "message_id", event.MessageID)
+		return nil
+	}
+
+	if event.Status == dto.WhatsAppStatusRead {
+		engagement := &models.EngagementEvent{Type: models.EngagementOpen, Source: models.EngagementSourceWhatsApp}
+		if _, err := s.engagementRepo.RecordByMessageID(ctx, event.MessageID, engagement); err != nil {
+			return fmt.Errorf("erro ao registrar leitura do WhatsApp: %w", err)
+		}
+	}
+
+	s.log.Info("✅ Status do WhatsApp atualizado", "message_id", event.MessageID, "event", event.Status, "status", status)
+	return nil
+}
+
+// mapWhatsAppStatusToAudience mapeia os status do WhatsApp para status de audiência
+func mapWhatsAppStatusToAudience(event dto.WhatsAppStatusEvent) (models.AudienceStatus, map[string]interface{}) {
+	switch event.Status {
+	case dto.WhatsAppStatusSent:
+		return models.AudienceEnviado, nil
+	case dto.WhatsAppStatusDelivered, dto.WhatsAppStatusRead:
+		return models.AudienceEntregue, nil
+	case dto.WhatsAppStatusFailed:
+		return models.AudienceRejeitado, map[string]interface{}{
+			"provider": event.Provider,
+			"status":   event.Status,
+			"errors":   event.Errors,
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,95 @@
+// File: /internal/service/whatsapp_status_service_test.go
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// statusAudienceRepo registra o último status aplicado a uma mensagem de campanha conhecida
+type statusAudienceRepo struct {
+	db.CampaignAudienceRepository
+
+	messageID string
+	status    models.AudienceStatus
+	feedback  map[string]interface{}
+}
+
+func (r *statusAudienceRepo) AdvanceStatusByMessageID(ctx context.Context, messageID string, status models.AudienceStatus, feedback map[string]interface{}) (bool, error) {
+	if messageID != r.messageID {
+		return false, nil
+	}
+	r.status, r.feedback = status, feedback
+	return true, nil
+}
+
+// statusEngagementRepo conta as aberturas registradas
+type statusEngagementRepo struct {
+	db.EngagementRepository
+
+	opens int
+}
+
+func (r *statusEngagementRepo) RecordByMessageID(ctx context.Context, messageID string, event *models.EngagementEvent) (bool, error) {
+	if event.Type == models.EngagementOpen && event.Source == models.EngagementSourceWhatsApp {
+		r.opens++
+	}
+	return true, nil
+}
+
+func TestWhatsAppStatusMapping(t *testing.T) {
+	tests := []struct {
+		name         string
+		event        dto.WhatsAppStatusEvent
+		wantStatus   models.AudienceStatus
+		wantFeedback bool
+		wantOpens    int
+	}{
+		{name: "sent vira enviado", event: dto.WhatsAppStatusEvent{MessageID: "wamid.1", Status: dto.WhatsAppStatusSent}, wantStatus: models.AudienceEnviado},
+		{name: "delivered vira entregue", event: dto.WhatsAppStatusEvent{MessageID: "wamid.1", Status: dto.WhatsAppStatusDelivered}, wantStatus: models.AudienceEntregue},
+		{name: "read vira entregue e abertura", event: dto.WhatsAppStatusEvent{MessageID: "wamid.1", Status: dto.WhatsAppStatusRead}, wantStatus: models.AudienceEntregue, wantOpens: 1},
+		{
+			name: "failed vira rejeitado com os erros",
+			event: dto.WhatsAppStatusEvent{
+				Provider: string(models.WhatsAppProviderCloudAPI), MessageID: "wamid.1", Status: dto.WhatsAppStatusFailed,
+				Errors: []dto.WhatsAppStatusError{{Code: 131026, Title: "Message undeliverable"}},
+			},
+			wantStatus:   models.AudienceRejeitado,
+			wantFeedback: true,
+		},
+		{name: "status desconhecido é ignorado", event: dto.WhatsAppStatusEvent{MessageID: "wamid.1", Status: "deleted"}},
+		{name: "mensagem do chat sem audiência", event: dto.WhatsAppStatusEvent{MessageID: "wamid.chat", Status: dto.WhatsAppStatusRead}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audiences, engagements := &statusAudienceRepo{messageID: "wamid.1"}, &statusEngagementRepo{}
+			svc := NewWhatsAppStatusService(audiences, engagements)
+
+			if err := svc.Process(context.Background(), tt.event); err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+
+			if audiences.status != tt.wantStatus {
+				t.Fatalf("status = %q, esperado %q", audiences.status, tt.wantStatus)
+			}
+			if (audiences.feedback != nil) != tt.wantFeedback {
+				t.Fatalf("feedback = %v, esperado feedback: %v", audiences.feedback, tt.wantFeedback)
+			}
+			if tt.wantFeedback {
+				errs, _ := audiences.feedback["errors"].([]dto.WhatsAppStatusError)
+				if len(errs) != 1 || errs[0].Code != 131026 {
+					t.Fatalf("feedback = %v, esperado os erros da Meta", audiences.feedback)
+				}
+			}
+			if engagements.opens != tt.wantOpens {
+				t.Fatalf("aberturas = %d, esperado %d", engagements.opens, tt.wantOpens)
+			}
+		})
+	}
+}
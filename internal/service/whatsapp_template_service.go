@@ -0,0 +1,435 @@
+// File: /internal/service/whatsapp_template_service.go
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// ErrWhatsAppTemplateInvalid indica template da Cloud API ausente, não aprovado ou com variáveis sem origem
+var ErrWhatsAppTemplateInvalid = errors.New("template do WhatsApp inválido")
+
+// WhatsAppTemplateService cadastra os números da Cloud API, sincroniza os templates (HSM) das WABAs
+// e resolve o template aprovado usado pelas campanhas
+type WhatsAppTemplateService interface {
+	// RegisterPhoneNumber confere o número no Graph e cadastra na conta
+	RegisterPhoneNumber(ctx context.Context, number *models.WhatsAppPhoneNumber) (*models.WhatsAppPhoneNumber, error)
+	// SyncTemplates baixa os templates da WABA do número e atualiza a cópia local
+	SyncTemplates(ctx context.Context, number *models.WhatsAppPhoneNumber) (*models.WhatsAppPhoneNumber, []models.WhatsAppTemplate, error)
+	// Resolve retorna o template aprovado das configurações, conferindo a WABA do chat e a origem de cada variável
+	Resolve(ctx context.Context, accountID uuid.UUID, chat *models.Chat, settings models.CampaignSettings) (*models.WhatsAppTemplate, error)
+}
+
+type whatsAppTemplateService struct {
+	log       *slog.Logger
+	providers WhatsAppProviders
+	numbers   db.WhatsAppPhoneNumberRepository
+	templates db.WhatsAppTemplateRepository
+}
+
+// NewWhatsAppTemplateService cria o serviço de templates da Cloud API
+func NewWhatsAppTemplateService(providers WhatsAppProviders, numbers db.WhatsAppPhoneNumberRepository, templates db.WhatsAppTemplateRepository) WhatsAppTemplateService {
+	return &whatsAppTemplateService{
+		log:       logger.GetLogger(),
+		providers: providers,
+		numbers:   numbers,
+		templates: templates,
+	}
+}
+
+// cloudProvider retorna o provedor da Cloud API registrado no servidor
+func (s *whatsAppTemplateService) cloudProvider() (WhatsAppTemplateProvider, error) {
+	provider, err := s.providers.Get(models.WhatsAppProviderCloudAPI)
+	if err != nil {
+		return nil, err
+	}
+	templateProvider, ok := provider.(WhatsAppTemplateProvider)
+	if !ok {
+		return nil, fmt.Errorf("%w: o provedor %s não envia templates", ErrWhatsAppNotSupported, provider.Name())
+	}
+	return templateProvider, nil
+}
+
+// RegisterPhoneNumber só cadastra números que o token da aplicação consegue acessar no Graph
+func (s *whatsAppTemplateService) RegisterPhoneNumber(ctx context.Context, number *models.WhatsAppPhoneNumber) (*models.WhatsAppPhoneNumber, error) {
+	provider, err := s.cloudProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := provider.GetPhoneNumber(ctx, number.PhoneNumberID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar o número %s no Graph: %w", number.PhoneNumberID, err)
+	}
+	applyPhoneNumberInfo(number, info)
+
+	saved, err := s.numbers.Create(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("📱 Número da Cloud API cadastrado", "account_id", saved.AccountID, "phone_number_id", saved.PhoneNumberID, "verified_name", info.VerifiedName)
+	return saved, nil
+}
+
+// SyncTemplates grava todos os templates da WABA (com o status da Meta) e as variáveis de cada um
+func (s *whatsAppTemplateService) SyncTemplates(ctx context.Context, number *models.WhatsAppPhoneNumber) (*models.WhatsAppPhoneNumber, []models.WhatsAppTemplate, error) {
+	provider, err := s.cloudProvider()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remote, err := provider.ListMessageTemplates(ctx, number.BusinessAccountID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	templates := make([]models.WhatsAppTemplate, 0, len(remote))
+	for _, info := range remote {
+		template, err := whatsAppTemplateFromInfo(info)
+		if err != nil {
+			s.log.Warn("⚠️ Template ignorado na sincronização", "name", info.Name, "language", info.Language, "error", err)
+			continue
+		}
+		templates = append(templates, *template)
+	}
+
+	synced, err := s.templates.Sync(ctx, number.AccountID, number.BusinessAccountID, templates)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 🔄 Aproveita a sincronização para atualizar nome verificado e qualidade do número
+	if info, err := provider.GetPhoneNumber(ctx, number.PhoneNumberID); err != nil {
+		s.log.Warn("⚠️ Erro ao atualizar dados do número no Graph", "phone_number_id", number.PhoneNumberID, "error", err)
+	} else {
+		applyPhoneNumberInfo(number, info)
+	}
+	now := time.Now()
+	number.TemplatesSyncedAt = &now
+
+	updated, err := s.numbers.Update(ctx, number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.log.Info("🔄 Templates da Cloud API sincronizados", "account_id", number.AccountID, "business_account_id", number.BusinessAccountID, "total", len(synced))
+	return updated, synced, nil
+}
+
+// Resolve valida as configurações da campanha com as mesmas regras usadas no envio
+func (s *whatsAppTemplateService) Resolve(ctx context.Context, accountID uuid.UUID, chat *models.Chat, settings models.CampaignSettings) (*models.WhatsAppTemplate, error) {
+	if settings.WhatsAppTemplateID == nil {
+		return nil, fmt.Errorf("%w: chats da Cloud API enviam campanhas apenas com template aprovado (whatsapp_template_id)", ErrWhatsAppTemplateInvalid)
+	}
+
+	template, err := s.templates.GetByID(ctx, *settings.WhatsAppTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil || template.AccountID != accountID {
+		return nil, fmt.Errorf("%w: template %s não encontrado na conta", ErrWhatsAppTemplateInvalid, *settings.WhatsAppTemplateID)
+	}
+	if !template.Approved() {
+		return nil, fmt.Errorf("%w: template %s está com status %s", ErrWhatsAppTemplateInvalid, template.Name, template.Status)
+	}
+
+	number, err := s.numbers.GetByPhoneNumberID(ctx, chat.InstanceName)
+	if err != nil {
+		return nil, err
+	}
+	if number == nil || number.AccountID != accountID {
+		return nil, fmt.Errorf("%w: o número %s do chat não está cadastrado na conta", ErrWhatsAppTemplateInvalid, chat.InstanceName)
+	}
+	if number.BusinessAccountID != template.BusinessAccountID {
+		return nil, fmt.Errorf("%w: o template %s pertence a outra WABA (%s)", ErrWhatsAppTemplateInvalid, template.Name, template.BusinessAccountID)
+	}
+
+	slots := make(map[string]bool, len(template.Slots))
+	for _, slot := range template.Slots {
+		slots[slot.Key] = true
+		if _, ok := settings.WhatsAppTemplateParams[slot.Key]; !ok {
+			return nil, fmt.Errorf("%w: a variável %s do template %s não tem origem em whatsapp_template_params", ErrWhatsAppTemplateInvalid, slot.Key, template.Name)
+		}
+	}
+	for key := range settings.WhatsAppTemplateParams {
+		if !slots[key] {
+			return nil, fmt.Errorf("%w: o template %s não possui a variável %s", ErrWhatsAppTemplateInvalid, template.Name, key)
+		}
+	}
+
+	return template, nil
+}
+
+// applyPhoneNumberInfo copia para o número os dados consultados no Graph
+func applyPhoneNumberInfo(number *models.WhatsAppPhoneNumber, info *WhatsAppPhoneNumberInfo) {
+	number.DisplayPhoneNumber = optionalString(info.DisplayPhoneNumber)
+	number.VerifiedName = optionalString(info.VerifiedName)
+	number.QualityRating = optionalString(info.QualityRating)
+}
+
+// whatsAppTemplateFromInfo converte o template do Graph e extrai as variáveis
+func whatsAppTemplateFromInfo(info WhatsAppTemplateInfo) (*models.WhatsAppTemplate, error) {
+	format := strings.ToUpper(info.ParameterFormat)
+	if format != models.WhatsAppTemplateNamed {
+		format = models.WhatsAppTemplatePositional
+	}
+
+	slots, err := ParseWhatsAppTemplateSlots(info.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WhatsAppTemplate{
+		ExternalID:      info.ID,
+		Name:            info.Name,
+		Language:        info.Language,
+		Category:        optionalString(info.Category),
+		Status:          strings.ToUpper(info.Status),
+		ParameterFormat: format,
+		Components:      info.Components,
+		Slots:           slots,
+	}, nil
+}
+
+// whatsAppTemplateVariable encontra as variáveis {{1}} ou {{nome}} no texto do componente
+var whatsAppTemplateVariable = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+// cloudTemplateComponent cobre os campos dos componentes usados para extrair as variáveis
+type cloudTemplateComponent struct {
+	Type    string `json:"type"`   // HEADER, BODY, FOOTER ou BUTTONS
+	Format  string `json:"format"` // HEADER: TEXT, IMAGE, VIDEO, DOCUMENT ou LOCATION
+	Text    string `json:"text"`
+	Example struct {
+		HeaderText          []string                         `json:"header_text"`
+		HeaderTextNamed     []cloudTemplateNamedParamExample `json:"header_text_named_params"`
+		HeaderHandle        []string                         `json:"header_handle"`
+		BodyText            [][]string                       `json:"body_text"`
+		BodyTextNamedParams []cloudTemplateNamedParamExample `json:"body_text_named_params"`
+	} `json:"example"`
+	Buttons []struct {
+		Type    string          `json:"type"` // URL, COPY_CODE, QUICK_REPLY, PHONE_NUMBER...
+		URL     string          `json:"url"`
+		Example json.RawMessage `json:"example"` // URL: lista de exemplos; COPY_CODE: o código
+	} `json:"buttons"`
+}
+
+type cloudTemplateNamedParamExample struct {
+	ParamName string `json:"param_name"`
+	Example   string `json:"example"`
+}
+
+// ParseWhatsAppTemplateSlots extrai as variáveis dos componentes do template, na ordem de envio:
+// cabeçalho (texto ou mídia), corpo e botões de URL dinâmica ou de cópia de código
+func ParseWhatsAppTemplateSlots(components json.RawMessage) ([]models.WhatsAppTemplateSlot, error) {
+	var parsed []cloudTemplateComponent
+	if len(components) > 0 {
+		if err := json.Unmarshal(components, &parsed); err != nil {
+			return nil, fmt.Errorf("componentes do template inválidos: %w", err)
+		}
+	}
+
+	slots := []models.WhatsAppTemplateSlot{}
+	for _, component := range parsed {
+		switch strings.ToUpper(component.Type) {
+		case "HEADER":
+			switch format := strings.ToUpper(component.Format); format {
+			case "TEXT", "":
+				examples := namedExamples(component.Example.HeaderTextNamed)
+				for i, name := range templateVariables(component.Text) {
+					example := examples[name]
+					if i < len(component.Example.HeaderText) {
+						example = component.Example.HeaderText[i]
+					}
+					slots = append(slots, textSlot(models.WhatsAppSlotHeader, name, example))
+				}
+			case "IMAGE", "VIDEO", "DOCUMENT":
+				slot := models.WhatsAppTemplateSlot{
+					Key:       models.WhatsAppSlotHeader + ".media",
+					Component: models.WhatsAppSlotHeader,
+					Type:      strings.ToLower(format),
+					Name:      "media",
+				}
+				if len(component.Example.HeaderHandle) > 0 {
+					slot.Example = component.Example.HeaderHandle[0]
+				}
+				slots = append(slots, slot)
+			}
+
+		case "BODY":
+			examples := namedExamples(component.Example.BodyTextNamedParams)
+			for i, name := range templateVariables(component.Text) {
+				example := examples[name]
+				if len(component.Example.BodyText) > 0 && i < len(component.Example.BodyText[0]) {
+					example = component.Example.BodyText[0][i]
+				}
+				slots = append(slots, textSlot(models.WhatsAppSlotBody, name, example))
+			}
+
+		case "BUTTONS":
+			for index, button := range component.Buttons {
+				switch strings.ToUpper(button.Type) {
+				case "URL":
+					var examples []string
+					_ = json.Unmarshal(button.Example, &examples)
+					for i, name := range templateVariables(button.URL) {
+						slot := textSlot(models.WhatsAppSlotButton, name, "")
+						slot.Key = fmt.Sprintf("%s.%d.%s", models.WhatsAppSlotButton, index, name)
+						slot.Index, slot.ButtonType = index, "url"
+						if i < len(examples) {
+							slot.Example = examples[i]
+						}
+						slots = append(slots, slot)
+					}
+				case "COPY_CODE":
+					var example string
+					_ = json.Unmarshal(button.Example, &example)
+					slots = append(slots, models.WhatsAppTemplateSlot{
+						Key:        fmt.Sprintf("%s.%d.coupon_code", models.WhatsAppSlotButton, index),
+						Component:  models.WhatsAppSlotButton,
+						Type:       "coupon_code",
+						Name:       "coupon_code",
+						Index:      index,
+						ButtonType: "copy_code",
+						Example:    example,
+					})
+				}
+			}
+		}
+	}
+	return slots, nil
+}
+
+// templateVariables retorna as variáveis do texto sem repetição: numeradas em ordem crescente, nomeadas na ordem em que aparecem
+func templateVariables(text string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, match := range whatsAppTemplateVariable.FindAllStringSubmatch(text, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		a, errA := strconv.Atoi(names[i])
+		b, errB := strconv.Atoi(names[j])
+		return errA == nil && errB == nil && a < b
+	})
+	return names
+}
+
+func namedExamples(params []cloudTemplateNamedParamExample) map[string]string {
+	examples := make(map[string]string, len(params))
+	for _, param := range params {
+		examples[param.ParamName] = param.Example
+	}
+	return examples
+}
+
+func textSlot(component, name, example string) models.WhatsAppTemplateSlot {
+	return models.WhatsAppTemplateSlot{
+		Key:       component + "." + name,
+		Component: component,
+		Type:      "text",
+		Name:      name,
+		Example:   example,
+	}
+}
+
+// WhatsAppTemplateValues reúne os valores disponíveis para as variáveis: conteúdo gerado pela IA, contato e marca
+func WhatsAppTemplateValues(content *dto.CampaignContentResult, contact *models.Contact, settings *models.CampaignSettings) map[string]string {
+	values := map[string]string{
+		models.WhatsAppParamContactName: contact.Name,
+		models.WhatsAppParamBrand:       settings.Brand,
+	}
+	if content != nil {
+		values[models.WhatsAppParamSaudacao] = content.Saudacao
+		values[models.WhatsAppParamCorpo] = content.Corpo
+		values[models.WhatsAppParamFinalizacao] = content.Finalizacao
+		values[models.WhatsAppParamAssinatura] = content.Assinatura
+	}
+	return values
+}
+
+// BuildWhatsAppTemplateMessage preenche as variáveis do template com a origem configurada de cada uma.
+// O texto das variáveis não pode ter quebras de linha nem tabulações (regra da Meta), então os espaços são compactados.
+func BuildWhatsAppTemplateMessage(template *models.WhatsAppTemplate, params map[string]string, values map[string]string) (*WhatsAppTemplateMessage, error) {
+	message := &WhatsAppTemplateMessage{
+		Name:     template.Name,
+		Language: WhatsAppTemplateLanguage{Code: template.Language},
+	}
+
+	components := map[string]*WhatsAppTemplateComponent{}
+	var order []string
+	for _, slot := range template.Slots {
+		source, ok := params[slot.Key]
+		if !ok {
+			return nil, fmt.Errorf("%w: a variável %s não tem origem configurada", ErrWhatsAppTemplateInvalid, slot.Key)
+		}
+
+		value := strings.TrimPrefix(source, models.WhatsAppParamLiteralPrefix)
+		if value == source {
+			value = values[source]
+		}
+		value = strings.Join(strings.Fields(value), " ")
+		if value == "" {
+			return nil, fmt.Errorf("%w: a variável %s ficou vazia (origem %s)", ErrWhatsAppTemplateInvalid, slot.Key, source)
+		}
+		if slot.Name == "media" && !strings.HasPrefix(value, "https://") && !strings.HasPrefix(value, "http://") {
+			return nil, fmt.Errorf("%w: a mídia do cabeçalho (%s) precisa ser uma URL pública", ErrWhatsAppTemplateInvalid, slot.Key)
+		}
+
+		componentKey := slot.Component
+		if slot.Component == models.WhatsAppSlotButton {
+			componentKey = fmt.Sprintf("%s.%d", slot.Component, slot.Index)
+		}
+		component, ok := components[componentKey]
+		if !ok {
+			component = &WhatsAppTemplateComponent{Type: slot.Component}
+			if slot.Component == models.WhatsAppSlotButton {
+				component.SubType, component.Index = slot.ButtonType, strconv.Itoa(slot.Index)
+			}
+			components[componentKey] = component
+			order = append(order, componentKey)
+		}
+
+		parameter := WhatsAppTemplateParameter{Type: slot.Type}
+		switch slot.Type {
+		case "image":
+			parameter.Image = &WhatsAppTemplateMediaLink{Link: value}
+		case "video":
+			parameter.Video = &WhatsAppTemplateMediaLink{Link: value}
+		case "document":
+			parameter.Document = &WhatsAppTemplateMediaLink{Link: value}
+		case "coupon_code":
+			parameter.CouponCode = value
+		default:
+			parameter.Text = value
+			if template.ParameterFormat == models.WhatsAppTemplateNamed && slot.Component != models.WhatsAppSlotButton {
+				parameter.ParameterName = slot.Name
+			}
+		}
+		component.Parameters = append(component.Parameters, parameter)
+	}
+
+	for _, key := range order {
+		message.Components = append(message.Components, *components[key])
+	}
+	return message, nil
+}
@@ -0,0 +1,99 @@
+// File: /internal/workers/campaign_gate.go
+
+package workers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// holdIfInactive verifica, antes de cada envio, se a campanha foi pausada ou cancelada.
+// Pausada: a audiência volta para "pendente" e será reenfileirada ao retomar.
+// Cancelada: a audiência é marcada como "cancelada".
+// Retorna true quando a mensagem deve ser descartada sem envio.
+func holdIfInactive(ctx context.Context, log *slog.Logger, audienceRepo db.CampaignAudienceRepository, campaign *models.Campaign, audienceID uuid.UUID) (bool, error) {
+	var status models.AudienceStatus
+
+	switch campaign.Status {
+	case models.StatusPausada:
+		status = models.AudiencePendente
+	case models.StatusCancelada:
+		status = models.AudienceCancelada
+	default:
+		return false, nil
+	}
+
+	log.Info("⏸️ Campanha inativa, mensagem descartada sem envio",
+		"campaign_id", campaign.ID, "campaign_status", campaign.Status, "audience_id", audienceID)
+
+	if err := audienceRepo.UpdateStatus(ctx, audienceID, string(status), "", nil); err != nil {
+		return true, err // 🔄 A fila reentrega e a verificação é refeita
+	}
+
+	return true, nil
+}
+
+// skipIfOptedOut descarta o envio para contatos descadastrados do canal depois do enfileiramento,
+// marcando a audiência como "cancelada". Retorna true quando a mensagem não deve ser enviada.
+func skipIfOptedOut(ctx context.Context, log *slog.Logger, audienceRepo db.CampaignAudienceRepository, contact *models.Contact, channel models.ChannelType, audienceID uuid.UUID) (bool, error) {
+	if !contact.OptedOut(channel) {
+		return false, nil
+	}
+
+	log.Info("🚫 Contato descadastrado, mensagem descartada sem envio", "contact_id", contact.ID, "channel", channel, "audience_id", audienceID)
+
+	feedback := map[string]interface{}{"reason": "opt_out"}
+	if err := audienceRepo.UpdateStatus(ctx, audienceID, string(models.AudienceCancelada), "", feedback); err != nil {
+		return true, err // 🔄 A fila reentrega e a verificação é refeita
+	}
+
+	return true, nil
+}
+
+// skipIfSuppressed descarta o envio para destinatários na lista de supressão da conta,
+// marcando a audiência como "cancelada". Retorna true quando a mensagem não deve ser enviada.
+func skipIfSuppressed(ctx context.Context, log *slog.Logger, audienceRepo db.CampaignAudienceRepository, suppression service.SuppressionService, msg dto.CampaignMessageDTO, channel models.ChannelType, address string) (bool, error) {
+	suppressed, err := suppression.Check(ctx, msg.AccountID, channel, address)
+	if err != nil {
+		return true, err // 🔄 Sem confirmação da lista, não arrisca o envio
+	}
+	if suppressed == nil {
+		return false, nil
+	}
+
+	log.Info("🛑 Destinatário suprimido, mensagem descartada sem envio",
+		"contact_id", msg.ContactID, "channel", channel, "reason", suppressed.Reason, "audience_id", msg.ID)
+
+	feedback := map[string]interface{}{"reason": "suppressed", "suppression_reason": suppressed.Reason}
+	if err := audienceRepo.UpdateStatus(ctx, msg.ID, string(models.AudienceCancelada), "", feedback); err != nil {
+		return true, err // 🔄 A fila reentrega e a verificação é refeita
+	}
+
+	return true, nil
+}
+
+// skipIfInvalidEmail descarta o envio para e-mails reprovados na verificação (sintaxe ou domínio sem servidor),
+// marcando a audiência como "cancelada" antes de pagar pelo envio e gerar bounce.
+func skipIfInvalidEmail(ctx context.Context, log *slog.Logger, audienceRepo db.CampaignAudienceRepository, contact *models.Contact, audienceID uuid.UUID) (bool, error) {
+	if contact.EmailStatus == nil || *contact.EmailStatus != models.EmailStatusInvalid {
+		return false, nil
+	}
+
+	log.Info("📭 E-mail inválido, mensagem descartada sem envio", "contact_id", contact.ID, "audience_id", audienceID)
+
+	feedback := map[string]interface{}{"reason": "invalid_email"}
+	if contact.EmailStatusReason != nil {
+		feedback["email_status_reason"] = *contact.EmailStatusReason
+	}
+	if err := audienceRepo.UpdateStatus(ctx, audienceID, string(models.AudienceCancelada), "", feedback); err != nil {
+		return true, err // 🔄 A fila reentrega e a verificação é refeita
+	}
+
+	return true, nil
+}
@@ -0,0 +1,226 @@
+// File: /internal/workers/campaign_scheduler.go
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// CampaignScheduler dispara as campanhas agendadas quando chega o horário
+type CampaignScheduler interface {
+	Start(ctx context.Context)
+}
+
+// campaignScheduler verifica periodicamente as campanhas com status "agendada"
+type campaignScheduler struct {
+	log               *slog.Logger
+	campaignRepo      db.CampaignRepository
+	audienceRepo      db.CampaignAudienceRepository
+	campaignProcessor service.CampaignProcessorService
+	campaignState     service.CampaignStateService
+	senderIdentities  service.SenderIdentityService
+	interval          time.Duration
+	lease             time.Duration
+	owner             string
+	batchSize         int
+}
+
+// NewCampaignScheduler cria o agendador de campanhas. A reserva de uma campanha em disparo é renovada
+// enquanto a audiência é enfileirada; sem renovação por `lease`, outra réplica retoma o disparo.
+func NewCampaignScheduler(
+	campaignRepo db.CampaignRepository,
+	audienceRepo db.CampaignAudienceRepository,
+	campaignProcessor service.CampaignProcessorService,
+	campaignState service.CampaignStateService,
+	senderIdentities service.SenderIdentityService,
+	interval time.Duration,
+	lease time.Duration,
+) CampaignScheduler {
+	hostname, _ := os.Hostname()
+
+	return &campaignScheduler{
+		log:               logger.GetLogger(),
+		campaignRepo:      campaignRepo,
+		audienceRepo:      audienceRepo,
+		campaignProcessor: campaignProcessor,
+		campaignState:     campaignState,
+		senderIdentities:  senderIdentities,
+		interval:          interval,
+		lease:             lease,
+		owner:             fmt.Sprintf("%s/%s", hostname, uuid.NewString()[:8]),
+		batchSize:         10,
+	}
+}
+
+// Start inicia o loop do agendador até o contexto ser cancelado
+func (s *campaignScheduler) Start(ctx context.Context) {
+	s.log.Info("🗓️ CampaignScheduler iniciado 🚀", "interval", s.interval, "lease", s.lease, "owner", s.owner)
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.log.Info("Encerrando CampaignScheduler")
+				return
+			case <-ticker.C:
+				s.reclaimExpiredCampaigns(ctx)
+				s.dispatchDueCampaigns(ctx)
+			}
+		}
+	}()
+}
+
+// dispatchDueCampaigns reserva as campanhas vencidas e envia a audiência para a fila.
+// A reserva troca o status para "processando" de forma atômica, então cada campanha é
+// disparada uma única vez mesmo com várias réplicas do servidor.
+func (s *campaignScheduler) dispatchDueCampaigns(ctx context.Context) {
+	campaigns, err := s.campaignRepo.ClaimDueScheduled(ctx, s.owner, s.batchSize)
+	if err != nil {
+		s.log.Error("❌ Erro ao buscar campanhas agendadas", "error", err)
+		return
+	}
+
+	for _, campaign := range campaigns {
+		s.log.Info("⏰ Disparando campanha agendada", "campaign_id", campaign.ID, "scheduled_at", campaign.ScheduledAt)
+		s.campaignState.RecordTransition(ctx, campaign.ID, models.StatusAgendada, models.StatusProcessando, service.CampaignStatusChange{
+			Source: models.StatusSourceScheduler,
+			Reason: "horário agendado atingido",
+		})
+
+		s.dispatch(ctx, campaign, false)
+	}
+}
+
+// reclaimExpiredCampaigns retoma as campanhas cuja réplica caiu (ou travou) durante o disparo
+func (s *campaignScheduler) reclaimExpiredCampaigns(ctx context.Context) {
+	campaigns, err := s.campaignRepo.ReclaimExpired(ctx, s.owner, s.lease, s.batchSize)
+	if err != nil {
+		s.log.Error("❌ Erro ao buscar campanhas com reserva vencida", "error", err)
+		return
+	}
+
+	for _, campaign := range campaigns {
+		s.log.Warn("♻️ Reserva da campanha vencida, retomando disparo", "campaign_id", campaign.ID, "lease", s.lease)
+		s.dispatch(ctx, campaign, true)
+	}
+}
+
+// dispatch enfileira a audiência ainda não enviada da campanha reservada. Uma falha devolve a campanha
+// para "agendada" (nova tentativa no próximo ciclo), sem deixá-la presa em "processando".
+func (s *campaignScheduler) dispatch(ctx context.Context, campaign models.Campaign, reclaimed bool) {
+	stopRenewal := s.keepClaim(ctx, campaign)
+	defer stopRenewal()
+
+	// ✉️ O remetente pode ter perdido a verificação desde o agendamento
+	if err := s.senderIdentities.CheckCampaign(ctx, &campaign); err != nil {
+		if !errors.Is(err, service.ErrSenderNotVerified) {
+			s.log.Error("❌ Erro ao verificar remetente da campanha agendada", "campaign_id", campaign.ID, "error", err)
+			s.restoreSchedule(ctx, campaign, "erro ao verificar remetente, nova tentativa no próximo ciclo")
+			return
+		}
+
+		s.log.Warn("⚠️ Campanha agendada com remetente não verificado, voltando para pendente", "campaign_id", campaign.ID, "error", err)
+		err := s.campaignState.Transition(ctx, &campaign, models.StatusPendente, service.CampaignStatusChange{
+			Source: models.StatusSourceScheduler,
+			Reason: err.Error(),
+		})
+		if err != nil {
+			s.log.Error("❌ Erro ao atualizar status da campanha", "campaign_id", campaign.ID, "error", err)
+		}
+		return
+	}
+
+	// 🔁 Somente quem ainda não foi enviado: uma nova tentativa (ou retomada) não reenvia para quem já recebeu
+	audience, err := s.audienceRepo.GetUnsentToSQS(ctx, campaign.AccountID, campaign.ID)
+	if err != nil {
+		s.log.Error("❌ Erro ao buscar audiência da campanha agendada", "campaign_id", campaign.ID, "error", err)
+		s.restoreSchedule(ctx, campaign, "erro ao buscar audiência, nova tentativa no próximo ciclo")
+		return
+	}
+
+	if len(audience) == 0 && !reclaimed {
+		s.log.Warn("⚠️ Campanha agendada sem audiência, voltando para pendente", "campaign_id", campaign.ID)
+		err := s.campaignState.Transition(ctx, &campaign, models.StatusPendente, service.CampaignStatusChange{
+			Source: models.StatusSourceScheduler,
+			Reason: "campanha sem audiência",
+		})
+		if err != nil {
+			s.log.Error("❌ Erro ao atualizar status da campanha", "campaign_id", campaign.ID, "error", err)
+		}
+		return
+	}
+
+	if len(audience) > 0 {
+		if err := s.campaignProcessor.ProcessCampaign(ctx, &campaign, audience); err != nil {
+			s.log.Error("❌ Erro no processamento da campanha agendada", "campaign_id", campaign.ID, "error", err)
+			s.restoreSchedule(ctx, campaign, "erro ao enfileirar audiência, nova tentativa no próximo ciclo")
+			return
+		}
+	}
+
+	// 📤 Tudo na fila: a campanha passa a "enviando" (a não ser que tenha sido pausada/cancelada no meio)
+	err = s.campaignState.Transition(ctx, &campaign, models.StatusEnviando, service.CampaignStatusChange{
+		Source: models.StatusSourcePipeline,
+		Reason: "audiência enfileirada",
+	})
+	if err != nil {
+		s.log.Warn("⚠️ Campanha agendada não passou para 'enviando'", "campaign_id", campaign.ID, "error", err)
+		return
+	}
+
+	// ✅ Retomada sem nada a enfileirar: os envios podem já ter terminado
+	if len(audience) == 0 {
+		if _, err := s.campaignState.CompleteIfFinished(ctx, campaign.ID); err != nil {
+			s.log.Error("❌ Erro ao concluir campanha retomada", "campaign_id", campaign.ID, "error", err)
+		}
+	}
+}
+
+// keepClaim renova a reserva da campanha a cada terço do lease enquanto o disparo acontece.
+// Retorna a função que encerra a renovação.
+func (s *campaignScheduler) keepClaim(ctx context.Context, campaign models.Campaign) func() {
+	renewCtx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		ticker := time.NewTicker(max(s.lease/3, time.Second))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-renewCtx.Done():
+				return
+			case <-ticker.C:
+				if err := s.campaignRepo.RenewClaim(renewCtx, campaign.ID, s.owner); err != nil && renewCtx.Err() == nil {
+					s.log.Warn("⚠️ Erro ao renovar reserva da campanha", "campaign_id", campaign.ID, "error", err)
+				}
+			}
+		}
+	}()
+
+	return cancel
+}
+
+// restoreSchedule devolve a campanha para "agendada" para ser tentada no próximo ciclo
+func (s *campaignScheduler) restoreSchedule(ctx context.Context, campaign models.Campaign, reason string) {
+	err := s.campaignState.Transition(ctx, &campaign, models.StatusAgendada, service.CampaignStatusChange{
+		Source: models.StatusSourceScheduler,
+		Reason: reason,
+	})
+	if err != nil {
+		s.log.Error("❌ Erro ao restaurar agendamento da campanha", "campaign_id", campaign.ID, "error", err)
+	}
+}
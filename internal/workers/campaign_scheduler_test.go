@@ -0,0 +1,166 @@
+// File: /internal/workers/campaign_scheduler_test.go
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// fakeCampaignRepo simula as reservas do agendador sobre uma única campanha
+type fakeCampaignRepo struct {
+	db.CampaignRepository
+
+	mu        sync.Mutex
+	campaign  models.Campaign
+	due       bool
+	expired   bool
+	claimedBy string
+}
+
+func (r *fakeCampaignRepo) GetByID(ctx context.Context, campaignID uuid.UUID) (*models.Campaign, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	campaign := r.campaign
+	return &campaign, nil
+}
+
+func (r *fakeCampaignRepo) TransitionStatus(ctx context.Context, campaignID uuid.UUID, from, to models.CampaignStatus) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.campaign.Status != from {
+		return false, nil
+	}
+	r.campaign.Status = to
+	r.claimedBy = ""
+	return true, nil
+}
+
+func (r *fakeCampaignRepo) ClaimDueScheduled(ctx context.Context, owner string, limit int) ([]models.Campaign, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.due || r.campaign.Status != models.StatusAgendada {
+		return nil, nil
+	}
+	r.campaign.Status = models.StatusProcessando
+	r.claimedBy = owner
+	return []models.Campaign{r.campaign}, nil
+}
+
+func (r *fakeCampaignRepo) ReclaimExpired(ctx context.Context, owner string, lease time.Duration, limit int) ([]models.Campaign, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.expired || r.campaign.Status != models.StatusProcessando {
+		return nil, nil
+	}
+	r.expired = false
+	r.claimedBy = owner
+	return []models.Campaign{r.campaign}, nil
+}
+
+func (r *fakeCampaignRepo) RenewClaim(ctx context.Context, campaignID uuid.UUID, owner string) error {
+	return nil
+}
+
+func (r *fakeCampaignRepo) status() models.CampaignStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.campaign.Status
+}
+
+// schedulerAudienceRepo devolve a audiência ainda não enfileirada
+type schedulerAudienceRepo struct {
+	db.CampaignAudienceRepository
+	unsent []dto.CampaignMessageDTO
+}
+
+func (r *schedulerAudienceRepo) GetUnsentToSQS(ctx context.Context, accountID, campaignID uuid.UUID) ([]dto.CampaignMessageDTO, error) {
+	return r.unsent, nil
+}
+
+func (r *schedulerAudienceRepo) HasPending(ctx context.Context, campaignID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (r *schedulerAudienceRepo) CountUnsentFailures(ctx context.Context, campaignID uuid.UUID) (int, error) {
+	return 0, nil
+}
+
+type fakeHistoryRepo struct {
+	db.CampaignStatusHistoryRepository
+}
+
+func (r *fakeHistoryRepo) Create(ctx context.Context, entry *models.CampaignStatusHistory) error {
+	return nil
+}
+
+type fakeSenderIdentities struct {
+	service.SenderIdentityService
+}
+
+func (s *fakeSenderIdentities) CheckCampaign(ctx context.Context, campaign *models.Campaign) error {
+	return nil
+}
+
+type fakeCampaignProcessor struct {
+	service.CampaignProcessorService
+	err      error
+	enqueued int
+}
+
+func (p *fakeCampaignProcessor) ProcessCampaign(ctx context.Context, campaign *models.Campaign, audience []dto.CampaignMessageDTO) error {
+	p.enqueued += len(audience)
+	return p.err
+}
+
+func TestCampaignSchedulerDispatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       models.CampaignStatus
+		due          bool
+		expired      bool
+		unsent       int
+		processErr   error
+		wantStatus   models.CampaignStatus
+		wantEnqueued int
+	}{
+		{name: "campanha vencida é disparada", status: models.StatusAgendada, due: true, unsent: 2, wantStatus: models.StatusEnviando, wantEnqueued: 2},
+		{name: "falha ao enfileirar volta para agendada", status: models.StatusAgendada, due: true, unsent: 2, processErr: errors.New("fila indisponível"), wantStatus: models.StatusAgendada, wantEnqueued: 2},
+		{name: "reserva vencida é retomada", status: models.StatusProcessando, expired: true, unsent: 1, wantStatus: models.StatusEnviando, wantEnqueued: 1},
+		{name: "retomada sem pendências conclui a campanha", status: models.StatusProcessando, expired: true, wantStatus: models.StatusConcluida},
+		{name: "reserva em dia não é retomada", status: models.StatusProcessando, unsent: 1, wantStatus: models.StatusProcessando},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaignRepo := &fakeCampaignRepo{
+				campaign: models.Campaign{ID: uuid.New(), AccountID: uuid.New(), Status: tt.status},
+				due:      tt.due,
+				expired:  tt.expired,
+			}
+			audienceRepo := &schedulerAudienceRepo{unsent: make([]dto.CampaignMessageDTO, tt.unsent)}
+			processor := &fakeCampaignProcessor{err: tt.processErr}
+			state := service.NewCampaignStateService(campaignRepo, audienceRepo, &fakeHistoryRepo{})
+
+			scheduler := NewCampaignScheduler(campaignRepo, audienceRepo, processor, state, &fakeSenderIdentities{}, time.Minute, time.Minute).(*campaignScheduler)
+			scheduler.reclaimExpiredCampaigns(context.Background())
+			scheduler.dispatchDueCampaigns(context.Background())
+
+			if got := campaignRepo.status(); got != tt.wantStatus {
+				t.Fatalf("status = %s, esperado %s", got, tt.wantStatus)
+			}
+			if processor.enqueued != tt.wantEnqueued {
+				t.Fatalf("enfileirados %d, esperado %d", processor.enqueued, tt.wantEnqueued)
+			}
+		})
+	}
+}
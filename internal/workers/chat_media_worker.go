@@ -0,0 +1,117 @@
+// File: /internal/workers/chat_media_worker.go
+
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// ChatMediaWorker processa as mídias recebidas no atendimento (transcrição de áudios, descrição de imagens e documentos)
+type ChatMediaWorker interface {
+	Start(ctx context.Context)
+}
+
+// chatMediaWorker reserva lotes de mensagens pendentes no banco e processa cada lote em paralelo
+type chatMediaWorker struct {
+	log         *slog.Logger
+	messages    db.ChatMessageRepository
+	processor   service.ChatMediaProcessor
+	interval    time.Duration
+	concurrency int
+	maxAttempts int
+	lease       time.Duration
+}
+
+// NewChatMediaWorker cria o processamento assíncrono das mídias do atendimento
+func NewChatMediaWorker(messages db.ChatMessageRepository, processor service.ChatMediaProcessor, interval time.Duration, concurrency, maxAttempts int) ChatMediaWorker {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &chatMediaWorker{
+		log:         logger.GetLogger(),
+		messages:    messages,
+		processor:   processor,
+		interval:    interval,
+		concurrency: concurrency,
+		maxAttempts: maxAttempts,
+		lease:       5 * time.Minute, // Também espaça as novas tentativas após uma falha
+	}
+}
+
+// Start inicia o loop de processamento até o contexto ser cancelado
+func (w *chatMediaWorker) Start(ctx context.Context) {
+	types := w.processor.Types()
+	if len(types) == 0 {
+		w.log.Warn("⚠️ ChatMediaWorker sem transcrição nem visão configuradas: mídias recebidas não serão processadas")
+		return
+	}
+	w.log.Info("🎤 ChatMediaWorker iniciado 🚀", "types", types, "interval", w.interval, "concurrency", w.concurrency)
+
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.processPending(ctx, types)
+
+			select {
+			case <-ctx.Done():
+				w.log.Info("Encerrando ChatMediaWorker")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// processPending processa lotes até não restar mensagem pendente
+func (w *chatMediaWorker) processPending(ctx context.Context, types []string) {
+	for ctx.Err() == nil {
+		messages, err := w.messages.ClaimUnprocessed(ctx, types, w.concurrency, w.maxAttempts, w.lease)
+		if err != nil {
+			w.log.Error("❌ Erro ao buscar mídias pendentes de processamento", "error", err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		for i := range messages {
+			wg.Add(1)
+			go func(msg *models.ChatMessage) {
+				defer wg.Done()
+				w.process(ctx, msg)
+			}(&messages[i])
+		}
+		wg.Wait()
+
+		if len(messages) < w.concurrency {
+			return
+		}
+	}
+}
+
+// process extrai o conteúdo da mídia e grava na mensagem; falhas temporárias voltam a ser tentadas após a reserva
+func (w *chatMediaWorker) process(ctx context.Context, msg *models.ChatMessage) {
+	content, err := w.processor.Process(ctx, msg)
+	if err != nil {
+		permanent := !service.IsRetryableError(err)
+		w.log.Error("❌ Erro ao processar mídia recebida", "message_id", msg.ID, "type", msg.Type, "permanent", permanent, "error", err)
+		if err := w.messages.SaveProcessingError(ctx, msg.ID, err.Error(), permanent); err != nil {
+			w.log.Error("❌ Erro ao registrar falha no processamento da mídia", "message_id", msg.ID, "error", err)
+		}
+		return
+	}
+
+	if err := w.messages.SaveProcessedContent(ctx, msg.ID, content); err != nil {
+		w.log.Error("❌ Erro ao gravar conteúdo processado da mídia", "message_id", msg.ID, "error", err)
+		return
+	}
+	w.log.Info("✅ Mídia recebida processada", "message_id", msg.ID, "type", msg.Type)
+}
@@ -0,0 +1,35 @@
+// File: /internal/workers/delivered_message.go
+
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+// recordDeliveredMessage guarda o conteúdo entregue ao destinatário.
+// A mensagem já saiu: uma falha aqui é registrada no log e não gera reenvio.
+func recordDeliveredMessage(ctx context.Context, log *slog.Logger, repo db.DeliveredMessageRepository, message models.DeliveredMessage) {
+	if message.SentAt.IsZero() {
+		message.SentAt = time.Now()
+	}
+	if message.TemplateID != nil && *message.TemplateID == uuid.Nil {
+		message.TemplateID = nil // Canal sem template configurado
+	}
+	if _, err := repo.Save(ctx, &message); err != nil {
+		log.Error("❌ Erro ao registrar conteúdo entregue", "audience_id", message.AudienceID, "channel", message.Channel, "error", err)
+	}
+}
+
+// deliveredString retorna nil para valores vazios (colunas opcionais da mensagem entregue)
+func deliveredString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
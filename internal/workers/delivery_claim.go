@@ -0,0 +1,114 @@
+// File: /internal/workers/delivery_claim.go
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// audienceClaimLease é o tempo após o qual a reserva de um worker que caiu durante o envio pode ser retomada
+const audienceClaimLease = 5 * time.Minute
+
+// errSentNotRecorded indica mensagem já entregue ao provedor cujo status "enviado" não pôde ser gravado
+var errSentNotRecorded = errors.New("mensagem enviada sem status gravado")
+
+// Tentativas de gravar "enviado" depois do envio (intervalo crescente entre elas)
+var (
+	sentStatusAttempts   = 3
+	sentStatusRetryDelay = time.Second
+)
+
+// deliveryClaim garante que cada audiência seja enviada por um único worker, mesmo com mensagens duplicadas na fila
+type deliveryClaim struct {
+	log          *slog.Logger
+	audienceRepo db.CampaignAudienceRepository
+}
+
+// newDeliveryClaim cria a reserva de audiências do canal
+func newDeliveryClaim(log *slog.Logger, audienceRepo db.CampaignAudienceRepository) *deliveryClaim {
+	return &deliveryClaim{log: log, audienceRepo: audienceRepo}
+}
+
+// Wrap reserva a audiência ("enviando") antes do processamento. Sem a reserva, a mensagem é descartada
+// (já enviada, cancelada, removida ou de um enfileiramento anterior) ou devolvida à fila (outro worker está enviando).
+// Ao final, uma reserva que continua aberta (reagendamento ou erro) volta para "fila", exceto quando a mensagem
+// foi enviada e só o status não pôde ser gravado (errSentNotRecorded): voltar para "fila" geraria reenvio.
+func (c *deliveryClaim) Wrap(process service.QueueMessageHandler) service.QueueMessageHandler {
+	return func(ctx context.Context, msg dto.CampaignMessageDTO) error {
+		claimed, err := c.audienceRepo.ClaimForSending(ctx, msg.ID, msg.DispatchToken, audienceClaimLease)
+		if err != nil {
+			return err // 🔄 A fila reentrega após o visibility timeout
+		}
+
+		if !claimed {
+			audience, err := c.audienceRepo.GetByID(ctx, msg.ID)
+			if err != nil {
+				return fmt.Errorf("erro ao buscar audiência (audience_id: %s): %w", msg.ID, err)
+			}
+			if audience != nil && !sameDispatch(audience.DispatchToken, msg.DispatchToken) {
+				// 🎟️ A audiência foi reenfileirada (retomada da campanha): a mensagem nova é quem envia
+				c.log.Warn("⚠️ Mensagem de um enfileiramento anterior, descartando", "audience_id", msg.ID)
+				return nil
+			}
+			if audience != nil && audience.Status == models.AudienceEnviando {
+				// 🔁 Outra cópia da mensagem está em envio: se ela falhar, esta cópia assume depois
+				c.log.Warn("⚠️ Audiência em envio por outro worker, mensagem devolvida à fila", "audience_id", msg.ID)
+				return fmt.Errorf("audiência %s em envio por outro worker", msg.ID)
+			}
+
+			c.log.Warn("⚠️ Audiência removida, cancelada ou já enviada, descartando mensagem", "audience_id", msg.ID)
+			return nil
+		}
+
+		processErr := process(ctx, msg)
+
+		if errors.Is(processErr, errSentNotRecorded) {
+			// 📬 A mensagem já saiu: a audiência fica "enviando" (retomadas não a reenfileiram) e a mensagem sai da fila
+			c.log.Error("❌ Audiência enviada mantida em envio até a gravação do status", "audience_id", msg.ID, "error", processErr)
+			return nil
+		}
+
+		if err := c.audienceRepo.ReleaseClaim(context.WithoutCancel(ctx), msg.ID); err != nil {
+			c.log.Error("❌ Erro ao liberar reserva da audiência", "audience_id", msg.ID, "error", err)
+		}
+
+		return processErr
+	}
+}
+
+// sameDispatch compara o token da audiência com o da mensagem (mensagens antigas, sem token, valem para audiências sem token)
+func sameDispatch(audienceToken *uuid.UUID, messageToken uuid.UUID) bool {
+	if audienceToken == nil {
+		return messageToken == uuid.Nil
+	}
+	return *audienceToken == messageToken
+}
+
+// markSent grava "enviado" e o message_id do provedor. A mensagem já saiu, então a gravação é repetida algumas
+// vezes (mesmo com o contexto cancelado) e, se continuar falhando, retorna errSentNotRecorded.
+func markSent(ctx context.Context, log *slog.Logger, audienceRepo db.CampaignAudienceRepository, audienceID uuid.UUID, messageID string) error {
+	ctx = context.WithoutCancel(ctx)
+
+	var err error
+	for attempt := 1; attempt <= sentStatusAttempts; attempt++ {
+		if err = audienceRepo.UpdateStatus(ctx, audienceID, string(models.AudienceEnviado), messageID, nil); err == nil {
+			return nil
+		}
+		log.Warn("⚠️ Erro ao gravar status de enviado", "audience_id", audienceID, "message_id", messageID, "attempt", attempt, "error", err)
+		if attempt < sentStatusAttempts {
+			time.Sleep(time.Duration(attempt) * sentStatusRetryDelay)
+		}
+	}
+
+	return fmt.Errorf("%w (audience_id: %s, message_id: %s): %v", errSentNotRecorded, audienceID, messageID, err)
+}
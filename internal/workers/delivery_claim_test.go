@@ -0,0 +1,189 @@
+// File: /internal/workers/delivery_claim_test.go
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+)
+
+func TestDeliveryClaim(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     models.AudienceStatus
+		processErr error
+		sendStatus models.AudienceStatus // status gravado pelo processamento ("" = não altera)
+		wantCalls  int
+		wantErr    bool
+		wantStatus models.AudienceStatus
+	}{
+		{name: "reserva e envia", status: models.AudienceFila, sendStatus: models.AudienceEnviado, wantCalls: 1, wantStatus: models.AudienceEnviado},
+		{name: "já enviada é descartada", status: models.AudienceEnviado, wantStatus: models.AudienceEnviado},
+		{name: "cancelada é descartada", status: models.AudienceCancelada, wantStatus: models.AudienceCancelada},
+		{name: "em envio por outro worker volta para a fila", status: models.AudienceEnviando, wantErr: true, wantStatus: models.AudienceEnviando},
+		{name: "falha libera a reserva", status: models.AudiencePendente, processErr: errors.New("falha"), wantCalls: 1, wantErr: true, wantStatus: models.AudienceFila},
+		{name: "reagendamento libera a reserva", status: models.AudienceFila, wantCalls: 1, wantStatus: models.AudienceFila},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeAudienceRepo()
+			msg := dto.CampaignMessageDTO{ID: uuid.New()}
+			repo.set(msg.ID, tt.status)
+
+			calls := 0
+			handler := newDeliveryClaim(logger.GetLogger(), repo).Wrap(func(ctx context.Context, msg dto.CampaignMessageDTO) error {
+				calls++
+				if got := repo.get(msg.ID); got != models.AudienceEnviando {
+					t.Fatalf("processamento sem reserva (status %s)", got)
+				}
+				if tt.sendStatus != "" {
+					repo.set(msg.ID, tt.sendStatus)
+				}
+				return tt.processErr
+			})
+
+			err := handler(context.Background(), msg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("erro = %v, esperado erro: %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Fatalf("processamento chamado %d vezes, esperado %d", calls, tt.wantCalls)
+			}
+			if got := repo.get(msg.ID); got != tt.wantStatus {
+				t.Fatalf("status = %s, esperado %s", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestDeliveryClaimSendsDuplicateMessagesOnce(t *testing.T) {
+	repo := newFakeAudienceRepo()
+	msg := dto.CampaignMessageDTO{ID: uuid.New()}
+	repo.set(msg.ID, models.AudienceFila)
+
+	sends := 0
+	handler := newDeliveryClaim(logger.GetLogger(), repo).Wrap(func(ctx context.Context, msg dto.CampaignMessageDTO) error {
+		sends++
+		return repo.UpdateStatus(ctx, msg.ID, string(models.AudienceEnviado), "msg-1", nil)
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := handler(context.Background(), msg); err != nil {
+			t.Fatalf("entrega %d: %v", i+1, err)
+		}
+	}
+
+	if sends != 1 {
+		t.Fatalf("mensagem enviada %d vezes, esperado 1", sends)
+	}
+}
+
+func TestDeliveryClaimDropsMessagesFromPreviousDispatch(t *testing.T) {
+	repo := newFakeAudienceRepo()
+	audienceID := uuid.New()
+	repo.set(audienceID, models.AudiencePendente)
+
+	// 📥 Disparo: a mensagem vai para a fila com o primeiro token
+	first := dto.CampaignMessageDTO{ID: audienceID, DispatchToken: uuid.New()}
+	if queued, _ := repo.MarkQueued(context.Background(), audienceID, first.DispatchToken); !queued {
+		t.Fatal("audiência pendente não foi enfileirada")
+	}
+
+	// ⏸️ Pausa devolve a audiência para "pendente"; ▶️ a retomada enfileira de novo com outro token
+	repo.set(audienceID, models.AudiencePendente)
+	second := dto.CampaignMessageDTO{ID: audienceID, DispatchToken: uuid.New()}
+	if queued, _ := repo.MarkQueued(context.Background(), audienceID, second.DispatchToken); !queued {
+		t.Fatal("audiência pendente não foi reenfileirada")
+	}
+
+	var sent []uuid.UUID
+	handler := newDeliveryClaim(logger.GetLogger(), repo).Wrap(func(ctx context.Context, msg dto.CampaignMessageDTO) error {
+		sent = append(sent, msg.DispatchToken)
+		return repo.UpdateStatus(ctx, msg.ID, string(models.AudienceEnviado), "msg-1", nil)
+	})
+
+	// 🔁 As duas cópias chegam ao worker: somente a do último enfileiramento envia
+	for _, msg := range []dto.CampaignMessageDTO{first, second, first} {
+		if err := handler(context.Background(), msg); err != nil {
+			t.Fatalf("entrega: %v", err)
+		}
+	}
+
+	if len(sent) != 1 || sent[0] != second.DispatchToken {
+		t.Fatalf("envios = %v, esperado apenas o token %s", sent, second.DispatchToken)
+	}
+}
+
+func TestDeliveryClaimKeepsSentAudienceWhenStatusIsNotRecorded(t *testing.T) {
+	sentStatusRetryDelay = 0
+	t.Cleanup(func() { sentStatusRetryDelay = time.Second })
+
+	repo := newFakeAudienceRepo()
+	queue := &fakeQueue{}
+	msg := dto.CampaignMessageDTO{ID: uuid.New()}
+	repo.set(msg.ID, models.AudienceFila)
+
+	log := logger.GetLogger()
+	sends := 0
+	process := func(ctx context.Context, msg dto.CampaignMessageDTO) error {
+		sends++
+		repo.updateErr = errors.New("value too long for type character varying(64)")
+		return markSent(ctx, log, repo, msg.ID, "uuid@um-dominio-de-remetente-bem-comprido.example.com")
+	}
+	handler := newDeliveryClaim(log, repo).Wrap(newDeliveryRetrier(log, "email", queue, repo).Wrap(process))
+
+	if err := handler(context.Background(), msg); err != nil {
+		t.Fatalf("entrega: %v", err)
+	}
+	// 🔁 Uma cópia duplicada na fila encontra a audiência ainda reservada e não envia
+	if err := handler(context.Background(), msg); err == nil {
+		t.Fatal("cópia duplicada deve voltar para a fila enquanto a reserva vale")
+	}
+
+	// 📬 Enviado sem status gravado: não volta para a fila, não conta como falha e não é reenviado
+	if got := repo.get(msg.ID); got != models.AudienceEnviando {
+		t.Fatalf("status = %s, esperado enviando", got)
+	}
+	if sends != 1 || queue.sent() != 0 || repo.attempts[msg.ID] != 0 {
+		t.Fatalf("envios = %d, reagendamentos = %d, falhas = %d, esperado um único envio", sends, queue.sent(), repo.attempts[msg.ID])
+	}
+}
+
+func TestMarkSentRetriesStatusWrite(t *testing.T) {
+	sentStatusRetryDelay = 0
+	t.Cleanup(func() { sentStatusRetryDelay = time.Second })
+
+	repo := &flakyStatusRepo{fakeAudienceRepo: newFakeAudienceRepo(), failures: sentStatusAttempts - 1}
+	audienceID := uuid.New()
+	repo.set(audienceID, models.AudienceEnviando)
+
+	if err := markSent(context.Background(), logger.GetLogger(), repo, audienceID, "msg-1"); err != nil {
+		t.Fatalf("markSent: %v", err)
+	}
+	if got := repo.get(audienceID); got != models.AudienceEnviado {
+		t.Fatalf("status = %s, esperado enviado", got)
+	}
+}
+
+// flakyStatusRepo falha as primeiras gravações de status
+type flakyStatusRepo struct {
+	*fakeAudienceRepo
+
+	failures int
+}
+
+func (r *flakyStatusRepo) UpdateStatus(ctx context.Context, audienceID uuid.UUID, status, messageID string, feedback map[string]interface{}) error {
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("conexão perdida")
+	}
+	return r.fakeAudienceRepo.UpdateStatus(ctx, audienceID, status, messageID, feedback)
+}
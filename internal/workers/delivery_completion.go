@@ -0,0 +1,43 @@
+// File: /internal/workers/delivery_completion.go
+
+package workers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// deliveryCompletion detecta, no próprio pipeline, quando a última mensagem da campanha foi finalizada
+type deliveryCompletion struct {
+	log           *slog.Logger
+	campaignState service.CampaignStateService
+}
+
+// newDeliveryCompletion cria a detecção de conclusão de campanhas
+func newDeliveryCompletion(log *slog.Logger, campaignState service.CampaignStateService) *deliveryCompletion {
+	return &deliveryCompletion{log: log, campaignState: campaignState}
+}
+
+// Wrap verifica a conclusão da campanha sempre que uma mensagem sai da fila em definitivo
+// (enviada, descartada ou movida para dead-letter)
+func (c *deliveryCompletion) Wrap(process service.QueueMessageHandler) service.QueueMessageHandler {
+	return func(ctx context.Context, msg dto.CampaignMessageDTO) error {
+		if err := process(ctx, msg); err != nil {
+			return err
+		}
+
+		completed, err := c.campaignState.CompleteIfFinished(ctx, msg.CampaignID)
+		if err != nil {
+			c.log.Warn("⚠️ Erro ao verificar conclusão da campanha", "campaign_id", msg.CampaignID, "error", err)
+			return nil // ✅ A mensagem já foi finalizada; a próxima verificação conclui a campanha
+		}
+		if completed {
+			c.log.Info("🏁 Campanha concluída", "campaign_id", msg.CampaignID)
+		}
+
+		return nil
+	}
+}
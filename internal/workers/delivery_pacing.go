@@ -0,0 +1,69 @@
+// File: /internal/workers/delivery_pacing.go
+
+package workers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// deliveryPacer respeita a janela de envio e os limites da conta antes de cada envio
+type deliveryPacer struct {
+	log        *slog.Logger
+	channel    string
+	sendPacer  service.SendPacerService
+	sqsService service.SQSService
+}
+
+// newDeliveryPacer cria o controle de ritmo do canal (email ou whatsapp)
+func newDeliveryPacer(log *slog.Logger, channel string, sendPacer service.SendPacerService, sqsService service.SQSService) *deliveryPacer {
+	return &deliveryPacer{log: log, channel: channel, sendPacer: sendPacer, sqsService: sqsService}
+}
+
+// sendSlot é a vaga do ritmo/limite diário reservada para um envio
+type sendSlot struct {
+	pacer       *deliveryPacer
+	reservation *service.SendReservation
+	used        bool
+}
+
+// Acquire reserva a vaga do envio depois das verificações (pausa, descadastro, supressão), para que mensagens
+// descartadas não consumam o limite da conta. Fora da janela ou acima do limite, a mensagem é reenfileirada
+// com atraso e `deferred` é true: o processamento deve terminar sem erro e sem enviar.
+func (p *deliveryPacer) Acquire(ctx context.Context, msg dto.CampaignMessageDTO) (slot *sendSlot, deferred bool, err error) {
+	reservation, delay, err := p.sendPacer.Reserve(ctx, msg.AccountID, p.channel)
+	if err != nil {
+		p.log.Error("❌ Erro ao verificar janela de envio", "account_id", msg.AccountID, "error", err)
+		return nil, false, err // 🔄 Falha temporária: passa pela política de retry
+	}
+
+	if delay > 0 {
+		// ⏳ Atrasos maiores que o máximo da fila são reavaliados quando a mensagem voltar
+		p.log.Debug("⏳ Envio adiado pela janela/limite da conta", "channel", p.channel, "audience_id", msg.ID, "delay", delay)
+		if err := p.sqsService.SendMessageWithDelay(ctx, p.channel, msg, delay); err != nil {
+			return nil, false, err
+		}
+		return nil, true, nil
+	}
+
+	return &sendSlot{pacer: p, reservation: reservation}, false, nil
+}
+
+// Confirm marca a vaga como usada: o envio aconteceu
+func (s *sendSlot) Confirm() {
+	s.used = true
+}
+
+// ReleaseUnused devolve a vaga quando o envio não aconteceu (descartado, falha de renderização ou do provedor)
+func (s *sendSlot) ReleaseUnused(ctx context.Context) {
+	if s.used {
+		return
+	}
+
+	if err := s.pacer.sendPacer.Release(context.WithoutCancel(ctx), s.reservation); err != nil {
+		s.pacer.log.Warn("⚠️ Erro ao devolver vaga de envio", "account_id", s.reservation.AccountID, "channel", s.pacer.channel, "error", err)
+	}
+}
@@ -0,0 +1,93 @@
+// File: /internal/workers/delivery_retry.go
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// deliveryRetrier aplica a política de retry de um canal sobre o processamento das mensagens
+type deliveryRetrier struct {
+	log          *slog.Logger
+	channel      string
+	policy       service.RetryPolicy
+	sqsService   service.SQSService
+	audienceRepo db.CampaignAudienceRepository
+	failedStatus models.AudienceStatus // Status da audiência quando o envio é abandonado
+}
+
+// newDeliveryRetrier cria o controle de tentativas do canal (email ou whatsapp).
+// Envios abandonados do WhatsApp ficam como "falha_envio"; os de e-mail, como "dead_letter".
+func newDeliveryRetrier(log *slog.Logger, channel string, sqsService service.SQSService, audienceRepo db.CampaignAudienceRepository) *deliveryRetrier {
+	failedStatus := models.AudienceDeadLetter
+	if channel == "whatsapp" {
+		failedStatus = models.AudienceFalhaEnvio
+	}
+
+	return &deliveryRetrier{
+		log:          log,
+		channel:      channel,
+		policy:       service.NewRetryPolicy(channel),
+		sqsService:   sqsService,
+		audienceRepo: audienceRepo,
+		failedStatus: failedStatus,
+	}
+}
+
+// Wrap envolve o processamento da mensagem: falhas temporárias são reenfileiradas com backoff e
+// falhas permanentes (ou tentativas esgotadas) marcam a audiência com o status de falha do canal,
+// com o erro em feedback_api.
+func (r *deliveryRetrier) Wrap(process service.QueueMessageHandler) service.QueueMessageHandler {
+	return func(ctx context.Context, msg dto.CampaignMessageDTO) error {
+		processErr := process(ctx, msg)
+		if processErr == nil {
+			return nil
+		}
+
+		// 📬 Enviada sem status gravado: não é falha de envio e não pode ser reagendada
+		if errors.Is(processErr, errSentNotRecorded) {
+			return processErr
+		}
+
+		// 🛑 Aplicação encerrando: a fila reentrega a mensagem depois
+		if ctx.Err() != nil {
+			return processErr
+		}
+
+		attempts, err := r.audienceRepo.RegisterFailure(ctx, msg.ID, processErr.Error())
+		if err != nil {
+			r.log.Error("❌ Erro ao registrar falha de envio", "audience_id", msg.ID, "error", err)
+			return processErr // 🔄 Sem controle de tentativas: deixa o visibility timeout reentregar
+		}
+
+		if !service.IsRetryableError(processErr) || attempts >= r.policy.MaxAttempts {
+			r.log.Error("☠️ Envio abandonado",
+				"channel", r.channel, "audience_id", msg.ID, "status", r.failedStatus, "attempts", attempts, "error", processErr)
+
+			feedback := map[string]interface{}{"error": processErr.Error(), "attempts": attempts}
+			if err := r.audienceRepo.UpdateStatus(ctx, msg.ID, string(r.failedStatus), "", feedback); err != nil {
+				r.log.Error("❌ Erro ao marcar falha do envio", "audience_id", msg.ID, "status", r.failedStatus, "error", err)
+				return processErr
+			}
+			return nil
+		}
+
+		delay := r.policy.Backoff(attempts)
+		r.log.Warn("🔁 Falha temporária no envio, reagendando",
+			"channel", r.channel, "audience_id", msg.ID, "attempts", attempts, "delay", delay, "error", processErr)
+
+		if err := r.sqsService.SendMessageWithDelay(ctx, r.channel, msg, delay); err != nil {
+			r.log.Error("❌ Erro ao reagendar mensagem", "audience_id", msg.ID, "error", err)
+			return processErr
+		}
+
+		return nil // ✅ A mensagem original sai da fila; a nova tentativa já foi agendada
+	}
+}
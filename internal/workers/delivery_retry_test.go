@@ -0,0 +1,93 @@
+// File: /internal/workers/delivery_retry_test.go
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/dto"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+func TestDeliveryRetrier(t *testing.T) {
+	t.Setenv("EMAIL_RETRY_MAX_ATTEMPTS", "3")
+
+	tests := []struct {
+		name       string
+		err        error
+		previous   int // Tentativas que já falharam
+		wantResent int
+		wantStatus models.AudienceStatus
+	}{
+		{name: "sucesso", err: nil, wantStatus: models.AudienceEnviando},
+		{name: "falha temporária é reagendada", err: errors.New("timeout"), wantResent: 1, wantStatus: models.AudienceEnviando},
+		{name: "falha permanente vai para dead-letter", err: service.NewPermanentError("sem e-mail"), wantStatus: models.AudienceDeadLetter},
+		{name: "tentativas esgotadas vão para dead-letter", err: errors.New("timeout"), previous: 2, wantStatus: models.AudienceDeadLetter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeAudienceRepo()
+			queue := &fakeQueue{}
+			msg := dto.CampaignMessageDTO{ID: uuid.New()}
+			repo.set(msg.ID, models.AudienceEnviando)
+			repo.attempts[msg.ID] = tt.previous
+
+			retrier := newDeliveryRetrier(logger.GetLogger(), "email", queue, repo)
+			err := retrier.Wrap(func(ctx context.Context, msg dto.CampaignMessageDTO) error { return tt.err })(context.Background(), msg)
+
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if got := queue.sent(); got != tt.wantResent {
+				t.Fatalf("reenfileirada %d vezes, esperado %d", got, tt.wantResent)
+			}
+			if got := repo.get(msg.ID); got != tt.wantStatus {
+				t.Fatalf("status = %s, esperado %s", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestDeliveryRetrierWhatsAppExhaustedMarksSendFailure(t *testing.T) {
+	t.Setenv("WHATSAPP_RETRY_MAX_ATTEMPTS", "3")
+
+	tests := []struct {
+		name     string
+		err      error
+		previous int
+	}{
+		{name: "tentativas esgotadas", err: errors.New("sessão do WhatsApp desconectada"), previous: 2},
+		{name: "falha permanente", err: service.NewPermanentError("contato sem WhatsApp")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeAudienceRepo()
+			queue := &fakeQueue{}
+			msg := dto.CampaignMessageDTO{ID: uuid.New()}
+			repo.set(msg.ID, models.AudienceEnviando)
+			repo.attempts[msg.ID] = tt.previous
+
+			retrier := newDeliveryRetrier(logger.GetLogger(), "whatsapp", queue, repo)
+			if err := retrier.Wrap(func(ctx context.Context, msg dto.CampaignMessageDTO) error { return tt.err })(context.Background(), msg); err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+
+			if queue.sent() != 0 {
+				t.Fatal("envio abandonado não deve ser reenfileirado")
+			}
+			if got := repo.get(msg.ID); got != models.AudienceFalhaEnvio {
+				t.Fatalf("status = %s, esperado falha_envio", got)
+			}
+			if feedback := repo.feedback[msg.ID]; feedback["error"] != tt.err.Error() {
+				t.Fatalf("feedback = %v, esperado o erro do envio", feedback)
+			}
+		})
+	}
+}
@@ -4,12 +4,14 @@ package workers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/jeancarlosdanese/go-marketing/internal/db"
 	"github.com/jeancarlosdanese/go-marketing/internal/dto"
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
 	"github.com/jeancarlosdanese/go-marketing/internal/service"
 )
 
@@ -30,6 +32,14 @@ type emailWorker struct {
 	accountSettingsRepo  db.AccountSettingsRepository
 	campaignSettingsRepo db.CampaignSettingsRepository
 	openAIClient         service.OpenAIService
+	concurrency          int
+	claim                *deliveryClaim
+	retrier              *deliveryRetrier
+	pacer                *deliveryPacer
+	completion           *deliveryCompletion
+	suppression          service.SuppressionService
+	senderIdentities     service.SenderIdentityService
+	deliveredMessages    db.DeliveredMessageRepository
 }
 
 // NewEmailWorker cria um novo Worker de E-mails
@@ -43,9 +53,17 @@ func NewEmailWorker(
 	accountSettingsRepo db.AccountSettingsRepository,
 	campaignSettingsRepo db.CampaignSettingsRepository,
 	openAIClient service.OpenAIService,
+	sendPacer service.SendPacerService,
+	campaignState service.CampaignStateService,
+	suppression service.SuppressionService,
+	senderIdentities service.SenderIdentityService,
+	deliveredMessages db.DeliveredMessageRepository,
+	concurrency int,
 ) EmailWorker {
+	log := logger.GetLogger()
+
 	return &emailWorker{
-		log:                  logger.GetLogger(),
+		log:                  log,
 		sqsService:           sqsService,
 		emailService:         emailService,
 		audienceRepo:         audienceRepo,
@@ -55,6 +73,14 @@ func NewEmailWorker(
 		accountSettingsRepo:  accountSettingsRepo,
 		campaignSettingsRepo: campaignSettingsRepo,
 		openAIClient:         openAIClient,
+		concurrency:          concurrency,
+		claim:                newDeliveryClaim(log, audienceRepo),
+		retrier:              newDeliveryRetrier(log, "email", sqsService, audienceRepo),
+		pacer:                newDeliveryPacer(log, "email", sendPacer, sqsService),
+		completion:           newDeliveryCompletion(log, campaignState),
+		suppression:          suppression,
+		senderIdentities:     senderIdentities,
+		deliveredMessages:    deliveredMessages,
 	}
 }
 
@@ -62,15 +88,9 @@ func NewEmailWorker(
 func (w *emailWorker) Start(ctx context.Context) {
 	w.log.Info("📨 EmailWorker iniciado 🚀")
 	go func() {
-		err := w.sqsService.ReceiveMessages(ctx, "email", func(msg dto.CampaignMessageDTO) error {
-			go func() {
-				err := w.processEmailMessage(ctx, msg)
-				if err != nil {
-					w.log.Error("❌ Erro ao processar mensagem", "error", err)
-				}
-			}()
-			return nil
-		})
+		// 🔒 No máximo w.concurrency mensagens em paralelo; cada audiência é reservada por um único worker,
+		// a janela de envio da conta é respeitada e as falhas passam pela política de retry do canal
+		err := w.sqsService.ReceiveMessages(ctx, "email", w.concurrency, w.completion.Wrap(w.claim.Wrap(w.retrier.Wrap(w.processEmailMessage))))
 		if err != nil {
 			w.log.Error("❌ Erro ao iniciar processamento de mensagens", "error", err)
 		}
@@ -102,6 +122,11 @@ func (w *emailWorker) processEmailMessage(ctx context.Context, campaignMessage d
 		return fmt.Errorf("campanha não encontrada (campaign_id: %s)", campaignMessage.CampaignID)
 	}
 
+	// ⏸️ Campanha pausada ou cancelada: não enviar
+	if hold, err := holdIfInactive(ctx, w.log, w.audienceRepo, campaign, campaignMessage.ID); hold {
+		return err
+	}
+
 	// 🔍 Buscar configurações da campanha
 	campaignSettings, err := w.campaignSettingsRepo.GetSettingsByCampaignID(ctx, campaignMessage.CampaignID)
 	if err != nil || campaignSettings == nil {
@@ -115,38 +140,100 @@ func (w *emailWorker) processEmailMessage(ctx context.Context, campaignMessage d
 		}
 	}
 
+	// ✉️ Remetente precisa estar verificado (a campanha é validada ao iniciar, mas a verificação pode ter caído)
+	sender, err := w.senderIdentities.Resolve(ctx, campaignMessage.AccountID, *campaignSettings)
+	if errors.Is(err, service.ErrSenderNotVerified) {
+		w.log.Error("❌ Remetente não verificado", "campaign_id", campaignMessage.CampaignID, "email_from", campaignSettings.EmailFrom, "error", err)
+		return service.NewPermanentError("%w", err)
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao buscar remetente da campanha (campaign_id: %s): %w", campaignMessage.CampaignID, err)
+	}
+
 	// 🔍 Buscar detalhes do contato no banco
 	contact, err := w.contactRepo.GetByID(ctx, campaignMessage.ContactID)
-	if err != nil || contact == nil {
-		w.log.Error("❌ Contato não encontrado", "contact_id", campaignMessage.ContactID, "error", err)
-		return fmt.Errorf("contato não encontrado (contact_id: %s)", campaignMessage.ContactID)
+	if err != nil {
+		w.log.Error("❌ Erro ao buscar contato", "contact_id", campaignMessage.ContactID, "error", err)
+		return fmt.Errorf("erro ao buscar contato (contact_id: %s): %w", campaignMessage.ContactID, err)
+	}
+	if contact == nil {
+		w.log.Error("❌ Contato não encontrado", "contact_id", campaignMessage.ContactID)
+		return service.NewPermanentError("contato não encontrado (contact_id: %s)", campaignMessage.ContactID)
+	}
+
+	// 🚫 Descadastrado depois do enfileiramento
+	if skip, err := skipIfOptedOut(ctx, w.log, w.audienceRepo, contact, models.EmailChannel, campaignMessage.ID); skip {
+		return err
 	}
 
 	// 🔍 Validar se o contato possui e-mail
 	if contact.Email == nil || *contact.Email == "" {
 		w.log.Error("❌ Contato não possui e-mail válido", "contact_id", campaignMessage.ContactID)
-		return fmt.Errorf("contato %s não possui e-mail válido", campaignMessage.ContactID)
+		return service.NewPermanentError("contato %s não possui e-mail válido", campaignMessage.ContactID)
 	}
 
+	// 📭 E-mail reprovado na verificação (sintaxe ou domínio sem servidor de e-mail)
+	if skip, err := skipIfInvalidEmail(ctx, w.log, w.audienceRepo, contact, campaignMessage.ID); skip {
+		return err
+	}
+
+	// 🛑 Destinatário na lista de supressão (bounce, reclamação ou bloqueio manual)
+	if skip, err := skipIfSuppressed(ctx, w.log, w.audienceRepo, w.suppression, campaignMessage, models.EmailChannel, *contact.Email); skip {
+		return err
+	}
+
+	// ⏱️ Janela de envio, ritmo e limite diário da conta (a vaga volta se o e-mail não sair)
+	slot, deferred, err := w.pacer.Acquire(ctx, campaignMessage)
+	if err != nil || deferred {
+		return err
+	}
+	defer slot.ReleaseUnused(ctx)
+
 	// 🔹 Criar conteúdo do e-mail usando AI
 	emailData, err := w.emailService.CreateEmailWithAI(ctx, *contact, *campaign, *campaignSettings)
-	if err != nil || emailData == nil {
+	if err != nil {
 		w.log.Error("❌ Erro ao criar e-mail com AI", "contact_id", campaignMessage.ContactID, "error", err)
+		return fmt.Errorf("falha ao gerar emailData (contact_id: %s): %w", campaignMessage.ContactID, err)
+	}
+	if emailData == nil {
+		w.log.Error("❌ E-mail gerado com AI vazio", "contact_id", campaignMessage.ContactID)
 		return fmt.Errorf("falha ao gerar emailData (contact_id: %s)", campaignMessage.ContactID)
 	}
 
 	w.log.Info("📨 Preparando e-mail para envio", "to", *contact.Email)
 
 	// 🚀 Enviar e-mail
-	sesEmailOutput, err := w.emailService.SendEmail(*account, *accountSettings, *campaign, *campaignSettings, *contact, *emailData)
+	sendResult, err := w.emailService.SendEmail(ctx, *account, *accountSettings, *campaign, *campaignSettings, *sender, *contact, campaignMessage.ID, *emailData)
 	if err != nil {
 		w.log.Error("Erro ao enviar email", "error", err)
 		return err
 	}
+	slot.Confirm()
+
+	// ✅ Atualizar status para "enviado" (o e-mail já saiu: uma falha aqui não deve gerar reenvio)
+	statusErr := markSent(ctx, w.log, w.audienceRepo, campaignMessage.ID, sendResult.MessageID)
+
+	// 🗄️ Guardar o conteúdo exato entregue (conformidade e atendimento)
+	if content := sendResult.Content; content != nil {
+		recordDeliveredMessage(ctx, w.log, w.deliveredMessages, models.DeliveredMessage{
+			AccountID:         campaignMessage.AccountID,
+			CampaignID:        campaignMessage.CampaignID,
+			AudienceID:        campaignMessage.ID,
+			ContactID:         &contact.ID,
+			Channel:           models.EmailChannel,
+			Provider:          deliveredString(string(sendResult.Provider)),
+			ProviderMessageID: deliveredString(sendResult.MessageID),
+			Subject:           deliveredString(content.Subject),
+			BodyFormat:        models.DeliveredMessageHTML,
+			Body:              content.HTML,
+			BodyText:          deliveredString(content.Text),
+			TemplateID:        &content.TemplateID,
+			TemplateVersion:   deliveredString(content.TemplateVersion),
+			AIModel:           deliveredString(emailData.AIModel),
+			AIPrompt:          deliveredString(emailData.AIPrompt),
+		})
+	}
 
-	// ✅ Atualizar status para "enviado"
-	w.audienceRepo.UpdateStatus(ctx, contact.ID, "enviado", *sesEmailOutput.MessageId, nil)
-
-	w.log.Info("✅ E-mail enviado com sucesso!", "to", *sesEmailOutput.MessageId)
-	return nil
+	w.log.Info("✅ E-mail enviado com sucesso!", "provider", sendResult.Provider, "message_id", sendResult.MessageID)
+	return statusErr
 }
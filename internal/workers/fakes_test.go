@@ -0,0 +1,142 @@
+// File: /internal/workers/fakes_test.go
+
+package workers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/models"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// fakeAudienceRepo guarda o status da audiência em memória (os demais métodos não são usados nos testes)
+type fakeAudienceRepo struct {
+	db.CampaignAudienceRepository
+
+	mu       sync.Mutex
+	status   map[uuid.UUID]models.AudienceStatus
+	feedback map[uuid.UUID]map[string]interface{}
+	attempts map[uuid.UUID]int
+	tokens   map[uuid.UUID]uuid.UUID
+	released int
+
+	updateErr error // Falha simulada na gravação de status
+}
+
+func newFakeAudienceRepo() *fakeAudienceRepo {
+	return &fakeAudienceRepo{
+		status:   map[uuid.UUID]models.AudienceStatus{},
+		feedback: map[uuid.UUID]map[string]interface{}{},
+		attempts: map[uuid.UUID]int{},
+		tokens:   map[uuid.UUID]uuid.UUID{},
+	}
+}
+
+func (r *fakeAudienceRepo) set(audienceID uuid.UUID, status models.AudienceStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status[audienceID] = status
+}
+
+func (r *fakeAudienceRepo) get(audienceID uuid.UUID) models.AudienceStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status[audienceID]
+}
+
+func (r *fakeAudienceRepo) GetByID(ctx context.Context, audienceID uuid.UUID) (*models.CampaignAudience, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status, ok := r.status[audienceID]
+	if !ok {
+		return nil, nil
+	}
+	audience := &models.CampaignAudience{ID: audienceID, Status: status, Attempts: r.attempts[audienceID]}
+	if token, ok := r.tokens[audienceID]; ok {
+		audience.DispatchToken = &token
+	}
+	return audience, nil
+}
+
+func (r *fakeAudienceRepo) UpdateStatus(ctx context.Context, audienceID uuid.UUID, status, messageID string, feedback map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+	r.status[audienceID] = models.AudienceStatus(status)
+	r.feedback[audienceID] = feedback
+	return nil
+}
+
+func (r *fakeAudienceRepo) MarkQueued(ctx context.Context, audienceID uuid.UUID, token uuid.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch r.status[audienceID] {
+	case models.AudiencePendente, models.AudienceFila, models.AudienceFalhaEnvio:
+		r.status[audienceID] = models.AudienceFila
+		r.tokens[audienceID] = token
+		return true, nil
+	}
+	return false, nil
+}
+
+func (r *fakeAudienceRepo) ClaimForSending(ctx context.Context, audienceID uuid.UUID, token uuid.UUID, lease time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tokens[audienceID] != token {
+		return false, nil // 🎟️ Mensagem de um enfileiramento anterior
+	}
+	switch r.status[audienceID] {
+	case models.AudiencePendente, models.AudienceFila:
+		r.status[audienceID] = models.AudienceEnviando
+		return true, nil
+	}
+	return false, nil
+}
+
+func (r *fakeAudienceRepo) ReleaseClaim(ctx context.Context, audienceID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.status[audienceID] == models.AudienceEnviando {
+		r.status[audienceID] = models.AudienceFila
+		r.released++
+	}
+	return nil
+}
+
+func (r *fakeAudienceRepo) RegisterFailure(ctx context.Context, audienceID uuid.UUID, lastError string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts[audienceID]++
+	return r.attempts[audienceID], nil
+}
+
+// fakeQueue registra as mensagens reenfileiradas pelos workers
+type fakeQueue struct {
+	service.SQSService
+
+	mu      sync.Mutex
+	delayed []time.Duration
+}
+
+func (q *fakeQueue) SendMessage(ctx context.Context, queueName string, message interface{}) error {
+	return q.SendMessageWithDelay(ctx, queueName, message, 0)
+}
+
+func (q *fakeQueue) SendMessageWithDelay(ctx context.Context, queueName string, message interface{}, delay time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.delayed = append(q.delayed, delay)
+	return nil
+}
+
+func (q *fakeQueue) sent() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.delayed)
+}
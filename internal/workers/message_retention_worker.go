@@ -0,0 +1,76 @@
+// File: /internal/workers/message_retention_worker.go
+
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/db"
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+)
+
+// MessageRetentionWorker remove o conteúdo entregue que passou da retenção de cada conta
+type MessageRetentionWorker interface {
+	Start(ctx context.Context)
+}
+
+// messageRetentionWorker apaga as mensagens expiradas em lotes, periodicamente
+type messageRetentionWorker struct {
+	log               *slog.Logger
+	deliveredMessages db.DeliveredMessageRepository
+	interval          time.Duration
+	batchSize         int
+}
+
+// NewMessageRetentionWorker cria a limpeza das mensagens entregues
+func NewMessageRetentionWorker(deliveredMessages db.DeliveredMessageRepository, interval time.Duration) MessageRetentionWorker {
+	return &messageRetentionWorker{
+		log:               logger.GetLogger(),
+		deliveredMessages: deliveredMessages,
+		interval:          interval,
+		batchSize:         1000,
+	}
+}
+
+// Start inicia o loop de limpeza até o contexto ser cancelado
+func (w *messageRetentionWorker) Start(ctx context.Context) {
+	w.log.Info("🧹 MessageRetentionWorker iniciado 🚀", "interval", w.interval)
+
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.purgeExpired(ctx)
+
+			select {
+			case <-ctx.Done():
+				w.log.Info("Encerrando MessageRetentionWorker")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// purgeExpired remove lotes até não restar mensagem expirada (lotes curtos não travam a tabela)
+func (w *messageRetentionWorker) purgeExpired(ctx context.Context) {
+	var total int64
+	for ctx.Err() == nil {
+		deleted, err := w.deliveredMessages.DeleteExpired(ctx, w.batchSize)
+		if err != nil {
+			w.log.Error("❌ Erro ao remover mensagens entregues expiradas", "error", err)
+			return
+		}
+		total += deleted
+		if deleted < int64(w.batchSize) {
+			break
+		}
+	}
+
+	if total > 0 {
+		w.log.Info("🧹 Mensagens entregues removidas pela política de retenção", "total", total)
+	}
+}
@@ -0,0 +1,57 @@
+// File: /internal/workers/ses_feedback_worker.go
+
+package workers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jeancarlosdanese/go-marketing/internal/logger"
+	"github.com/jeancarlosdanese/go-marketing/internal/service"
+)
+
+// SESFeedbackWorker define as operações para consumir os eventos do SES de uma fila SQS
+type SESFeedbackWorker interface {
+	Start(ctx context.Context)
+}
+
+// sesFeedbackWorker aplica os eventos do SES (via SNS → SQS) como o webhook /ses-feedback,
+// para instalações em rede privada onde o SNS não alcança a API
+type sesFeedbackWorker struct {
+	log         *slog.Logger
+	consumer    service.SQSConsumer
+	sesEvents   service.SESEventService
+	concurrency int
+}
+
+// NewSESFeedbackWorker cria o consumidor da fila de eventos do SES
+func NewSESFeedbackWorker(consumer service.SQSConsumer, sesEvents service.SESEventService, concurrency int) SESFeedbackWorker {
+	return &sesFeedbackWorker{
+		log:         logger.GetLogger(),
+		consumer:    consumer,
+		sesEvents:   sesEvents,
+		concurrency: concurrency,
+	}
+}
+
+// Start inicia o consumo da fila de eventos do SES
+func (w *sesFeedbackWorker) Start(ctx context.Context) {
+	w.log.Info("📬 SESFeedbackWorker iniciado 🚀")
+	go func() {
+		if err := w.consumer.Consume(ctx, w.concurrency, w.processEvent); err != nil {
+			w.log.Error("❌ Erro ao iniciar processamento de eventos do SES", "error", err)
+		}
+	}()
+}
+
+// processEvent aplica um evento; mensagens que não são eventos do SES são descartadas
+// e falhas de banco mantêm a mensagem na fila para nova tentativa
+func (w *sesFeedbackWorker) processEvent(ctx context.Context, body []byte) error {
+	sesEvent, err := service.DecodeSESQueueMessage(body)
+	if err != nil {
+		w.log.Warn("⚠️ Mensagem inválida na fila de eventos do SES, descartando", "error", err, "raw_msg", string(body))
+		return nil
+	}
+
+	return w.sesEvents.Process(ctx, *sesEvent)
+}
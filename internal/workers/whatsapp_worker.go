@@ -4,13 +4,19 @@ package workers
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 
+	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/go-marketing/internal/db"
 	"github.com/jeancarlosdanese/go-marketing/internal/dto"
 	"github.com/jeancarlosdanese/go-marketing/internal/logger"
 	"github.com/jeancarlosdanese/go-marketing/internal/models"
 	"github.com/jeancarlosdanese/go-marketing/internal/service"
+	"github.com/jeancarlosdanese/go-marketing/internal/utils"
 )
 
 type WhatsAppWorker interface {
@@ -21,39 +27,66 @@ type WhatsAppWorker interface {
 type whatsAppWorker struct {
 	log                  *slog.Logger
 	sqsService           service.SQSService
-	whatsappService      service.WhatsAppService
+	providers            service.WhatsAppProviders
+	chatRepo             db.ChatRepository
+	campaignProcessor    service.CampaignProcessorService
 	audienceRepo         db.CampaignAudienceRepository
 	contactRepo          db.ContactRepository
 	campaignRepo         db.CampaignRepository
 	accountRepo          db.AccountRepository
 	accountSettingsRepo  db.AccountSettingsRepository
 	campaignSettingsRepo db.CampaignSettingsRepository
-	openAIClient         service.OpenAIService
+	concurrency          int
+	claim                *deliveryClaim
+	retrier              *deliveryRetrier
+	pacer                *deliveryPacer
+	completion           *deliveryCompletion
+	suppression          service.SuppressionService
+	deliveredMessages    db.DeliveredMessageRepository
+	templates            service.WhatsAppTemplateService
 }
 
 // NewWhatsAppWorker cria um novo Worker de WhatsApp
 func NewWhatsAppWorker(
 	sqsService service.SQSService,
-	whatsappService service.WhatsAppService,
+	providers service.WhatsAppProviders,
+	chatRepo db.ChatRepository,
+	campaignProcessor service.CampaignProcessorService,
 	audienceRepo db.CampaignAudienceRepository,
 	contactRepo db.ContactRepository,
 	campaignRepo db.CampaignRepository,
 	accountRepo db.AccountRepository,
 	accountSettingsRepo db.AccountSettingsRepository,
 	campaignSettingsRepo db.CampaignSettingsRepository,
-	openAIClient service.OpenAIService,
+	sendPacer service.SendPacerService,
+	campaignState service.CampaignStateService,
+	suppression service.SuppressionService,
+	deliveredMessages db.DeliveredMessageRepository,
+	templates service.WhatsAppTemplateService,
+	concurrency int,
 ) WhatsAppWorker {
+	log := logger.GetLogger()
+
 	return &whatsAppWorker{
-		log:                  logger.GetLogger(),
+		log:                  log,
 		sqsService:           sqsService,
-		whatsappService:      whatsappService,
+		providers:            providers,
+		chatRepo:             chatRepo,
+		campaignProcessor:    campaignProcessor,
 		audienceRepo:         audienceRepo,
 		contactRepo:          contactRepo,
 		campaignRepo:         campaignRepo,
 		accountRepo:          accountRepo,
 		accountSettingsRepo:  accountSettingsRepo,
 		campaignSettingsRepo: campaignSettingsRepo,
-		openAIClient:         openAIClient,
+		concurrency:          concurrency,
+		claim:                newDeliveryClaim(log, audienceRepo),
+		retrier:              newDeliveryRetrier(log, "whatsapp", sqsService, audienceRepo),
+		pacer:                newDeliveryPacer(log, "whatsapp", sendPacer, sqsService),
+		completion:           newDeliveryCompletion(log, campaignState),
+		suppression:          suppression,
+		deliveredMessages:    deliveredMessages,
+		templates:            templates,
 	}
 }
 
@@ -61,76 +94,310 @@ func NewWhatsAppWorker(
 func (w *whatsAppWorker) Start(ctx context.Context) {
 	w.log.Info("📨 WhatsAppWorker iniciado 🚀")
 	go func() {
-		err := w.sqsService.ReceiveMessages(ctx, "whatsapp", func(msg dto.CampaignMessageDTO) error {
-			go func() { // Processa cada mensagem em uma goroutine separada
-				err := w.processWhatsAppMessage(ctx, msg)
-				if err != nil {
-					w.log.Error("❌ Erro ao processar mensagem do WhatsApp", "error", err)
-				}
-			}()
-			return nil
-		})
+		// 🔒 No máximo w.concurrency mensagens em paralelo; cada audiência é reservada por um único worker,
+		// a janela de envio da conta é respeitada e as falhas passam pela política de retry do canal
+		err := w.sqsService.ReceiveMessages(ctx, "whatsapp", w.concurrency, w.completion.Wrap(w.claim.Wrap(w.retrier.Wrap(w.processWhatsAppMessage))))
 		if err != nil {
 			w.log.Error("❌ Erro ao iniciar processamento de mensagens", "error", err)
 		}
 	}()
 }
 
-// processWhatsAppMessage processa mensagens do WhatsApp
+// processWhatsAppMessage gera o conteúdo da mensagem, renderiza o template do WhatsApp e envia
+// pela sessão do chat escolhido nas configurações da campanha
 func (w *whatsAppWorker) processWhatsAppMessage(ctx context.Context, campaignMessage dto.CampaignMessageDTO) error {
-	w.log.Info("📨 Processando mensagem de WhatsApp", "contact_id", campaignMessage.ContactID)
-
-	// // 🔍 Buscar conta
-	// account, err := w.accountRepo.GetByID(ctx, campaignMessage.AccountID)
-	// if err != nil {
-	// 	w.log.Error("❌ Erro ao buscar conta", "account_id", campaignMessage.AccountID, "error", err)
-	// 	return err
-	// }
+	w.log.Info("📨 Processando mensagem de WhatsApp", "campaign_id", campaignMessage.CampaignID, "contact_id", campaignMessage.ContactID)
 
-	// // 🔍 Buscar configurações da conta
-	// accountSettings, err := w.accountSettingsRepo.GetByAccountID(ctx, campaignMessage.AccountID)
-	// if err != nil {
-	// 	w.log.Error("❌ Erro ao buscar configurações da conta", "account_id", campaignMessage.AccountID, "error", err)
-	// }
+	// 🔍 Buscar conta
+	account, err := w.accountRepo.GetByID(ctx, campaignMessage.AccountID)
+	if err != nil || account == nil {
+		w.log.Error("❌ Conta não encontrada", "account_id", campaignMessage.AccountID, "error", err)
+		return fmt.Errorf("conta não encontrada (account_id: %s)", campaignMessage.AccountID)
+	}
 
-	// 🔍 Buscar configurações da campanha
+	// 🔍 Buscar campanha
 	campaign, err := w.campaignRepo.GetByID(ctx, campaignMessage.CampaignID)
 	if err != nil {
 		w.log.Error("❌ Erro ao buscar campanha", "campaign_id", campaignMessage.CampaignID, "error", err)
 		return err
 	}
+	if campaign == nil {
+		w.log.Warn("⚠️ Campanha removida, descartando mensagem", "campaign_id", campaignMessage.CampaignID)
+		return nil
+	}
+
+	// ⏸️ Campanha pausada ou cancelada: não enviar
+	if hold, err := holdIfInactive(ctx, w.log, w.audienceRepo, campaign, campaignMessage.ID); hold {
+		return err
+	}
+
+	// 🔍 Buscar configurações da campanha
+	campaignSettings, err := w.campaignSettingsRepo.GetSettingsByCampaignID(ctx, campaignMessage.CampaignID)
+	if err != nil || campaignSettings == nil {
+		w.log.Warn("⚠️ Configurações da campanha não encontradas, buscando última configuração usada pela conta...", "campaign_id", campaignMessage.CampaignID)
 
-	// // 🔍 Buscar configurações da campanha
-	// campaignSettings, err := w.campaignSettingsRepo.GetSettingsByCampaignID(ctx, campaignMessage.CampaignID)
-	// if err != nil {
-	// 	w.log.Error("❌ Erro ao buscar configurações da campanha", "campaign_id", campaignMessage.CampaignID, "error", err)
-	// 	return err
-	// }
+		// 🔄 Fallback: tentar buscar a última configuração usada pela conta
+		campaignSettings, err = w.campaignSettingsRepo.GetLastSettings(ctx, campaignMessage.AccountID)
+		if err != nil || campaignSettings == nil {
+			w.log.Error("❌ Nenhuma configuração encontrada para esta campanha ou conta", "campaign_id", campaignMessage.CampaignID)
+			return fmt.Errorf("nenhuma configuração encontrada para a campanha ou conta (campaign_id: %s)", campaignMessage.CampaignID)
+		}
+	}
+
+	// 💬 Chat (sessão do WhatsApp) que envia a campanha
+	chat, provider, err := w.sendingChat(ctx, campaignMessage, campaignSettings)
+	if err != nil {
+		return err
+	}
 
 	// 🔍 Buscar detalhes do contato no banco
 	contact, err := w.contactRepo.GetByID(ctx, campaignMessage.ContactID)
 	if err != nil {
 		w.log.Error("❌ Erro ao buscar contato", "contact_id", campaignMessage.ContactID, "error", err)
+		return fmt.Errorf("erro ao buscar contato (contact_id: %s): %w", campaignMessage.ContactID, err)
+	}
+
+	// 🔍 Validar se o contato possui WhatsApp
+	if contact == nil || contact.WhatsApp == nil || *contact.WhatsApp == "" {
+		w.log.Error("❌ Contato não possui WhatsApp válido", "contact_id", campaignMessage.ContactID)
+		return service.NewPermanentError("contato %s não possui WhatsApp válido", campaignMessage.ContactID)
+	}
+
+	// 🚫 Descadastrado depois do enfileiramento
+	if skip, err := skipIfOptedOut(ctx, w.log, w.audienceRepo, contact, models.WhatsappChannel, campaignMessage.ID); skip {
 		return err
 	}
 
-	// 🔹 Enviar mensagem via Evolution API
-	channel := campaign.Channels["email"]
-	whatsappRequest := models.WhatsAppRequest{
-		To:         *contact.WhatsApp,
-		TemplateID: channel.TemplateID.String(),
-		Variables: map[string]string{
-			"Nome":     contact.Name,
-			"WhatsApp": *contact.WhatsApp,
-		},
+	// 🛑 Destinatário na lista de supressão (bounce, reclamação ou bloqueio manual)
+	if skip, err := skipIfSuppressed(ctx, w.log, w.audienceRepo, w.suppression, campaignMessage, models.WhatsappChannel, *contact.WhatsApp); skip {
+		return err
 	}
 
-	err = w.whatsappService.SendWhatsApp(whatsappRequest)
-	if err != nil {
-		w.log.Error("Erro ao enviar WhatsApp", "error", err)
+	// ⏱️ Janela de envio, ritmo e limite diário da conta (a vaga volta se a mensagem não sair)
+	slot, deferred, err := w.pacer.Acquire(ctx, campaignMessage)
+	if err != nil || deferred {
 		return err
 	}
+	defer slot.ReleaseUnused(ctx)
+
+	// 🔎 Resolver o JID do destinatário na sessão (o número precisa ter WhatsApp)
+	number := utils.NormalizeWhatsAppNumber(*contact.WhatsApp)
+	resolved, err := provider.ResolveNumber(ctx, chat, number)
+	if err != nil {
+		return fmt.Errorf("erro ao resolver número do WhatsApp (contact_id: %s): %w", campaignMessage.ContactID, err)
+	}
+	if !resolved.Found {
+		w.log.Warn("⚠️ Número sem conta no WhatsApp", "contact_id", campaignMessage.ContactID, "number", number)
+		return service.NewPermanentError("número %s não possui conta no WhatsApp", number)
+	}
+	jid := resolved.RegisteredJID
+	if jid == "" {
+		jid = number + "@s.whatsapp.net"
+	}
+
+	// 🧾 Na Cloud API a campanha sai como template aprovado (HSM) da WABA do número
+	if chat.Provider == models.WhatsAppProviderCloudAPI {
+		return w.sendCloudTemplate(ctx, campaignMessage, account, campaign, campaignSettings, contact, chat, provider, jid, slot)
+	}
+
+	channel, ok := campaign.Channels["whatsapp"]
+	if !ok || channel.TemplateID == uuid.Nil {
+		w.log.Error("❌ Campanha sem template de WhatsApp", "campaign_id", campaignMessage.CampaignID)
+		return service.NewPermanentError("campanha %s sem template de WhatsApp", campaignMessage.CampaignID)
+	}
+
+	// 🔹 Criar conteúdo da mensagem usando AI
+	content, prompt, err := w.campaignProcessor.GenerateCampaignContent(ctx,
+		dto.ToCampaignMessageFullDTO(*account, *campaign, *campaignSettings, *contact, "whatsapp"))
+	if err != nil || content == nil {
+		w.log.Error("❌ Erro ao gerar conteúdo com AI", "contact_id", campaignMessage.ContactID, "error", err)
+		return fmt.Errorf("falha ao gerar conteúdo do WhatsApp (contact_id: %s): %w", campaignMessage.ContactID, err)
+	}
+
+	// 🧩 Aplicar o conteúdo ao template do WhatsApp
+	message, templateVersion, err := service.RenderWithTemplateFileVersion(content, channel.TemplateID, "whatsapp")
+	if err != nil {
+		w.log.Error("❌ Erro ao renderizar template do WhatsApp", "template_id", channel.TemplateID, "error", err)
+
+		feedback := map[string]interface{}{"error": err.Error(), "template_id": channel.TemplateID}
+		if err := w.audienceRepo.UpdateStatus(ctx, campaignMessage.ID, string(models.AudienceFalhaRenderizacao), "", feedback); err != nil {
+			return err // 🔄 A fila reentrega e a renderização é refeita
+		}
+		return nil
+	}
+
+	// 🚀 Enviar pelo provedor do chat
+	sendResult, err := provider.SendText(ctx, chat, jid, message)
+	if err != nil {
+		w.log.Error("❌ Erro ao enviar WhatsApp", "chat_id", chat.ID, "contact_id", campaignMessage.ContactID, "error", err)
+		return fmt.Errorf("erro ao enviar WhatsApp (chat_id: %s): %w", chat.ID, err)
+	}
+	slot.Confirm()
+
+	// ✅ Atualizar status para "enviado" (a mensagem já saiu: uma falha aqui não deve gerar reenvio)
+	statusErr := markSent(ctx, w.log, w.audienceRepo, campaignMessage.ID, sendResult.MessageID)
+
+	// 🗄️ Guardar o conteúdo exato entregue (conformidade e atendimento)
+	recordDeliveredMessage(ctx, w.log, w.deliveredMessages, models.DeliveredMessage{
+		AccountID:         campaignMessage.AccountID,
+		CampaignID:        campaignMessage.CampaignID,
+		AudienceID:        campaignMessage.ID,
+		ContactID:         &contact.ID,
+		Channel:           models.WhatsappChannel,
+		Provider:          deliveredString(string(provider.Name())),
+		ProviderMessageID: deliveredString(sendResult.MessageID),
+		BodyFormat:        models.DeliveredMessageText,
+		Body:              message,
+		TemplateID:        &channel.TemplateID,
+		TemplateVersion:   deliveredString(templateVersion),
+		AIModel:           deliveredString(w.campaignProcessor.Model()),
+		AIPrompt:          deliveredString(prompt),
+	})
+
+	w.log.Info("✅ Mensagem de WhatsApp enviada com sucesso!", "chat_id", chat.ID, "to", campaignMessage.ContactID, "message_id", sendResult.MessageID)
+	return statusErr
+}
+
+// sendingChat busca o chat configurado na campanha e confirma que a sessão do WhatsApp está conectada.
+// Sessão desconectada é falha temporária: a mensagem volta para a fila até a reconexão (ou até esgotar as tentativas).
+func (w *whatsAppWorker) sendingChat(ctx context.Context, campaignMessage dto.CampaignMessageDTO, settings *models.CampaignSettings) (*models.Chat, service.WhatsAppProvider, error) {
+	if settings.WhatsAppChatID == nil {
+		w.log.Error("❌ Campanha sem chat do WhatsApp configurado", "campaign_id", campaignMessage.CampaignID)
+		return nil, nil, service.NewPermanentError("campanha %s sem chat do WhatsApp configurado (whatsapp_chat_id)", campaignMessage.CampaignID)
+	}
+
+	chat, err := w.chatRepo.GetActiveByID(ctx, campaignMessage.AccountID, *settings.WhatsAppChatID)
+	if errors.Is(err, sql.ErrNoRows) {
+		w.log.Error("❌ Chat do WhatsApp não encontrado ou inativo", "chat_id", *settings.WhatsAppChatID)
+		return nil, nil, service.NewPermanentError("chat %s não encontrado ou inativo", *settings.WhatsAppChatID)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao buscar chat do WhatsApp (chat_id: %s): %w", *settings.WhatsAppChatID, err)
+	}
+
+	provider, err := w.providers.For(chat)
+	if err != nil {
+		return nil, nil, service.NewPermanentError("chat %s sem provedor do WhatsApp disponível: %v", chat.ID, err)
+	}
+
+	state, err := provider.GetSessionState(ctx, chat)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao consultar sessão do WhatsApp (chat_id: %s): %w", chat.ID, err)
+	}
+	if state.Status != "" && state.Status != chat.SessionStatus {
+		if err := w.chatRepo.UpdateSessionStatus(ctx, chat.ID, state.Status); err != nil {
+			w.log.Warn("⚠️ Erro ao atualizar status da sessão do chat", "chat_id", chat.ID, "error", err)
+		}
+	}
+	if !state.Connected {
+		w.log.Warn("📴 Sessão do WhatsApp desconectada, envio adiado", "chat_id", chat.ID, "provider", provider.Name(), "session_status", state.Status)
+		return nil, nil, fmt.Errorf("sessão do WhatsApp desconectada (chat_id: %s, status: %s)", chat.ID, state.Status)
+	}
+
+	return chat, provider, nil
+}
+
+// sendCloudTemplate preenche as variáveis do template aprovado com o conteúdo gerado pela IA (quando alguma
+// variável usa esse conteúdo) e envia pela Cloud API. Template inválido é falha permanente: reenviar não resolve.
+func (w *whatsAppWorker) sendCloudTemplate(
+	ctx context.Context,
+	campaignMessage dto.CampaignMessageDTO,
+	account *models.Account,
+	campaign *models.Campaign,
+	settings *models.CampaignSettings,
+	contact *models.Contact,
+	chat *models.Chat,
+	provider service.WhatsAppProvider,
+	jid string,
+	slot *sendSlot,
+) error {
+	templateProvider, ok := provider.(service.WhatsAppTemplateProvider)
+	if !ok {
+		return service.NewPermanentError("provedor %s não envia templates", provider.Name())
+	}
+
+	template, err := w.templates.Resolve(ctx, campaignMessage.AccountID, chat, *settings)
+	if errors.Is(err, service.ErrWhatsAppTemplateInvalid) {
+		w.log.Error("❌ Template do WhatsApp inválido", "campaign_id", campaignMessage.CampaignID, "error", err)
+		return &service.PermanentError{Err: err}
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao buscar template do WhatsApp (campaign_id: %s): %w", campaignMessage.CampaignID, err)
+	}
 
-	w.log.Info("✅ Mensagem de WhatsApp enviada com sucesso!", "to", campaignMessage.ContactID)
-	return nil
+	// 🔹 Conteúdo da IA só é gerado quando alguma variável usa saudação, corpo, finalização ou assinatura
+	var content *dto.CampaignContentResult
+	var prompt string
+	if usesGeneratedContent(settings.WhatsAppTemplateParams) {
+		content, prompt, err = w.campaignProcessor.GenerateCampaignContent(ctx,
+			dto.ToCampaignMessageFullDTO(*account, *campaign, *settings, *contact, "whatsapp"))
+		if err != nil || content == nil {
+			w.log.Error("❌ Erro ao gerar conteúdo com AI", "contact_id", campaignMessage.ContactID, "error", err)
+			return fmt.Errorf("falha ao gerar conteúdo do WhatsApp (contact_id: %s): %w", campaignMessage.ContactID, err)
+		}
+	}
+
+	// 🧩 Preencher as variáveis do template
+	message, err := service.BuildWhatsAppTemplateMessage(template, settings.WhatsAppTemplateParams, service.WhatsAppTemplateValues(content, contact, settings))
+	if err != nil {
+		w.log.Error("❌ Erro ao preencher template do WhatsApp", "whatsapp_template_id", template.ID, "error", err)
+
+		feedback := map[string]interface{}{"error": err.Error(), "whatsapp_template_id": template.ID}
+		if err := w.audienceRepo.UpdateStatus(ctx, campaignMessage.ID, string(models.AudienceFalhaRenderizacao), "", feedback); err != nil {
+			return err // 🔄 A fila reentrega e o preenchimento é refeito
+		}
+		return nil
+	}
+
+	// 🚀 Enviar pela Cloud API
+	sendResult, err := templateProvider.SendTemplate(ctx, chat, jid, *message)
+	if err != nil {
+		w.log.Error("❌ Erro ao enviar template do WhatsApp", "chat_id", chat.ID, "contact_id", campaignMessage.ContactID, "error", err)
+		return fmt.Errorf("erro ao enviar template do WhatsApp (chat_id: %s): %w", chat.ID, err)
+	}
+	slot.Confirm()
+
+	// ✅ Atualizar status para "enviado": entrega, leitura e falha chegam depois pelo webhook de status
+	statusErr := markSent(ctx, w.log, w.audienceRepo, campaignMessage.ID, sendResult.MessageID)
+
+	// 🗄️ Guardar o template e as variáveis entregues
+	body, err := json.Marshal(message)
+	if err != nil {
+		w.log.Warn("⚠️ Erro ao serializar template entregue", "audience_id", campaignMessage.ID, "error", err)
+	}
+	recordDeliveredMessage(ctx, w.log, w.deliveredMessages, models.DeliveredMessage{
+		AccountID:         campaignMessage.AccountID,
+		CampaignID:        campaignMessage.CampaignID,
+		AudienceID:        campaignMessage.ID,
+		ContactID:         &contact.ID,
+		Channel:           models.WhatsappChannel,
+		Provider:          deliveredString(string(provider.Name())),
+		ProviderMessageID: deliveredString(sendResult.MessageID),
+		BodyFormat:        models.DeliveredMessageJSON,
+		Body:              string(body),
+		AIModel:           deliveredString(modelIfGenerated(content, w.campaignProcessor.Model())),
+		AIPrompt:          deliveredString(prompt),
+	})
+
+	w.log.Info("✅ Template do WhatsApp enviado com sucesso!", "chat_id", chat.ID, "to", campaignMessage.ContactID, "template", template.Name, "message_id", sendResult.MessageID)
+	return statusErr
+}
+
+// usesGeneratedContent indica se alguma variável do template usa o conteúdo gerado pela IA
+func usesGeneratedContent(params map[string]string) bool {
+	for _, source := range params {
+		switch source {
+		case models.WhatsAppParamSaudacao, models.WhatsAppParamCorpo, models.WhatsAppParamFinalizacao, models.WhatsAppParamAssinatura:
+			return true
+		}
+	}
+	return false
+}
+
+// modelIfGenerated retorna o modelo da IA apenas quando o conteúdo foi gerado
+func modelIfGenerated(content *dto.CampaignContentResult, model string) string {
+	if content == nil {
+		return ""
+	}
+	return model
 }
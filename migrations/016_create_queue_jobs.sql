@@ -0,0 +1,13 @@
+-- File: /migrations/016_create_queue_jobs.sql
+
+-- 🔹 Fila de mensagens em PostgreSQL (QUEUE_DRIVER=postgres), consumida com FOR UPDATE SKIP LOCKED
+CREATE TABLE queue_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    queue VARCHAR(50) NOT NULL,             -- Nome da fila (email, whatsapp)
+    payload JSONB NOT NULL,                 -- Corpo da mensagem
+    attempts INT NOT NULL DEFAULT 0,        -- Quantas vezes a mensagem foi entregue a um consumidor
+    visible_at TIMESTAMP NOT NULL DEFAULT now(), -- Só pode ser consumida a partir deste instante (visibility timeout)
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX idx_queue_jobs_queue_visible ON queue_jobs (queue, visible_at);
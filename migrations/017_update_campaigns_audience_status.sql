@@ -0,0 +1,12 @@
+-- File: /migrations/017_update_campaigns_audience_status.sql
+
+-- 🔄 Alinha os status aceitos com models.AudienceStatus (o pipeline grava "fila" e "falha_envio")
+ALTER TABLE campaigns_audience DROP CONSTRAINT IF EXISTS campaigns_audience_status_check;
+
+ALTER TABLE campaigns_audience ADD CONSTRAINT campaigns_audience_status_check CHECK (status IN (
+    'pendente', 'fila', 'falha_envio', 'enviado', 'entregue', 'falha_renderizacao',
+    'rejeitado', 'devolvido', 'reclamado', 'atrasado', 'atualizou_assinatura'
+));
+
+-- 🔍 Os workers atualizam a audiência pelo id; o feedback do SES busca pelo message_id
+CREATE INDEX IF NOT EXISTS idx_campaigns_audience_message_id ON campaigns_audience (message_id);
@@ -0,0 +1,15 @@
+-- File: /migrations/018_add_retry_to_campaigns_audience.sql
+
+-- 🔁 Controle de tentativas de envio por contato da campanha
+ALTER TABLE campaigns_audience ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0; -- Tentativas que falharam
+ALTER TABLE campaigns_audience ADD COLUMN IF NOT EXISTS last_error TEXT; -- Último erro de envio
+
+-- ☠️ Novo status "dead_letter": esgotou as tentativas ou falhou de forma permanente
+ALTER TABLE campaigns_audience DROP CONSTRAINT IF EXISTS campaigns_audience_status_check;
+
+ALTER TABLE campaigns_audience ADD CONSTRAINT campaigns_audience_status_check CHECK (status IN (
+    'pendente', 'fila', 'falha_envio', 'enviado', 'entregue', 'falha_renderizacao',
+    'rejeitado', 'devolvido', 'reclamado', 'atrasado', 'atualizou_assinatura', 'dead_letter'
+));
+
+CREATE INDEX IF NOT EXISTS idx_campaigns_audience_campaign_status ON campaigns_audience (campaign_id, status);
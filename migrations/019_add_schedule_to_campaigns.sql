@@ -0,0 +1,15 @@
+-- File: /migrations/019_add_schedule_to_campaigns.sql
+
+-- 🗓️ Agendamento de campanhas: instante do disparo + fuso horário (IANA) escolhido pelo usuário
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS scheduled_at TIMESTAMPTZ DEFAULT NULL;
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) DEFAULT NULL; -- Ex.: America/Sao_Paulo
+
+-- 🔄 Alinha os status aceitos com models.AllowedCampaignStatus
+ALTER TABLE campaigns DROP CONSTRAINT IF EXISTS campaigns_status_check;
+
+ALTER TABLE campaigns ADD CONSTRAINT campaigns_status_check CHECK (status IN (
+    'pendente', 'agendada', 'processando', 'enviando', 'concluida', 'cancelada'
+));
+
+-- 🔍 O agendador busca campanhas agendadas vencidas
+CREATE INDEX IF NOT EXISTS idx_campaigns_scheduled ON campaigns (scheduled_at) WHERE status = 'agendada';
@@ -0,0 +1,27 @@
+-- File: /migrations/020_create_send_policies.sql
+
+-- 🕒 Janela de envio e limites por conta e canal
+CREATE TABLE IF NOT EXISTS send_policies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('email', 'whatsapp')),
+    timezone VARCHAR(64) NOT NULL DEFAULT 'America/Sao_Paulo',
+    weekdays INT[] NOT NULL DEFAULT '{1,2,3,4,5}', -- 0 = domingo ... 6 = sábado
+    start_time VARCHAR(5) NOT NULL DEFAULT '08:00', -- HH:MM no fuso da política
+    end_time VARCHAR(5) NOT NULL DEFAULT '20:00',
+    rate_per_minute INT NOT NULL DEFAULT 0, -- 0 = sem limite
+    daily_cap INT NOT NULL DEFAULT 0, -- 0 = sem limite
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    UNIQUE (account_id, channel)
+);
+
+-- 🔢 Contadores de envio compartilhados entre as réplicas (por minuto e por dia)
+CREATE TABLE IF NOT EXISTS send_counters (
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL,
+    period VARCHAR(10) NOT NULL CHECK (period IN ('minute', 'day')),
+    bucket TIMESTAMP NOT NULL,
+    count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (account_id, channel, period, bucket)
+);
@@ -0,0 +1,16 @@
+-- File: /migrations/021_add_pause_cancel_statuses.sql
+
+-- ⏸️ Campanhas podem ser pausadas (e retomadas) durante o envio
+ALTER TABLE campaigns DROP CONSTRAINT IF EXISTS campaigns_status_check;
+
+ALTER TABLE campaigns ADD CONSTRAINT campaigns_status_check CHECK (status IN (
+    'pendente', 'agendada', 'processando', 'enviando', 'pausada', 'concluida', 'cancelada'
+));
+
+-- 🚫 Contatos que não receberam a mensagem de uma campanha cancelada ficam como "cancelada"
+ALTER TABLE campaigns_audience DROP CONSTRAINT IF EXISTS campaigns_audience_status_check;
+
+ALTER TABLE campaigns_audience ADD CONSTRAINT campaigns_audience_status_check CHECK (status IN (
+    'pendente', 'fila', 'falha_envio', 'enviado', 'entregue', 'falha_renderizacao',
+    'rejeitado', 'devolvido', 'reclamado', 'atrasado', 'atualizou_assinatura', 'dead_letter', 'cancelada'
+));
@@ -0,0 +1,15 @@
+-- File: /migrations/022_create_campaign_status_history.sql
+
+-- 🕓 Linha do tempo de status das campanhas: quem mudou, de onde, para onde e quando
+CREATE TABLE IF NOT EXISTS campaign_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    campaign_id UUID NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    changed_by UUID REFERENCES accounts(id) ON DELETE SET NULL, -- NULL quando a mudança é automática
+    source VARCHAR(20) NOT NULL CHECK (source IN ('api', 'scheduler', 'pipeline')),
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_status_history_campaign ON campaign_status_history (campaign_id, created_at);
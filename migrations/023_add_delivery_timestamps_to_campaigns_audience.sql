@@ -0,0 +1,14 @@
+-- File: /migrations/023_add_delivery_timestamps_to_campaigns_audience.sql
+
+-- 📊 Instantes de envio e entrega para as métricas (o status é sobrescrito pelos eventos do SES)
+ALTER TABLE campaigns_audience ADD COLUMN IF NOT EXISTS sent_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE campaigns_audience ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+-- 🔄 Melhor estimativa para os registros já existentes
+UPDATE campaigns_audience SET sent_at = updated_at
+WHERE sent_at IS NULL AND status IN ('enviado', 'entregue', 'rejeitado', 'devolvido', 'reclamado', 'atrasado', 'atualizou_assinatura');
+
+UPDATE campaigns_audience SET delivered_at = updated_at
+WHERE delivered_at IS NULL AND status IN ('entregue', 'reclamado');
+
+CREATE INDEX IF NOT EXISTS idx_campaigns_audience_campaign_sent_at ON campaigns_audience (campaign_id, sent_at);
@@ -0,0 +1,21 @@
+-- File: /migrations/024_create_campaign_engagement_events.sql
+
+-- 👀 Engajamento dos e-mails: aberturas e cliques por contato da campanha
+CREATE TABLE IF NOT EXISTS campaign_engagement_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    audience_id UUID NOT NULL REFERENCES campaigns_audience(id) ON DELETE CASCADE,
+    campaign_id UUID NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('open', 'click')),
+    url TEXT, -- Link clicado
+    ip_address VARCHAR(64),
+    user_agent TEXT,
+    source VARCHAR(10) NOT NULL CHECK (source IN ('pixel', 'redirect', 'ses')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_engagement_events_campaign ON campaign_engagement_events (campaign_id, type, created_at);
+CREATE INDEX IF NOT EXISTS idx_campaign_engagement_events_audience ON campaign_engagement_events (audience_id);
+
+-- 📊 Primeira abertura/clique de cada contato (métricas únicas sem varrer os eventos)
+ALTER TABLE campaigns_audience ADD COLUMN IF NOT EXISTS opened_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE campaigns_audience ADD COLUMN IF NOT EXISTS clicked_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
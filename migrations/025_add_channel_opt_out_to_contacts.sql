@@ -0,0 +1,5 @@
+-- File: /migrations/025_add_channel_opt_out_to_contacts.sql
+
+-- 🚫 Descadastro por canal (opt_out_at continua sendo o descadastro geral)
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS email_opt_out_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS whatsapp_opt_out_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
@@ -0,0 +1,19 @@
+-- File: /migrations/026_create_suppressions.sql
+
+-- 🛑 Lista de supressão por conta: destinatários que não devem receber mensagens (bounce, reclamação ou manual)
+CREATE TABLE IF NOT EXISTS suppressions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    channel VARCHAR(10) NOT NULL CHECK (channel IN ('email', 'whatsapp')),
+    address VARCHAR(255) NOT NULL, -- E-mail em minúsculas ou WhatsApp normalizado
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('hard_bounce', 'soft_bounce', 'complaint', 'manual')),
+    campaign_id UUID REFERENCES campaigns(id) ON DELETE SET NULL, -- Campanha que originou a supressão
+    bounce_count INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE DEFAULT NULL, -- NULL = permanente; soft bounce abaixo do limite fica expirado (apenas contagem)
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (account_id, channel, address)
+);
+
+CREATE INDEX IF NOT EXISTS idx_suppressions_account_channel ON suppressions (account_id, channel, expires_at);
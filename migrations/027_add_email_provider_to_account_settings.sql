@@ -0,0 +1,11 @@
+-- File: /migrations/027_add_email_provider_to_account_settings.sql
+
+-- 📧 Provedor de envio de e-mail por conta: Amazon SES (padrão) ou servidor SMTP próprio
+ALTER TABLE account_settings ADD COLUMN IF NOT EXISTS email_provider VARCHAR(10) NOT NULL DEFAULT 'ses'
+    CHECK (email_provider IN ('ses', 'smtp'));
+ALTER TABLE account_settings ADD COLUMN IF NOT EXISTS smtp_host VARCHAR(255);
+ALTER TABLE account_settings ADD COLUMN IF NOT EXISTS smtp_port INTEGER NOT NULL DEFAULT 587;
+ALTER TABLE account_settings ADD COLUMN IF NOT EXISTS smtp_username VARCHAR(255);
+ALTER TABLE account_settings ADD COLUMN IF NOT EXISTS smtp_password VARCHAR(255);
+ALTER TABLE account_settings ADD COLUMN IF NOT EXISTS smtp_tls VARCHAR(10) NOT NULL DEFAULT 'starttls'
+    CHECK (smtp_tls IN ('starttls', 'tls', 'none')); -- 🔒 `none` apenas para servidores locais (MailHog/smtp4dev)
@@ -0,0 +1,7 @@
+-- File: /migrations/028_add_email_preheader_and_headers_to_campaign_settings.sql
+
+-- 📨 Texto de pré-visualização (preheader) exibido pelos clientes de e-mail ao lado do assunto
+ALTER TABLE campaign_settings ADD COLUMN IF NOT EXISTS email_preheader VARCHAR(200) DEFAULT NULL;
+
+-- 🏷️ Cabeçalhos adicionais da mensagem (ex.: {"X-Campaign": "black-friday", "Feedback-ID": "..."})
+ALTER TABLE campaign_settings ADD COLUMN IF NOT EXISTS email_headers JSONB NOT NULL DEFAULT '{}'::jsonb;
@@ -0,0 +1,25 @@
+-- File: /migrations/029_create_sender_identities.sql
+
+-- ✉️ Remetentes da conta: endereço ou domínio verificado no provedor de e-mail
+CREATE TABLE IF NOT EXISTS sender_identities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('email', 'domain')),
+    identity VARCHAR(255) NOT NULL, -- E-mail ou domínio em minúsculas
+    display_name VARCHAR(150), -- Nome exibido no From (ex.: "Loja Exemplo")
+    reply_to VARCHAR(150), -- Reply-To padrão das campanhas que usam o remetente
+    configuration_set VARCHAR(64), -- Configuration Set do SES (NULL = padrão da aplicação)
+    status VARCHAR(20) NOT NULL DEFAULT 'pendente' CHECK (status IN ('pendente', 'verificado', 'falhou')),
+    verification_token VARCHAR(255), -- Valor do TXT _amazonses.<domínio> (somente domínios)
+    verified_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    checked_at TIMESTAMP WITH TIME ZONE DEFAULT NULL, -- Última consulta ao provedor
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (account_id, identity)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sender_identities_account ON sender_identities (account_id, status);
+
+-- 🔗 Configurações da campanha referenciam o remetente verificado
+ALTER TABLE campaign_settings ADD COLUMN IF NOT EXISTS sender_identity_id UUID
+    REFERENCES sender_identities(id) ON DELETE SET NULL;
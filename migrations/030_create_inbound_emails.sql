@@ -0,0 +1,42 @@
+-- File: /migrations/030_create_inbound_emails.sql
+
+-- 🔧 Colunas usadas pelo chat (sessões do WhatsApp) que não constavam na criação da tabela
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS instance_name VARCHAR(50);
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS session_status VARCHAR(20) NOT NULL DEFAULT 'desconhecido';
+
+-- 💌 Conversas por e-mail: o atendimento aponta direto para o contato (sem contato do WhatsApp)
+ALTER TABLE chat_contacts ALTER COLUMN whatsapp_contact_id DROP NOT NULL;
+ALTER TABLE chat_contacts ADD COLUMN IF NOT EXISTS contact_id UUID REFERENCES contacts(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_chat_contacts_chat_contact ON chat_contacts (chat_id, contact_id)
+    WHERE contact_id IS NOT NULL;
+
+ALTER TABLE chat_messages DROP CONSTRAINT IF EXISTS chat_messages_type_check;
+
+ALTER TABLE chat_messages ADD CONSTRAINT chat_messages_type_check CHECK (type IN (
+    'texto', 'audio', 'imagem', 'video', 'documento', 'email'
+));
+
+-- 📥 Respostas recebidas por e-mail (receipt rule do SES ou upload do MIME)
+CREATE TABLE IF NOT EXISTS inbound_emails (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    contact_id UUID REFERENCES contacts(id) ON DELETE SET NULL,
+    campaign_id UUID REFERENCES campaigns(id) ON DELETE SET NULL, -- Campanha respondida (via In-Reply-To/References)
+    audience_id UUID REFERENCES campaigns_audience(id) ON DELETE SET NULL,
+    chat_message_id UUID REFERENCES chat_messages(id) ON DELETE SET NULL,
+    message_id VARCHAR(255) NOT NULL, -- Message-ID da resposta (sem < >)
+    in_reply_to VARCHAR(255),
+    from_address VARCHAR(255) NOT NULL,
+    from_name VARCHAR(150),
+    to_address VARCHAR(255),
+    subject TEXT,
+    text_body TEXT, -- Texto da resposta sem o conteúdo citado
+    source VARCHAR(10) NOT NULL CHECK (source IN ('ses', 'upload')),
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (account_id, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_inbound_emails_contact ON inbound_emails (contact_id, received_at);
+CREATE INDEX IF NOT EXISTS idx_inbound_emails_campaign ON inbound_emails (campaign_id);
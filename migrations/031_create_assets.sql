@@ -0,0 +1,17 @@
+-- File: /migrations/031_create_assets.sql
+
+-- 📎 Arquivos da conta (imagens para templates, catálogos em PDF e anexos das campanhas)
+CREATE TABLE IF NOT EXISTS assets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL, -- Nome original do arquivo (usado como nome do anexo)
+    storage_key VARCHAR(512) NOT NULL UNIQUE, -- Caminho no armazenamento (disco local ou bucket S3)
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_assets_account ON assets (account_id, created_at);
+
+-- 📨 Anexos enviados em todos os e-mails da campanha
+ALTER TABLE campaign_settings ADD COLUMN IF NOT EXISTS email_attachments UUID[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,11 @@
+-- File: /migrations/032_add_contacts_email_status.sql
+
+-- ✉️ Resultado da verificação do e-mail do contato (sintaxe, domínio descartável, conta de função e MX)
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS email_status VARCHAR(10)
+    CHECK (email_status IN ('valid', 'risky', 'invalid', 'unknown'));
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS email_status_reason VARCHAR(50);
+
+-- 🔹 Contatos já cadastrados ainda não foram verificados
+UPDATE contacts SET email_status = 'unknown' WHERE email IS NOT NULL AND email_status IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_contacts_email_status ON contacts (account_id, email_status);
@@ -0,0 +1,30 @@
+-- File: /migrations/033_create_delivered_messages.sql
+
+-- 🗄️ Conteúdo exato entregue a cada destinatário (conformidade e atendimento)
+CREATE TABLE IF NOT EXISTS delivered_messages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    campaign_id UUID NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    audience_id UUID NOT NULL UNIQUE REFERENCES campaigns_audience(id) ON DELETE CASCADE,
+    contact_id UUID REFERENCES contacts(id) ON DELETE SET NULL,
+    channel VARCHAR(10) NOT NULL CHECK (channel IN ('email', 'whatsapp')),
+    provider VARCHAR(20), -- ses, smtp, evolution...
+    provider_message_id VARCHAR(255), -- Mesmo valor de campaigns_audience.message_id
+    subject TEXT,
+    body_format VARCHAR(10) NOT NULL CHECK (body_format IN ('html', 'text', 'json')),
+    body BYTEA NOT NULL, -- 🗜️ gzip: HTML enviado (com rastreamento), texto do WhatsApp ou variáveis do template
+    body_text BYTEA, -- 🗜️ gzip: parte texto do e-mail
+    template_id UUID REFERENCES templates(id) ON DELETE SET NULL,
+    template_version VARCHAR(64), -- SHA-256 do arquivo do template no momento do envio
+    ai_model VARCHAR(100),
+    ai_prompt BYTEA, -- 🗜️ gzip
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_delivered_messages_account_sent ON delivered_messages (account_id, sent_at);
+CREATE INDEX IF NOT EXISTS idx_delivered_messages_campaign ON delivered_messages (campaign_id);
+
+-- ⏳ Política de retenção por conta: mensagens mais antigas são removidas automaticamente
+ALTER TABLE account_settings ADD COLUMN IF NOT EXISTS message_retention_days INTEGER NOT NULL DEFAULT 365
+    CHECK (message_retention_days BETWEEN 1 AND 3650);
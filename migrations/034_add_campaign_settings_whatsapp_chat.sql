@@ -0,0 +1,4 @@
+-- File: /migrations/034_add_campaign_settings_whatsapp_chat.sql
+
+-- 💬 Sessão do WhatsApp (chat conectado da conta) usada para enviar a campanha
+ALTER TABLE campaign_settings ADD COLUMN IF NOT EXISTS whatsapp_chat_id UUID REFERENCES chats(id) ON DELETE SET NULL;
@@ -0,0 +1,8 @@
+-- File: /migrations/035_add_chats_whatsapp_provider.sql
+
+-- 📱 Provedor do WhatsApp usado pelo chat (sessão Baileys, Evolution API v2 ou API oficial do WhatsApp Cloud)
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS provider VARCHAR(20) NOT NULL DEFAULT 'baileys'
+    CHECK (provider IN ('baileys', 'evolution', 'cloud_api'));
+
+-- 🔎 Webhooks localizam o chat pela instância (sessão ou phone_number_id)
+CREATE INDEX IF NOT EXISTS idx_chats_instance_name ON chats (instance_name) WHERE status = 'ativo';
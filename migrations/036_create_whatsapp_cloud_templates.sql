@@ -0,0 +1,48 @@
+-- File: /migrations/036_create_whatsapp_cloud_templates.sql
+
+-- 📱 Números da Cloud API (Meta) cadastrados pelas contas: o phone_number_id é o instance_name dos chats cloud_api
+CREATE TABLE IF NOT EXISTS whatsapp_phone_numbers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    phone_number_id VARCHAR(50) NOT NULL UNIQUE, -- Um número pertence a uma única conta (roteamento dos webhooks)
+    business_account_id VARCHAR(50) NOT NULL, -- WABA (WhatsApp Business Account) dona dos templates
+    display_phone_number VARCHAR(30),
+    verified_name VARCHAR(255),
+    quality_rating VARCHAR(20),
+    templates_synced_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_whatsapp_phone_numbers_account ON whatsapp_phone_numbers (account_id);
+
+-- 🧾 Templates de mensagem (HSM) sincronizados da WABA, com as variáveis que cada envio precisa preencher
+CREATE TABLE IF NOT EXISTS whatsapp_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    business_account_id VARCHAR(50) NOT NULL,
+    external_id VARCHAR(50) NOT NULL, -- ID do template na Meta
+    name VARCHAR(512) NOT NULL,
+    language VARCHAR(15) NOT NULL,
+    category VARCHAR(30),
+    status VARCHAR(30) NOT NULL, -- APPROVED, PENDING, REJECTED, PAUSED, DISABLED, DELETED...
+    parameter_format VARCHAR(15) NOT NULL DEFAULT 'POSITIONAL' CHECK (parameter_format IN ('POSITIONAL', 'NAMED')),
+    components JSONB NOT NULL DEFAULT '[]', -- Componentes como retornados pelo Graph
+    slots JSONB NOT NULL DEFAULT '[]', -- Variáveis extraídas dos componentes (body.1, header.media, button.0.1...)
+    synced_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (account_id, business_account_id, name, language)
+);
+
+CREATE INDEX IF NOT EXISTS idx_whatsapp_templates_account ON whatsapp_templates (account_id, status);
+
+-- 🔗 Template aprovado usado pela campanha na Cloud API e a origem do valor de cada variável
+ALTER TABLE campaign_settings ADD COLUMN IF NOT EXISTS whatsapp_template_id UUID
+    REFERENCES whatsapp_templates(id) ON DELETE SET NULL;
+ALTER TABLE campaign_settings ADD COLUMN IF NOT EXISTS whatsapp_template_params JSONB NOT NULL DEFAULT '{}';
+
+-- 👀 Leitura informada pelo webhook de status do WhatsApp conta como abertura
+ALTER TABLE campaign_engagement_events DROP CONSTRAINT IF EXISTS campaign_engagement_events_source_check;
+ALTER TABLE campaign_engagement_events ADD CONSTRAINT campaign_engagement_events_source_check
+    CHECK (source IN ('pixel', 'redirect', 'ses', 'whatsapp'));
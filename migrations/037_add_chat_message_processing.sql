@@ -0,0 +1,10 @@
+-- File: /migrations/037_add_chat_message_processing.sql
+
+-- 🎤 Processamento assíncrono das mídias recebidas (transcrição de áudios, descrição e OCR de imagens e documentos)
+ALTER TABLE chat_messages ADD COLUMN IF NOT EXISTS processing_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE chat_messages ADD COLUMN IF NOT EXISTS processing_error TEXT; -- Última falha (a mensagem segue com a legenda)
+ALTER TABLE chat_messages ADD COLUMN IF NOT EXISTS processing_locked_until TIMESTAMP WITH TIME ZONE DEFAULT NULL; -- Reserva do worker
+
+-- 🔎 Mensagens de clientes com mídia ainda não processada
+CREATE INDEX IF NOT EXISTS idx_chat_messages_pending_processing ON chat_messages (created_at)
+    WHERE source_processed = FALSE AND actor = 'cliente' AND deleted_at IS NULL;
@@ -0,0 +1,9 @@
+-- File: /migrations/038_add_dead_letter_to_queue_jobs.sql
+
+-- ☠️ Mensagens que não decodificam ou esgotam as entregas (QUEUE_MAX_RECEIVES) saem da fila, como o redrive do SQS
+ALTER TABLE queue_jobs ADD COLUMN IF NOT EXISTS dead_lettered_at TIMESTAMP; -- Movida para dead-letter (não é mais consumida)
+ALTER TABLE queue_jobs ADD COLUMN IF NOT EXISTS last_error TEXT; -- Motivo da última falha
+
+-- 🔍 O consumo só considera mensagens ativas
+DROP INDEX IF EXISTS idx_queue_jobs_queue_visible;
+CREATE INDEX idx_queue_jobs_queue_visible ON queue_jobs (queue, visible_at) WHERE dead_lettered_at IS NULL;
@@ -0,0 +1,10 @@
+-- File: /migrations/039_add_enviando_status_to_campaigns_audience.sql
+
+-- 🔒 "enviando": a audiência foi reservada por um worker (UPDATE condicional) e está sendo enviada.
+-- Duas cópias da mesma mensagem na fila não conseguem reservar a mesma audiência.
+ALTER TABLE campaigns_audience DROP CONSTRAINT IF EXISTS campaigns_audience_status_check;
+
+ALTER TABLE campaigns_audience ADD CONSTRAINT campaigns_audience_status_check CHECK (status IN (
+    'pendente', 'fila', 'enviando', 'falha_envio', 'enviado', 'entregue', 'falha_renderizacao',
+    'rejeitado', 'devolvido', 'reclamado', 'atrasado', 'atualizou_assinatura', 'dead_letter', 'cancelada'
+));
@@ -0,0 +1,8 @@
+-- File: /migrations/040_add_scheduler_claim_to_campaigns.sql
+
+-- 🗓️ Reserva do agendador com prazo (lease): se a réplica cair durante o disparo, outra retoma a campanha
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMPTZ DEFAULT NULL; -- Última renovação da reserva
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS claimed_by VARCHAR(100) DEFAULT NULL; -- Réplica que está disparando
+
+-- 🔍 O agendador busca reservas vencidas
+CREATE INDEX IF NOT EXISTS idx_campaigns_claimed ON campaigns (claimed_at) WHERE status = 'processando' AND claimed_at IS NOT NULL;
@@ -0,0 +1,5 @@
+-- File: /migrations/041_add_dispatch_token_to_campaigns_audience.sql
+
+-- 🎟️ Token do último enfileiramento da audiência: cada disparo/retomada grava um token novo e a mensagem
+-- leva o mesmo token. Cópias de um enfileiramento anterior (pausa seguida de retomada) não conseguem reservar o envio.
+ALTER TABLE campaigns_audience ADD COLUMN IF NOT EXISTS dispatch_token UUID DEFAULT NULL;
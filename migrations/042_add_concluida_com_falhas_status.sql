@@ -0,0 +1,9 @@
+-- File: /migrations/042_add_concluida_com_falhas_status.sql
+
+-- ⚠️ Campanha finalizada com contatos que não receberam a mensagem (falha de envio, de renderização ou dead-letter).
+-- Os contatos com falha podem ser reenfileirados pelo reprocessamento de dead-letters.
+ALTER TABLE campaigns DROP CONSTRAINT IF EXISTS campaigns_status_check;
+
+ALTER TABLE campaigns ADD CONSTRAINT campaigns_status_check CHECK (status IN (
+    'pendente', 'agendada', 'processando', 'enviando', 'pausada', 'concluida', 'concluida_com_falhas', 'cancelada'
+));
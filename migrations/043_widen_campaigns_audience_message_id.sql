@@ -0,0 +1,5 @@
+-- File: /migrations/043_widen_campaigns_audience_message_id.sql
+
+-- ✉️ O SMTP gera Message-IDs no formato uuid@dominio-do-remetente: 64 caracteres não comportam domínios longos
+-- (mesmo tamanho de delivered_messages.provider_message_id)
+ALTER TABLE campaigns_audience ALTER COLUMN message_id TYPE VARCHAR(255);